@@ -15,37 +15,58 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/v1/clients": {
+        "/v1/accounts/{accountNum}/balance": {
             "get": {
-                "description": "Retorna uma lista de todos os clientes cadastrados",
+                "description": "Retorna o saldo da conta pelo livro-razão, considerando só o que foi lançado antes de as_of. as_of aceita uma data (YYYY-MM-DD, no horário de Brasília), que retorna o saldo no fim do dia, ou um instante RFC 3339. Sem as_of, retorna o saldo atual.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "clients"
+                    "accounts"
+                ],
+                "summary": "Saldo da conta em uma data",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Número da conta",
+                        "name": "accountNum",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data (YYYY-MM-DD) ou instante (RFC 3339)",
+                        "name": "as_of",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Lista todos os clientes",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Client"
-                            }
+                            "$ref": "#/definitions/models.AccountBalance"
                         }
                     },
-                    "500": {
-                        "description": "Mensagem de erro",
+                    "400": {
+                        "description": "as_of inválido",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Conta inexistente",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
                 }
-            },
+            }
+        },
+        "/v1/accounts/{accountNum}/close": {
             "post": {
-                "description": "Cria um novo cliente com as informações fornecidas",
+                "description": "Transfere o saldo restante para to_account, como uma transferência comum, e encerra a conta. A conta precisa estar ativa, sem saldo negativo, sem reservas ativas e sem transferências agendadas ou ordens permanentes em aberto. Depois de encerrada, a conta não recebe créditos nem pode ser debitada, mas o histórico, o extrato e os saldos continuam disponíveis.",
                 "consumes": [
                     "application/json"
                 ],
@@ -53,17 +74,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "clients"
+                    "accounts"
                 ],
-                "summary": "Cria um novo cliente",
+                "summary": "Encerra a conta",
                 "parameters": [
                     {
-                        "description": "Cliente",
-                        "name": "client",
+                        "type": "string",
+                        "description": "Número da conta",
+                        "name": "accountNum",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Conta que recebe o saldo e motivo",
+                        "name": "accountClosureRequest",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Client"
+                            "$ref": "#/definitions/controllers.AccountClosureRequest"
                         }
                     }
                 ],
@@ -71,11 +98,25 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Client"
+                            "$ref": "#/definitions/models.AccountClosure"
                         }
                     },
                     "400": {
-                        "description": "Mensagem de erro",
+                        "description": "Conta de destino ausente ou transferência do saldo recusada (code)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Conta inexistente",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conta não ativa, com saldo negativo ou com pendências",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -84,16 +125,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/clients/{accountNum}": {
+        "/v1/accounts/{accountNum}/daily-balances": {
             "get": {
-                "description": "Busca um cliente pelo número da conta fornecido",
+                "description": "Retorna os saldos de fim de dia guardados pela rotina diária, do mais antigo para o mais recente, entre as datas from e to (YYYY-MM-DD, inclusive). Por padrão, os últimos 30 dias até ontem.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "clients"
+                    "accounts"
                 ],
-                "summary": "Busca cliente por número da conta",
+                "summary": "Saldos diários da conta",
                 "parameters": [
                     {
                         "type": "string",
@@ -101,17 +142,39 @@ const docTemplate = `{
                         "name": "accountNum",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Primeiro dia (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Último dia (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Client"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.BalanceSnapshot"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Período inválido",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "404": {
-                        "description": "client not found",
+                        "description": "Conta inexistente",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -120,9 +183,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/transfer": {
+        "/v1/accounts/{accountNum}/deposits": {
             "post": {
-                "description": "Realiza uma transferência entre duas contas fornecidas",
+                "description": "Credita o valor informado na conta. O depósito aparece no histórico da conta.",
                 "consumes": [
                     "application/json"
                 ],
@@ -130,30 +193,43 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "transfers"
+                    "accounts"
                 ],
-                "summary": "Realiza uma transferência",
+                "summary": "Realiza um depósito",
                 "parameters": [
                     {
-                        "description": "Dados da Transferência",
-                        "name": "transferRequest",
+                        "type": "string",
+                        "description": "Número da conta",
+                        "name": "accountNum",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Valor do depósito",
+                        "name": "movementRequest",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/controllers.TransferRequest"
+                            "$ref": "#/definitions/controllers.MovementRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Transferência realizada com sucesso",
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Transfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Mensagem de erro e código do motivo (code)",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "400": {
-                        "description": "Mensagem de erro",
+                    "404": {
+                        "description": "Conta inexistente",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -162,16 +238,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/transfers/{accountNum}": {
+        "/v1/accounts/{accountNum}/holds": {
             "get": {
-                "description": "Retorna o histórico de transferências associado a uma conta fornecida",
+                "description": "Retorna as reservas da conta, das mais recentes para as mais antigas, opcionalmente filtradas por status",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "transfers"
+                    "holds"
                 ],
-                "summary": "Obtém histórico de transferências",
+                "summary": "Lista reservas",
                 "parameters": [
                     {
                         "type": "string",
@@ -179,6 +255,12 @@ const docTemplate = `{
                         "name": "accountNum",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Status (active, captured, voided, expired)",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -187,12 +269,65 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Transfer"
+                                "$ref": "#/definitions/models.Hold"
                             }
                         }
                     },
-                    "500": {
-                        "description": "Mensagem de erro",
+                    "404": {
+                        "description": "Conta inexistente",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Reserva o valor do saldo disponível da conta em favor da conta de destino, sem alterar o saldo atual. A reserva pode depois ser capturada (total ou parcialmente), cancelada ou expirar.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "holds"
+                ],
+                "summary": "Cria uma reserva de saldo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Número da conta",
+                        "name": "accountNum",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Destino, valor e prazo",
+                        "name": "holdRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.HoldRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Hold"
+                        }
+                    },
+                    "400": {
+                        "description": "Mensagem de erro e código do motivo (code)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Conta inexistente",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -200,64 +335,2891 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "controllers.TransferRequest": {
-            "type": "object",
-            "properties": {
-                "amount": {
-                    "type": "number",
-                    "example": 100.5
-                },
-                "from_account": {
-                    "type": "string",
-                    "example": "123456"
-                },
-                "to_account": {
-                    "type": "string",
-                    "example": "654321"
+        },
+        "/v1/accounts/{accountNum}/interest": {
+            "get": {
+                "description": "Retorna os juros acumulados dia a dia (saldo do fim do dia, taxa e juros do dia arredondados) e as capitalizações mensais da conta, dos mais recentes para os mais antigos",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "accounts"
+                ],
+                "summary": "Juros da conta",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Número da conta",
+                        "name": "accountNum",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.InterestHistory"
+                        }
+                    },
+                    "404": {
+                        "description": "Conta inexistente",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
                 }
             }
         },
-        "models.Client": {
-            "type": "object",
-            "properties": {
-                "account_num": {
-                    "type": "string"
-                },
-                "balance": {
-                    "type": "number"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "name": {
-                    "type": "string"
+        "/v1/accounts/{accountNum}/notifications": {
+            "get": {
+                "description": "Retorna os avisos da conta, como a entrada e a saída do cheque especial, dos mais recentes para os mais antigos",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "accounts"
+                ],
+                "summary": "Lista notificações",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Número da conta",
+                        "name": "accountNum",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Notification"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Conta inexistente",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
                 }
             }
         },
-        "models.Transfer": {
-            "type": "object",
-            "properties": {
-                "amount": {
-                    "type": "number"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "from_account_num": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "status": {
-                    "description": "\"success\" ou \"failed\"",
-                    "type": "string"
-                },
+        "/v1/accounts/{accountNum}/overdraft-interest": {
+            "get": {
+                "description": "Retorna as cobranças diárias de juros do cheque especial da conta, das mais recentes para as mais antigas, com o saldo do fim do dia e a taxa usados",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "accounts"
+                ],
+                "summary": "Juros do cheque especial",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Número da conta",
+                        "name": "accountNum",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.OverdraftInterest"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Conta inexistente",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/accounts/{accountNum}/scheduled-transfers": {
+            "get": {
+                "description": "Retorna os agendamentos em que a conta é origem ou destino, em ordem de execução, opcionalmente filtrados por status",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Lista transferências agendadas",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Número da conta",
+                        "name": "accountNum",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Status (pending, processing, executed, failed, cancelled)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ScheduledTransfer"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Mensagem de erro",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/accounts/{accountNum}/standing-orders": {
+            "get": {
+                "description": "Retorna as ordens em que a conta é origem ou destino, opcionalmente filtradas por status",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "standing-orders"
+                ],
+                "summary": "Lista ordens permanentes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Número da conta",
+                        "name": "accountNum",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Status (active, paused, cancelled, completed)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.StandingOrder"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Mensagem de erro",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/accounts/{accountNum}/statement": {
+            "get": {
+                "description": "Retorna o saldo de abertura, cada movimentação do período em ordem cronológica (data, tipo, contraparte, valor com sinal e saldo logo depois), o saldo de fechamento e os totais de créditos e débitos, pelo livro-razão. from e to aceitam datas (YYYY-MM-DD, no horário de Brasília, com o dia de to incluído) ou instantes RFC 3339 (to excluído). Por padrão o período vai do início do mês até agora.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "accounts"
+                ],
+                "summary": "Extrato da conta",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Número da conta",
+                        "name": "accountNum",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Início do período (YYYY-MM-DD ou RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fim do período (YYYY-MM-DD ou RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Statement"
+                        }
+                    },
+                    "400": {
+                        "description": "Período inválido",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Conta inexistente",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/accounts/{accountNum}/status-history": {
+            "get": {
+                "description": "Retorna as mudanças de status da conta, das mais recentes para as mais antigas, com o motivo de cada uma",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "accounts"
+                ],
+                "summary": "Histórico de status da conta",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Número da conta",
+                        "name": "accountNum",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.AccountStatusChange"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Conta inexistente",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/accounts/{accountNum}/withdrawals": {
+            "post": {
+                "description": "Debita o valor informado da conta, cujo saldo disponível (descontadas as reservas) não pode ficar abaixo de zero, ou abaixo de -overdraft_limit com cheque especial. O saque aparece no histórico da conta.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "accounts"
+                ],
+                "summary": "Realiza um saque",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Número da conta",
+                        "name": "accountNum",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Valor do saque",
+                        "name": "movementRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.MovementRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Transfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Mensagem de erro e código do motivo (code)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Conta inexistente",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/accounts/{accountNum}/status": {
+            "put": {
+                "description": "Congela (frozen: recebe créditos, mas não pode ser debitada), bloqueia (blocked: não recebe créditos nem pode ser debitada), reativa (active) ou encerra (closed) a conta. Contas ativas podem ser congeladas, bloqueadas ou encerradas; congeladas e bloqueadas só podem voltar a ser ativas; encerrar é definitivo e exige a conta sem saldo e sem reservas. O motivo é obrigatório e fica no histórico de status.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Muda o status da conta",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Número da conta",
+                        "name": "accountNum",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Novo status e motivo",
+                        "name": "accountStatusRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.AccountStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Client"
+                        }
+                    },
+                    "400": {
+                        "description": "Status inválido ou motivo ausente",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Conta inexistente",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Transição não permitida ou conta com saldo",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/fees": {
+            "get": {
+                "description": "Retorna a tabela de tarifas de transferência de cada tipo de conta",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Lista as tabelas de tarifas",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.FeeSchedule"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/fees/{accountType}": {
+            "put": {
+                "description": "Substitui a tabela de tarifas das transferências feitas por contas do tipo (checking ou savings). A tarifa é flat mais percent_bps do valor; a faixa (tiers) de maior min_amount alcançado pelo valor substitui flat e percent_bps. As primeiras free_transfers transferências do mês são gratuitas. Tudo zerado desativa as tarifas do tipo.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Define a tabela de tarifas",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tipo de conta (checking, savings)",
+                        "name": "accountType",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tabela de tarifas",
+                        "name": "feeScheduleRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.FeeScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.FeeSchedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Tipo de conta ou tarifas inválidos",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/interest-rates": {
+            "get": {
+                "description": "Retorna a taxa de juros anual, em pontos-base, de cada tipo de conta",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Lista as taxas de juros",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.InterestRate"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/interest-rates/{accountType}": {
+            "put": {
+                "description": "Define a taxa de juros anual, em pontos-base e com base de 365 dias, das contas do tipo (checking ou savings). A nova taxa vale a partir do próximo acúmulo diário. Taxa zero desativa os juros do tipo.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Define a taxa de juros",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tipo de conta (checking, savings)",
+                        "name": "accountType",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Taxa",
+                        "name": "interestRateRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.InterestRateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.InterestRate"
+                        }
+                    },
+                    "400": {
+                        "description": "Tipo de conta ou taxa inválidos",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/limits/{accountNum}": {
+            "get": {
+                "description": "Retorna os limites em vigor para a conta (os próprios ou, com inherited = true, os padrão) e o quanto ela já transferiu no dia, no mês e no período noturno. Use \"default\" como conta para consultar os limites padrão.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Consulta limites de transferência",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Número da conta ou default",
+                        "name": "accountNum",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.LimitStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Conta inexistente",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Cria ou substitui os limites da conta: por transferência, total diário, total mensal e quantidade diária de transferências, além dos limites do período noturno (20h às 6h, horário de Brasília), que se somam aos demais. Use \"default\" como conta para alterar os limites padrão.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Define limites de transferência",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Número da conta ou default",
+                        "name": "accountNum",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Limites",
+                        "name": "limitsRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.LimitsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TransferLimits"
+                        }
+                    },
+                    "400": {
+                        "description": "Limites inválidos",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Conta inexistente",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove os limites próprios da conta, que volta a seguir os limites padrão. Os limites padrão não podem ser removidos.",
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Remove limites de transferência",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Número da conta",
+                        "name": "accountNum",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "A conta não tem limites próprios",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Os limites padrão não podem ser removidos",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/overdraft/{accountNum}": {
+            "put": {
+                "description": "Ativa ou altera o cheque especial da conta: o saldo pode ficar negativo até -limit, e os juros (taxa anual em pontos-base, base de 365 dias) são cobrados diariamente sobre o saldo negativo do fim do dia. Limite zero desativa o cheque especial. O limite não pode ficar abaixo do valor em uso.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Define o cheque especial",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Número da conta",
+                        "name": "accountNum",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Limite e taxa",
+                        "name": "overdraftRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.OverdraftRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Client"
+                        }
+                    },
+                    "400": {
+                        "description": "Limite ou taxa inválidos",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Conta inexistente",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "O limite é menor que o valor em uso",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/treasury/fundings": {
+            "post": {
+                "description": "Transfere o valor da conta interna da tesouraria para a conta informada. É assim que contas novas, que começam com saldo zero, recebem o saldo inicial; cada conta só pode ser financiada uma vez.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "treasury"
+                ],
+                "summary": "Financia uma conta",
+                "parameters": [
+                    {
+                        "description": "Conta e valor",
+                        "name": "fundingRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.FundingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Transfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Mensagem de erro e código do motivo (code)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Conta inexistente",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conta já financiada (code already_funded)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Mensagem de erro",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/clients": {
+            "get": {
+                "description": "Retorna uma lista de todos os clientes cadastrados",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "clients"
+                ],
+                "summary": "Lista todos os clientes",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Client"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Mensagem de erro",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Cria um novo cliente com as informações fornecidas. O número da\nconta é gerado pelo servidor no formato agência-conta-DV; o\naccount_num enviado é ignorado.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "clients"
+                ],
+                "summary": "Cria um novo cliente",
+                "parameters": [
+                    {
+                        "description": "Cliente",
+                        "name": "client",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Client"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Client"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versão do cliente"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Mensagem de erro",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/clients/{accountNum}": {
+            "get": {
+                "description": "Busca um cliente pelo número da conta fornecido. A resposta traz\na versão do cliente no cabeçalho ETag; com If-None-Match igual à\nversão atual, retorna 304 sem corpo.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "clients"
+                ],
+                "summary": "Busca cliente por número da conta",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Número da conta",
+                        "name": "accountNum",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag já conhecida",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Client"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versão do cliente"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Cliente não foi alterado"
+                    },
+                    "400": {
+                        "description": "Número de conta inválido",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "client not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Altera o nome de um cliente. Envie em If-Match a ETag obtida na\nleitura para que a alteração só ocorra se o cliente não tiver sido\nmodificado desde então.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "clients"
+                ],
+                "summary": "Altera um cliente",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Número da conta",
+                        "name": "accountNum",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag da versão lida",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Dados do cliente",
+                        "name": "client",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.UpdateClientRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Client"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Nova versão do cliente"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Mensagem de erro",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "client not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "412": {
+                        "description": "Cliente alterado por outra requisição",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/customers": {
+            "post": {
+                "description": "Cria um cliente ainda sem contas; as contas são abertas em /v1/customers/{id}/accounts",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "customers"
+                ],
+                "summary": "Cria um cliente",
+                "parameters": [
+                    {
+                        "description": "Nome do cliente",
+                        "name": "customer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.CustomerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Customer"
+                        }
+                    },
+                    "400": {
+                        "description": "Mensagem de erro",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/customers/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "customers"
+                ],
+                "summary": "Busca um cliente",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do cliente",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Customer"
+                        }
+                    },
+                    "404": {
+                        "description": "customer not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/customers/{id}/accounts": {
+            "get": {
+                "description": "Retorna as contas do cliente na ordem em que foram abertas, inclusive as encerradas",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "customers"
+                ],
+                "summary": "Lista as contas de um cliente",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do cliente",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Account"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "customer not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Abre mais uma conta para o cliente, com saldo zero e status active. O número da conta é gerado no formato agência-conta-DV e o nome do titular vem do cliente. A moeda (BRL por padrão) precisa ser um código ISO 4217 de três letras.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "customers"
+                ],
+                "summary": "Abre uma conta para um cliente",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do cliente",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tipo e moeda da conta",
+                        "name": "account",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.OpenAccountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Account"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versão da conta"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Mensagem de erro",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "customer not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/holds/{id}": {
+            "get": {
+                "description": "Retorna a reserva com o valor já capturado e o status",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "holds"
+                ],
+                "summary": "Busca uma reserva",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da reserva",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Hold"
+                        }
+                    },
+                    "404": {
+                        "description": "hold not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/holds/{id}/capture": {
+            "post": {
+                "description": "Transforma o valor (ou todo o restante da reserva) em uma transferência para a conta de destino da reserva. Com captura parcial, a reserva continua ativa com o valor restante.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "holds"
+                ],
+                "summary": "Captura uma reserva",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da reserva",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Valor da captura parcial",
+                        "name": "captureRequest",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.CaptureRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.CaptureResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Mensagem de erro e código do motivo (code)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "hold not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "hold is no longer active",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/holds/{id}/void": {
+            "post": {
+                "description": "Encerra a reserva e devolve ao saldo disponível o valor ainda não capturado",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "holds"
+                ],
+                "summary": "Cancela uma reserva",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da reserva",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Hold"
+                        }
+                    },
+                    "404": {
+                        "description": "hold not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "hold is no longer active",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/scheduled-transfers/{id}": {
+            "get": {
+                "description": "Retorna o agendamento e, depois da execução, o seu resultado",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Busca uma transferência agendada",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do agendamento",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ScheduledTransfer"
+                        }
+                    },
+                    "404": {
+                        "description": "scheduled transfer not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/scheduled-transfers/{id}/cancel": {
+            "post": {
+                "description": "Cancela um agendamento que ainda está pendente",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Cancela uma transferência agendada",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do agendamento",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ScheduledTransfer"
+                        }
+                    },
+                    "404": {
+                        "description": "scheduled transfer not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "scheduled transfer is no longer pending",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/standing-orders": {
+            "post": {
+                "description": "Agenda uma transferência recorrente diária, semanal, mensal ou por expressão cron (em UTC), com data de início, data final e número máximo de ocorrências opcionais. Recusas por saldo insuficiente são tentadas novamente.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "standing-orders"
+                ],
+                "summary": "Cria uma ordem permanente",
+                "parameters": [
+                    {
+                        "description": "Regra da ordem permanente",
+                        "name": "standingOrderRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.StandingOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.StandingOrder"
+                        }
+                    },
+                    "400": {
+                        "description": "Mensagem de erro e código do motivo (code)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/standing-orders/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "standing-orders"
+                ],
+                "summary": "Busca uma ordem permanente",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da ordem",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.StandingOrder"
+                        }
+                    },
+                    "404": {
+                        "description": "standing order not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/standing-orders/{id}/cancel": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "standing-orders"
+                ],
+                "summary": "Cancela uma ordem permanente",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da ordem",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.StandingOrder"
+                        }
+                    },
+                    "404": {
+                        "description": "standing order not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "A ordem já foi cancelada ou concluída",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/standing-orders/{id}/pause": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "standing-orders"
+                ],
+                "summary": "Pausa uma ordem permanente",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da ordem",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.StandingOrder"
+                        }
+                    },
+                    "404": {
+                        "description": "standing order not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "A ordem não está ativa",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/standing-orders/{id}/resume": {
+            "post": {
+                "description": "Reativa uma ordem pausada a partir da próxima ocorrência futura; ocorrências vencidas durante a pausa não são executadas",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "standing-orders"
+                ],
+                "summary": "Retoma uma ordem permanente",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da ordem",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.StandingOrder"
+                        }
+                    },
+                    "404": {
+                        "description": "standing order not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "A ordem não está pausada",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/standing-orders/{id}/runs": {
+            "get": {
+                "description": "Retorna cada tentativa de execução da ordem, da mais recente para a mais antiga, com o resultado e a próxima tentativa quando houver",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "standing-orders"
+                ],
+                "summary": "Histórico de execuções",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da ordem",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.StandingOrderRun"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "standing order not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/transfer": {
+            "post": {
+                "description": "Realiza uma transferência entre duas contas fornecidas e retorna a transferência e a tarifa cobrada da conta de origem (fee), conforme a tabela de tarifas do tipo da conta. Com execute_at, a transferência é agendada para essa data e a resposta é 202 com o agendamento. Com o cabeçalho Idempotency-Key, repetições da mesma requisição reproduzem a primeira resposta sem movimentar dinheiro novamente.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Realiza uma transferência",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Chave de idempotência",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Dados da Transferência",
+                        "name": "transferRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.TransferRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transferência realizada com sucesso (transfer) e tarifa cobrada (fee)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "202": {
+                        "description": "Transferência agendada (scheduled_transfer)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Mensagem de erro e código do motivo (code)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Requisição com a mesma chave ainda em processamento",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Chave de idempotência usada com outro conteúdo",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Erro inesperado; a chave de idempotência é liberada para nova tentativa",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/transfers/{accountNum}": {
+            "get": {
+                "description": "Retorna o histórico de transferências associado a uma conta fornecida",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Obtém histórico de transferências",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Número da conta",
+                        "name": "accountNum",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Transfer"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Mensagem de erro",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/transfers/{id}/reversal": {
+            "post": {
+                "description": "Devolve o valor (ou parte dele) da conta de destino para a de origem, criando uma transferência do tipo \"reversal\" ligada à original. A soma dos estornos não pode ultrapassar o valor original.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Estorna uma transferência",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da transferência",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Valor do estorno parcial",
+                        "name": "reversalRequest",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ReversalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Transfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Mensagem de erro e código do motivo (code)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "transfer not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Mensagem de erro",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/treasury/position": {
+            "get": {
+                "description": "Retorna o saldo da tesouraria, das demais contas internas e a soma dos saldos dos clientes em uma moeda. Em um sistema íntegro, todos somam zero (balanced = true).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "treasury"
+                ],
+                "summary": "Posição da tesouraria",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Moeda (padrão BRL)",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TreasuryPosition"
+                        }
+                    },
+                    "500": {
+                        "description": "Mensagem de erro",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "controllers.AccountClosureRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "example": "mudança de banco"
+                },
+                "to_account": {
+                    "description": "conta que recebe o saldo restante, obrigatória se houver saldo",
+                    "type": "string",
+                    "example": "654321"
+                }
+            }
+        },
+        "controllers.AccountStatusRequest": {
+            "type": "object",
+            "required": [
+                "reason",
+                "status"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "example": "suspeita de fraude"
+                },
+                "status": {
+                    "description": "active, frozen, blocked ou closed",
+                    "type": "string",
+                    "example": "frozen"
+                }
+            }
+        },
+        "controllers.CaptureRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "$ref": "#/definitions/models.Money"
+                }
+            }
+        },
+        "controllers.CaptureResponse": {
+            "type": "object",
+            "properties": {
+                "hold": {
+                    "$ref": "#/definitions/models.Hold"
+                },
+                "transfer": {
+                    "$ref": "#/definitions/models.Transfer"
+                }
+            }
+        },
+        "controllers.CustomerRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Maria Silva"
+                }
+            }
+        },
+        "controllers.FeeScheduleRequest": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
+                "flat": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "free_transfers": {
+                    "description": "transferências gratuitas por mês",
+                    "type": "integer",
+                    "example": 5
+                },
+                "percent_bps": {
+                    "description": "percentual do valor em pontos-base (50 = 0,5%)",
+                    "type": "integer",
+                    "example": 50
+                },
+                "tiers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FeeTier"
+                    }
+                }
+            }
+        },
+        "controllers.FundingRequest": {
+            "type": "object",
+            "required": [
+                "account_num"
+            ],
+            "properties": {
+                "account_num": {
+                    "type": "string",
+                    "example": "123456"
+                },
+                "amount": {
+                    "$ref": "#/definitions/models.Money"
+                }
+            }
+        },
+        "controllers.HoldRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "expires_at": {
+                    "description": "ExpiresAt é o prazo da reserva (RFC 3339); sem ele, a reserva vale 7 dias",
+                    "type": "string",
+                    "example": "2030-01-05T09:00:00-03:00"
+                },
+                "to_account": {
+                    "type": "string",
+                    "example": "654321"
+                }
+            }
+        },
+        "controllers.InterestRateRequest": {
+            "type": "object",
+            "properties": {
+                "rate_bps": {
+                    "description": "juros anuais em pontos-base (650 = 6,5% a.a.)",
+                    "type": "integer",
+                    "example": 650
+                }
+            }
+        },
+        "controllers.LimitsRequest": {
+            "type": "object",
+            "properties": {
+                "daily": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "daily_count": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "monthly": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "night_per_transfer": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "night_total": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "per_transfer": {
+                    "$ref": "#/definitions/models.Money"
+                }
+            }
+        },
+        "controllers.MovementRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "$ref": "#/definitions/models.Money"
+                }
+            }
+        },
+        "controllers.OpenAccountRequest": {
+            "type": "object",
+            "properties": {
+                "account_type": {
+                    "type": "string",
+                    "example": "savings"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                }
+            }
+        },
+        "controllers.OverdraftRequest": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "rate_bps": {
+                    "description": "juros anuais em pontos-base (1200 = 12% a.a.)",
+                    "type": "integer",
+                    "example": 1200
+                }
+            }
+        },
+        "controllers.ReversalRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "$ref": "#/definitions/models.Money"
+                }
+            }
+        },
+        "controllers.StandingOrderRequest": {
+            "type": "object",
+            "required": [
+                "frequency",
+                "from_account",
+                "to_account"
+            ],
+            "properties": {
+                "amount": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "cron": {
+                    "type": "string",
+                    "example": "0 12 1 * *"
+                },
+                "end_at": {
+                    "type": "string"
+                },
+                "frequency": {
+                    "description": "daily, weekly, monthly ou cron",
+                    "type": "string",
+                    "example": "monthly"
+                },
+                "from_account": {
+                    "type": "string",
+                    "example": "123456"
+                },
+                "interval": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "max_occurrences": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "start_at": {
+                    "type": "string",
+                    "example": "2030-01-05T09:00:00-03:00"
+                },
+                "to_account": {
+                    "type": "string",
+                    "example": "654321"
+                }
+            }
+        },
+        "controllers.TransferRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "execute_at": {
+                    "description": "ExecuteAt agenda a transferência para uma data futura (RFC 3339)",
+                    "type": "string",
+                    "example": "2030-01-05T09:00:00-03:00"
+                },
+                "from_account": {
+                    "type": "string",
+                    "example": "123456"
+                },
+                "to_account": {
+                    "type": "string",
+                    "example": "654321"
+                }
+            }
+        },
+        "controllers.UpdateClientRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Account": {
+            "type": "object",
+            "properties": {
+                "account_num": {
+                    "type": "string"
+                },
+                "account_type": {
+                    "type": "string"
+                },
+                "available_balance": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "balance": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "customer_id": {
+                    "type": "integer"
+                },
+                "held": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "overdraft_limit": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "overdraft_rate_bps": {
+                    "description": "juros anuais do cheque especial, em pontos-base",
+                    "type": "integer"
+                },
+                "overdraft_usage": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AccountBalance": {
+            "type": "object",
+            "properties": {
+                "account_num": {
+                    "type": "string"
+                },
+                "as_of": {
+                    "type": "string"
+                },
+                "balance": {
+                    "$ref": "#/definitions/models.Money"
+                }
+            }
+        },
+        "models.AccountClosure": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "$ref": "#/definitions/models.Client"
+                },
+                "sweep": {
+                    "$ref": "#/definitions/models.Transfer"
+                }
+            }
+        },
+        "models.AccountStatusChange": {
+            "type": "object",
+            "properties": {
+                "account_num": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "from_status": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "to_status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.BalanceSnapshot": {
+            "type": "object",
+            "properties": {
+                "account_num": {
+                    "type": "string"
+                },
+                "as_of": {
+                    "type": "string"
+                },
+                "balance": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2030-03-01"
+                }
+            }
+        },
+        "models.Client": {
+            "type": "object",
+            "properties": {
+                "account_num": {
+                    "type": "string"
+                },
+                "account_type": {
+                    "type": "string"
+                },
+                "available_balance": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "balance": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "customer_id": {
+                    "type": "integer"
+                },
+                "held": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "overdraft_limit": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "overdraft_rate_bps": {
+                    "description": "juros anuais do cheque especial, em pontos-base",
+                    "type": "integer"
+                },
+                "overdraft_usage": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Customer": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FeeSchedule": {
+            "type": "object",
+            "properties": {
+                "account_type": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
+                "flat": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "free_transfers": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "percent_bps": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "tiers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FeeTier"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FeeTier": {
+            "type": "object",
+            "properties": {
+                "flat": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "min_amount": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "percent_bps": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Hold": {
+            "type": "object",
+            "properties": {
+                "account_num": {
+                    "type": "string"
+                },
+                "amount": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "captured_amount": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "\"active\", \"captured\", \"voided\" ou \"expired\"",
+                    "type": "string"
+                },
+                "to_account_num": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.InterestAccrual": {
+            "type": "object",
+            "properties": {
+                "account_num": {
+                    "type": "string"
+                },
+                "accrual_date": {
+                    "description": "AAAA-MM-DD, em BusinessLocation",
+                    "type": "string"
+                },
+                "balance": {
+                    "description": "saldo no fim do dia",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Money"
+                        }
+                    ]
+                },
+                "capitalization_id": {
+                    "description": "vazio até a capitalização do mês",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "interest": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "rate_bps": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.InterestCapitalization": {
+            "type": "object",
+            "properties": {
+                "account_num": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "interest": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "period": {
+                    "description": "AAAA-MM, em BusinessLocation",
+                    "type": "string"
+                },
+                "transfer_id": {
+                    "description": "vazio quando os juros arredondam para zero",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.InterestHistory": {
+            "type": "object",
+            "properties": {
+                "accruals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.InterestAccrual"
+                    }
+                },
+                "capitalizations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.InterestCapitalization"
+                    }
+                }
+            }
+        },
+        "models.InterestRate": {
+            "type": "object",
+            "properties": {
+                "account_type": {
+                    "type": "string"
+                },
+                "rate_bps": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.LimitStatus": {
+            "type": "object",
+            "properties": {
+                "inherited": {
+                    "description": "a conta segue os limites padrão",
+                    "type": "boolean"
+                },
+                "limits": {
+                    "$ref": "#/definitions/models.TransferLimits"
+                },
+                "usage": {
+                    "$ref": "#/definitions/models.LimitUsage"
+                }
+            }
+        },
+        "models.LimitUsage": {
+            "type": "object",
+            "properties": {
+                "daily": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "daily_count": {
+                    "type": "integer"
+                },
+                "monthly": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "night_total": {
+                    "$ref": "#/definitions/models.Money"
+                }
+            }
+        },
+        "models.Money": {
+            "type": "object",
+            "properties": {
+                "cents": {
+                    "type": "integer",
+                    "example": 10050
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                }
+            }
+        },
+        "models.Notification": {
+            "type": "object",
+            "properties": {
+                "account_num": {
+                    "type": "string"
+                },
+                "balance": {
+                    "description": "saldo logo após o evento",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Money"
+                        }
+                    ]
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.OverdraftInterest": {
+            "type": "object",
+            "properties": {
+                "account_num": {
+                    "type": "string"
+                },
+                "accrual_date": {
+                    "description": "AAAA-MM-DD, em BusinessLocation",
+                    "type": "string"
+                },
+                "balance": {
+                    "description": "saldo no fim do dia",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Money"
+                        }
+                    ]
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "interest": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "rate_bps": {
+                    "type": "integer"
+                },
+                "transfer_id": {
+                    "description": "vazio quando os juros arredondam para zero",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ScheduledTransfer": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "execute_at": {
+                    "type": "string"
+                },
+                "executed_at": {
+                    "type": "string"
+                },
+                "failure_reason": {
+                    "description": "preenchido apenas quando Status é \"failed\"",
+                    "type": "string"
+                },
+                "from_account_num": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "\"pending\", \"processing\", \"executed\", \"failed\" ou \"cancelled\"",
+                    "type": "string"
+                },
+                "to_account_num": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.StandingOrder": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "cron": {
+                    "description": "expressão cron em UTC, quando Frequency é \"cron\"",
+                    "type": "string"
+                },
+                "end_at": {
+                    "type": "string"
+                },
+                "frequency": {
+                    "description": "\"daily\", \"weekly\", \"monthly\" ou \"cron\"",
+                    "type": "string"
+                },
+                "from_account_num": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "interval": {
+                    "description": "a cada quantos dias, semanas ou meses",
+                    "type": "integer"
+                },
+                "max_occurrences": {
+                    "description": "zero para ilimitado",
+                    "type": "integer"
+                },
+                "next_occurrence_at": {
+                    "type": "string"
+                },
+                "next_run_at": {
+                    "type": "string"
+                },
+                "occurrences": {
+                    "description": "ocorrências já processadas, com sucesso ou não",
+                    "type": "integer"
+                },
+                "retries": {
+                    "description": "tentativas recusadas da ocorrência pendente",
+                    "type": "integer"
+                },
+                "start_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "\"active\", \"paused\", \"cancelled\" ou \"completed\"",
+                    "type": "string"
+                },
+                "to_account_num": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.StandingOrderRun": {
+            "type": "object",
+            "properties": {
+                "attempt": {
+                    "type": "integer"
+                },
+                "executed_at": {
+                    "type": "string"
+                },
+                "failure_reason": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "occurrence_at": {
+                    "type": "string"
+                },
+                "retry_at": {
+                    "description": "próxima tentativa, quando a recusa será retentada",
+                    "type": "string"
+                },
+                "standing_order_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "\"success\" ou \"failed\"",
+                    "type": "string"
+                }
+            }
+        },
+        "models.Statement": {
+            "type": "object",
+            "properties": {
+                "account_num": {
+                    "type": "string"
+                },
+                "closing_balance": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "movements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.StatementLine"
+                    }
+                },
+                "opening_balance": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "total_credits": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "total_debits": {
+                    "$ref": "#/definitions/models.Money"
+                }
+            }
+        },
+        "models.StatementLine": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "balance": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "counterparty": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "entry_id": {
+                    "type": "integer"
+                },
+                "transfer_id": {
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "tipo da transferência, quando o lançamento tem uma",
+                    "type": "string"
+                }
+            }
+        },
+        "models.Transfer": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "failure_reason": {
+                    "description": "preenchido apenas quando Status é \"failed\"",
+                    "type": "string"
+                },
+                "fee": {
+                    "description": "tarifa cobrada por esta transferência",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Money"
+                        }
+                    ]
+                },
+                "fee_of": {
+                    "description": "transferência tarifada, quando Type é \"fee\"",
+                    "type": "integer"
+                },
+                "from_account_num": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reversal_of": {
+                    "description": "transferência estornada, quando Type é \"reversal\"",
+                    "type": "integer"
+                },
+                "reversed_amount": {
+                    "description": "total já estornado desta transferência",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Money"
+                        }
+                    ]
+                },
+                "status": {
+                    "description": "\"success\" ou \"failed\"",
+                    "type": "string"
+                },
                 "to_account_num": {
                     "type": "string"
+                },
+                "type": {
+                    "description": "\"transfer\", \"deposit\", \"withdrawal\", \"funding\", \"reversal\", \"overdraft_interest\", \"interest\" ou \"fee\"",
+                    "type": "string"
+                }
+            }
+        },
+        "models.TransferLimits": {
+            "type": "object",
+            "properties": {
+                "account_num": {
+                    "description": "\"default\" para os limites padrão",
+                    "type": "string"
+                },
+                "daily": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "daily_count": {
+                    "type": "integer"
+                },
+                "monthly": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "night_per_transfer": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "night_total": {
+                    "description": "total por período noturno",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Money"
+                        }
+                    ]
+                },
+                "per_transfer": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TreasuryPosition": {
+            "type": "object",
+            "properties": {
+                "balanced": {
+                    "type": "boolean"
+                },
+                "client_balances": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "internal_balances": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/models.Money"
+                    }
+                },
+                "ledger_total": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "treasury_balance": {
+                    "$ref": "#/definitions/models.Money"
                 }
             }
         }
@@ -0,0 +1,80 @@
+package controllers
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"banking/src/services"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AccountClosureController gerencia a rota de encerramento de contas
+type AccountClosureController struct {
+	AccountClosureService services.AccountClosureServiceInterface
+}
+
+// NewAccountClosureController cria uma nova instância de AccountClosureController
+func NewAccountClosureController(accountClosureService services.AccountClosureServiceInterface) *AccountClosureController {
+	return &AccountClosureController{AccountClosureService: accountClosureService}
+}
+
+// AccountClosureRequest representa o corpo opcional do encerramento de uma conta
+type AccountClosureRequest struct {
+	ToAccount string `json:"to_account" example:"654321"` // conta que recebe o saldo restante, obrigatória se houver saldo
+	Reason    string `json:"reason" example:"mudança de banco"`
+}
+
+// CloseAccount encerra uma conta
+// @Summary Encerra a conta
+// @Description Transfere o saldo restante para to_account, como uma transferência comum, e encerra a conta. A conta precisa estar ativa, sem saldo negativo, sem reservas ativas e sem transferências agendadas ou ordens permanentes em aberto. Depois de encerrada, a conta não recebe créditos nem pode ser debitada, mas o histórico, o extrato e os saldos continuam disponíveis.
+// @Tags accounts
+// @Accept json
+// @Produce json
+// @Param accountNum path string true "Número da conta"
+// @Param accountClosureRequest body AccountClosureRequest false "Conta que recebe o saldo e motivo"
+// @Success 200 {object} models.AccountClosure
+// @Failure 400 {object} map[string]interface{} "Conta de destino ausente ou transferência do saldo recusada (code)"
+// @Failure 404 {object} map[string]interface{} "Conta inexistente"
+// @Failure 409 {object} map[string]interface{} "Conta não ativa, com saldo negativo ou com pendências"
+// @Router /v1/accounts/{accountNum}/close [post]
+func (cc *AccountClosureController) CloseAccount(c *gin.Context) {
+	// O corpo é opcional: contas sem saldo não precisam de conta de destino
+	var req AccountClosureRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	closure, err := cc.AccountClosureService.CloseAccount(c.Param("accountNum"), req.ToAccount, req.Reason)
+	if err != nil {
+		var transferErr *services.TransferError
+		switch {
+		case errors.As(err, &transferErr), errors.Is(err, services.ErrSweepAccountRequired):
+			c.JSON(http.StatusBadRequest, transferErrorResponse(err))
+		case errors.Is(err, repositories.ErrClientNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, models.ErrInvalidStatusTransition), errors.Is(err, services.ErrAccountNotEmpty),
+			errors.Is(err, services.ErrAccountHasPendingItems):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, closure)
+}
+
+// InitAccountClosureRoutes inicializa a rota de encerramento de contas
+func InitAccountClosureRoutes(r *gin.Engine, accountClosureService services.AccountClosureServiceInterface) {
+	accountClosureController := NewAccountClosureController(accountClosureService)
+
+	v1 := r.Group("/v1")
+	{
+		v1.POST("/accounts/:accountNum/close", accountClosureController.CloseAccount)
+	}
+}
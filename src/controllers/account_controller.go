@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"banking/src/models"
+	"banking/src/services"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AccountController gerencia as rotas de operações sobre uma conta
+type AccountController struct {
+	AccountService services.AccountServiceInterface
+}
+
+// NewAccountController cria uma nova instância de AccountController
+func NewAccountController(accountService services.AccountServiceInterface) *AccountController {
+	return &AccountController{AccountService: accountService}
+}
+
+// MovementRequest representa o corpo de um depósito ou saque
+type MovementRequest struct {
+	Amount models.Money `json:"amount"`
+}
+
+// Deposit deposita um valor em uma conta
+// @Summary Realiza um depósito
+// @Description Credita o valor informado na conta. O depósito aparece no histórico da conta.
+// @Tags accounts
+// @Accept json
+// @Produce json
+// @Param accountNum path string true "Número da conta"
+// @Param movementRequest body MovementRequest true "Valor do depósito"
+// @Success 201 {object} models.Transfer
+// @Failure 400 {object} map[string]interface{} "Mensagem de erro e código do motivo (code)"
+// @Failure 404 {object} map[string]interface{} "Conta inexistente"
+// @Router /v1/accounts/{accountNum}/deposits [post]
+func (ac *AccountController) Deposit(c *gin.Context) {
+	ac.move(c, ac.AccountService.Deposit)
+}
+
+// Withdraw saca um valor de uma conta
+// @Summary Realiza um saque
+// @Description Debita o valor informado da conta, cujo saldo disponível (descontadas as reservas) não pode ficar abaixo de zero, ou abaixo de -overdraft_limit com cheque especial. O saque aparece no histórico da conta.
+// @Tags accounts
+// @Accept json
+// @Produce json
+// @Param accountNum path string true "Número da conta"
+// @Param movementRequest body MovementRequest true "Valor do saque"
+// @Success 201 {object} models.Transfer
+// @Failure 400 {object} map[string]interface{} "Mensagem de erro e código do motivo (code)"
+// @Failure 404 {object} map[string]interface{} "Conta inexistente"
+// @Router /v1/accounts/{accountNum}/withdrawals [post]
+func (ac *AccountController) Withdraw(c *gin.Context) {
+	ac.move(c, ac.AccountService.Withdraw)
+}
+
+func (ac *AccountController) move(c *gin.Context, operation func(accountNum string, amount models.Money) (*models.Transfer, error)) {
+	var req MovementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	movement, err := operation(c.Param("accountNum"), req.Amount)
+	if err != nil {
+		c.JSON(movementErrorStatus(err), transferErrorResponse(err))
+		return
+	}
+	c.JSON(http.StatusCreated, movement)
+}
+
+// movementErrorStatus escolhe o status HTTP de um depósito ou saque recusado
+func movementErrorStatus(err error) int {
+	var transferErr *services.TransferError
+	if !errors.As(err, &transferErr) {
+		return http.StatusInternalServerError
+	}
+	switch transferErr.Reason {
+	case models.FailureSourceNotFound, models.FailureDestinationNotFound:
+		return http.StatusNotFound
+	case models.FailureAlreadyFunded:
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// InitAccountRoutes inicializa as rotas de contas
+func InitAccountRoutes(r *gin.Engine, accountService services.AccountServiceInterface) {
+	accountController := NewAccountController(accountService)
+
+	v1 := r.Group("/v1")
+	{
+		v1.POST("/accounts/:accountNum/deposits", accountController.Deposit)
+		v1.POST("/accounts/:accountNum/withdrawals", accountController.Withdraw)
+	}
+}
@@ -0,0 +1,43 @@
+package controllers
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"banking/src/services"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ValidateAccountNum recusa com 400 as requisições cujo parâmetro :accountNum
+// está fora do formato agência-conta-DV ou tem um DV que nenhum algoritmo
+// aceita. Números legados, só com algarismos, passam sem verificação, e um
+// número inválido só é recusado se não houver conta com ele, para que contas
+// existentes continuem acessíveis mesmo que o formato mude. Deve ser
+// registrado com r.Use antes das rotas, para valer em todas elas.
+func ValidateAccountNum(clientService services.ClientServiceInterface) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		accountNum := c.Param("accountNum")
+		if accountNum == "" {
+			c.Next()
+			return
+		}
+
+		invalid := models.ValidateAccountNumber(accountNum)
+		if invalid == nil {
+			c.Next()
+			return
+		}
+		_, err := clientService.GetClientByAccountNum(accountNum)
+		switch {
+		case errors.Is(err, repositories.ErrClientNotFound):
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": invalid.Error()})
+			return
+		case err != nil:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,93 @@
+package controllers
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"banking/src/services"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AccountStatusController gerencia as rotas do status das contas
+type AccountStatusController struct {
+	AccountStatusService services.AccountStatusServiceInterface
+}
+
+// NewAccountStatusController cria uma nova instância de AccountStatusController
+func NewAccountStatusController(accountStatusService services.AccountStatusServiceInterface) *AccountStatusController {
+	return &AccountStatusController{AccountStatusService: accountStatusService}
+}
+
+// AccountStatusRequest representa o corpo da mudança de status de uma conta
+type AccountStatusRequest struct {
+	Status string `json:"status" binding:"required" example:"frozen"` // active, frozen, blocked ou closed
+	Reason string `json:"reason" binding:"required" example:"suspeita de fraude"`
+}
+
+// SetAccountStatus muda o status de uma conta
+// @Summary Muda o status da conta
+// @Description Congela (frozen: recebe créditos, mas não pode ser debitada), bloqueia (blocked: não recebe créditos nem pode ser debitada), reativa (active) ou encerra (closed) a conta. Contas ativas podem ser congeladas, bloqueadas ou encerradas; congeladas e bloqueadas só podem voltar a ser ativas; encerrar é definitivo e exige a conta sem saldo e sem reservas. O motivo é obrigatório e fica no histórico de status.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param accountNum path string true "Número da conta"
+// @Param accountStatusRequest body AccountStatusRequest true "Novo status e motivo"
+// @Success 200 {object} models.Client
+// @Failure 400 {object} map[string]interface{} "Status inválido ou motivo ausente"
+// @Failure 404 {object} map[string]interface{} "Conta inexistente"
+// @Failure 409 {object} map[string]interface{} "Transição não permitida ou conta com saldo"
+// @Router /v1/admin/accounts/{accountNum}/status [put]
+func (sc *AccountStatusController) SetAccountStatus(c *gin.Context) {
+	var req AccountStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	client, err := sc.AccountStatusService.ChangeStatus(c.Param("accountNum"), req.Status, req.Reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrClientNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, models.ErrInvalidAccountStatus), errors.Is(err, models.ErrStatusReasonRequired):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, models.ErrInvalidStatusTransition), errors.Is(err, services.ErrAccountNotEmpty):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, client)
+}
+
+// GetStatusHistory lista as mudanças de status de uma conta
+// @Summary Histórico de status da conta
+// @Description Retorna as mudanças de status da conta, das mais recentes para as mais antigas, com o motivo de cada uma
+// @Tags accounts
+// @Produce json
+// @Param accountNum path string true "Número da conta"
+// @Success 200 {array} models.AccountStatusChange
+// @Failure 404 {object} map[string]interface{} "Conta inexistente"
+// @Router /v1/accounts/{accountNum}/status-history [get]
+func (sc *AccountStatusController) GetStatusHistory(c *gin.Context) {
+	history, err := sc.AccountStatusService.GetStatusHistory(c.Param("accountNum"))
+	if err != nil {
+		accountReadError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, history)
+}
+
+// InitAccountStatusRoutes inicializa as rotas do status das contas
+func InitAccountStatusRoutes(r *gin.Engine, accountStatusService services.AccountStatusServiceInterface) {
+	accountStatusController := NewAccountStatusController(accountStatusService)
+
+	v1 := r.Group("/v1")
+	{
+		v1.PUT("/admin/accounts/:accountNum/status", accountStatusController.SetAccountStatus)
+		v1.GET("/accounts/:accountNum/status-history", accountStatusController.GetStatusHistory)
+	}
+}
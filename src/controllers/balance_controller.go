@@ -0,0 +1,93 @@
+package controllers
+
+import (
+	"banking/src/models"
+	"banking/src/services"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BalanceController gerencia as rotas de saldo histórico das contas
+type BalanceController struct {
+	BalanceService services.BalanceServiceInterface
+}
+
+// NewBalanceController cria uma nova instância de BalanceController
+func NewBalanceController(balanceService services.BalanceServiceInterface) *BalanceController {
+	return &BalanceController{BalanceService: balanceService}
+}
+
+// GetBalance retorna o saldo de uma conta em um instante
+// @Summary Saldo da conta em uma data
+// @Description Retorna o saldo da conta pelo livro-razão, considerando só o que foi lançado antes de as_of. as_of aceita uma data (YYYY-MM-DD, no horário de Brasília), que retorna o saldo no fim do dia, ou um instante RFC 3339. Sem as_of, retorna o saldo atual.
+// @Tags accounts
+// @Produce json
+// @Param accountNum path string true "Número da conta"
+// @Param as_of query string false "Data (YYYY-MM-DD) ou instante (RFC 3339)"
+// @Success 200 {object} models.AccountBalance
+// @Failure 400 {object} map[string]interface{} "as_of inválido"
+// @Failure 404 {object} map[string]interface{} "Conta inexistente"
+// @Router /v1/accounts/{accountNum}/balance [get]
+func (bc *BalanceController) GetBalance(c *gin.Context) {
+	asOf, err := parsePeriodBound(c.Query("as_of"), true)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	balance, err := bc.BalanceService.GetBalance(c.Param("accountNum"), asOf)
+	if err != nil {
+		accountReadError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, balance)
+}
+
+// GetDailyBalances lista os saldos de fim de dia de uma conta
+// @Summary Saldos diários da conta
+// @Description Retorna os saldos de fim de dia guardados pela rotina diária, do mais antigo para o mais recente, entre as datas from e to (YYYY-MM-DD, inclusive). Por padrão, os últimos 30 dias até ontem.
+// @Tags accounts
+// @Produce json
+// @Param accountNum path string true "Número da conta"
+// @Param from query string false "Primeiro dia (YYYY-MM-DD)"
+// @Param to query string false "Último dia (YYYY-MM-DD)"
+// @Success 200 {array} models.BalanceSnapshot
+// @Failure 400 {object} map[string]interface{} "Período inválido"
+// @Failure 404 {object} map[string]interface{} "Conta inexistente"
+// @Router /v1/accounts/{accountNum}/daily-balances [get]
+func (bc *BalanceController) GetDailyBalances(c *gin.Context) {
+	from, err := parsePeriodBound(c.Query("from"), false)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	to, err := parsePeriodBound(c.Query("to"), false)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	snapshots, err := bc.BalanceService.GetDailyBalances(c.Param("accountNum"), from, to)
+	if errors.Is(err, models.ErrInvalidPeriod) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		accountReadError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, snapshots)
+}
+
+// InitBalanceRoutes inicializa as rotas de saldo histórico das contas
+func InitBalanceRoutes(r *gin.Engine, balanceService services.BalanceServiceInterface) {
+	balanceController := NewBalanceController(balanceService)
+
+	v1 := r.Group("/v1")
+	{
+		v1.GET("/accounts/:accountNum/balance", balanceController.GetBalance)
+		v1.GET("/accounts/:accountNum/daily-balances", balanceController.GetDailyBalances)
+	}
+}
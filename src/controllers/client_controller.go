@@ -2,7 +2,9 @@ package controllers
 
 import (
 	"banking/src/models"
+	"banking/src/repositories"
 	"banking/src/services"
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -20,12 +22,15 @@ func NewClientController(clientService services.ClientServiceInterface) *ClientC
 
 // CreateClient cria um novo cliente
 // @Summary Cria um novo cliente
-// @Description Cria um novo cliente com as informações fornecidas
+// @Description Cria um novo cliente com as informações fornecidas. O número da
+// @Description conta é gerado pelo servidor no formato agência-conta-DV; o
+// @Description account_num enviado é ignorado.
 // @Tags clients
 // @Accept json
 // @Produce json
 // @Param client body models.Client true "Cliente"
 // @Success 200 {object} models.Client
+// @Header 200 {string} ETag "Versão do cliente"
 // @Failure 400 {object} map[string]interface{} "Mensagem de erro"
 // @Router /v1/clients [post]
 func (cc *ClientController) CreateClient(c *gin.Context) {
@@ -35,12 +40,14 @@ func (cc *ClientController) CreateClient(c *gin.Context) {
 		return
 	}
 
+	client.AccountNum = ""
 	err := cc.ClientService.CreateClient(&client)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
+	setVersionETag(c, client.Version)
 	c.JSON(http.StatusOK, client)
 }
 
@@ -63,11 +70,17 @@ func (cc *ClientController) GetClients(c *gin.Context) {
 
 // GetClientByAccountNum busca cliente por número da conta
 // @Summary Busca cliente por número da conta
-// @Description Busca um cliente pelo número da conta fornecido
+// @Description Busca um cliente pelo número da conta fornecido. A resposta traz
+// @Description a versão do cliente no cabeçalho ETag; com If-None-Match igual à
+// @Description versão atual, retorna 304 sem corpo.
 // @Tags clients
 // @Produce json
 // @Param accountNum path string true "Número da conta"
+// @Param If-None-Match header string false "ETag já conhecida"
 // @Success 200 {object} models.Client
+// @Header 200 {string} ETag "Versão do cliente"
+// @Success 304 "Cliente não foi alterado"
+// @Failure 400 {object} map[string]interface{} "Número de conta inválido"
 // @Failure 404 {object} map[string]interface{} "client not found"
 // @Router /v1/clients/{accountNum} [get]
 func (cc *ClientController) GetClientByAccountNum(c *gin.Context) {
@@ -77,6 +90,64 @@ func (cc *ClientController) GetClientByAccountNum(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "client not found"})
 		return
 	}
+
+	setVersionETag(c, client.Version)
+	if !noneMatch(c, client.Version) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.JSON(http.StatusOK, client)
+}
+
+// UpdateClientRequest representa os dados cadastrais que podem ser alterados
+type UpdateClientRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// UpdateClient altera os dados cadastrais de um cliente
+// @Summary Altera um cliente
+// @Description Altera o nome de um cliente. Envie em If-Match a ETag obtida na
+// @Description leitura para que a alteração só ocorra se o cliente não tiver sido
+// @Description modificado desde então.
+// @Tags clients
+// @Accept json
+// @Produce json
+// @Param accountNum path string true "Número da conta"
+// @Param If-Match header string false "ETag da versão lida"
+// @Param client body UpdateClientRequest true "Dados do cliente"
+// @Success 200 {object} models.Client
+// @Header 200 {string} ETag "Nova versão do cliente"
+// @Failure 400 {object} map[string]interface{} "Mensagem de erro"
+// @Failure 404 {object} map[string]interface{} "client not found"
+// @Failure 412 {object} map[string]interface{} "Cliente alterado por outra requisição"
+// @Router /v1/clients/{accountNum} [patch]
+func (cc *ClientController) UpdateClient(c *gin.Context) {
+	var req UpdateClientRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	expectedVersion, ok := ifMatchVersion(c)
+	if !ok {
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": "invalid If-Match header"})
+		return
+	}
+
+	client, err := cc.ClientService.UpdateClient(c.Param("accountNum"), req.Name, expectedVersion)
+	switch {
+	case errors.Is(err, repositories.ErrClientNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "client not found"})
+		return
+	case errors.Is(err, repositories.ErrVersionConflict):
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	setVersionETag(c, client.Version)
 	c.JSON(http.StatusOK, client)
 }
 
@@ -88,5 +159,6 @@ func InitRoutes(r *gin.Engine, clientService services.ClientServiceInterface) {
 		v1.POST("/clients", clientController.CreateClient)
 		v1.GET("/clients", clientController.GetClients)
 		v1.GET("/clients/:accountNum", clientController.GetClientByAccountNum)
+		v1.PATCH("/clients/:accountNum", clientController.UpdateClient)
 	}
 }
@@ -0,0 +1,179 @@
+package controllers
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"banking/src/services"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CustomerController gerencia as rotas de clientes e das suas contas
+type CustomerController struct {
+	CustomerService services.CustomerServiceInterface
+}
+
+// NewCustomerController cria uma nova instância de CustomerController
+func NewCustomerController(customerService services.CustomerServiceInterface) *CustomerController {
+	return &CustomerController{CustomerService: customerService}
+}
+
+// CustomerRequest representa o corpo da criação de um cliente
+type CustomerRequest struct {
+	Name string `json:"name" binding:"required" example:"Maria Silva"`
+}
+
+// OpenAccountRequest representa o corpo da abertura de uma conta. Sem
+// account_type, a conta é corrente; sem currency, é em BRL. O número da conta
+// é gerado pelo servidor.
+type OpenAccountRequest struct {
+	AccountType string `json:"account_type" example:"savings"`
+	Currency    string `json:"currency" example:"BRL"`
+}
+
+// CreateCustomer cria um cliente
+// @Summary Cria um cliente
+// @Description Cria um cliente ainda sem contas; as contas são abertas em /v1/customers/{id}/accounts
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Param customer body CustomerRequest true "Nome do cliente"
+// @Success 201 {object} models.Customer
+// @Failure 400 {object} map[string]interface{} "Mensagem de erro"
+// @Router /v1/customers [post]
+func (cc *CustomerController) CreateCustomer(c *gin.Context) {
+	var req CustomerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	customer, err := cc.CustomerService.CreateCustomer(req.Name)
+	if errors.Is(err, models.ErrCustomerNameRequired) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, customer)
+}
+
+// GetCustomer busca um cliente
+// @Summary Busca um cliente
+// @Tags customers
+// @Produce json
+// @Param id path int true "ID do cliente"
+// @Success 200 {object} models.Customer
+// @Failure 404 {object} map[string]interface{} "customer not found"
+// @Router /v1/customers/{id} [get]
+func (cc *CustomerController) GetCustomer(c *gin.Context) {
+	id, ok := customerID(c)
+	if !ok {
+		return
+	}
+	customer, err := cc.CustomerService.GetCustomer(id)
+	if err != nil {
+		c.JSON(customerErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, customer)
+}
+
+// GetAccounts lista as contas de um cliente
+// @Summary Lista as contas de um cliente
+// @Description Retorna as contas do cliente na ordem em que foram abertas, inclusive as encerradas
+// @Tags customers
+// @Produce json
+// @Param id path int true "ID do cliente"
+// @Success 200 {array} models.Account
+// @Failure 404 {object} map[string]interface{} "customer not found"
+// @Router /v1/customers/{id}/accounts [get]
+func (cc *CustomerController) GetAccounts(c *gin.Context) {
+	id, ok := customerID(c)
+	if !ok {
+		return
+	}
+	accounts, err := cc.CustomerService.GetAccounts(id)
+	if err != nil {
+		c.JSON(customerErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, accounts)
+}
+
+// OpenAccount abre uma conta para um cliente
+// @Summary Abre uma conta para um cliente
+// @Description Abre mais uma conta para o cliente, com saldo zero e status active. O número da conta é gerado no formato agência-conta-DV e o nome do titular vem do cliente. A moeda (BRL por padrão) precisa ser um código ISO 4217 de três letras.
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Param id path int true "ID do cliente"
+// @Param account body OpenAccountRequest false "Tipo e moeda da conta"
+// @Success 201 {object} models.Account
+// @Header 201 {string} ETag "Versão da conta"
+// @Failure 400 {object} map[string]interface{} "Mensagem de erro"
+// @Failure 404 {object} map[string]interface{} "customer not found"
+// @Router /v1/customers/{id}/accounts [post]
+func (cc *CustomerController) OpenAccount(c *gin.Context) {
+	id, ok := customerID(c)
+	if !ok {
+		return
+	}
+
+	// O corpo é opcional: sem ele, a conta é corrente e em BRL
+	var req OpenAccountRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	account := models.Account{AccountType: req.AccountType, Balance: models.Money{Currency: req.Currency}}
+	if err := cc.CustomerService.OpenAccount(id, &account); err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, repositories.ErrCustomerNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	setVersionETag(c, account.Version)
+	c.JSON(http.StatusCreated, account)
+}
+
+// customerID lê o id do cliente da rota, respondendo 400 se ele for inválido
+func customerID(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid customer id"})
+		return 0, false
+	}
+	return id, true
+}
+
+func customerErrorStatus(err error) int {
+	if errors.Is(err, repositories.ErrCustomerNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+// InitCustomerRoutes inicializa as rotas de clientes e das suas contas
+func InitCustomerRoutes(r *gin.Engine, customerService services.CustomerServiceInterface) {
+	customerController := NewCustomerController(customerService)
+
+	v1 := r.Group("/v1")
+	{
+		v1.POST("/customers", customerController.CreateCustomer)
+		v1.GET("/customers/:id", customerController.GetCustomer)
+		v1.GET("/customers/:id/accounts", customerController.GetAccounts)
+		v1.POST("/customers/:id/accounts", customerController.OpenAccount)
+	}
+}
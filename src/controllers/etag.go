@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// versionETag formata a versão de um registro como ETag forte ("3")
+func versionETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// setVersionETag define o cabeçalho ETag da resposta
+func setVersionETag(c *gin.Context, version int) {
+	c.Header("ETag", versionETag(version))
+}
+
+// ifMatchVersion lê o cabeçalho If-Match, que deve conter uma única ETag.
+// Retorna 0 quando o cabeçalho está ausente ou é "*" (qualquer versão) e
+// ok=false quando a ETag não é uma versão válida, caso em que a pré-condição
+// não pode ser satisfeita.
+func ifMatchVersion(c *gin.Context) (version int, ok bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return 0, true
+	}
+	tag := strings.Trim(strings.TrimPrefix(header, "W/"), `"`)
+	version, err := strconv.Atoi(tag)
+	if err != nil || version <= 0 {
+		return 0, false
+	}
+	return version, true
+}
+
+// noneMatch informa se a versão atual não está no cabeçalho If-None-Match,
+// ou seja, se o cliente HTTP precisa receber o corpo da resposta
+func noneMatch(c *gin.Context, version int) bool {
+	header := c.GetHeader("If-None-Match")
+	if header == "" {
+		return true
+	}
+	current := versionETag(version)
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == current {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,93 @@
+package controllers
+
+import (
+	"banking/src/models"
+	"banking/src/services"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FeeController gerencia as rotas das tabelas de tarifas
+type FeeController struct {
+	FeeService services.FeeServiceInterface
+}
+
+// NewFeeController cria uma nova instância de FeeController
+func NewFeeController(feeService services.FeeServiceInterface) *FeeController {
+	return &FeeController{FeeService: feeService}
+}
+
+// FeeScheduleRequest representa o corpo da definição de uma tabela de tarifas
+type FeeScheduleRequest struct {
+	Currency      string           `json:"currency" example:"BRL"`
+	Flat          models.Money     `json:"flat"`
+	PercentBps    int              `json:"percent_bps" example:"50"` // percentual do valor em pontos-base (50 = 0,5%)
+	Tiers         []models.FeeTier `json:"tiers"`
+	FreeTransfers int              `json:"free_transfers" example:"5"` // transferências gratuitas por mês
+}
+
+// GetFeeSchedules lista as tabelas de tarifas
+// @Summary Lista as tabelas de tarifas
+// @Description Retorna a tabela de tarifas de transferência de cada tipo de conta
+// @Tags admin
+// @Produce json
+// @Success 200 {array} models.FeeSchedule
+// @Router /v1/admin/fees [get]
+func (fc *FeeController) GetFeeSchedules(c *gin.Context) {
+	schedules, err := fc.FeeService.GetSchedules()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, schedules)
+}
+
+// SetFeeSchedule define a tabela de tarifas de um tipo de conta
+// @Summary Define a tabela de tarifas
+// @Description Substitui a tabela de tarifas das transferências feitas por contas do tipo (checking ou savings). A tarifa é flat mais percent_bps do valor; a faixa (tiers) de maior min_amount alcançado pelo valor substitui flat e percent_bps. As primeiras free_transfers transferências do mês são gratuitas. Tudo zerado desativa as tarifas do tipo.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param accountType path string true "Tipo de conta (checking, savings)"
+// @Param feeScheduleRequest body FeeScheduleRequest true "Tabela de tarifas"
+// @Success 200 {object} models.FeeSchedule
+// @Failure 400 {object} map[string]interface{} "Tipo de conta ou tarifas inválidos"
+// @Router /v1/admin/fees/{accountType} [put]
+func (fc *FeeController) SetFeeSchedule(c *gin.Context) {
+	var req FeeScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	schedule, err := fc.FeeService.SetSchedule(models.FeeSchedule{
+		AccountType:   c.Param("accountType"),
+		Currency:      req.Currency,
+		Flat:          req.Flat,
+		PercentBps:    req.PercentBps,
+		Tiers:         req.Tiers,
+		FreeTransfers: req.FreeTransfers,
+	})
+	if errors.Is(err, services.ErrInvalidFeeSchedule) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, schedule)
+}
+
+// InitFeeRoutes inicializa as rotas das tabelas de tarifas
+func InitFeeRoutes(r *gin.Engine, feeService services.FeeServiceInterface) {
+	feeController := NewFeeController(feeService)
+
+	v1 := r.Group("/v1")
+	{
+		v1.GET("/admin/fees", feeController.GetFeeSchedules)
+		v1.PUT("/admin/fees/:accountType", feeController.SetFeeSchedule)
+	}
+}
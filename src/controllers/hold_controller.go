@@ -0,0 +1,210 @@
+package controllers
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"banking/src/services"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HoldController gerencia as rotas de reservas de saldo
+type HoldController struct {
+	HoldService services.HoldServiceInterface
+}
+
+// NewHoldController cria uma nova instância de HoldController
+func NewHoldController(holdService services.HoldServiceInterface) *HoldController {
+	return &HoldController{HoldService: holdService}
+}
+
+// HoldRequest representa o corpo da criação de uma reserva
+type HoldRequest struct {
+	ToAccount string       `json:"to_account" example:"654321"`
+	Amount    models.Money `json:"amount"`
+	// ExpiresAt é o prazo da reserva (RFC 3339); sem ele, a reserva vale 7 dias
+	ExpiresAt *time.Time `json:"expires_at,omitempty" example:"2030-01-05T09:00:00-03:00"`
+}
+
+// CaptureRequest representa o corpo opcional de uma captura. Sem amount, todo
+// o valor ainda reservado é capturado.
+type CaptureRequest struct {
+	Amount models.Money `json:"amount"`
+}
+
+// CaptureResponse é a reserva depois da captura e a transferência criada
+type CaptureResponse struct {
+	Hold     *models.Hold     `json:"hold"`
+	Transfer *models.Transfer `json:"transfer"`
+}
+
+// PlaceHold reserva saldo de uma conta
+// @Summary Cria uma reserva de saldo
+// @Description Reserva o valor do saldo disponível da conta em favor da conta de destino, sem alterar o saldo atual. A reserva pode depois ser capturada (total ou parcialmente), cancelada ou expirar.
+// @Tags holds
+// @Accept json
+// @Produce json
+// @Param accountNum path string true "Número da conta"
+// @Param holdRequest body HoldRequest true "Destino, valor e prazo"
+// @Success 201 {object} models.Hold
+// @Failure 400 {object} map[string]interface{} "Mensagem de erro e código do motivo (code)"
+// @Failure 404 {object} map[string]interface{} "Conta inexistente"
+// @Router /v1/accounts/{accountNum}/holds [post]
+func (hc *HoldController) PlaceHold(c *gin.Context) {
+	var req HoldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var expiresAt time.Time
+	if req.ExpiresAt != nil {
+		expiresAt = *req.ExpiresAt
+	}
+
+	hold, err := hc.HoldService.PlaceHold(c.Param("accountNum"), req.ToAccount, req.Amount, expiresAt)
+	if errors.Is(err, services.ErrExpiresAtNotInFuture) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(movementErrorStatus(err), transferErrorResponse(err))
+		return
+	}
+	c.JSON(http.StatusCreated, hold)
+}
+
+// GetHolds lista as reservas de uma conta
+// @Summary Lista reservas
+// @Description Retorna as reservas da conta, das mais recentes para as mais antigas, opcionalmente filtradas por status
+// @Tags holds
+// @Produce json
+// @Param accountNum path string true "Número da conta"
+// @Param status query string false "Status (active, captured, voided, expired)"
+// @Success 200 {array} models.Hold
+// @Failure 404 {object} map[string]interface{} "Conta inexistente"
+// @Router /v1/accounts/{accountNum}/holds [get]
+func (hc *HoldController) GetHolds(c *gin.Context) {
+	holds, err := hc.HoldService.GetHolds(c.Param("accountNum"), c.Query("status"))
+	if err != nil {
+		accountReadError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, holds)
+}
+
+// GetHold busca uma reserva
+// @Summary Busca uma reserva
+// @Description Retorna a reserva com o valor já capturado e o status
+// @Tags holds
+// @Produce json
+// @Param id path int true "ID da reserva"
+// @Success 200 {object} models.Hold
+// @Failure 404 {object} map[string]interface{} "hold not found"
+// @Router /v1/holds/{id} [get]
+func (hc *HoldController) GetHold(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hold id"})
+		return
+	}
+	hold, err := hc.HoldService.GetHold(id)
+	if err != nil {
+		c.JSON(holdErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, hold)
+}
+
+// CaptureHold captura uma reserva
+// @Summary Captura uma reserva
+// @Description Transforma o valor (ou todo o restante da reserva) em uma transferência para a conta de destino da reserva. Com captura parcial, a reserva continua ativa com o valor restante.
+// @Tags holds
+// @Accept json
+// @Produce json
+// @Param id path int true "ID da reserva"
+// @Param captureRequest body CaptureRequest false "Valor da captura parcial"
+// @Success 201 {object} CaptureResponse
+// @Failure 400 {object} map[string]interface{} "Mensagem de erro e código do motivo (code)"
+// @Failure 404 {object} map[string]interface{} "hold not found"
+// @Failure 409 {object} map[string]interface{} "hold is no longer active"
+// @Router /v1/holds/{id}/capture [post]
+func (hc *HoldController) CaptureHold(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hold id"})
+		return
+	}
+
+	// O corpo é opcional: sem ele, a captura é total
+	var req CaptureRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	hold, transfer, err := hc.HoldService.CaptureHold(id, req.Amount)
+	if err != nil {
+		c.JSON(holdErrorStatus(err), transferErrorResponse(err))
+		return
+	}
+	c.JSON(http.StatusCreated, CaptureResponse{Hold: hold, Transfer: transfer})
+}
+
+// VoidHold cancela uma reserva
+// @Summary Cancela uma reserva
+// @Description Encerra a reserva e devolve ao saldo disponível o valor ainda não capturado
+// @Tags holds
+// @Produce json
+// @Param id path int true "ID da reserva"
+// @Success 200 {object} models.Hold
+// @Failure 404 {object} map[string]interface{} "hold not found"
+// @Failure 409 {object} map[string]interface{} "hold is no longer active"
+// @Router /v1/holds/{id}/void [post]
+func (hc *HoldController) VoidHold(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hold id"})
+		return
+	}
+	hold, err := hc.HoldService.VoidHold(id)
+	if err != nil {
+		c.JSON(holdErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, hold)
+}
+
+func holdErrorStatus(err error) int {
+	var transferErr *services.TransferError
+	switch {
+	case errors.Is(err, repositories.ErrHoldNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, repositories.ErrHoldNotActive):
+		return http.StatusConflict
+	case errors.Is(err, models.ErrCaptureExceedsHold), errors.As(err, &transferErr):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// InitHoldRoutes inicializa as rotas de reservas de saldo
+func InitHoldRoutes(r *gin.Engine, holdService services.HoldServiceInterface) {
+	holdController := NewHoldController(holdService)
+
+	v1 := r.Group("/v1")
+	{
+		v1.POST("/accounts/:accountNum/holds", holdController.PlaceHold)
+		v1.GET("/accounts/:accountNum/holds", holdController.GetHolds)
+		v1.GET("/holds/:id", holdController.GetHold)
+		v1.POST("/holds/:id/capture", holdController.CaptureHold)
+		v1.POST("/holds/:id/void", holdController.VoidHold)
+	}
+}
@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"banking/src/services"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// InterestController gerencia as rotas de juros das contas
+type InterestController struct {
+	InterestService services.InterestServiceInterface
+}
+
+// NewInterestController cria uma nova instância de InterestController
+func NewInterestController(interestService services.InterestServiceInterface) *InterestController {
+	return &InterestController{InterestService: interestService}
+}
+
+// InterestRateRequest representa o corpo da definição de uma taxa de juros
+type InterestRateRequest struct {
+	RateBps int `json:"rate_bps" example:"650"` // juros anuais em pontos-base (650 = 6,5% a.a.)
+}
+
+// GetInterestRates lista as taxas de juros por tipo de conta
+// @Summary Lista as taxas de juros
+// @Description Retorna a taxa de juros anual, em pontos-base, de cada tipo de conta
+// @Tags admin
+// @Produce json
+// @Success 200 {array} models.InterestRate
+// @Router /v1/admin/interest-rates [get]
+func (ic *InterestController) GetInterestRates(c *gin.Context) {
+	rates, err := ic.InterestService.GetRates()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, rates)
+}
+
+// SetInterestRate define a taxa de juros de um tipo de conta
+// @Summary Define a taxa de juros
+// @Description Define a taxa de juros anual, em pontos-base e com base de 365 dias, das contas do tipo (checking ou savings). A nova taxa vale a partir do próximo acúmulo diário. Taxa zero desativa os juros do tipo.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param accountType path string true "Tipo de conta (checking, savings)"
+// @Param interestRateRequest body InterestRateRequest true "Taxa"
+// @Success 200 {object} models.InterestRate
+// @Failure 400 {object} map[string]interface{} "Tipo de conta ou taxa inválidos"
+// @Router /v1/admin/interest-rates/{accountType} [put]
+func (ic *InterestController) SetInterestRate(c *gin.Context) {
+	var req InterestRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rate, err := ic.InterestService.SetRate(c.Param("accountType"), req.RateBps)
+	if errors.Is(err, services.ErrInvalidRate) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, rate)
+}
+
+// GetInterest lista os juros de uma conta
+// @Summary Juros da conta
+// @Description Retorna os juros acumulados dia a dia (saldo do fim do dia, taxa e juros do dia arredondados) e as capitalizações mensais da conta, dos mais recentes para os mais antigos
+// @Tags accounts
+// @Produce json
+// @Param accountNum path string true "Número da conta"
+// @Success 200 {object} models.InterestHistory
+// @Failure 404 {object} map[string]interface{} "Conta inexistente"
+// @Router /v1/accounts/{accountNum}/interest [get]
+func (ic *InterestController) GetInterest(c *gin.Context) {
+	history, err := ic.InterestService.GetInterest(c.Param("accountNum"))
+	if err != nil {
+		accountReadError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, history)
+}
+
+// InitInterestRoutes inicializa as rotas de juros das contas
+func InitInterestRoutes(r *gin.Engine, interestService services.InterestServiceInterface) {
+	interestController := NewInterestController(interestService)
+
+	v1 := r.Group("/v1")
+	{
+		v1.GET("/admin/interest-rates", interestController.GetInterestRates)
+		v1.PUT("/admin/interest-rates/:accountType", interestController.SetInterestRate)
+		v1.GET("/accounts/:accountNum/interest", interestController.GetInterest)
+	}
+}
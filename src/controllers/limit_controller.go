@@ -0,0 +1,127 @@
+package controllers
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"banking/src/services"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LimitController gerencia as rotas administrativas de limites de transferência
+type LimitController struct {
+	LimitService services.LimitServiceInterface
+}
+
+// NewLimitController cria uma nova instância de LimitController
+func NewLimitController(limitService services.LimitServiceInterface) *LimitController {
+	return &LimitController{LimitService: limitService}
+}
+
+// LimitsRequest representa o corpo da definição de limites. Valores omitidos ou
+// zero significam "sem limite".
+type LimitsRequest struct {
+	PerTransfer      models.Money `json:"per_transfer"`
+	Daily            models.Money `json:"daily"`
+	Monthly          models.Money `json:"monthly"`
+	DailyCount       int          `json:"daily_count" example:"20"`
+	NightPerTransfer models.Money `json:"night_per_transfer"`
+	NightTotal       models.Money `json:"night_total"`
+}
+
+// GetLimits retorna os limites de uma conta
+// @Summary Consulta limites de transferência
+// @Description Retorna os limites em vigor para a conta (os próprios ou, com inherited = true, os padrão) e o quanto ela já transferiu no dia, no mês e no período noturno. Use "default" como conta para consultar os limites padrão.
+// @Tags admin
+// @Produce json
+// @Param accountNum path string true "Número da conta ou default"
+// @Success 200 {object} models.LimitStatus
+// @Failure 404 {object} map[string]interface{} "Conta inexistente"
+// @Router /v1/admin/limits/{accountNum} [get]
+func (lc *LimitController) GetLimits(c *gin.Context) {
+	status, err := lc.LimitService.GetLimits(c.Param("accountNum"))
+	if err != nil {
+		limitError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, status)
+}
+
+// SetLimits define os limites de uma conta
+// @Summary Define limites de transferência
+// @Description Cria ou substitui os limites da conta: por transferência, total diário, total mensal e quantidade diária de transferências, além dos limites do período noturno (20h às 6h, horário de Brasília), que se somam aos demais. Use "default" como conta para alterar os limites padrão.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param accountNum path string true "Número da conta ou default"
+// @Param limitsRequest body LimitsRequest true "Limites"
+// @Success 200 {object} models.TransferLimits
+// @Failure 400 {object} map[string]interface{} "Limites inválidos"
+// @Failure 404 {object} map[string]interface{} "Conta inexistente"
+// @Router /v1/admin/limits/{accountNum} [put]
+func (lc *LimitController) SetLimits(c *gin.Context) {
+	var req LimitsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	limits, err := lc.LimitService.SetLimits(models.TransferLimits{
+		AccountNum:       c.Param("accountNum"),
+		PerTransfer:      req.PerTransfer,
+		Daily:            req.Daily,
+		Monthly:          req.Monthly,
+		DailyCount:       req.DailyCount,
+		NightPerTransfer: req.NightPerTransfer,
+		NightTotal:       req.NightTotal,
+	})
+	if err != nil {
+		limitError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, limits)
+}
+
+// DeleteLimits remove os limites próprios de uma conta
+// @Summary Remove limites de transferência
+// @Description Remove os limites próprios da conta, que volta a seguir os limites padrão. Os limites padrão não podem ser removidos.
+// @Tags admin
+// @Param accountNum path string true "Número da conta"
+// @Success 204
+// @Failure 404 {object} map[string]interface{} "A conta não tem limites próprios"
+// @Failure 409 {object} map[string]interface{} "Os limites padrão não podem ser removidos"
+// @Router /v1/admin/limits/{accountNum} [delete]
+func (lc *LimitController) DeleteLimits(c *gin.Context) {
+	if err := lc.LimitService.DeleteLimits(c.Param("accountNum")); err != nil {
+		limitError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func limitError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrClientNotFound), errors.Is(err, repositories.ErrLimitsNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrDefaultLimitsRequired):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidLimits):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// InitLimitRoutes inicializa as rotas administrativas de limites
+func InitLimitRoutes(r *gin.Engine, limitService services.LimitServiceInterface) {
+	limitController := NewLimitController(limitService)
+
+	v1 := r.Group("/v1")
+	{
+		v1.GET("/admin/limits/:accountNum", limitController.GetLimits)
+		v1.PUT("/admin/limits/:accountNum", limitController.SetLimits)
+		v1.DELETE("/admin/limits/:accountNum", limitController.DeleteLimits)
+	}
+}
@@ -0,0 +1,46 @@
+package controllers
+
+import (
+	"banking/src/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NotificationController gerencia as rotas de notificações
+type NotificationController struct {
+	NotificationService services.NotificationServiceInterface
+}
+
+// NewNotificationController cria uma nova instância de NotificationController
+func NewNotificationController(notificationService services.NotificationServiceInterface) *NotificationController {
+	return &NotificationController{NotificationService: notificationService}
+}
+
+// GetNotifications lista as notificações de uma conta
+// @Summary Lista notificações
+// @Description Retorna os avisos da conta, como a entrada e a saída do cheque especial, dos mais recentes para os mais antigos
+// @Tags accounts
+// @Produce json
+// @Param accountNum path string true "Número da conta"
+// @Success 200 {array} models.Notification
+// @Failure 404 {object} map[string]interface{} "Conta inexistente"
+// @Router /v1/accounts/{accountNum}/notifications [get]
+func (nc *NotificationController) GetNotifications(c *gin.Context) {
+	notifications, err := nc.NotificationService.GetNotifications(c.Param("accountNum"))
+	if err != nil {
+		accountReadError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, notifications)
+}
+
+// InitNotificationRoutes inicializa as rotas de notificações
+func InitNotificationRoutes(r *gin.Engine, notificationService services.NotificationServiceInterface) {
+	notificationController := NewNotificationController(notificationService)
+
+	v1 := r.Group("/v1")
+	{
+		v1.GET("/accounts/:accountNum/notifications", notificationController.GetNotifications)
+	}
+}
@@ -0,0 +1,102 @@
+package controllers
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"banking/src/services"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OverdraftController gerencia as rotas do cheque especial
+type OverdraftController struct {
+	OverdraftService services.OverdraftServiceInterface
+}
+
+// NewOverdraftController cria uma nova instância de OverdraftController
+func NewOverdraftController(overdraftService services.OverdraftServiceInterface) *OverdraftController {
+	return &OverdraftController{OverdraftService: overdraftService}
+}
+
+// OverdraftRequest representa o corpo da definição do cheque especial
+type OverdraftRequest struct {
+	Limit   models.Money `json:"limit"`
+	RateBps int          `json:"rate_bps" example:"1200"` // juros anuais em pontos-base (1200 = 12% a.a.)
+}
+
+// SetOverdraft define o cheque especial de uma conta
+// @Summary Define o cheque especial
+// @Description Ativa ou altera o cheque especial da conta: o saldo pode ficar negativo até -limit, e os juros (taxa anual em pontos-base, base de 365 dias) são cobrados diariamente sobre o saldo negativo do fim do dia. Limite zero desativa o cheque especial. O limite não pode ficar abaixo do valor em uso.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param accountNum path string true "Número da conta"
+// @Param overdraftRequest body OverdraftRequest true "Limite e taxa"
+// @Success 200 {object} models.Client
+// @Failure 400 {object} map[string]interface{} "Limite ou taxa inválidos"
+// @Failure 404 {object} map[string]interface{} "Conta inexistente"
+// @Failure 409 {object} map[string]interface{} "O limite é menor que o valor em uso"
+// @Router /v1/admin/overdraft/{accountNum} [put]
+func (oc *OverdraftController) SetOverdraft(c *gin.Context) {
+	var req OverdraftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	client, err := oc.OverdraftService.SetOverdraft(c.Param("accountNum"), req.Limit, req.RateBps)
+	if err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrClientNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, repositories.ErrOverdraftInUse):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrInvalidOverdraft), errors.Is(err, models.ErrCurrencyMismatch):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, client)
+}
+
+// GetOverdraftInterest lista os juros de cheque especial cobrados de uma conta
+// @Summary Juros do cheque especial
+// @Description Retorna as cobranças diárias de juros do cheque especial da conta, das mais recentes para as mais antigas, com o saldo do fim do dia e a taxa usados
+// @Tags accounts
+// @Produce json
+// @Param accountNum path string true "Número da conta"
+// @Success 200 {array} models.OverdraftInterest
+// @Failure 404 {object} map[string]interface{} "Conta inexistente"
+// @Router /v1/accounts/{accountNum}/overdraft-interest [get]
+func (oc *OverdraftController) GetOverdraftInterest(c *gin.Context) {
+	charges, err := oc.OverdraftService.GetOverdraftInterest(c.Param("accountNum"))
+	if err != nil {
+		accountReadError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, charges)
+}
+
+// accountReadError responde a um erro de consulta sobre uma conta
+func accountReadError(c *gin.Context, err error) {
+	if errors.Is(err, repositories.ErrClientNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
+
+// InitOverdraftRoutes inicializa as rotas do cheque especial
+func InitOverdraftRoutes(r *gin.Engine, overdraftService services.OverdraftServiceInterface) {
+	overdraftController := NewOverdraftController(overdraftService)
+
+	v1 := r.Group("/v1")
+	{
+		v1.PUT("/admin/overdraft/:accountNum", overdraftController.SetOverdraft)
+		v1.GET("/accounts/:accountNum/overdraft-interest", overdraftController.GetOverdraftInterest)
+	}
+}
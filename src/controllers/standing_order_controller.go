@@ -0,0 +1,221 @@
+package controllers
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"banking/src/services"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StandingOrderController gerencia as rotas de ordens permanentes
+type StandingOrderController struct {
+	StandingOrderService services.StandingOrderServiceInterface
+}
+
+// NewStandingOrderController cria uma nova instância de StandingOrderController
+func NewStandingOrderController(standingOrderService services.StandingOrderServiceInterface) *StandingOrderController {
+	return &StandingOrderController{StandingOrderService: standingOrderService}
+}
+
+// StandingOrderRequest representa o corpo da criação de uma ordem permanente
+type StandingOrderRequest struct {
+	FromAccount    string       `json:"from_account" binding:"required" example:"123456"`
+	ToAccount      string       `json:"to_account" binding:"required" example:"654321"`
+	Amount         models.Money `json:"amount"`
+	Frequency      string       `json:"frequency" binding:"required" example:"monthly"` // daily, weekly, monthly ou cron
+	Interval       int          `json:"interval,omitempty" example:"1"`
+	Cron           string       `json:"cron,omitempty" example:"0 12 1 * *"`
+	StartAt        *time.Time   `json:"start_at,omitempty" example:"2030-01-05T09:00:00-03:00"`
+	EndAt          *time.Time   `json:"end_at,omitempty"`
+	MaxOccurrences int          `json:"max_occurrences,omitempty" example:"12"`
+}
+
+// CreateStandingOrder cria uma ordem permanente
+// @Summary Cria uma ordem permanente
+// @Description Agenda uma transferência recorrente diária, semanal, mensal ou por expressão cron (em UTC), com data de início, data final e número máximo de ocorrências opcionais. Recusas por saldo insuficiente são tentadas novamente.
+// @Tags standing-orders
+// @Accept json
+// @Produce json
+// @Param standingOrderRequest body StandingOrderRequest true "Regra da ordem permanente"
+// @Success 201 {object} models.StandingOrder
+// @Failure 400 {object} map[string]interface{} "Mensagem de erro e código do motivo (code)"
+// @Router /v1/standing-orders [post]
+func (sc *StandingOrderController) CreateStandingOrder(c *gin.Context) {
+	var req StandingOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	order := models.StandingOrder{
+		FromAccountNum: req.FromAccount,
+		ToAccountNum:   req.ToAccount,
+		Amount:         req.Amount,
+		Frequency:      req.Frequency,
+		Interval:       req.Interval,
+		Cron:           req.Cron,
+		EndAt:          req.EndAt,
+		MaxOccurrences: req.MaxOccurrences,
+	}
+	if req.StartAt != nil {
+		order.StartAt = *req.StartAt
+	}
+
+	created, err := sc.StandingOrderService.CreateStandingOrder(order)
+	if err != nil {
+		standingOrderError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, created)
+}
+
+// GetStandingOrder busca uma ordem permanente
+// @Summary Busca uma ordem permanente
+// @Tags standing-orders
+// @Produce json
+// @Param id path int true "ID da ordem"
+// @Success 200 {object} models.StandingOrder
+// @Failure 404 {object} map[string]interface{} "standing order not found"
+// @Router /v1/standing-orders/{id} [get]
+func (sc *StandingOrderController) GetStandingOrder(c *gin.Context) {
+	sc.withOrder(c, sc.StandingOrderService.GetStandingOrder)
+}
+
+// GetStandingOrders lista as ordens permanentes de uma conta
+// @Summary Lista ordens permanentes
+// @Description Retorna as ordens em que a conta é origem ou destino, opcionalmente filtradas por status
+// @Tags standing-orders
+// @Produce json
+// @Param accountNum path string true "Número da conta"
+// @Param status query string false "Status (active, paused, cancelled, completed)"
+// @Success 200 {array} models.StandingOrder
+// @Failure 500 {object} map[string]interface{} "Mensagem de erro"
+// @Router /v1/accounts/{accountNum}/standing-orders [get]
+func (sc *StandingOrderController) GetStandingOrders(c *gin.Context) {
+	orders, err := sc.StandingOrderService.GetStandingOrders(c.Param("accountNum"), c.Query("status"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, orders)
+}
+
+// GetStandingOrderRuns lista as execuções de uma ordem permanente
+// @Summary Histórico de execuções
+// @Description Retorna cada tentativa de execução da ordem, da mais recente para a mais antiga, com o resultado e a próxima tentativa quando houver
+// @Tags standing-orders
+// @Produce json
+// @Param id path int true "ID da ordem"
+// @Success 200 {array} models.StandingOrderRun
+// @Failure 404 {object} map[string]interface{} "standing order not found"
+// @Router /v1/standing-orders/{id}/runs [get]
+func (sc *StandingOrderController) GetStandingOrderRuns(c *gin.Context) {
+	id, ok := standingOrderID(c)
+	if !ok {
+		return
+	}
+	runs, err := sc.StandingOrderService.GetStandingOrderRuns(id)
+	if err != nil {
+		standingOrderError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, runs)
+}
+
+// PauseStandingOrder pausa uma ordem permanente
+// @Summary Pausa uma ordem permanente
+// @Tags standing-orders
+// @Produce json
+// @Param id path int true "ID da ordem"
+// @Success 200 {object} models.StandingOrder
+// @Failure 404 {object} map[string]interface{} "standing order not found"
+// @Failure 409 {object} map[string]interface{} "A ordem não está ativa"
+// @Router /v1/standing-orders/{id}/pause [post]
+func (sc *StandingOrderController) PauseStandingOrder(c *gin.Context) {
+	sc.withOrder(c, sc.StandingOrderService.PauseStandingOrder)
+}
+
+// ResumeStandingOrder retoma uma ordem permanente
+// @Summary Retoma uma ordem permanente
+// @Description Reativa uma ordem pausada a partir da próxima ocorrência futura; ocorrências vencidas durante a pausa não são executadas
+// @Tags standing-orders
+// @Produce json
+// @Param id path int true "ID da ordem"
+// @Success 200 {object} models.StandingOrder
+// @Failure 404 {object} map[string]interface{} "standing order not found"
+// @Failure 409 {object} map[string]interface{} "A ordem não está pausada"
+// @Router /v1/standing-orders/{id}/resume [post]
+func (sc *StandingOrderController) ResumeStandingOrder(c *gin.Context) {
+	sc.withOrder(c, sc.StandingOrderService.ResumeStandingOrder)
+}
+
+// CancelStandingOrder cancela uma ordem permanente
+// @Summary Cancela uma ordem permanente
+// @Tags standing-orders
+// @Produce json
+// @Param id path int true "ID da ordem"
+// @Success 200 {object} models.StandingOrder
+// @Failure 404 {object} map[string]interface{} "standing order not found"
+// @Failure 409 {object} map[string]interface{} "A ordem já foi cancelada ou concluída"
+// @Router /v1/standing-orders/{id}/cancel [post]
+func (sc *StandingOrderController) CancelStandingOrder(c *gin.Context) {
+	sc.withOrder(c, sc.StandingOrderService.CancelStandingOrder)
+}
+
+// withOrder executa uma operação sobre a ordem identificada na rota e responde com a ordem resultante
+func (sc *StandingOrderController) withOrder(c *gin.Context, operation func(id int) (*models.StandingOrder, error)) {
+	id, ok := standingOrderID(c)
+	if !ok {
+		return
+	}
+	order, err := operation(id)
+	if err != nil {
+		standingOrderError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, order)
+}
+
+func standingOrderID(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid standing order id"})
+		return 0, false
+	}
+	return id, true
+}
+
+func standingOrderError(c *gin.Context, err error) {
+	var transferErr *services.TransferError
+	switch {
+	case errors.Is(err, repositories.ErrStandingOrderNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, repositories.ErrStandingOrderInvalidState):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidStandingOrder), errors.As(err, &transferErr):
+		c.JSON(http.StatusBadRequest, transferErrorResponse(err))
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// InitStandingOrderRoutes inicializa as rotas de ordens permanentes
+func InitStandingOrderRoutes(r *gin.Engine, standingOrderService services.StandingOrderServiceInterface) {
+	standingOrderController := NewStandingOrderController(standingOrderService)
+
+	v1 := r.Group("/v1")
+	{
+		v1.POST("/standing-orders", standingOrderController.CreateStandingOrder)
+		v1.GET("/standing-orders/:id", standingOrderController.GetStandingOrder)
+		v1.GET("/standing-orders/:id/runs", standingOrderController.GetStandingOrderRuns)
+		v1.POST("/standing-orders/:id/pause", standingOrderController.PauseStandingOrder)
+		v1.POST("/standing-orders/:id/resume", standingOrderController.ResumeStandingOrder)
+		v1.POST("/standing-orders/:id/cancel", standingOrderController.CancelStandingOrder)
+		v1.GET("/accounts/:accountNum/standing-orders", standingOrderController.GetStandingOrders)
+	}
+}
@@ -0,0 +1,89 @@
+package controllers
+
+import (
+	"banking/src/models"
+	"banking/src/services"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StatementController gerencia as rotas de extrato das contas
+type StatementController struct {
+	StatementService services.StatementServiceInterface
+}
+
+// NewStatementController cria uma nova instância de StatementController
+func NewStatementController(statementService services.StatementServiceInterface) *StatementController {
+	return &StatementController{StatementService: statementService}
+}
+
+// GetStatement retorna o extrato de uma conta
+// @Summary Extrato da conta
+// @Description Retorna o saldo de abertura, cada movimentação do período em ordem cronológica (data, tipo, contraparte, valor com sinal e saldo logo depois), o saldo de fechamento e os totais de créditos e débitos, pelo livro-razão. from e to aceitam datas (YYYY-MM-DD, no horário de Brasília, com o dia de to incluído) ou instantes RFC 3339 (to excluído). Por padrão o período vai do início do mês até agora.
+// @Tags accounts
+// @Produce json
+// @Param accountNum path string true "Número da conta"
+// @Param from query string false "Início do período (YYYY-MM-DD ou RFC 3339)"
+// @Param to query string false "Fim do período (YYYY-MM-DD ou RFC 3339)"
+// @Success 200 {object} models.Statement
+// @Failure 400 {object} map[string]interface{} "Período inválido"
+// @Failure 404 {object} map[string]interface{} "Conta inexistente"
+// @Router /v1/accounts/{accountNum}/statement [get]
+func (sc *StatementController) GetStatement(c *gin.Context) {
+	from, err := parsePeriodBound(c.Query("from"), false)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	to, err := parsePeriodBound(c.Query("to"), true)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	statement, err := sc.StatementService.GetStatement(c.Param("accountNum"), from, to)
+	if errors.Is(err, models.ErrInvalidPeriod) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		accountReadError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, statement)
+}
+
+// parsePeriodBound interpreta um limite de período informado como data
+// (YYYY-MM-DD, em models.BusinessLocation) ou instante RFC 3339. Uma data
+// usada como fim do período inclui o dia inteiro. Vazio retorna o instante
+// zero, e o serviço aplica o padrão.
+func parsePeriodBound(value string, end bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if day, err := time.ParseInLocation(time.DateOnly, value, models.BusinessLocation); err == nil {
+		if end {
+			day = day.AddDate(0, 0, 1)
+		}
+		return day, nil
+	}
+	instant, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid period bound %q: use YYYY-MM-DD or RFC 3339", value)
+	}
+	return instant, nil
+}
+
+// InitStatementRoutes inicializa as rotas de extrato das contas
+func InitStatementRoutes(r *gin.Engine, statementService services.StatementServiceInterface) {
+	statementController := NewStatementController(statementService)
+
+	v1 := r.Group("/v1")
+	{
+		v1.GET("/accounts/:accountNum/statement", statementController.GetStatement)
+	}
+}
@@ -1,31 +1,56 @@
 package controllers
 
 import (
+	"banking/src/models"
+	"banking/src/repositories"
 	"banking/src/services"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+// IdempotencyKeyHeader é o cabeçalho usado para tornar POST /v1/transfer seguro para retentativas
+const IdempotencyKeyHeader = "Idempotency-Key"
+
 // TransferController define o controlador para as operações de transferência
 type TransferController struct {
-	TransferService services.TransferServiceInterface
+	TransferService          services.TransferServiceInterface
+	ScheduledTransferService services.ScheduledTransferServiceInterface
+	IdempotencyService       services.IdempotencyServiceInterface
 }
 
 // NewTransferController cria uma nova instância de TransferController
-func NewTransferController(transferService services.TransferServiceInterface) *TransferController {
-	return &TransferController{TransferService: transferService}
+func NewTransferController(transferService services.TransferServiceInterface, scheduledTransferService services.ScheduledTransferServiceInterface, idempotencyService services.IdempotencyServiceInterface) *TransferController {
+	return &TransferController{
+		TransferService:          transferService,
+		ScheduledTransferService: scheduledTransferService,
+		IdempotencyService:       idempotencyService,
+	}
 }
 
 // TransferFunds realiza uma transferência entre contas
 // @Summary Realiza uma transferência
-// @Description Realiza uma transferência entre duas contas fornecidas
+// @Description Realiza uma transferência entre duas contas fornecidas e retorna a transferência e a tarifa cobrada da conta de origem (fee), conforme a tabela de tarifas do tipo da conta. Com execute_at, a transferência é agendada para essa data e a resposta é 202 com o agendamento. Com o cabeçalho Idempotency-Key, repetições da mesma requisição reproduzem a primeira resposta sem movimentar dinheiro novamente.
 // @Tags transfers
 // @Accept json
 // @Produce json
+// @Param Idempotency-Key header string false "Chave de idempotência"
 // @Param transferRequest body TransferRequest true "Dados da Transferência"
-// @Success 200 {object} map[string]interface{} "Transferência realizada com sucesso"
-// @Failure 400 {object} map[string]interface{} "Mensagem de erro"
+// @Success 200 {object} map[string]interface{} "Transferência realizada com sucesso (transfer) e tarifa cobrada (fee)"
+// @Success 202 {object} map[string]interface{} "Transferência agendada (scheduled_transfer)"
+// @Failure 400 {object} map[string]interface{} "Mensagem de erro e código do motivo (code)"
+// @Failure 409 {object} map[string]interface{} "Requisição com a mesma chave ainda em processamento"
+// @Failure 422 {object} map[string]interface{} "Chave de idempotência usada com outro conteúdo"
+// @Failure 500 {object} map[string]interface{} "Erro inesperado; a chave de idempotência é liberada para nova tentativa"
 // @Router /v1/transfer [post]
 func (tc *TransferController) TransferFunds(c *gin.Context) {
 	var transferRequest TransferRequest
@@ -34,13 +59,142 @@ func (tc *TransferController) TransferFunds(c *gin.Context) {
 		return
 	}
 
-	err := tc.TransferService.TransferFunds(transferRequest.FromAccount, transferRequest.ToAccount, transferRequest.Amount)
+	key := c.GetHeader(IdempotencyKeyHeader)
+	if key == "" {
+		status, body := tc.transferFunds(transferRequest)
+		c.JSON(status, body)
+		return
+	}
+
+	record, err := tc.IdempotencyService.Reserve(key, transferRequest.hash())
+	switch {
+	case errors.Is(err, services.ErrIdempotencyKeyConflict):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, services.ErrIdempotencyKeyInProgress):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	case record != nil:
+		c.Header("Idempotent-Replayed", "true")
+		c.Data(record.StatusCode, "application/json; charset=utf-8", record.ResponseBody)
+		return
+	}
+
+	// Erros inesperados não são armazenados: a chave é liberada para que a
+	// requisição possa ser repetida
+	status, body := tc.transferFunds(transferRequest)
+	payload, err := json.Marshal(body)
+	if err == nil && status < http.StatusInternalServerError {
+		err = tc.IdempotencyService.Complete(key, status, payload)
+		if err != nil {
+			log.Printf("Error storing response for idempotency key %s: %v", key, err)
+		}
+	}
+	if err != nil || status >= http.StatusInternalServerError {
+		if err := tc.IdempotencyService.Release(key); err != nil {
+			log.Printf("Error releasing idempotency key %s: %v", key, err)
+		}
+	}
+	if payload == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to encode response"})
+		return
+	}
+	c.Data(status, "application/json; charset=utf-8", payload)
+}
+
+// transferFunds executa (ou agenda, quando há execute_at) a transferência e
+// retorna o status e o corpo da resposta
+func (tc *TransferController) transferFunds(transferRequest TransferRequest) (int, gin.H) {
+	if transferRequest.ExecuteAt != nil {
+		scheduled, err := tc.ScheduledTransferService.ScheduleTransfer(transferRequest.FromAccount, transferRequest.ToAccount, transferRequest.Amount, *transferRequest.ExecuteAt)
+		if err != nil {
+			return transferErrorStatus(err), transferErrorResponse(err)
+		}
+		return http.StatusAccepted, gin.H{"status": "transfer scheduled", "scheduled_transfer": scheduled}
+	}
+
+	transfer, err := tc.TransferService.TransferFunds(transferRequest.FromAccount, transferRequest.ToAccount, transferRequest.Amount)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return transferErrorStatus(err), transferErrorResponse(err)
+	}
+	return http.StatusOK, gin.H{"status": "transfer successful", "transfer": transfer, "fee": transfer.Fee}
+}
+
+// transferErrorStatus retorna 400 para recusas pelas regras de negócio e 500
+// para erros inesperados (banco de dados, livro-razão), que podem ser repetidos
+func transferErrorStatus(err error) int {
+	var transferErr *services.TransferError
+	if errors.As(err, &transferErr) || errors.Is(err, services.ErrExecuteAtNotInFuture) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// transferErrorResponse monta o corpo de erro incluindo o código do motivo
+// quando a transferência foi recusada pelas regras de negócio e, para limites
+// excedidos, o limite e quanto ainda pode ser transferido
+func transferErrorResponse(err error) gin.H {
+	response := gin.H{"error": err.Error()}
+	var transferErr *services.TransferError
+	if errors.As(err, &transferErr) {
+		response["code"] = transferErr.Reason
+	}
+	var limitErr *models.LimitExceededError
+	if errors.As(err, &limitErr) {
+		response["limit"] = limitErr.Limit
+		response["remaining"] = limitErr.Remaining
+	}
+	return response
+}
+
+// ReversalRequest representa o corpo opcional de um estorno. Sem amount, todo o
+// valor ainda não estornado é devolvido.
+type ReversalRequest struct {
+	Amount models.Money `json:"amount"`
+}
+
+// ReverseTransfer estorna uma transferência
+// @Summary Estorna uma transferência
+// @Description Devolve o valor (ou parte dele) da conta de destino para a de origem, criando uma transferência do tipo "reversal" ligada à original. A soma dos estornos não pode ultrapassar o valor original.
+// @Tags transfers
+// @Accept json
+// @Produce json
+// @Param id path int true "ID da transferência"
+// @Param reversalRequest body ReversalRequest false "Valor do estorno parcial"
+// @Success 201 {object} models.Transfer
+// @Failure 400 {object} map[string]interface{} "Mensagem de erro e código do motivo (code)"
+// @Failure 404 {object} map[string]interface{} "transfer not found"
+// @Failure 500 {object} map[string]interface{} "Mensagem de erro"
+// @Router /v1/transfers/{id}/reversal [post]
+func (tc *TransferController) ReverseTransfer(c *gin.Context) {
+	transferID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid transfer id"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"status": "transfer successful"})
+	// O corpo é opcional: sem ele, o estorno é total
+	var req ReversalRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	reversal, err := tc.TransferService.ReverseTransfer(transferID, req.Amount)
+	if errors.Is(err, repositories.ErrTransferNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(transferErrorStatus(err), transferErrorResponse(err))
+		return
+	}
+	c.JSON(http.StatusCreated, reversal)
 }
 
 // GetTransferHistory obtém o histórico de transferências de uma conta
@@ -62,211 +216,118 @@ func (tc *TransferController) GetTransferHistory(c *gin.Context) {
 	c.JSON(http.StatusOK, transfers)
 }
 
+// GetScheduledTransfers lista as transferências agendadas de uma conta
+// @Summary Lista transferências agendadas
+// @Description Retorna os agendamentos em que a conta é origem ou destino, em ordem de execução, opcionalmente filtrados por status
+// @Tags transfers
+// @Produce json
+// @Param accountNum path string true "Número da conta"
+// @Param status query string false "Status (pending, processing, executed, failed, cancelled)"
+// @Success 200 {array} models.ScheduledTransfer
+// @Failure 500 {object} map[string]interface{} "Mensagem de erro"
+// @Router /v1/accounts/{accountNum}/scheduled-transfers [get]
+func (tc *TransferController) GetScheduledTransfers(c *gin.Context) {
+	scheduledTransfers, err := tc.ScheduledTransferService.GetScheduledTransfers(c.Param("accountNum"), c.Query("status"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, scheduledTransfers)
+}
+
+// GetScheduledTransfer busca uma transferência agendada
+// @Summary Busca uma transferência agendada
+// @Description Retorna o agendamento e, depois da execução, o seu resultado
+// @Tags transfers
+// @Produce json
+// @Param id path int true "ID do agendamento"
+// @Success 200 {object} models.ScheduledTransfer
+// @Failure 404 {object} map[string]interface{} "scheduled transfer not found"
+// @Router /v1/scheduled-transfers/{id} [get]
+func (tc *TransferController) GetScheduledTransfer(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid scheduled transfer id"})
+		return
+	}
+	scheduled, err := tc.ScheduledTransferService.GetScheduledTransfer(id)
+	if err != nil {
+		c.JSON(scheduledTransferErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, scheduled)
+}
+
+// CancelScheduledTransfer cancela uma transferência agendada
+// @Summary Cancela uma transferência agendada
+// @Description Cancela um agendamento que ainda está pendente
+// @Tags transfers
+// @Produce json
+// @Param id path int true "ID do agendamento"
+// @Success 200 {object} models.ScheduledTransfer
+// @Failure 404 {object} map[string]interface{} "scheduled transfer not found"
+// @Failure 409 {object} map[string]interface{} "scheduled transfer is no longer pending"
+// @Router /v1/scheduled-transfers/{id}/cancel [post]
+func (tc *TransferController) CancelScheduledTransfer(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid scheduled transfer id"})
+		return
+	}
+	scheduled, err := tc.ScheduledTransferService.CancelScheduledTransfer(id)
+	if err != nil {
+		c.JSON(scheduledTransferErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, scheduled)
+}
+
+func scheduledTransferErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, repositories.ErrScheduledTransferNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, repositories.ErrScheduledTransferNotPending):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 // TransferRequest representa o corpo da requisição de transferência
 type TransferRequest struct {
-	FromAccount string  `json:"from_account" example:"123456"`
-	ToAccount   string  `json:"to_account" example:"654321"`
-	Amount      float64 `json:"amount" example:"100.50"`
+	FromAccount string       `json:"from_account" example:"123456"`
+	ToAccount   string       `json:"to_account" example:"654321"`
+	Amount      models.Money `json:"amount"`
+	// ExecuteAt agenda a transferência para uma data futura (RFC 3339)
+	ExecuteAt *time.Time `json:"execute_at,omitempty" example:"2030-01-05T09:00:00-03:00"`
+}
+
+// hash identifica o conteúdo da requisição para detectar chaves de
+// idempotência reutilizadas com outros dados
+func (r TransferRequest) hash() string {
+	amount := r.Amount
+	if amount.Currency == "" {
+		amount.Currency = models.DefaultCurrency
+	}
+	content := fmt.Sprintf("%s|%s|%d|%s", r.FromAccount, r.ToAccount, amount.Cents, amount.Currency)
+	if r.ExecuteAt != nil {
+		content += "|" + r.ExecuteAt.UTC().Format(time.RFC3339Nano)
+	}
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
 }
 
 // InitTransferRoutes inicializa as rotas de transferência
-func InitTransferRoutes(r *gin.Engine, transferService services.TransferServiceInterface) {
-	transferController := NewTransferController(transferService)
+func InitTransferRoutes(r *gin.Engine, transferService services.TransferServiceInterface, scheduledTransferService services.ScheduledTransferServiceInterface, idempotencyService services.IdempotencyServiceInterface) {
+	transferController := NewTransferController(transferService, scheduledTransferService, idempotencyService)
 
 	v1 := r.Group("/v1")
 	{
 		v1.POST("/transfer", transferController.TransferFunds)
 		v1.GET("/transfers/:accountNum", transferController.GetTransferHistory)
+		v1.POST("/transfers/:id/reversal", transferController.ReverseTransfer)
+		v1.GET("/scheduled-transfers/:id", transferController.GetScheduledTransfer)
+		v1.POST("/scheduled-transfers/:id/cancel", transferController.CancelScheduledTransfer)
+		v1.GET("/accounts/:accountNum/scheduled-transfers", transferController.GetScheduledTransfers)
 	}
 }
-# Banking API
-
-Esta é uma API de exemplo para um sistema bancário, desenvolvida em Go utilizando o framework Gin. A API permite a criação de clientes, realização de transferências entre contas e consulta de histórico de transferências. A documentação foi gerada automaticamente com Swagger.
-
-## Sumário
-- [Tecnologias](#tecnologias)
-- [Pré-requisitos](#pré-requisitos)
-- [Instalação e Configuração](#instalação-e-configuração)
-- [Endpoints da API](#endpoints-da-api)
-- [Documentação Swagger](#documentação-swagger)
-- [Comandos Úteis](#comandos-úteis)
-- [Estrutura do Projeto](#estrutura-do-projeto)
-- [Exemplo de Uso](#exemplo-de-uso)
-
-## Tecnologias
-
-- Go
-- Gin
-- SQLite
-- Swagger para documentação
-
-## Pré-requisitos
-
-- [Go](https://golang.org/doc/install) versão 1.16 ou superior
-- [Docker](https://docs.docker.com/get-docker/) (opcional, para rodar com Docker)
-- [Swag CLI](https://github.com/swaggo/swag) para gerar a documentação Swagger
-    ```bash
-    go get -u github.com/swaggo/swag/cmd/swag
-    ```
-
-## Instalação e Configuração
-
-1. Clone o repositório:
-
-    ```bash
-    git clone https://github.com/seu-usuario/banking-api.git
-    cd banking-api
-    ```
-
-2. Inicialize o módulo Go (se ainda não estiver inicializado):
-
-    ```bash
-    go mod init banking
-    go mod tidy
-    ```
-
-3. Gere a documentação Swagger:
-
-    ```bash
-    swag init -g src/main.go
-    ```
-
-4. Construa o banco de dados SQLite. A aplicação criará as tabelas `clients` e `transfers` automaticamente ao iniciar. O caminho padrão do banco de dados é `./bank.db`.
-
-## Executando a Aplicação
-
-Você pode rodar a aplicação localmente com Go ou usando Docker.
-
-### Executando Localmente
-
-1. Compile e execute a aplicação:
-
-    ```bash
-    go run src/main.go
-    ```
-
-2. Acesse a API em `http://localhost:8080`.
-
-### Executando com Docker
-
-1. Construa a imagem Docker:
-
-    ```bash
-    docker build -t banking-api .
-    ```
-
-2. Execute o contêiner:
-
-    ```bash
-    docker run -p 8080:8080 banking-api
-    ```
-
-## Endpoints da API
-
-### Clientes
-
-- **POST** `/v1/clients`: Cria um novo cliente.
-- **GET** `/v1/clients`: Lista todos os clientes.
-- **GET** `/v1/clients/{accountNum}`: Busca um cliente pelo número da conta.
-
-### Transferências
-
-- **POST** `/v1/transfer`: Realiza uma transferência entre duas contas.
-- **GET** `/v1/transfers/{accountNum}`: Obtém o histórico de transferências associado a uma conta específica.
-
-## Documentação Swagger
-
-A documentação Swagger está disponível em `http://localhost:8080/swagger/index.html` após iniciar a aplicação.
-
-### Configuração no Código
-
-No arquivo `main.go`, a configuração Swagger está especificada para gerar a documentação baseada em anotações nos handlers.
-
-Exemplo de anotação:
-
-```go
-// @Summary Cria um novo cliente
-// @Description Cria um novo cliente com as informações fornecidas
-// @Tags clients
-// @Accept json
-// @Produce json
-// @Param client body models.Client true "Cliente"
-// @Success 200 {object} models.Client
-// @Failure 400 {object} map[string]interface{} "Mensagem de erro"
-// @Router /v1/clients [post]
-```
-
-# Comandos Úteis
-Geração da documentação Swagger:
-
-```bash
-swag init -g src/main.go
-```
-
-# Remover a documentação:
-```bash
-rm -rf docs
-```
-# Estrutura do Projeto
-## O projeto está organizado da seguinte forma:
-
-banking/
-├── go.mod
-├── go.sum
-└── src/
-    ├── main.go                  # Ponto de entrada da aplicação
-    ├── controllers/             # Controladores de rota
-    │   ├── client_controller.go # Controller de Clientes
-    │   ├── transfer_controller.go # Controller de Transferências
-    │   └── routes.go            # Iniciação de Rotas
-    ├── database/
-    │   └── database.go          # Inicialização do banco de dados SQLite
-    ├── models/                  # Definições de Modelos (Client, Transfer)
-    ├── repositories/            # Repositórios de Acesso ao Banco
-    └── services/                # Serviços de Lógica de Negócio
-
-/
-
-# Exemplo de Uso
-
-## Criar um Cliente:
-
-```bash
-curl -X POST http://localhost:8080/v1/clients \
--H "Content-Type: application/json" \
--d '{
-      "name": "John Doe",
-      "account_num": "123456",
-      "balance": 1000.0
-    }'
-```
-
-## Listar Clientes:
-```bash
-curl -X GET http://localhost:8080/v1/clients
-```
-
-## Buscar um Cliente:
-```bash
-curl -X GET http://localhost:8080/v1/clients/123456
-```
-
-## Realizar uma Transferência:
-```bash
-curl -X POST http://localhost:8080/v1/transfer \
--H "Content-Type: application/json" \
--d '{
-      "from_account": "123456",
-      "to_account": "654321",
-      "amount": 100.0
-    }'
-```
-
-## Consultar Histórico de Transferências:
-```bash
-curl -X GET http://localhost:8080/v1/transfers/123456
-```
-
-
-
-
@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"banking/src/models"
+	"banking/src/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TreasuryController gerencia as rotas da tesouraria
+type TreasuryController struct {
+	AccountService  services.AccountServiceInterface
+	TreasuryService services.TreasuryServiceInterface
+}
+
+// NewTreasuryController cria uma nova instância de TreasuryController
+func NewTreasuryController(accountService services.AccountServiceInterface, treasuryService services.TreasuryServiceInterface) *TreasuryController {
+	return &TreasuryController{AccountService: accountService, TreasuryService: treasuryService}
+}
+
+// FundingRequest representa o corpo de um financiamento da tesouraria
+type FundingRequest struct {
+	AccountNum string       `json:"account_num" binding:"required" example:"123456"`
+	Amount     models.Money `json:"amount"`
+}
+
+// Fund financia uma conta com dinheiro da tesouraria
+// @Summary Financia uma conta
+// @Description Transfere o valor da conta interna da tesouraria para a conta informada. É assim que contas novas, que começam com saldo zero, recebem o saldo inicial; cada conta só pode ser financiada uma vez.
+// @Tags treasury
+// @Accept json
+// @Produce json
+// @Param fundingRequest body FundingRequest true "Conta e valor"
+// @Success 201 {object} models.Transfer
+// @Failure 400 {object} map[string]interface{} "Mensagem de erro e código do motivo (code)"
+// @Failure 404 {object} map[string]interface{} "Conta inexistente"
+// @Failure 409 {object} map[string]interface{} "Conta já financiada (code already_funded)"
+// @Failure 500 {object} map[string]interface{} "Mensagem de erro"
+// @Router /v1/admin/treasury/fundings [post]
+func (tc *TreasuryController) Fund(c *gin.Context) {
+	var req FundingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	funding, err := tc.AccountService.Fund(req.AccountNum, req.Amount)
+	if err != nil {
+		c.JSON(movementErrorStatus(err), transferErrorResponse(err))
+		return
+	}
+	c.JSON(http.StatusCreated, funding)
+}
+
+// GetPosition retorna a posição da tesouraria
+// @Summary Posição da tesouraria
+// @Description Retorna o saldo da tesouraria, das demais contas internas e a soma dos saldos dos clientes em uma moeda. Em um sistema íntegro, todos somam zero (balanced = true).
+// @Tags treasury
+// @Produce json
+// @Param currency query string false "Moeda (padrão BRL)"
+// @Success 200 {object} models.TreasuryPosition
+// @Failure 500 {object} map[string]interface{} "Mensagem de erro"
+// @Router /v1/treasury/position [get]
+func (tc *TreasuryController) GetPosition(c *gin.Context) {
+	position, err := tc.TreasuryService.GetPosition(c.Query("currency"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, position)
+}
+
+// InitTreasuryRoutes inicializa as rotas da tesouraria
+func InitTreasuryRoutes(r *gin.Engine, accountService services.AccountServiceInterface, treasuryService services.TreasuryServiceInterface) {
+	treasuryController := NewTreasuryController(accountService, treasuryService)
+
+	v1 := r.Group("/v1")
+	{
+		v1.POST("/admin/treasury/fundings", treasuryController.Fund)
+		v1.GET("/treasury/position", treasuryController.GetPosition)
+	}
+}
@@ -1,14 +1,30 @@
 package database
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
 	"log"
+	"strings"
 
 	_ "github.com/mattn/go-sqlite3"
 )
 
+// sqliteOptions faz cada transação reservar o banco para escrita já no BEGIN e
+// esperar por outras conexões em vez de falhar com SQLITE_BUSY. Sem isso, duas
+// transferências concorrentes entre contas distintas podem ler e depois
+// disputar a escrita, e uma delas falharia. Também liga em cada conexão a
+// verificação das chaves estrangeiras, que o SQLite deixa desligada por padrão.
+const sqliteOptions = "_txlock=immediate&_busy_timeout=5000&_foreign_keys=on"
+
 func InitDB(db_name string) (*sql.DB, error) {
-	db, err := sql.Open("sqlite3", db_name)
+	db, err := sql.Open("sqlite3", withSQLiteOptions(db_name))
+	if err != nil {
+		return nil, err
+	}
+
+	// Chama a função para criar a tabela customers
+	err = createCustomersTable(db)
 	if err != nil {
 		return nil, err
 	}
@@ -25,18 +41,157 @@ func InitDB(db_name string) (*sql.DB, error) {
 		return nil, err
 	}
 
+	// Converte valores monetários REAL de bancos antigos para centavos
+	err = migrateMoneyColumns(db)
+	if err != nil {
+		return nil, err
+	}
+
+	// Adiciona colunas criadas depois da primeira versão do esquema
+	err = addMissingColumns(db)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cria um cliente para cada conta de bancos anteriores à tabela customers
+	err = migrateCustomers(db)
+	if err != nil {
+		return nil, err
+	}
+
+	// Remove de bancos antigos as chaves estrangeiras de transfers para clients
+	err = dropTransferAccountForeignKeys(db)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cria os índices que dependem de colunas adicionadas acima
+	err = createTransferIndexes(db)
+	if err != nil {
+		return nil, err
+	}
+
+	// Chama a função para criar as tabelas do livro-razão
+	err = createLedgerTables(db)
+	if err != nil {
+		return nil, err
+	}
+
+	// Chama a função para criar a tabela de chaves de idempotência
+	err = createIdempotencyKeysTable(db)
+	if err != nil {
+		return nil, err
+	}
+
+	// Chama a função para criar a tabela de transferências agendadas
+	err = createScheduledTransfersTable(db)
+	if err != nil {
+		return nil, err
+	}
+
+	// Chama a função para criar as tabelas de ordens permanentes
+	err = createStandingOrderTables(db)
+	if err != nil {
+		return nil, err
+	}
+
+	// Chama a função para criar a tabela de limites de transferência
+	err = createTransferLimitsTable(db)
+	if err != nil {
+		return nil, err
+	}
+
+	// Chama a função para criar as tabelas do cheque especial e de notificações
+	err = createOverdraftTables(db)
+	if err != nil {
+		return nil, err
+	}
+
+	// Chama a função para criar a tabela de reservas de saldo
+	err = createHoldsTable(db)
+	if err != nil {
+		return nil, err
+	}
+
+	// Chama a função para criar as tabelas de juros das contas
+	err = createInterestTables(db)
+	if err != nil {
+		return nil, err
+	}
+
+	// Chama a função para criar as tabelas de tarifas
+	err = createFeeTables(db)
+	if err != nil {
+		return nil, err
+	}
+
+	// Chama a função para criar a tabela de saldos de fim de dia
+	err = createBalanceSnapshotsTable(db)
+	if err != nil {
+		return nil, err
+	}
+
+	// Chama a função para criar a tabela do histórico de status das contas
+	err = createAccountStatusHistoryTable(db)
+	if err != nil {
+		return nil, err
+	}
+
+	// Chama a função para criar a tabela de sequências dos números de conta
+	err = createAccountNumberSequencesTable(db)
+	if err != nil {
+		return nil, err
+	}
+
+	// Gera lançamentos para dados anteriores ao livro-razão
+	err = backfillLedger(db)
+	if err != nil {
+		return nil, err
+	}
+
 	return db, nil
 }
 
-func createClientsTable(db *sql.DB) error {
-	query := `
-	CREATE TABLE IF NOT EXISTS clients (
+func withSQLiteOptions(dbName string) string {
+	if strings.Contains(dbName, "?") {
+		return dbName + "&" + sqliteOptions
+	}
+	return dbName + "?" + sqliteOptions
+}
+
+const clientsSchema = `
+	CREATE TABLE IF NOT EXISTS %s (
 		id INTEGER PRIMARY KEY AUTOINCREMENT,
 		name TEXT NOT NULL,
 		account_num TEXT NOT NULL UNIQUE,
-		balance REAL NOT NULL
+		balance INTEGER NOT NULL,
+		currency TEXT NOT NULL DEFAULT 'BRL',
+		version INTEGER NOT NULL DEFAULT 1,
+		overdraft_limit INTEGER NOT NULL DEFAULT 0,
+		overdraft_rate_bps INTEGER NOT NULL DEFAULT 0,
+		held INTEGER NOT NULL DEFAULT 0,
+		account_type TEXT NOT NULL DEFAULT 'checking',
+		status TEXT NOT NULL DEFAULT 'active',
+		customer_id INTEGER REFERENCES customers(id)
+	);`
+
+func createCustomersTable(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS customers (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 	);`
 	_, err := db.Exec(query)
+	if err != nil {
+		log.Printf("Error creating customers table: %v", err)
+		return err
+	}
+	return nil
+}
+
+func createClientsTable(db *sql.DB) error {
+	_, err := db.Exec(fmt.Sprintf(clientsSchema, "clients"))
 	if err != nil {
 		log.Printf("Error creating clients table: %v", err)
 		return err
@@ -44,22 +199,691 @@ func createClientsTable(db *sql.DB) error {
 	return nil
 }
 
+// transfersSchema não liga as contas de origem e destino a clients: além das
+// contas de clientes, transferências movimentam as contas internas SYSTEM-* e
+// registram tentativas recusadas para contas inexistentes. A integridade
+// dessas referências é conferida pelo comando reconcile.
+const transfersSchema = `
+	CREATE TABLE IF NOT EXISTS %s (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		from_account_num TEXT NOT NULL,
+		to_account_num TEXT NOT NULL,
+		amount INTEGER NOT NULL,
+		currency TEXT NOT NULL DEFAULT 'BRL',
+		type TEXT NOT NULL DEFAULT 'transfer',
+		status TEXT NOT NULL,
+		failure_reason TEXT NOT NULL DEFAULT '',
+		reversal_of INTEGER REFERENCES transfers(id),
+		fee_of INTEGER REFERENCES transfers(id),
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	);`
+
 func createTransfersTable(db *sql.DB) error {
+	_, err := db.Exec(fmt.Sprintf(transfersSchema, "transfers"))
+	if err != nil {
+		log.Printf("Error creating transfers table: %v", err)
+		return err
+	}
+	return nil
+}
+
+func createTransferIndexes(db *sql.DB) error {
+	query := `
+	CREATE INDEX IF NOT EXISTS idx_transfers_reversal_of ON transfers (reversal_of);
+	CREATE INDEX IF NOT EXISTS idx_transfers_fee_of ON transfers (fee_of);
+	CREATE INDEX IF NOT EXISTS idx_transfers_from_created_at ON transfers (from_account_num, created_at);`
+	_, err := db.Exec(query)
+	if err != nil {
+		log.Printf("Error creating transfers indexes: %v", err)
+		return err
+	}
+	return nil
+}
+
+func createLedgerTables(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS journal_entries (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		transfer_id INTEGER,
+		description TEXT NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (transfer_id) REFERENCES transfers(id)
+	);
+	CREATE TABLE IF NOT EXISTS postings (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		entry_id INTEGER NOT NULL,
+		account_num TEXT NOT NULL,
+		amount INTEGER NOT NULL,
+		currency TEXT NOT NULL,
+		FOREIGN KEY (entry_id) REFERENCES journal_entries(id)
+	);
+	CREATE INDEX IF NOT EXISTS idx_postings_account_num ON postings (account_num);
+	CREATE INDEX IF NOT EXISTS idx_journal_entries_transfer_id ON journal_entries (transfer_id);`
+	_, err := db.Exec(query)
+	if err != nil {
+		log.Printf("Error creating ledger tables: %v", err)
+		return err
+	}
+	return nil
+}
+
+func createIdempotencyKeysTable(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS idempotency_keys (
+		key TEXT PRIMARY KEY,
+		request_hash TEXT NOT NULL,
+		status_code INTEGER NOT NULL DEFAULT 0,
+		response_body BLOB,
+		created_at TIMESTAMP NOT NULL,
+		expires_at TIMESTAMP NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);`
+	_, err := db.Exec(query)
+	if err != nil {
+		log.Printf("Error creating idempotency_keys table: %v", err)
+		return err
+	}
+	return nil
+}
+
+func createScheduledTransfersTable(db *sql.DB) error {
 	query := `
-	CREATE TABLE IF NOT EXISTS transfers (
+	CREATE TABLE IF NOT EXISTS scheduled_transfers (
 		id INTEGER PRIMARY KEY AUTOINCREMENT,
 		from_account_num TEXT NOT NULL,
 		to_account_num TEXT NOT NULL,
-		amount REAL NOT NULL,
-		status TEXT NOT NULL,
+		amount INTEGER NOT NULL,
+		currency TEXT NOT NULL,
+		execute_at TIMESTAMP NOT NULL,
+		status TEXT NOT NULL DEFAULT 'pending',
+		failure_reason TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		executed_at TIMESTAMP,
+		claimed_at TIMESTAMP,
+		FOREIGN KEY (from_account_num) REFERENCES clients(account_num),
+		FOREIGN KEY (to_account_num) REFERENCES clients(account_num)
+	);
+	CREATE INDEX IF NOT EXISTS idx_scheduled_transfers_due ON scheduled_transfers (status, execute_at);
+	CREATE INDEX IF NOT EXISTS idx_scheduled_transfers_from ON scheduled_transfers (from_account_num);
+	CREATE INDEX IF NOT EXISTS idx_scheduled_transfers_to ON scheduled_transfers (to_account_num);`
+	_, err := db.Exec(query)
+	if err != nil {
+		log.Printf("Error creating scheduled_transfers table: %v", err)
+		return err
+	}
+	// Bancos anteriores à reivindicação com prazo não têm claimed_at
+	return addColumnIfMissing(db, "scheduled_transfers", "claimed_at", "TIMESTAMP")
+}
+
+func createStandingOrderTables(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS standing_orders (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		from_account_num TEXT NOT NULL,
+		to_account_num TEXT NOT NULL,
+		amount INTEGER NOT NULL,
+		currency TEXT NOT NULL,
+		frequency TEXT NOT NULL,
+		interval_count INTEGER NOT NULL DEFAULT 0,
+		cron TEXT NOT NULL DEFAULT '',
+		start_at TIMESTAMP NOT NULL,
+		end_at TIMESTAMP,
+		max_occurrences INTEGER NOT NULL DEFAULT 0,
+		occurrences INTEGER NOT NULL DEFAULT 0,
+		next_occurrence_at TIMESTAMP NOT NULL,
+		next_run_at TIMESTAMP,
+		retries INTEGER NOT NULL DEFAULT 0,
+		status TEXT NOT NULL DEFAULT 'active',
+		claimed_at TIMESTAMP,
 		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 		FOREIGN KEY (from_account_num) REFERENCES clients(account_num),
 		FOREIGN KEY (to_account_num) REFERENCES clients(account_num)
+	);
+	CREATE TABLE IF NOT EXISTS standing_order_runs (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		standing_order_id INTEGER NOT NULL,
+		occurrence_at TIMESTAMP NOT NULL,
+		attempt INTEGER NOT NULL,
+		status TEXT NOT NULL,
+		failure_reason TEXT NOT NULL DEFAULT '',
+		retry_at TIMESTAMP,
+		executed_at TIMESTAMP NOT NULL,
+		FOREIGN KEY (standing_order_id) REFERENCES standing_orders(id)
+	);
+	CREATE INDEX IF NOT EXISTS idx_standing_orders_due ON standing_orders (status, next_run_at);
+	CREATE INDEX IF NOT EXISTS idx_standing_orders_from ON standing_orders (from_account_num);
+	CREATE INDEX IF NOT EXISTS idx_standing_orders_to ON standing_orders (to_account_num);
+	CREATE INDEX IF NOT EXISTS idx_standing_order_runs_order ON standing_order_runs (standing_order_id);`
+	_, err := db.Exec(query)
+	if err != nil {
+		log.Printf("Error creating standing order tables: %v", err)
+		return err
+	}
+	return nil
+}
+
+// createTransferLimitsTable cria a tabela de limites e os limites padrão. O
+// limite padrão por transferência começa em R$ 10.000,00, o valor que era fixo
+// antes dos limites configuráveis.
+func createTransferLimitsTable(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS transfer_limits (
+		account_num TEXT PRIMARY KEY,
+		currency TEXT NOT NULL,
+		per_transfer INTEGER NOT NULL DEFAULT 0,
+		daily INTEGER NOT NULL DEFAULT 0,
+		monthly INTEGER NOT NULL DEFAULT 0,
+		daily_count INTEGER NOT NULL DEFAULT 0,
+		night_per_transfer INTEGER NOT NULL DEFAULT 0,
+		night_total INTEGER NOT NULL DEFAULT 0,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	);
+	INSERT OR IGNORE INTO transfer_limits (account_num, currency, per_transfer) VALUES ('default', 'BRL', 1000000);`
+	_, err := db.Exec(query)
+	if err != nil {
+		log.Printf("Error creating transfer_limits table: %v", err)
+		return err
+	}
+	return nil
+}
+
+func createOverdraftTables(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS overdraft_interest (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		account_num TEXT NOT NULL,
+		accrual_date TEXT NOT NULL,
+		balance INTEGER NOT NULL,
+		currency TEXT NOT NULL,
+		rate_bps INTEGER NOT NULL,
+		interest INTEGER NOT NULL,
+		transfer_id INTEGER,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE (account_num, accrual_date),
+		FOREIGN KEY (account_num) REFERENCES clients(account_num),
+		FOREIGN KEY (transfer_id) REFERENCES transfers(id)
+	);
+	CREATE TABLE IF NOT EXISTS notifications (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		account_num TEXT NOT NULL,
+		type TEXT NOT NULL,
+		message TEXT NOT NULL,
+		balance INTEGER NOT NULL,
+		currency TEXT NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (account_num) REFERENCES clients(account_num)
+	);
+	CREATE INDEX IF NOT EXISTS idx_notifications_account_num ON notifications (account_num);`
+	_, err := db.Exec(query)
+	if err != nil {
+		log.Printf("Error creating overdraft tables: %v", err)
+		return err
+	}
+	return nil
+}
+
+// createHoldsTable cria a tabela de reservas. A soma do que falta capturar das
+// reservas ativas de uma conta fica também em clients.held, para que os
+// débitos verifiquem o saldo disponível no mesmo UPDATE.
+func createHoldsTable(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS holds (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		account_num TEXT NOT NULL,
+		to_account_num TEXT NOT NULL,
+		amount INTEGER NOT NULL,
+		captured_amount INTEGER NOT NULL DEFAULT 0,
+		currency TEXT NOT NULL,
+		status TEXT NOT NULL DEFAULT 'active',
+		expires_at TIMESTAMP NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (account_num) REFERENCES clients(account_num),
+		FOREIGN KEY (to_account_num) REFERENCES clients(account_num)
+	);
+	CREATE INDEX IF NOT EXISTS idx_holds_account_num ON holds (account_num);
+	CREATE INDEX IF NOT EXISTS idx_holds_expiry ON holds (status, expires_at);`
+	_, err := db.Exec(query)
+	if err != nil {
+		log.Printf("Error creating holds table: %v", err)
+		return err
+	}
+	return nil
+}
+
+// createInterestTables cria as taxas de juros por tipo de conta, começando
+// zeradas, e as tabelas de juros acumulados e capitalizados. Cada dia acumula
+// uma vez por conta e cada mês é capitalizado uma vez por conta.
+func createInterestTables(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS interest_rates (
+		account_type TEXT PRIMARY KEY,
+		rate_bps INTEGER NOT NULL DEFAULT 0,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	);
+	INSERT OR IGNORE INTO interest_rates (account_type) VALUES ('checking'), ('savings');
+	CREATE TABLE IF NOT EXISTS interest_capitalizations (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		account_num TEXT NOT NULL,
+		period TEXT NOT NULL,
+		interest INTEGER NOT NULL,
+		currency TEXT NOT NULL,
+		transfer_id INTEGER,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE (account_num, period),
+		FOREIGN KEY (account_num) REFERENCES clients(account_num),
+		FOREIGN KEY (transfer_id) REFERENCES transfers(id)
+	);
+	CREATE TABLE IF NOT EXISTS interest_accruals (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		account_num TEXT NOT NULL,
+		accrual_date TEXT NOT NULL,
+		balance INTEGER NOT NULL,
+		currency TEXT NOT NULL,
+		rate_bps INTEGER NOT NULL,
+		capitalization_id INTEGER,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE (account_num, accrual_date),
+		FOREIGN KEY (account_num) REFERENCES clients(account_num),
+		FOREIGN KEY (capitalization_id) REFERENCES interest_capitalizations(id)
 	);`
 	_, err := db.Exec(query)
 	if err != nil {
-		log.Printf("Error creating transfers table: %v", err)
+		log.Printf("Error creating interest tables: %v", err)
+		return err
+	}
+	return nil
+}
+
+// createFeeTables cria as tabelas de tarifas por tipo de conta, começando sem
+// nenhuma tarifa
+func createFeeTables(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS fee_schedules (
+		account_type TEXT PRIMARY KEY,
+		currency TEXT NOT NULL DEFAULT 'BRL',
+		flat INTEGER NOT NULL DEFAULT 0,
+		percent_bps INTEGER NOT NULL DEFAULT 0,
+		free_transfers INTEGER NOT NULL DEFAULT 0,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	);
+	INSERT OR IGNORE INTO fee_schedules (account_type) VALUES ('checking'), ('savings');
+	CREATE TABLE IF NOT EXISTS fee_tiers (
+		account_type TEXT NOT NULL,
+		min_amount INTEGER NOT NULL,
+		flat INTEGER NOT NULL DEFAULT 0,
+		percent_bps INTEGER NOT NULL DEFAULT 0,
+		PRIMARY KEY (account_type, min_amount),
+		FOREIGN KEY (account_type) REFERENCES fee_schedules(account_type)
+	);`
+	_, err := db.Exec(query)
+	if err != nil {
+		log.Printf("Error creating fee tables: %v", err)
+		return err
+	}
+	return nil
+}
+
+func createBalanceSnapshotsTable(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS balance_snapshots (
+		account_num TEXT NOT NULL,
+		snapshot_date TEXT NOT NULL,
+		as_of TIMESTAMP NOT NULL,
+		balance INTEGER NOT NULL,
+		currency TEXT NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (account_num, currency, snapshot_date),
+		FOREIGN KEY (account_num) REFERENCES clients(account_num)
+	);
+	CREATE INDEX IF NOT EXISTS idx_balance_snapshots_as_of ON balance_snapshots (account_num, currency, as_of);`
+	_, err := db.Exec(query)
+	if err != nil {
+		log.Printf("Error creating balance snapshots table: %v", err)
+		return err
+	}
+	return nil
+}
+
+func createAccountStatusHistoryTable(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS account_status_history (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		account_num TEXT NOT NULL,
+		from_status TEXT NOT NULL,
+		to_status TEXT NOT NULL,
+		reason TEXT NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (account_num) REFERENCES clients(account_num)
+	);
+	CREATE INDEX IF NOT EXISTS idx_account_status_history_account_num ON account_status_history (account_num);`
+	_, err := db.Exec(query)
+	if err != nil {
+		log.Printf("Error creating account status history table: %v", err)
+		return err
+	}
+	return nil
+}
+
+// createAccountNumberSequencesTable cria a tabela com o último número de conta
+// gerado em cada agência
+func createAccountNumberSequencesTable(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS account_number_sequences (
+		branch TEXT PRIMARY KEY,
+		last_number INTEGER NOT NULL
+	);`
+	_, err := db.Exec(query)
+	if err != nil {
+		log.Printf("Error creating account number sequences table: %v", err)
+		return err
+	}
+	return nil
+}
+
+// backfillLedger popula o livro-razão de bancos criados antes dele: cada
+// transferência bem-sucedida vira um lançamento e a diferença entre o saldo
+// armazenado e o saldo dessas transferências vira um saldo de abertura contra a
+// conta interna SYSTEM-OPENING. Só executa enquanto o livro-razão estiver vazio.
+//
+// O saldo de abertura é datado da primeira transferência da conta e gravado
+// antes dos lançamentos das transferências, para que saldos históricos e
+// extratos nunca mostrem a conta sem ele. Como as contas não guardam a data de
+// criação, contas sem transferências usam a transferência mais antiga do banco
+// ou, sem nenhuma, a data da migração.
+func backfillLedger(db *sql.DB) error {
+	var entries int
+	if err := db.QueryRow("SELECT COUNT(*) FROM journal_entries").Scan(&entries); err != nil {
+		return err
+	}
+	if entries > 0 {
+		return nil
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(`
+		SELECT c.account_num, c.currency, c.balance - COALESCE((
+			SELECT SUM(CASE WHEN t.to_account_num = c.account_num THEN t.amount ELSE -t.amount END) FROM transfers t
+			WHERE t.status = 'success' AND t.currency = c.currency AND t.from_account_num <> t.to_account_num
+				AND (t.from_account_num = c.account_num OR t.to_account_num = c.account_num)), 0)
+		FROM clients c ORDER BY c.id`)
+	if err != nil {
+		return err
+	}
+	type opening struct {
+		accountNum string
+		currency   string
+		diff       int64
+	}
+	var openings []opening
+	for rows.Next() {
+		var o opening
+		if err := rows.Scan(&o.accountNum, &o.currency, &o.diff); err != nil {
+			rows.Close()
+			return err
+		}
+		if o.diff != 0 {
+			openings = append(openings, o)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return err
+	}
+	rows.Close()
+
+	// A data é copiada em SQL, e não lida e regravada pelo Go, para que fique no
+	// mesmo formato texto das transferências e seja comparada corretamente
+	for _, o := range openings {
+		result, err := tx.Exec(`INSERT INTO journal_entries (description, created_at) SELECT 'opening balance', COALESCE(
+			(SELECT MIN(created_at) FROM transfers WHERE status = 'success' AND (from_account_num = ? OR to_account_num = ?)),
+			(SELECT MIN(created_at) FROM transfers WHERE status = 'success'),
+			CURRENT_TIMESTAMP)`, o.accountNum, o.accountNum)
+		if err != nil {
+			return err
+		}
+		entryID, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec("INSERT INTO postings (entry_id, account_num, amount, currency) VALUES (?, ?, ?, ?), (?, ?, ?, ?)",
+			entryID, o.accountNum, o.diff, o.currency,
+			entryID, "SYSTEM-OPENING", -o.diff, o.currency)
+		if err != nil {
+			return err
+		}
+	}
+
+	statements := []string{
+		`INSERT INTO journal_entries (transfer_id, description, created_at)
+		SELECT id, 'transfer', created_at FROM transfers WHERE status = 'success' ORDER BY id`,
+		`INSERT INTO postings (entry_id, account_num, amount, currency)
+		SELECT e.id, t.from_account_num, -t.amount, t.currency FROM journal_entries e JOIN transfers t ON t.id = e.transfer_id`,
+		`INSERT INTO postings (entry_id, account_num, amount, currency)
+		SELECT e.id, t.to_account_num, t.amount, t.currency FROM journal_entries e JOIN transfers t ON t.id = e.transfer_id`,
+	}
+	for _, statement := range statements {
+		if _, err := tx.Exec(statement); err != nil {
+			log.Printf("Error backfilling ledger: %v", err)
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// migrateMoneyColumns converte as colunas balance/amount, que antes eram REAL
+// em unidades da moeda, para INTEGER em centavos. Valores são arredondados com
+// ROUND do SQLite (meio centavo para longe do zero). Bancos já migrados não
+// são alterados.
+func migrateMoneyColumns(db *sql.DB) error {
+	migrations := []struct {
+		table   string
+		column  string
+		columns string
+		schema  string
+	}{
+		{"clients", "balance", "id, name, account_num", clientsSchema},
+		{"transfers", "amount", "id, from_account_num, to_account_num, status, created_at", transfersSchema},
+	}
+
+	for _, m := range migrations {
+		columnType, err := getColumnType(db, m.table, m.column)
+		if err != nil {
+			return err
+		}
+		if columnType != "REAL" {
+			continue
+		}
+
+		copyQuery := fmt.Sprintf("INSERT INTO %s_new (%s, %s, currency) SELECT %s, CAST(ROUND(%s * 100) AS INTEGER), 'BRL' FROM %s",
+			m.table, m.columns, m.column, m.columns, m.column, m.table)
+		if err := rebuildTable(db, m.table, m.schema, copyQuery); err != nil {
+			log.Printf("Error migrating %s.%s to cents: %v", m.table, m.column, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateCustomers liga a um cliente as contas criadas quando cliente e conta
+// eram o mesmo registro: cada conta sem customer_id ganha um cliente próprio,
+// com o nome da conta, todos na mesma transação. Contas de um mesmo titular
+// continuam em clientes separados, já que só o nome não basta para juntá-las.
+func migrateCustomers(db *sql.DB) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query("SELECT id, name FROM clients WHERE customer_id IS NULL ORDER BY id")
+	if err != nil {
+		return err
+	}
+	type account struct {
+		id   int
+		name string
+	}
+	var accounts []account
+	for rows.Next() {
+		var a account
+		if err := rows.Scan(&a.id, &a.name); err != nil {
+			rows.Close()
+			return err
+		}
+		accounts = append(accounts, a)
+	}
+	rows.Close()
+
+	for _, a := range accounts {
+		var customerID int64
+		if err := tx.QueryRow("INSERT INTO customers (name) VALUES (?) RETURNING id", a.name).Scan(&customerID); err != nil {
+			log.Printf("Error migrating client %d to customers: %v", a.id, err)
+			return err
+		}
+		if _, err := tx.Exec("UPDATE clients SET customer_id = ? WHERE id = ?", customerID, a.id); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.Exec("CREATE INDEX IF NOT EXISTS idx_clients_customer_id ON clients (customer_id)"); err != nil {
+		log.Printf("Error creating clients customer index: %v", err)
+		return err
+	}
+	return tx.Commit()
+}
+
+// dropTransferAccountForeignKeys recria a tabela transfers de bancos criados
+// quando ela declarava chaves estrangeiras para clients, que passariam a
+// recusar as movimentações das contas internas com a verificação ligada
+func dropTransferAccountForeignKeys(db *sql.DB) error {
+	var references int
+	err := db.QueryRow("SELECT COUNT(*) FROM pragma_foreign_key_list('transfers') WHERE \"table\" = 'clients'").Scan(&references)
+	if err != nil || references == 0 {
+		return err
+	}
+
+	columns := "id, from_account_num, to_account_num, amount, currency, type, status, failure_reason, reversal_of, fee_of, created_at"
+	copyQuery := fmt.Sprintf("INSERT INTO transfers_new (%s) SELECT %s FROM transfers", columns, columns)
+	if err := rebuildTable(db, "transfers", transfersSchema, copyQuery); err != nil {
+		log.Printf("Error dropping transfers foreign keys to clients: %v", err)
+		return err
+	}
+	return nil
+}
+
+// rebuildTable recria uma tabela com o esquema atual (o SQLite não permite
+// alterar o tipo de uma coluna nem as suas restrições): cria <table>_new,
+// copia os dados, remove a antiga e renomeia, tudo na mesma transação. A
+// verificação das chaves estrangeiras fica desligada na conexão durante a
+// troca, para que remover a tabela antiga não apague nem recuse as linhas que
+// apontam para ela.
+func rebuildTable(db *sql.DB, table, schema, copyQuery string) error {
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "PRAGMA foreign_keys = OFF"); err != nil {
+		return err
+	}
+	defer conn.ExecContext(ctx, "PRAGMA foreign_keys = ON")
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	statements := []string{
+		fmt.Sprintf(schema, table+"_new"),
+		copyQuery,
+		"DROP TABLE " + table,
+		"ALTER TABLE " + table + "_new RENAME TO " + table,
+	}
+	for _, statement := range statements {
+		if _, err := tx.Exec(statement); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// addMissingColumns adiciona a bancos existentes as colunas que passaram a
+// fazer parte do esquema depois da sua criação
+func addMissingColumns(db *sql.DB) error {
+	columns := []struct {
+		table      string
+		column     string
+		definition string
+	}{
+		{"transfers", "failure_reason", "TEXT NOT NULL DEFAULT ''"},
+		{"clients", "version", "INTEGER NOT NULL DEFAULT 1"},
+		{"transfers", "type", "TEXT NOT NULL DEFAULT 'transfer'"},
+		{"transfers", "reversal_of", "INTEGER REFERENCES transfers(id)"},
+		{"clients", "overdraft_limit", "INTEGER NOT NULL DEFAULT 0"},
+		{"clients", "overdraft_rate_bps", "INTEGER NOT NULL DEFAULT 0"},
+		{"clients", "held", "INTEGER NOT NULL DEFAULT 0"},
+		{"clients", "account_type", "TEXT NOT NULL DEFAULT 'checking'"},
+		{"transfers", "fee_of", "INTEGER REFERENCES transfers(id)"},
+		{"clients", "status", "TEXT NOT NULL DEFAULT 'active'"},
+		{"clients", "customer_id", "INTEGER REFERENCES customers(id)"},
+	}
+
+	for _, c := range columns {
+		if err := addColumnIfMissing(db, c.table, c.column, c.definition); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addColumnIfMissing adiciona a coluna à tabela, se ela ainda não existir
+func addColumnIfMissing(db *sql.DB, table, column, definition string) error {
+	columnType, err := getColumnType(db, table, column)
+	if err != nil {
+		return err
+	}
+	if columnType != "" {
+		return nil
+	}
+	if _, err := db.Exec("ALTER TABLE " + table + " ADD COLUMN " + column + " " + definition); err != nil {
+		log.Printf("Error adding column %s.%s: %v", table, column, err)
 		return err
 	}
 	return nil
 }
+
+// getColumnType retorna o tipo declarado de uma coluna, ou "" se ela não existir
+func getColumnType(db *sql.DB, table, column string) (string, error) {
+	rows, err := db.Query("PRAGMA table_info(" + table + ")")
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cid       int
+			name      string
+			colType   string
+			notNull   int
+			dfltValue sql.NullString
+			pk        int
+		)
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dfltValue, &pk); err != nil {
+			return "", err
+		}
+		if name == column {
+			return strings.ToUpper(colType), nil
+		}
+	}
+	return "", rows.Err()
+}
@@ -3,10 +3,14 @@ package main
 import (
 	"banking/src/controllers"
 	"banking/src/database"
+	"banking/src/models"
 	"banking/src/repositories"
 	"banking/src/services"
+	"context"
+	"encoding/json"
 	"fmt"
 	"os"
+	"time"
 
 	_ "banking/docs" // Importa a documentação gerada pelo Swag
 
@@ -47,8 +51,138 @@ func main() {
 		},
 	}
 
+	var interestCmd = &cobra.Command{
+		Use:   "interest",
+		Short: "Accrue and capitalize account interest",
+		Long:  "Runs the interest jobs that the server also runs daily in the background. Both are idempotent: running the same day or month again pays nothing twice.",
+	}
+
+	var accrueDate string
+	var accrueCmd = &cobra.Command{
+		Use:   "accrue",
+		Short: "Accrue one day of interest",
+		Long:  "Accrues the interest of a day (yesterday by default) on the end-of-day balance of every interest-bearing account",
+		Run: func(cmd *cobra.Command, args []string) {
+			now := time.Now()
+			day := models.StartOfBusinessDay(now).AddDate(0, 0, -1)
+			if accrueDate != "" {
+				var err error
+				if day, err = time.ParseInLocation(time.DateOnly, accrueDate, models.BusinessLocation); err != nil {
+					fmt.Println("Invalid --date, expected YYYY-MM-DD:", err)
+					os.Exit(1)
+				}
+			}
+			runInterestCommand(func(interestService *services.InterestService) error {
+				accrued, err := interestService.AccrueInterest(day, now)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("Interest for %s accrued on %d accounts\n", day.Format(time.DateOnly), accrued)
+				return nil
+			})
+		},
+	}
+	accrueCmd.Flags().StringVar(&accrueDate, "date", "", "day to accrue (YYYY-MM-DD, default yesterday)")
+
+	var capitalizeMonth string
+	var capitalizeCmd = &cobra.Command{
+		Use:   "capitalize",
+		Short: "Capitalize one month of interest",
+		Long:  "Credits the interest accrued in a month (last month by default) to each account, from the bank interest expense account",
+		Run: func(cmd *cobra.Command, args []string) {
+			now := time.Now()
+			month := models.StartOfBusinessMonth(now).AddDate(0, -1, 0)
+			if capitalizeMonth != "" {
+				var err error
+				if month, err = time.ParseInLocation("2006-01", capitalizeMonth, models.BusinessLocation); err != nil {
+					fmt.Println("Invalid --month, expected YYYY-MM:", err)
+					os.Exit(1)
+				}
+			}
+			runInterestCommand(func(interestService *services.InterestService) error {
+				capitalizations, err := interestService.Capitalize(month, now)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("Interest for %s capitalized on %d accounts\n", month.Format("2006-01"), len(capitalizations))
+				return nil
+			})
+		},
+	}
+	capitalizeCmd.Flags().StringVar(&capitalizeMonth, "month", "", "month to capitalize (YYYY-MM, default last month)")
+
+	interestCmd.AddCommand(accrueCmd)
+	interestCmd.AddCommand(capitalizeCmd)
+
+	var snapshotDate string
+	var snapshotCmd = &cobra.Command{
+		Use:   "snapshot-balances",
+		Short: "Store one day of end-of-day balances",
+		Long:  "Stores the end-of-day balance of every account for a day (yesterday by default), as the server also does daily in the background. Use it to backfill older days; running the same day again changes nothing.",
+		Run: func(cmd *cobra.Command, args []string) {
+			now := time.Now()
+			day := models.StartOfBusinessDay(now).AddDate(0, 0, -1)
+			if snapshotDate != "" {
+				var err error
+				if day, err = time.ParseInLocation(time.DateOnly, snapshotDate, models.BusinessLocation); err != nil {
+					fmt.Println("Invalid --date, expected YYYY-MM-DD:", err)
+					os.Exit(1)
+				}
+			}
+
+			db, err := database.InitDB("./bank.db")
+			if err != nil {
+				fmt.Println("Failed to connect to the database:", err)
+				os.Exit(1)
+			}
+			balanceService := services.NewBalanceService(repositories.NewClientRepository(db),
+				repositories.NewLedgerRepository(db), repositories.NewBalanceSnapshotRepository(db))
+			stored, err := balanceService.SnapshotBalances(day, now)
+			db.Close()
+			if err != nil {
+				fmt.Println("Failed to store balances:", err)
+				os.Exit(1)
+			}
+			fmt.Printf("Balances for %s stored for %d accounts\n", day.Format(time.DateOnly), stored)
+		},
+	}
+	snapshotCmd.Flags().StringVar(&snapshotDate, "date", "", "day to store (YYYY-MM-DD, default yesterday)")
+
+	var reconcileCmd = &cobra.Command{
+		Use:   "reconcile",
+		Short: "Check balances and ledger consistency",
+		Long:  "Recomputes every balance from the ledger and from transfers, checks that money is conserved across all accounts and looks for orphan transfers, duplicate account numbers and foreign key violations. Prints a JSON report and exits with 1 when it finds discrepancies, or 2 when the check could not run.",
+		Run: func(cmd *cobra.Command, args []string) {
+			db, err := database.InitDB("./bank.db")
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "Failed to connect to the database:", err)
+				os.Exit(2)
+			}
+			reconciliationService := services.NewReconciliationService(repositories.NewReconciliationRepository(db))
+			report, err := reconciliationService.Reconcile()
+			db.Close()
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "Failed to reconcile:", err)
+				os.Exit(2)
+			}
+
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(report); err != nil {
+				fmt.Fprintln(os.Stderr, "Failed to write the report:", err)
+				os.Exit(2)
+			}
+			if !report.OK {
+				os.Exit(1)
+			}
+		},
+	}
+
 	rootCmd.AddCommand(runCmd)
 	rootCmd.AddCommand(migrateCmd)
+	rootCmd.AddCommand(interestCmd)
+	rootCmd.AddCommand(snapshotCmd)
+	rootCmd.AddCommand(reconcileCmd)
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Println(err)
@@ -65,14 +199,98 @@ func runServer() {
 	}
 	defer db.Close()
 
+	// Formato dos números de conta gerados pelo servidor
+	accountNumbers, err := accountNumberFormatFromEnv()
+	if err != nil {
+		fmt.Println("Invalid account number settings:", err)
+		os.Exit(1)
+	}
+
+	uow := repositories.NewUnitOfWork(db)
+
 	clientRepo := repositories.NewClientRepository(db)
-	clientService := services.NewClientService(clientRepo)
+	clientService := services.NewClientService(clientRepo, uow, accountNumbers)
+	// Toda rota com :accountNum verifica o número da conta antes de executar
+	r.Use(controllers.ValidateAccountNum(clientService))
+
+	customerRepo := repositories.NewCustomerRepository(db)
+	customerService := services.NewCustomerService(customerRepo, clientRepo, uow, accountNumbers)
 
 	transferRepo := repositories.NewTransferRepository(db)
-	transferService := services.NewTransferService(clientRepo, transferRepo)
+	ledgerRepo := repositories.NewLedgerRepository(db)
+	// Transferências, depósitos e saques compartilham os mesmos mutexes por conta
+	accountLocks := services.NewAccountLocks()
+	transferService := services.NewTransferService(clientRepo, transferRepo, ledgerRepo, uow, accountLocks)
+
+	scheduledTransferRepo := repositories.NewScheduledTransferRepository(db)
+	scheduledTransferService := services.NewScheduledTransferService(clientRepo, scheduledTransferRepo, transferService)
+
+	standingOrderRepo := repositories.NewStandingOrderRepository(db)
+	standingOrderService := services.NewStandingOrderService(clientRepo, standingOrderRepo, uow, transferService)
+
+	accountService := services.NewAccountService(transferRepo, uow, accountLocks)
+	treasuryService := services.NewTreasuryService(uow)
+
+	limitRepo := repositories.NewLimitRepository(db)
+	limitService := services.NewLimitService(clientRepo, limitRepo)
+
+	overdraftRepo := repositories.NewOverdraftRepository(db)
+	overdraftService := services.NewOverdraftService(clientRepo, overdraftRepo, uow)
+
+	notificationRepo := repositories.NewNotificationRepository(db)
+	notificationService := services.NewNotificationService(clientRepo, notificationRepo)
+
+	holdRepo := repositories.NewHoldRepository(db)
+	holdService := services.NewHoldService(clientRepo, holdRepo, uow, transferService)
+
+	interestRepo := repositories.NewInterestRepository(db)
+	interestService := services.NewInterestService(clientRepo, interestRepo, uow)
+
+	feeRepo := repositories.NewFeeRepository(db)
+	feeService := services.NewFeeService(feeRepo, uow)
+
+	statementService := services.NewStatementService(clientRepo, ledgerRepo)
+
+	balanceSnapshotRepo := repositories.NewBalanceSnapshotRepository(db)
+	balanceService := services.NewBalanceService(clientRepo, ledgerRepo, balanceSnapshotRepo)
+
+	accountStatusRepo := repositories.NewAccountStatusRepository(db)
+	accountStatusService := services.NewAccountStatusService(clientRepo, accountStatusRepo, uow)
+	accountClosureService := services.NewAccountClosureService(clientRepo, uow, transferService)
+
+	idempotencyRepo := repositories.NewIdempotencyRepository(db)
+	idempotencyService := services.NewIdempotencyService(idempotencyRepo, durationFromEnv("IDEMPOTENCY_KEY_TTL", services.DefaultIdempotencyKeyTTL))
 
 	controllers.InitRoutes(r, clientService)
-	controllers.InitTransferRoutes(r, transferService)
+	controllers.InitCustomerRoutes(r, customerService)
+	controllers.InitTransferRoutes(r, transferService, scheduledTransferService, idempotencyService)
+	controllers.InitAccountRoutes(r, accountService)
+	controllers.InitStandingOrderRoutes(r, standingOrderService)
+	controllers.InitTreasuryRoutes(r, accountService, treasuryService)
+	controllers.InitLimitRoutes(r, limitService)
+	controllers.InitOverdraftRoutes(r, overdraftService)
+	controllers.InitNotificationRoutes(r, notificationService)
+	controllers.InitHoldRoutes(r, holdService)
+	controllers.InitInterestRoutes(r, interestService)
+	controllers.InitFeeRoutes(r, feeService)
+	controllers.InitStatementRoutes(r, statementService)
+	controllers.InitBalanceRoutes(r, balanceService)
+	controllers.InitAccountStatusRoutes(r, accountStatusService)
+	controllers.InitAccountClosureRoutes(r, accountClosureService)
+
+	// Executa em segundo plano as transferências agendadas e as ordens
+	// permanentes que vencerem, cobra os juros diários do cheque especial,
+	// expira as reservas de saldo vencidas, acumula e capitaliza os juros das
+	// contas e guarda os saldos de fim de dia
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	workerInterval := durationFromEnv("WORKER_INTERVAL", services.DefaultWorkerInterval)
+	go services.RunEvery(ctx, workerInterval, "scheduled transfers", scheduledTransferService.ExecuteDue)
+	go services.RunEvery(ctx, workerInterval, "standing orders", standingOrderService.ExecuteDue)
+	go services.RunEvery(ctx, workerInterval, "overdraft interest", overdraftService.AccrueDue)
+	go services.RunEvery(ctx, workerInterval, "hold expiry", holdService.ExpireDue)
+	go services.RunEvery(ctx, workerInterval, "interest", interestService.AccrueDue)
+	go services.RunEvery(ctx, workerInterval, "balance snapshots", balanceService.SnapshotDue)
 
 	// Rota Swagger
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -80,6 +298,55 @@ func runServer() {
 	r.Run(":8080")
 }
 
+// durationFromEnv lê uma duração (ex.: "24h", "30m") de uma variável de
+// ambiente, usando fallback quando ela está ausente ou é inválida
+func durationFromEnv(name string, fallback time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		fmt.Printf("Invalid %s %q, using %s\n", name, value, fallback)
+		return fallback
+	}
+	return duration
+}
+
+// accountNumberFormatFromEnv lê a agência (ACCOUNT_BRANCH) e o algoritmo do
+// DV (ACCOUNT_CHECK_DIGIT, mod11 ou mod10) dos números de conta, usando
+// models.DefaultAccountNumberFormat no que estiver ausente
+func accountNumberFormatFromEnv() (models.AccountNumberFormat, error) {
+	branch := os.Getenv("ACCOUNT_BRANCH")
+	if branch == "" {
+		branch = models.DefaultAccountNumberFormat.Branch
+	}
+	algorithm := os.Getenv("ACCOUNT_CHECK_DIGIT")
+	if algorithm == "" {
+		algorithm = string(models.DefaultAccountNumberFormat.Algorithm)
+	}
+	return models.NewAccountNumberFormat(branch, algorithm)
+}
+
+// runInterestCommand abre o banco e executa job com o serviço de juros,
+// encerrando o processo com erro se job falhar
+func runInterestCommand(job func(interestService *services.InterestService) error) {
+	db, err := database.InitDB("./bank.db")
+	if err != nil {
+		fmt.Println("Failed to connect to the database:", err)
+		os.Exit(1)
+	}
+
+	uow := repositories.NewUnitOfWork(db)
+	interestService := services.NewInterestService(repositories.NewClientRepository(db), repositories.NewInterestRepository(db), uow)
+	err = job(interestService)
+	db.Close()
+	if err != nil {
+		fmt.Println("Failed to run interest job:", err)
+		os.Exit(1)
+	}
+}
+
 func runMigrations(dbPath string) error {
 	m, err := migrate.New(
 		"file://migrations",
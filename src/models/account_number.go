@@ -0,0 +1,136 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// CheckDigitAlgorithm é o cálculo do dígito verificador (DV) dos números de
+// conta
+type CheckDigitAlgorithm string
+
+const (
+	// CheckDigitMod11: pesos de 2 a 9 da direita para a esquerda, repetidos; o
+	// DV é 11 menos o resto da soma por 11, e os resultados 10 e 11 viram 0
+	CheckDigitMod11 CheckDigitAlgorithm = "mod11"
+	// CheckDigitMod10: pesos 2 e 1 alternados da direita para a esquerda,
+	// somando os algarismos de cada produto; o DV é o que falta para a soma
+	// chegar ao próximo múltiplo de 10
+	CheckDigitMod10 CheckDigitAlgorithm = "mod10"
+)
+
+// Tamanhos da agência e da conta nos números gerados
+const (
+	BranchDigits  = 4
+	AccountDigits = 8
+)
+
+var (
+	ErrInvalidCheckDigitAlgorithm = errors.New("invalid check digit algorithm")
+	ErrInvalidBranch              = errors.New("branch must have 4 digits")
+	ErrInvalidAccountNumber       = errors.New("invalid account number")
+	ErrInvalidCheckDigit          = errors.New("invalid account number check digit")
+)
+
+// ParseCheckDigitAlgorithm converte o nome do algoritmo ("mod11" ou "mod10")
+func ParseCheckDigitAlgorithm(name string) (CheckDigitAlgorithm, error) {
+	switch algorithm := CheckDigitAlgorithm(strings.ToLower(name)); algorithm {
+	case CheckDigitMod11, CheckDigitMod10:
+		return algorithm, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidCheckDigitAlgorithm, name)
+	}
+}
+
+// Digit calcula o DV de digits, que só pode conter algarismos
+func (a CheckDigitAlgorithm) Digit(digits string) string {
+	sum := 0
+	for i := range digits {
+		digit := int(digits[len(digits)-1-i] - '0')
+		switch a {
+		case CheckDigitMod10:
+			product := digit * (2 - i%2)
+			sum += product/10 + product%10
+		default:
+			sum += digit * (2 + i%8)
+		}
+	}
+
+	if a == CheckDigitMod10 {
+		return fmt.Sprint((10 - sum%10) % 10)
+	}
+	dv := 11 - sum%11
+	if dv >= 10 {
+		dv = 0
+	}
+	return fmt.Sprint(dv)
+}
+
+// AccountNumberFormat define como os números de conta são gerados e
+// verificados: agência com 4 dígitos, conta com 8 e o DV calculado sobre os
+// dois, como em 0001-00000123-4
+type AccountNumberFormat struct {
+	Branch    string
+	Algorithm CheckDigitAlgorithm
+}
+
+// DefaultAccountNumberFormat é a agência 0001 com DV módulo 11
+var DefaultAccountNumberFormat = AccountNumberFormat{Branch: "0001", Algorithm: CheckDigitMod11}
+
+// NewAccountNumberFormat valida a agência e o nome do algoritmo do DV
+func NewAccountNumberFormat(branch, algorithm string) (AccountNumberFormat, error) {
+	if len(branch) != BranchDigits || !isDigits(branch) {
+		return AccountNumberFormat{}, fmt.Errorf("%w: %q", ErrInvalidBranch, branch)
+	}
+	parsed, err := ParseCheckDigitAlgorithm(algorithm)
+	if err != nil {
+		return AccountNumberFormat{}, err
+	}
+	return AccountNumberFormat{Branch: branch, Algorithm: parsed}, nil
+}
+
+// Format monta o número da conta sequence da agência, com o DV
+func (f AccountNumberFormat) Format(sequence int64) string {
+	account := fmt.Sprintf("%0*d", AccountDigits, sequence)
+	return f.Branch + "-" + account + "-" + f.Algorithm.Digit(f.Branch+account)
+}
+
+// Validate verifica se accountNum está no formato agência-conta-DV, de
+// qualquer agência, com o DV calculado pelo algoritmo do formato
+func (f AccountNumberFormat) Validate(accountNum string) error {
+	parts := strings.Split(accountNum, "-")
+	if len(parts) != 3 || len(parts[0]) != BranchDigits || len(parts[1]) != AccountDigits || len(parts[2]) != 1 ||
+		!isDigits(parts[0]+parts[1]+parts[2]) {
+		return fmt.Errorf("%w: %q", ErrInvalidAccountNumber, accountNum)
+	}
+	if f.Algorithm.Digit(parts[0]+parts[1]) != parts[2] {
+		return fmt.Errorf("%w: %q", ErrInvalidCheckDigit, accountNum)
+	}
+	return nil
+}
+
+// IsLegacyAccountNumber indica se accountNum é um número só com algarismos,
+// como os atribuídos pelos clientes da API antes de o servidor gerar os
+// números. Esses números não têm DV.
+func IsLegacyAccountNumber(accountNum string) bool {
+	return accountNum != "" && isDigits(accountNum)
+}
+
+// ValidateAccountNumber verifica um número de conta recebido numa rota.
+// Números legados são aceitos; os demais precisam estar no formato
+// agência-conta-DV com o DV correto por algum dos algoritmos, já que contas
+// criadas antes de uma mudança de ACCOUNT_CHECK_DIGIT continuam com o DV
+// antigo.
+func ValidateAccountNumber(accountNum string) error {
+	if IsLegacyAccountNumber(accountNum) {
+		return nil
+	}
+	var err error
+	for _, algorithm := range []CheckDigitAlgorithm{CheckDigitMod11, CheckDigitMod10} {
+		if err = (AccountNumberFormat{Algorithm: algorithm}).Validate(accountNum); err == nil {
+			return nil
+		}
+	}
+	return err
+}
@@ -0,0 +1,108 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Status de uma conta
+const (
+	// AccountStatusActive: a conta movimenta normalmente
+	AccountStatusActive = "active"
+	// AccountStatusFrozen: a conta recebe créditos, mas não pode ser debitada
+	AccountStatusFrozen = "frozen"
+	// AccountStatusBlocked: a conta não recebe créditos nem pode ser debitada
+	AccountStatusBlocked = "blocked"
+	// AccountStatusClosed: a conta foi encerrada e não volta a movimentar
+	AccountStatusClosed = "closed"
+)
+
+// AccountStatuses lista os status de conta aceitos
+var AccountStatuses = []string{AccountStatusActive, AccountStatusFrozen, AccountStatusBlocked, AccountStatusClosed}
+
+// accountStatusTransitions são as mudanças de status permitidas: uma conta
+// ativa pode ser congelada, bloqueada ou encerrada, e uma congelada ou
+// bloqueada só pode voltar a ser ativa. Encerrar é definitivo.
+var accountStatusTransitions = map[string][]string{
+	AccountStatusActive:  {AccountStatusFrozen, AccountStatusBlocked, AccountStatusClosed},
+	AccountStatusFrozen:  {AccountStatusActive},
+	AccountStatusBlocked: {AccountStatusActive},
+}
+
+var (
+	ErrInvalidAccountStatus    = errors.New("invalid account status")
+	ErrInvalidStatusTransition = errors.New("account status transition not allowed")
+	ErrStatusReasonRequired    = errors.New("reason is required")
+	ErrDebitsNotAllowed        = errors.New("account status does not allow debits")
+	ErrCreditsNotAllowed       = errors.New("account status does not allow credits")
+)
+
+// IsValidAccountStatus informa se status é um dos AccountStatuses
+func IsValidAccountStatus(status string) bool {
+	for _, known := range AccountStatuses {
+		if status == known {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckStatusTransition verifica se a conta pode passar do status from para to
+func CheckStatusTransition(from, to string) error {
+	if !IsValidAccountStatus(to) {
+		return fmt.Errorf("%w: %q", ErrInvalidAccountStatus, to)
+	}
+	for _, allowed := range accountStatusTransitions[from] {
+		if to == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: from %s to %s", ErrInvalidStatusTransition, from, to)
+}
+
+// AllowsDebits informa se uma conta com status pode ser debitada
+func AllowsDebits(status string) bool {
+	return status == AccountStatusActive
+}
+
+// AllowsCredits informa se uma conta com status pode receber créditos
+func AllowsCredits(status string) bool {
+	return status == AccountStatusActive || status == AccountStatusFrozen
+}
+
+// AccountStatusChange é uma mudança de status de conta, com o motivo
+// informado pelo administrador
+type AccountStatusChange struct {
+	ID         int       `json:"id"`
+	AccountNum string    `json:"account_num"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	Reason     string    `json:"reason"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// NewAccountStatusChange valida a mudança do status from para to e cria o seu
+// registro
+func NewAccountStatusChange(accountNum, from, to, reason string) (*AccountStatusChange, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, ErrStatusReasonRequired
+	}
+	if err := CheckStatusTransition(from, to); err != nil {
+		return nil, err
+	}
+	return &AccountStatusChange{AccountNum: accountNum, FromStatus: from, ToStatus: to, Reason: reason}, nil
+}
+
+// DefaultClosureReason é o motivo registrado no histórico de status quando o
+// encerramento da conta não informa outro
+const DefaultClosureReason = "account closed at the holder's request"
+
+// AccountClosure é o resultado do encerramento de uma conta: a conta já
+// encerrada e, se havia saldo, a transferência que o levou para outra conta
+type AccountClosure struct {
+	Account *Client   `json:"account"`
+	Sweep   *Transfer `json:"sweep,omitempty"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// AccountBalance é o saldo de uma conta, pelo livro-razão, no instante AsOf
+type AccountBalance struct {
+	AccountNum string    `json:"account_num"`
+	AsOf       time.Time `json:"as_of"`
+	Balance    Money     `json:"balance"`
+}
+
+// BalanceSnapshot é o saldo de uma conta no fim de um dia (em
+// BusinessLocation), guardado pela rotina diária para que consultas de saldo
+// histórico e gráficos não precisem somar todo o livro-razão. AsOf é o
+// instante em que o dia terminou.
+type BalanceSnapshot struct {
+	AccountNum string    `json:"account_num"`
+	Date       string    `json:"date" example:"2030-03-01"`
+	AsOf       time.Time `json:"as_of"`
+	Balance    Money     `json:"balance"`
+}
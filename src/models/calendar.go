@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// BusinessLocation é o fuso dos dias e meses do banco (horário de Brasília).
+// Limites diários e a cobrança diária de juros seguem esse calendário.
+var BusinessLocation = time.FixedZone("BRT", -3*60*60)
+
+// StartOfBusinessDay retorna o início, em BusinessLocation, do dia que contém t
+func StartOfBusinessDay(t time.Time) time.Time {
+	local := t.In(BusinessLocation)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, BusinessLocation)
+}
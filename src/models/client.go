@@ -1,8 +1,55 @@
 package models
 
+// Client é uma conta e o nome do seu titular, o Customer identificado por
+// CustomerID. O nome vem da época em que cliente e conta eram o mesmo registro
+// e continua sendo usado pelas rotas /v1/clients; Account é o mesmo tipo com o
+// nome usado pelas rotas de clientes (/v1/customers). Version é incrementada a
+// cada alteração do registro e é usada como ETag nas rotas de clientes.
+//
+// Balance é o saldo atual (contábil). Held é a soma das reservas ativas, e
+// AvailableBalance, o saldo atual menos as reservas: é ele que saques,
+// transferências e novas reservas podem usar.
+//
+// Com cheque especial (OverdraftLimit positivo), o saldo disponível pode ficar
+// negativo até -OverdraftLimit; OverdraftUsage é quanto do limite o saldo
+// atual está usando.
+//
+// AccountType (checking ou savings) define a taxa de juros que a conta rende.
+//
+// Status (active, frozen, blocked ou closed) define se a conta pode ser
+// debitada e se pode receber créditos; veja AccountStatusActive e seguintes.
 type Client struct {
-	ID         int     `json:"id"`
-	Name       string  `json:"name"`
-	AccountNum string  `json:"account_num"`
-	Balance    float64 `json:"balance"`
+	ID               int    `json:"id"`
+	CustomerID       int    `json:"customer_id"`
+	Name             string `json:"name"`
+	AccountNum       string `json:"account_num"`
+	AccountType      string `json:"account_type"`
+	Status           string `json:"status"`
+	Balance          Money  `json:"balance"`
+	Held             Money  `json:"held"`
+	AvailableBalance Money  `json:"available_balance"`
+	OverdraftLimit   Money  `json:"overdraft_limit"`
+	OverdraftRateBps int    `json:"overdraft_rate_bps"` // juros anuais do cheque especial, em pontos-base
+	OverdraftUsage   Money  `json:"overdraft_usage"`
+	Version          int    `json:"version"`
+}
+
+// Account é só outro nome de Client, usado pelas rotas de clientes. As contas
+// não têm modelo nem repositório próprios: continuam sendo os registros da
+// tabela clients, lidos e gravados por ClientRepository e ligados ao titular
+// por CustomerID.
+type Account = Client
+
+// SetOverdraftUsage calcula OverdraftUsage a partir do saldo
+func (c *Client) SetOverdraftUsage() {
+	c.OverdraftUsage = NewMoney(0, c.Balance.Currency)
+	if c.Balance.IsNegative() {
+		c.OverdraftUsage = c.Balance.Neg()
+	}
+}
+
+// SetAvailableBalance calcula AvailableBalance a partir do saldo e das reservas
+func (c *Client) SetAvailableBalance() {
+	c.Held.Currency = c.Balance.Currency
+	c.AvailableBalance = c.Balance.Sub(c.Held)
 }
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// ErrCustomerNameRequired é retornado ao criar ou renomear um cliente sem nome
+var ErrCustomerNameRequired = errors.New("customer name is required")
+
+// Customer é o titular das contas. Um cliente pode ter várias contas, de tipos
+// diferentes: os registros de Client com o seu ID em CustomerID, nos quais o
+// seu nome é repetido.
+type Customer struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewCustomer cria um cliente com o nome informado, sem espaços nas pontas
+func NewCustomer(name string) (*Customer, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrCustomerNameRequired
+	}
+	return &Customer{Name: name}, nil
+}
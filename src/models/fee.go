@@ -0,0 +1,113 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// FeeRevenueAccountNum é a conta interna de receita de tarifas: as tarifas
+// cobradas das transferências vão para ela
+const FeeRevenueAccountNum = "SYSTEM-FEE-REVENUE"
+
+var (
+	ErrInvalidFee          = errors.New("fees must not be negative")
+	ErrInvalidFeeTiers     = errors.New("fee tiers must have distinct, non-negative minimum amounts")
+	ErrFeeCurrencyMismatch = errors.New("fee amounts must be in the schedule currency")
+)
+
+// FeeSchedule é a tabela de tarifas das transferências feitas por contas de
+// um tipo. A tarifa de uma transferência é Flat mais PercentBps (pontos-base)
+// do valor; se o valor alcançar o MinAmount de alguma faixa, vale a faixa de
+// maior MinAmount alcançado no lugar de Flat e PercentBps. As primeiras
+// FreeTransfers transferências de cada mês (em BusinessLocation) não pagam
+// tarifa. Os valores estão em Currency; transferências em outra moeda não
+// pagam tarifa.
+type FeeSchedule struct {
+	AccountType   string    `json:"account_type"`
+	Currency      string    `json:"currency" example:"BRL"`
+	Flat          Money     `json:"flat"`
+	PercentBps    int       `json:"percent_bps" example:"0"`
+	Tiers         []FeeTier `json:"tiers"`
+	FreeTransfers int       `json:"free_transfers" example:"0"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// FeeTier é uma faixa da tabela de tarifas, aplicada a transferências a partir
+// de MinAmount
+type FeeTier struct {
+	MinAmount  Money `json:"min_amount"`
+	Flat       Money `json:"flat"`
+	PercentBps int   `json:"percent_bps"`
+}
+
+// Normalize preenche a moeda dos valores sem moeda com a da tabela (BRL por
+// padrão) e ordena as faixas por MinAmount
+func (s *FeeSchedule) Normalize() {
+	if s.Currency == "" {
+		s.Currency = DefaultCurrency
+	}
+	s.Currency = strings.ToUpper(s.Currency)
+	if s.Tiers == nil {
+		s.Tiers = []FeeTier{}
+	}
+	fill := func(m *Money) {
+		if m.Currency == "" {
+			m.Currency = s.Currency
+		}
+		m.Currency = strings.ToUpper(m.Currency)
+	}
+	fill(&s.Flat)
+	for i := range s.Tiers {
+		fill(&s.Tiers[i].MinAmount)
+		fill(&s.Tiers[i].Flat)
+	}
+	for i := 1; i < len(s.Tiers); i++ {
+		for j := i; j > 0 && s.Tiers[j].MinAmount.LessThan(s.Tiers[j-1].MinAmount); j-- {
+			s.Tiers[j], s.Tiers[j-1] = s.Tiers[j-1], s.Tiers[j]
+		}
+	}
+}
+
+// Validate verifica uma tabela já normalizada
+func (s *FeeSchedule) Validate() error {
+	if s.Flat.IsNegative() || s.PercentBps < 0 || s.FreeTransfers < 0 {
+		return ErrInvalidFee
+	}
+	if s.Flat.Currency != s.Currency {
+		return ErrFeeCurrencyMismatch
+	}
+	for i, tier := range s.Tiers {
+		if tier.Flat.IsNegative() || tier.PercentBps < 0 {
+			return ErrInvalidFee
+		}
+		if tier.MinAmount.Currency != s.Currency || tier.Flat.Currency != s.Currency {
+			return ErrFeeCurrencyMismatch
+		}
+		if tier.MinAmount.IsNegative() || (i > 0 && tier.MinAmount == s.Tiers[i-1].MinAmount) {
+			return ErrInvalidFeeTiers
+		}
+	}
+	return nil
+}
+
+// Fee calcula a tarifa de uma transferência de amount, sabendo que a conta já
+// fez transfersThisMonth transferências no mês. A parte percentual é
+// arredondada para o centavo mais próximo (half-even).
+func (s *FeeSchedule) Fee(amount Money, transfersThisMonth int) Money {
+	fee := NewMoney(0, amount.Currency)
+	if amount.Currency != s.Currency || transfersThisMonth < s.FreeTransfers {
+		return fee
+	}
+
+	flat, percentBps := s.Flat, s.PercentBps
+	for _, tier := range s.Tiers {
+		if !amount.LessThan(tier.MinAmount) {
+			flat, percentBps = tier.Flat, tier.PercentBps
+		}
+	}
+	if percentBps > 0 {
+		fee = amount.MulDiv(int64(percentBps), 10000)
+	}
+	return fee.Add(flat)
+}
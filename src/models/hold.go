@@ -0,0 +1,47 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// Status de uma reserva de saldo
+const (
+	HoldStatusActive   = "active"
+	HoldStatusCaptured = "captured"
+	HoldStatusVoided   = "voided"
+	HoldStatusExpired  = "expired"
+)
+
+// DefaultHoldTTL é o prazo de uma reserva criada sem expires_at
+const DefaultHoldTTL = 7 * 24 * time.Hour
+
+var ErrCaptureExceedsHold = errors.New("capture exceeds the amount still held")
+
+// Hold é uma reserva de saldo (autorização) da conta AccountNum em favor da
+// conta ToAccountNum. Enquanto está ativa, o valor ainda não capturado reduz o
+// saldo disponível da conta, mas não o saldo contábil. Cada captura vira uma
+// transferência comum; capturas parciais mantêm a reserva ativa com o restante.
+type Hold struct {
+	ID             int       `json:"id"`
+	AccountNum     string    `json:"account_num"`
+	ToAccountNum   string    `json:"to_account_num"`
+	Amount         Money     `json:"amount"`
+	CapturedAmount Money     `json:"captured_amount"`
+	Status         string    `json:"status"` // "active", "captured", "voided" ou "expired"
+	ExpiresAt      time.Time `json:"expires_at"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// Remaining é o valor da reserva ainda não capturado
+func (h Hold) Remaining() Money {
+	return h.Amount.Sub(h.CapturedAmount)
+}
+
+// IsActive informa se a reserva ainda pode ser capturada no instante now:
+// reservas vencidas não podem, mesmo antes de o executor marcá-las como
+// expiradas e liberar o saldo
+func (h Hold) IsActive(now time.Time) bool {
+	return h.Status == HoldStatusActive && now.Before(h.ExpiresAt)
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// IdempotencyRecord guarda o resultado da primeira requisição feita com uma
+// chave de idempotência. StatusCode zero indica que a requisição ainda está em
+// processamento; nesse caso CreatedAt é quando a chave foi reservada.
+type IdempotencyRecord struct {
+	Key          string    `json:"key"`
+	RequestHash  string    `json:"request_hash"`
+	StatusCode   int       `json:"status_code"`
+	ResponseBody []byte    `json:"response_body"`
+	CreatedAt    time.Time `json:"created_at"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// Completed indica se a resposta da requisição original já foi armazenada
+func (r IdempotencyRecord) Completed() bool {
+	return r.StatusCode != 0
+}
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// Tipos de conta
+const (
+	AccountTypeChecking = "checking"
+	AccountTypeSavings  = "savings"
+)
+
+// AccountTypes lista os tipos de conta aceitos
+var AccountTypes = []string{AccountTypeChecking, AccountTypeSavings}
+
+// InterestExpenseAccountNum é a conta interna de despesa com juros: os juros
+// pagos aos clientes saem dela
+const InterestExpenseAccountNum = "SYSTEM-INTEREST-EXPENSE"
+
+// InterestDenominator converte saldo × taxa anual em pontos-base em juros de
+// um dia: juros = saldo × taxa ÷ InterestDenominator
+const InterestDenominator = 10000 * DaysPerYear
+
+var (
+	ErrInvalidAccountType  = errors.New("invalid account type")
+	ErrInvalidInterestRate = errors.New("interest rate must not be negative")
+)
+
+// IsValidAccountType informa se accountType é um dos AccountTypes
+func IsValidAccountType(accountType string) bool {
+	for _, known := range AccountTypes {
+		if accountType == known {
+			return true
+		}
+	}
+	return false
+}
+
+// InterestRate é a taxa de juros anual, em pontos-base, paga às contas de um tipo
+type InterestRate struct {
+	AccountType string    `json:"account_type"`
+	RateBps     int       `json:"rate_bps"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// InterestAccrual são os juros de um dia de uma conta, sobre o saldo do fim do
+// dia. Interest é arredondado só para exibição: a capitalização soma o valor
+// exato (Balance × RateBps ÷ InterestDenominator) de todos os dias do mês e
+// arredonda uma única vez.
+type InterestAccrual struct {
+	ID               int       `json:"id"`
+	AccountNum       string    `json:"account_num"`
+	AccrualDate      string    `json:"accrual_date"` // AAAA-MM-DD, em BusinessLocation
+	Balance          Money     `json:"balance"`      // saldo no fim do dia
+	RateBps          int       `json:"rate_bps"`
+	Interest         Money     `json:"interest"`
+	CapitalizationID *int      `json:"capitalization_id,omitempty"` // vazio até a capitalização do mês
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// InterestCapitalization é o crédito, em um mês, dos juros acumulados da conta
+type InterestCapitalization struct {
+	ID         int       `json:"id"`
+	AccountNum string    `json:"account_num"`
+	Period     string    `json:"period"` // AAAA-MM, em BusinessLocation
+	Interest   Money     `json:"interest"`
+	TransferID *int      `json:"transfer_id,omitempty"` // vazio quando os juros arredondam para zero
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// InterestHistory reúne os juros acumulados e capitalizados de uma conta
+type InterestHistory struct {
+	Accruals        []InterestAccrual        `json:"accruals"`
+	Capitalizations []InterestCapitalization `json:"capitalizations"`
+}
+
+// DailyInterest calcula os juros de um dia sobre um saldo positivo, com taxa
+// anual em pontos-base, arredondando para o centavo mais próximo (half-even).
+// Saldos não positivos não rendem juros.
+func DailyInterest(balance Money, rateBps int) Money {
+	if !balance.IsPositive() || rateBps <= 0 {
+		return NewMoney(0, balance.Currency)
+	}
+	return balance.MulDiv(int64(rateBps), InterestDenominator)
+}
+
+// CapitalizedInterest soma o valor exato dos juros diários e arredonda o total
+// para o centavo mais próximo (half-even). accrued é a soma de
+// saldo × taxa de cada dia, na moeda currency.
+func CapitalizedInterest(accrued int64, currency string) Money {
+	return NewMoney(accrued, currency).MulDiv(1, InterestDenominator)
+}
+
+// StartOfBusinessMonth retorna a meia-noite do primeiro dia do mês de t em
+// BusinessLocation
+func StartOfBusinessMonth(t time.Time) time.Time {
+	day := StartOfBusinessDay(t)
+	return day.AddDate(0, 0, 1-day.Day())
+}
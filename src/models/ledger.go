@@ -0,0 +1,98 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// OpeningBalanceAccountNum é a conta interna de contrapartida dos saldos de
+// abertura: todo saldo inicial de cliente é lançado contra ela
+const OpeningBalanceAccountNum = "SYSTEM-OPENING"
+
+// TreasuryAccountNum é a conta interna da tesouraria, de onde sai o dinheiro
+// que financia as contas de clientes. O saldo dela é negativo e igual, em
+// módulo, ao total emitido, o que permite auditar o dinheiro do sistema.
+const TreasuryAccountNum = "SYSTEM-TREASURY"
+
+// InternalAccountPrefix identifica as contas internas do banco no livro-razão
+const InternalAccountPrefix = "SYSTEM-"
+
+// CashAccountNum é a conta interna de contrapartida de depósitos e saques: o
+// dinheiro que entra no banco vem dela e o que sai volta para ela
+const CashAccountNum = "SYSTEM-CASH"
+
+// Descrições padrão dos lançamentos
+const (
+	EntryTransfer          = "transfer"
+	EntryOpeningBalance    = "opening balance"
+	EntryDeposit           = "deposit"
+	EntryWithdrawal        = "withdrawal"
+	EntryFunding           = "funding"
+	EntryReversal          = "reversal"
+	EntryOverdraftInterest = "overdraft interest"
+	EntryInterest          = "interest"
+	EntryFee               = "fee"
+)
+
+var ErrUnbalancedEntry = errors.New("journal entry is not balanced")
+
+// JournalEntry é um lançamento contábil. A soma das partidas de cada moeda deve
+// ser zero (partidas dobradas).
+type JournalEntry struct {
+	ID          int       `json:"id"`
+	TransferID  *int      `json:"transfer_id,omitempty"`
+	Description string    `json:"description"`
+	Postings    []Posting `json:"postings"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Posting é uma partida de um lançamento. Valores negativos debitam a conta
+// (dinheiro saindo) e positivos creditam (dinheiro entrando).
+type Posting struct {
+	ID         int    `json:"id"`
+	EntryID    int    `json:"entry_id"`
+	AccountNum string `json:"account_num"`
+	Amount     Money  `json:"amount"`
+}
+
+// NewTransferEntry cria o lançamento de uma movimentação de amount da conta
+// from para a conta to
+func NewTransferEntry(description, fromAccountNum, toAccountNum string, amount Money) JournalEntry {
+	return JournalEntry{
+		Description: description,
+		Postings: []Posting{
+			{AccountNum: fromAccountNum, Amount: amount.Neg()},
+			{AccountNum: toAccountNum, Amount: amount},
+		},
+	}
+}
+
+// Validate verifica se o lançamento tem ao menos duas partidas e se elas somam
+// zero em cada moeda
+func (e JournalEntry) Validate() error {
+	if len(e.Postings) < 2 {
+		return ErrUnbalancedEntry
+	}
+	totals := make(map[string]int64)
+	for _, posting := range e.Postings {
+		totals[posting.Amount.Currency] += posting.Amount.Cents
+	}
+	for _, total := range totals {
+		if total != 0 {
+			return ErrUnbalancedEntry
+		}
+	}
+	return nil
+}
+
+// TreasuryPosition resume o dinheiro do sistema em uma moeda. Como todo
+// lançamento soma zero, o saldo da tesouraria mais os das demais contas
+// internas e os dos clientes deve ser zero.
+type TreasuryPosition struct {
+	Currency         string           `json:"currency"`
+	TreasuryBalance  Money            `json:"treasury_balance"`
+	InternalBalances map[string]Money `json:"internal_balances"`
+	ClientBalances   Money            `json:"client_balances"`
+	LedgerTotal      Money            `json:"ledger_total"`
+	Balanced         bool             `json:"balanced"`
+}
@@ -0,0 +1,159 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultLimitsKey identifica os limites padrão, aplicados às contas que não
+// têm limites próprios
+const DefaultLimitsKey = "default"
+
+// Limites que uma transferência pode exceder
+const (
+	LimitPerTransfer      = "per_transfer"
+	LimitDaily            = "daily"
+	LimitMonthly          = "monthly"
+	LimitDailyCount       = "daily_count"
+	LimitNightPerTransfer = "night_per_transfer"
+	LimitNightTotal       = "night_total"
+)
+
+// O período noturno vai das 20h às 6h do dia seguinte, em BusinessLocation
+const (
+	NightStartHour = 20
+	NightEndHour   = 6
+)
+
+var (
+	ErrNegativeLimit = errors.New("limits must not be negative")
+	ErrLimitCurrency = errors.New("all limits must use the same currency")
+)
+
+// TransferLimits são os limites de transferências de saída de uma conta.
+// Valores zero significam "sem limite". Os limites noturnos valem apenas
+// durante o período noturno e se somam aos demais.
+type TransferLimits struct {
+	AccountNum       string    `json:"account_num"` // "default" para os limites padrão
+	PerTransfer      Money     `json:"per_transfer"`
+	Daily            Money     `json:"daily"`
+	Monthly          Money     `json:"monthly"`
+	DailyCount       int       `json:"daily_count"`
+	NightPerTransfer Money     `json:"night_per_transfer"`
+	NightTotal       Money     `json:"night_total"` // total por período noturno
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// LimitUsage é o quanto a conta já transferiu ou reservou em cada janela dos
+// limites
+type LimitUsage struct {
+	Daily      Money `json:"daily"`
+	Monthly    Money `json:"monthly"`
+	DailyCount int   `json:"daily_count"`
+	NightTotal Money `json:"night_total"`
+}
+
+// LimitStatus são os limites em vigor para uma conta e o quanto ela já usou
+type LimitStatus struct {
+	Limits    TransferLimits `json:"limits"`
+	Inherited bool           `json:"inherited"` // a conta segue os limites padrão
+	Usage     *LimitUsage    `json:"usage,omitempty"`
+}
+
+// LimitWindows são os inícios das janelas que contêm now
+type LimitWindows struct {
+	DayStart   time.Time
+	MonthStart time.Time
+	NightStart time.Time
+	Night      bool // now está dentro do período noturno
+}
+
+// NewLimitWindows calcula as janelas dos limites no horário de BusinessLocation
+func NewLimitWindows(now time.Time) LimitWindows {
+	local := now.In(BusinessLocation)
+	day := StartOfBusinessDay(now)
+
+	windows := LimitWindows{
+		DayStart:   day,
+		MonthStart: time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, BusinessLocation),
+		NightStart: day.Add(NightStartHour * time.Hour),
+	}
+	switch {
+	case local.Hour() >= NightStartHour:
+		windows.Night = true
+	case local.Hour() < NightEndHour:
+		windows.Night = true
+		windows.NightStart = day.AddDate(0, 0, -1).Add(NightStartHour * time.Hour)
+	}
+	return windows
+}
+
+// Validate verifica se os limites não são negativos e usam uma única moeda
+func (l TransferLimits) Validate() error {
+	amounts := []Money{l.PerTransfer, l.Daily, l.Monthly, l.NightPerTransfer, l.NightTotal}
+	for _, amount := range amounts {
+		if amount.IsNegative() {
+			return ErrNegativeLimit
+		}
+		if !amount.SameCurrency(amounts[0]) {
+			return ErrLimitCurrency
+		}
+	}
+	if l.DailyCount < 0 {
+		return ErrNegativeLimit
+	}
+	return nil
+}
+
+// LimitExceededError informa qual limite a transferência excederia e quanto
+// ainda pode ser transferido dentro dele. Para o limite de quantidade,
+// Remaining é zero e Count é o número de transferências permitidas por dia.
+type LimitExceededError struct {
+	Limit     string
+	Remaining Money
+	Count     int
+}
+
+func (e *LimitExceededError) Error() string {
+	if e.Limit == LimitDailyCount {
+		return fmt.Sprintf("daily_count limit exceeded: all %d transfers allowed today have been made", e.Count)
+	}
+	return fmt.Sprintf("%s limit exceeded: remaining allowance is %s", e.Limit, e.Remaining)
+}
+
+// Check verifica se amount cabe nos limites, dado o uso atual e o horário da
+// transferência. Limites em outra moeda que não a do valor são ignorados.
+func (l TransferLimits) Check(amount Money, usage LimitUsage, now time.Time) *LimitExceededError {
+	night := NewLimitWindows(now).Night
+
+	if l.DailyCount > 0 && usage.DailyCount >= l.DailyCount {
+		return &LimitExceededError{Limit: LimitDailyCount, Remaining: NewMoney(0, amount.Currency), Count: l.DailyCount}
+	}
+
+	checks := []struct {
+		name  string
+		limit Money
+		used  Money
+		apply bool
+	}{
+		{LimitPerTransfer, l.PerTransfer, NewMoney(0, amount.Currency), true},
+		{LimitNightPerTransfer, l.NightPerTransfer, NewMoney(0, amount.Currency), night},
+		{LimitDaily, l.Daily, usage.Daily, true},
+		{LimitNightTotal, l.NightTotal, usage.NightTotal, night},
+		{LimitMonthly, l.Monthly, usage.Monthly, true},
+	}
+	for _, check := range checks {
+		if !check.apply || !check.limit.IsPositive() || !check.limit.SameCurrency(amount) {
+			continue
+		}
+		remaining := check.limit.Sub(check.used)
+		if remaining.IsNegative() {
+			remaining = NewMoney(0, amount.Currency)
+		}
+		if amount.GreaterThan(remaining) {
+			return &LimitExceededError{Limit: check.name, Remaining: remaining}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,281 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency é a moeda usada quando nenhuma é informada
+const DefaultCurrency = "BRL"
+
+var (
+	ErrInvalidAmount    = errors.New("invalid amount")
+	ErrAmountPrecision  = errors.New("amount has more than 2 decimal places")
+	ErrAmountOutOfRange = errors.New("amount is too large")
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	ErrInvalidCurrency  = errors.New("currency must be a 3-letter ISO 4217 code")
+)
+
+// NormalizeCurrency devolve o código da moeda em maiúsculas, ou
+// DefaultCurrency se ele estiver vazio, e recusa códigos que não tenham três
+// letras
+func NormalizeCurrency(currency string) (string, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" {
+		return DefaultCurrency, nil
+	}
+	if len(currency) != 3 {
+		return "", fmt.Errorf("%w: %q", ErrInvalidCurrency, currency)
+	}
+	for _, r := range currency {
+		if r < 'A' || r > 'Z' {
+			return "", fmt.Errorf("%w: %q", ErrInvalidCurrency, currency)
+		}
+	}
+	return currency, nil
+}
+
+// Money representa um valor monetário exato em centavos (unidade mínima) e o
+// código ISO 4217 da moeda.
+//
+// Regras de arredondamento:
+//   - valores decimais recebidos (JSON ou ParseMoney) devem ter no máximo duas
+//     casas decimais; qualquer precisão adicional é rejeitada com ErrAmountPrecision
+//   - valores calculados (percentuais, taxas) usam MulDiv, que arredonda para o
+//     centavo mais próximo e, em caso de empate, para o centavo par (half-even)
+//   - a migração de saldos REAL antigos usa ROUND do SQLite (meio centavo para longe do zero)
+type Money struct {
+	Cents    int64  `json:"cents" example:"10050"`
+	Currency string `json:"currency" example:"BRL"`
+}
+
+// NewMoney cria um valor a partir de centavos; moeda vazia assume DefaultCurrency
+func NewMoney(cents int64, currency string) Money {
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	return Money{Cents: cents, Currency: currency}
+}
+
+// BRL cria um valor em reais a partir de centavos
+func BRL(cents int64) Money {
+	return NewMoney(cents, DefaultCurrency)
+}
+
+// ParseMoney converte uma string decimal ("100.50", "-3", "0.5") em Money sem
+// passar por ponto flutuante. Valores que não cabem em centavos int64 são
+// recusados com ErrAmountOutOfRange, e a moeda é validada por
+// NormalizeCurrency.
+func ParseMoney(s, currency string) (Money, error) {
+	currency, err := NormalizeCurrency(currency)
+	if err != nil {
+		return Money{}, err
+	}
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Money{}, ErrInvalidAmount
+	}
+
+	negative := false
+	switch s[0] {
+	case '-':
+		negative = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	intPart, fracPart, hasFrac := strings.Cut(s, ".")
+	if intPart == "" && (!hasFrac || fracPart == "") {
+		return Money{}, ErrInvalidAmount
+	}
+	if hasFrac && fracPart == "" {
+		return Money{}, ErrInvalidAmount
+	}
+	if !isDigits(intPart) || !isDigits(fracPart) {
+		return Money{}, ErrInvalidAmount
+	}
+	if len(strings.TrimRight(fracPart, "0")) > 2 {
+		return Money{}, ErrAmountPrecision
+	}
+	fracPart = (fracPart + "00")[:2]
+	if intPart == "" {
+		intPart = "0"
+	}
+
+	cents, _ := strconv.ParseInt(fracPart, 10, 64)
+	units, err := strconv.ParseInt(intPart, 10, 64)
+	if errors.Is(err, strconv.ErrRange) || units > (math.MaxInt64-cents)/100 {
+		return Money{}, ErrAmountOutOfRange
+	}
+	if err != nil {
+		return Money{}, ErrInvalidAmount
+	}
+	total := units*100 + cents
+	if negative {
+		total = -total
+	}
+	return NewMoney(total, currency), nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Decimal retorna o valor formatado com duas casas decimais, ex.: "100.50"
+func (m Money) Decimal() string {
+	cents := m.Cents
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// String retorna o valor com a moeda, ex.: "100.50 BRL"
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+// SameCurrency indica se dois valores estão na mesma moeda
+func (m Money) SameCurrency(other Money) bool {
+	return m.Currency == other.Currency
+}
+
+// Add soma dois valores da mesma moeda. Moedas diferentes são erro de
+// programação: valide com SameCurrency antes.
+func (m Money) Add(other Money) Money {
+	m.mustMatch(other)
+	return Money{Cents: m.Cents + other.Cents, Currency: m.Currency}
+}
+
+// Sub subtrai dois valores da mesma moeda
+func (m Money) Sub(other Money) Money {
+	m.mustMatch(other)
+	return Money{Cents: m.Cents - other.Cents, Currency: m.Currency}
+}
+
+// Neg retorna o valor com sinal invertido
+func (m Money) Neg() Money {
+	return Money{Cents: -m.Cents, Currency: m.Currency}
+}
+
+// LessThan compara dois valores da mesma moeda
+func (m Money) LessThan(other Money) bool {
+	m.mustMatch(other)
+	return m.Cents < other.Cents
+}
+
+// GreaterThan compara dois valores da mesma moeda
+func (m Money) GreaterThan(other Money) bool {
+	m.mustMatch(other)
+	return m.Cents > other.Cents
+}
+
+func (m Money) IsZero() bool     { return m.Cents == 0 }
+func (m Money) IsPositive() bool { return m.Cents > 0 }
+func (m Money) IsNegative() bool { return m.Cents < 0 }
+
+// MulDiv calcula m * num / den arredondando para o centavo mais próximo
+// (half-even). Usado para percentuais: 1,5% = MulDiv(15, 1000).
+func (m Money) MulDiv(num, den int64) Money {
+	if den == 0 {
+		panic("models: MulDiv by zero")
+	}
+	if den < 0 {
+		num, den = -num, -den
+	}
+	product := m.Cents * num
+	quotient := product / den
+	remainder := product % den
+	if remainder != 0 {
+		twice := 2 * remainder
+		if twice < 0 {
+			twice = -twice
+		}
+		roundAway := twice > den || (twice == den && quotient%2 != 0)
+		if roundAway {
+			if product < 0 {
+				quotient--
+			} else {
+				quotient++
+			}
+		}
+	}
+	return Money{Cents: quotient, Currency: m.Currency}
+}
+
+func (m Money) mustMatch(other Money) {
+	if m.Currency != other.Currency {
+		panic(fmt.Sprintf("models: %v: %s vs %s", ErrCurrencyMismatch, m.Currency, other.Currency))
+	}
+}
+
+// UnmarshalJSON aceita o formato canônico {"cents": 10050, "currency": "BRL"}
+// e, por compatibilidade, um número ou string decimal (100.50 ou "100.50"),
+// que é interpretado em DefaultCurrency. A moeda é validada por
+// NormalizeCurrency, e valores que não cabem em centavos int64 são recusados
+// com ErrAmountOutOfRange.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	switch data[0] {
+	case '{':
+		var raw struct {
+			Cents    *json.Number `json:"cents"`
+			Currency string       `json:"currency"`
+		}
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return err
+		}
+		if raw.Cents == nil {
+			return ErrInvalidAmount
+		}
+		cents, err := strconv.ParseInt(raw.Cents.String(), 10, 64)
+		if errors.Is(err, strconv.ErrRange) {
+			return ErrAmountOutOfRange
+		}
+		if err != nil {
+			return ErrInvalidAmount
+		}
+		currency, err := NormalizeCurrency(raw.Currency)
+		if err != nil {
+			return err
+		}
+		*m = NewMoney(cents, currency)
+		return nil
+	case '"':
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		parsed, err := ParseMoney(s, DefaultCurrency)
+		if err != nil {
+			return err
+		}
+		*m = parsed
+		return nil
+	default:
+		// O texto do número é convertido diretamente, sem float64
+		parsed, err := ParseMoney(string(data), DefaultCurrency)
+		if err != nil {
+			return err
+		}
+		*m = parsed
+		return nil
+	}
+}
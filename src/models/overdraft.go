@@ -0,0 +1,76 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// OverdraftInterestAccountNum é a conta interna de receita dos juros de cheque
+// especial: os juros cobrados dos clientes vão para ela
+const OverdraftInterestAccountNum = "SYSTEM-OVERDRAFT-INTEREST"
+
+// DaysPerYear é a base de dias usada para converter taxas anuais em diárias
+const DaysPerYear = 365
+
+var (
+	ErrInvalidOverdraftLimit = errors.New("overdraft limit must not be negative")
+	ErrInvalidOverdraftRate  = errors.New("overdraft rate must not be negative")
+)
+
+// OverdraftInterest é a cobrança de juros de cheque especial de uma conta em
+// um dia. Há no máximo uma por conta e dia.
+type OverdraftInterest struct {
+	ID          int       `json:"id"`
+	AccountNum  string    `json:"account_num"`
+	AccrualDate string    `json:"accrual_date"` // AAAA-MM-DD, em BusinessLocation
+	Balance     Money     `json:"balance"`      // saldo no fim do dia
+	RateBps     int       `json:"rate_bps"`
+	Interest    Money     `json:"interest"`
+	TransferID  *int      `json:"transfer_id,omitempty"` // vazio quando os juros arredondam para zero
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// DailyOverdraftInterest calcula os juros de um dia sobre um saldo negativo,
+// com taxa anual em pontos-base, arredondando para o centavo mais próximo
+// (half-even). Saldos não negativos não pagam juros.
+func DailyOverdraftInterest(balance Money, rateBps int) Money {
+	if !balance.IsNegative() || rateBps <= 0 {
+		return NewMoney(0, balance.Currency)
+	}
+	return balance.Neg().MulDiv(int64(rateBps), 10000*DaysPerYear)
+}
+
+// Tipos de notificação
+const (
+	NotificationOverdraftEntered = "overdraft_entered"
+	NotificationOverdraftLeft    = "overdraft_left"
+)
+
+// Notification é um aviso ao titular da conta
+type Notification struct {
+	ID         int       `json:"id"`
+	AccountNum string    `json:"account_num"`
+	Type       string    `json:"type"`
+	Message    string    `json:"message"`
+	Balance    Money     `json:"balance"` // saldo logo após o evento
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// OverdraftNotification retorna a notificação de entrada ou saída do cheque
+// especial quando o saldo passa de before para after, ou nil se a conta não
+// entrou nem saiu do cheque especial
+func OverdraftNotification(accountNum string, before, after Money) *Notification {
+	notification := &Notification{AccountNum: accountNum, Balance: after}
+	switch {
+	case !before.IsNegative() && after.IsNegative():
+		notification.Type = NotificationOverdraftEntered
+		notification.Message = fmt.Sprintf("account %s entered overdraft: balance is %s", accountNum, after)
+	case before.IsNegative() && !after.IsNegative():
+		notification.Type = NotificationOverdraftLeft
+		notification.Message = fmt.Sprintf("account %s left overdraft: balance is %s", accountNum, after)
+	default:
+		return nil
+	}
+	return notification
+}
@@ -0,0 +1,65 @@
+package models
+
+import "time"
+
+// Tipos de divergência encontrados pela conciliação
+const (
+	// DiscrepancyLedgerBalance: o saldo armazenado da conta difere da soma das
+	// suas partidas no livro-razão
+	DiscrepancyLedgerBalance = "ledger_balance_mismatch"
+	// DiscrepancyTransferBalance: o saldo armazenado da conta difere do saldo
+	// recalculado pelas transferências bem-sucedidas e saldos de abertura
+	DiscrepancyTransferBalance = "transfer_balance_mismatch"
+	// DiscrepancyHeldBalance: o valor reservado da conta difere do restante
+	// das suas reservas ativas
+	DiscrepancyHeldBalance = "held_balance_mismatch"
+	// DiscrepancyUnbalancedEntry: as partidas de um lançamento não somam zero
+	// em alguma moeda, ou o lançamento tem menos de duas partidas
+	DiscrepancyUnbalancedEntry = "unbalanced_entry"
+	// DiscrepancyMoneyNotConserved: a soma de todas as partidas de uma moeda,
+	// de clientes e contas internas, não é zero
+	DiscrepancyMoneyNotConserved = "money_not_conserved"
+	// DiscrepancyMissingEntry: uma transferência bem-sucedida não tem
+	// lançamento no livro-razão
+	DiscrepancyMissingEntry = "missing_ledger_entry"
+	// DiscrepancyEntryMismatch: o lançamento não corresponde à transferência
+	// (contas ou valor diferentes, ou a transferência foi recusada)
+	DiscrepancyEntryMismatch = "entry_mismatch"
+	// DiscrepancyOrphanTransfer: uma transferência bem-sucedida movimenta uma
+	// conta que não é de cliente nem interna, ou aponta para uma transferência
+	// inexistente em reversal_of ou fee_of
+	DiscrepancyOrphanTransfer = "orphan_transfer"
+	// DiscrepancyOrphanEntry: um lançamento aponta para uma transferência
+	// inexistente, ou uma partida aponta para um lançamento inexistente ou para
+	// uma conta que não é de cliente nem interna
+	DiscrepancyOrphanEntry = "orphan_entry"
+	// DiscrepancyDuplicateAccount: mais de um cliente com o mesmo número de
+	// conta, ignorando maiúsculas e espaços nas pontas
+	DiscrepancyDuplicateAccount = "duplicate_account_num"
+	// DiscrepancyForeignKey: uma linha aponta para uma linha inexistente em
+	// uma chave estrangeira declarada no esquema
+	DiscrepancyForeignKey = "foreign_key_violation"
+)
+
+// Discrepancy é uma divergência encontrada pela conciliação. Expected é o
+// valor recalculado e Actual o armazenado, quando a divergência é de valor.
+type Discrepancy struct {
+	Type       string `json:"type"`
+	AccountNum string `json:"account_num,omitempty"`
+	TransferID *int   `json:"transfer_id,omitempty"`
+	EntryID    *int   `json:"entry_id,omitempty"`
+	Expected   *Money `json:"expected,omitempty"`
+	Actual     *Money `json:"actual,omitempty"`
+	Detail     string `json:"detail"`
+}
+
+// ReconciliationReport é o resultado da conciliação: o que foi conferido e
+// as divergências encontradas. OK é verdadeiro quando não há nenhuma.
+type ReconciliationReport struct {
+	GeneratedAt      time.Time     `json:"generated_at"`
+	OK               bool          `json:"ok"`
+	AccountsChecked  int           `json:"accounts_checked"`
+	TransfersChecked int           `json:"transfers_checked"`
+	EntriesChecked   int           `json:"entries_checked"`
+	Discrepancies    []Discrepancy `json:"discrepancies"`
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// Status de uma transferência agendada
+const (
+	ScheduledStatusPending    = "pending"
+	ScheduledStatusProcessing = "processing"
+	ScheduledStatusExecuted   = "executed"
+	ScheduledStatusFailed     = "failed"
+	ScheduledStatusCancelled  = "cancelled"
+)
+
+// ScheduledTransfer é uma transferência que será executada em ExecuteAt. Ao ser
+// executada, a transferência em si é registrada na tabela transfers e o
+// agendamento guarda apenas o resultado.
+type ScheduledTransfer struct {
+	ID             int        `json:"id"`
+	FromAccountNum string     `json:"from_account_num"`
+	ToAccountNum   string     `json:"to_account_num"`
+	Amount         Money      `json:"amount"`
+	ExecuteAt      time.Time  `json:"execute_at"`
+	Status         string     `json:"status"`                   // "pending", "processing", "executed", "failed" ou "cancelled"
+	FailureReason  string     `json:"failure_reason,omitempty"` // preenchido apenas quando Status é "failed"
+	CreatedAt      time.Time  `json:"created_at"`
+	ExecutedAt     *time.Time `json:"executed_at,omitempty"`
+}
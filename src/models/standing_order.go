@@ -0,0 +1,164 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// Frequências de uma ordem permanente
+const (
+	FrequencyDaily   = "daily"
+	FrequencyWeekly  = "weekly"
+	FrequencyMonthly = "monthly"
+	FrequencyCron    = "cron"
+)
+
+// Status de uma ordem permanente
+const (
+	StandingOrderActive    = "active"
+	StandingOrderPaused    = "paused"
+	StandingOrderCancelled = "cancelled"
+	StandingOrderCompleted = "completed"
+)
+
+// Status de uma execução de ordem permanente
+const (
+	StandingOrderRunSuccess = "success"
+	StandingOrderRunFailed  = "failed"
+)
+
+var (
+	ErrInvalidFrequency = errors.New("frequency must be daily, weekly, monthly or cron")
+	ErrInvalidInterval  = errors.New("interval must be greater than zero")
+	ErrInvalidEndAt     = errors.New("end_at must be after start_at")
+	ErrInvalidMaxRuns   = errors.New("max_occurrences must not be negative")
+)
+
+// StandingOrder é uma transferência recorrente. Cada ocorrência é executada
+// como uma transferência comum; NextOccurrenceAt é a data da ocorrência
+// pendente e NextRunAt é quando ela será tentada, que só difere da primeira
+// quando uma tentativa recusada por saldo insuficiente está aguardando nova
+// tentativa.
+type StandingOrder struct {
+	ID               int        `json:"id"`
+	FromAccountNum   string     `json:"from_account_num"`
+	ToAccountNum     string     `json:"to_account_num"`
+	Amount           Money      `json:"amount"`
+	Frequency        string     `json:"frequency"`          // "daily", "weekly", "monthly" ou "cron"
+	Interval         int        `json:"interval,omitempty"` // a cada quantos dias, semanas ou meses
+	Cron             string     `json:"cron,omitempty"`     // expressão cron em UTC, quando Frequency é "cron"
+	StartAt          time.Time  `json:"start_at"`
+	EndAt            *time.Time `json:"end_at,omitempty"`
+	MaxOccurrences   int        `json:"max_occurrences,omitempty"` // zero para ilimitado
+	Occurrences      int        `json:"occurrences"`               // ocorrências já processadas, com sucesso ou não
+	NextOccurrenceAt time.Time  `json:"next_occurrence_at"`
+	NextRunAt        *time.Time `json:"next_run_at,omitempty"`
+	Retries          int        `json:"retries"` // tentativas recusadas da ocorrência pendente
+	Status           string     `json:"status"`  // "active", "paused", "cancelled" ou "completed"
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// StandingOrderRun registra uma tentativa de executar uma ocorrência
+type StandingOrderRun struct {
+	ID              int        `json:"id"`
+	StandingOrderID int        `json:"standing_order_id"`
+	OccurrenceAt    time.Time  `json:"occurrence_at"`
+	Attempt         int        `json:"attempt"`
+	Status          string     `json:"status"` // "success" ou "failed"
+	FailureReason   string     `json:"failure_reason,omitempty"`
+	RetryAt         *time.Time `json:"retry_at,omitempty"` // próxima tentativa, quando a recusa será retentada
+	ExecutedAt      time.Time  `json:"executed_at"`
+}
+
+// Validate verifica a regra de recorrência da ordem
+func (o StandingOrder) Validate() error {
+	switch o.Frequency {
+	case FrequencyDaily, FrequencyWeekly, FrequencyMonthly:
+		if o.Interval <= 0 {
+			return ErrInvalidInterval
+		}
+	case FrequencyCron:
+		if _, err := ParseCron(o.Cron); err != nil {
+			return err
+		}
+	default:
+		return ErrInvalidFrequency
+	}
+	if o.EndAt != nil && !o.EndAt.After(o.StartAt) {
+		return ErrInvalidEndAt
+	}
+	if o.MaxOccurrences < 0 {
+		return ErrInvalidMaxRuns
+	}
+	return nil
+}
+
+// FirstOccurrence retorna a primeira ocorrência a partir de StartAt (inclusive)
+func (o StandingOrder) FirstOccurrence() time.Time {
+	return o.NextOccurrence(o.StartAt.Add(-time.Nanosecond))
+}
+
+// NextOccurrence retorna a primeira ocorrência estritamente posterior a after,
+// ou o tempo zero se não houver. As ocorrências diárias e semanais são
+// múltiplos do intervalo a partir de StartAt; as mensais caem no dia do mês de
+// StartAt, ou no último dia do mês quando ele é mais curto.
+func (o StandingOrder) NextOccurrence(after time.Time) time.Time {
+	start := o.StartAt.UTC()
+	if after.Before(start) && o.Frequency != FrequencyCron {
+		return start
+	}
+
+	switch o.Frequency {
+	case FrequencyDaily, FrequencyWeekly:
+		period := 24 * time.Hour * time.Duration(o.Interval)
+		if o.Frequency == FrequencyWeekly {
+			period *= 7
+		}
+		return start.Add(period * (after.Sub(start)/period + 1))
+	case FrequencyMonthly:
+		after = after.UTC()
+		months := (after.Year()-start.Year())*12 + int(after.Month()-start.Month())
+		k := months / o.Interval
+		for {
+			occurrence := addMonthsClamped(start, k*o.Interval)
+			if occurrence.After(after) {
+				return occurrence
+			}
+			k++
+		}
+	case FrequencyCron:
+		schedule, err := ParseCron(o.Cron)
+		if err != nil {
+			return time.Time{}
+		}
+		if after.Before(start) {
+			after = start.Add(-time.Nanosecond)
+		}
+		return schedule.Next(after)
+	}
+	return time.Time{}
+}
+
+// Finished indica se a ocorrência next não deve mais ser executada, seja pelo
+// limite de ocorrências, seja pela data final
+func (o StandingOrder) Finished(next time.Time) bool {
+	if o.MaxOccurrences > 0 && o.Occurrences >= o.MaxOccurrences {
+		return true
+	}
+	if next.IsZero() {
+		return true
+	}
+	return o.EndAt != nil && next.After(*o.EndAt)
+}
+
+// addMonthsClamped soma meses a t mantendo o dia do mês, limitado ao último dia
+// do mês de destino (31/01 + 1 mês = 28/02 ou 29/02)
+func addMonthsClamped(t time.Time, months int) time.Time {
+	firstOfMonth := time.Date(t.Year(), t.Month()+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+	day := t.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return firstOfMonth.AddDate(0, 0, day-1)
+}
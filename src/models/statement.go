@@ -0,0 +1,68 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrInvalidPeriod é retornado quando o início de um período consultado é
+// posterior ao fim
+var ErrInvalidPeriod = errors.New("period must start before it ends")
+
+// Statement é o extrato de uma conta em um período [From, To): o saldo de
+// abertura, cada movimentação com o saldo logo depois dela, o saldo de
+// fechamento e os totais de créditos e débitos do período
+type Statement struct {
+	AccountNum     string          `json:"account_num"`
+	From           time.Time       `json:"from"`
+	To             time.Time       `json:"to"`
+	OpeningBalance Money           `json:"opening_balance"`
+	ClosingBalance Money           `json:"closing_balance"`
+	TotalCredits   Money           `json:"total_credits"`
+	TotalDebits    Money           `json:"total_debits"`
+	Movements      []StatementLine `json:"movements"`
+}
+
+// StatementLine é uma movimentação do extrato, montada a partir de um
+// lançamento do livro-razão. Amount é negativo nos débitos e positivo nos
+// créditos, e Balance é o saldo da conta logo depois da movimentação.
+type StatementLine struct {
+	EntryID      int       `json:"entry_id"`
+	TransferID   *int      `json:"transfer_id,omitempty"`
+	Type         string    `json:"type,omitempty"` // tipo da transferência, quando o lançamento tem uma
+	Description  string    `json:"description"`
+	Counterparty string    `json:"counterparty"`
+	Amount       Money     `json:"amount"`
+	Balance      Money     `json:"balance"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// NewStatement monta o extrato a partir do saldo de abertura e das
+// movimentações do período, em ordem cronológica, preenchendo o saldo de cada
+// linha, os totais e o saldo de fechamento
+func NewStatement(accountNum string, from, to time.Time, opening Money, movements []StatementLine) *Statement {
+	statement := &Statement{
+		AccountNum:     accountNum,
+		From:           from,
+		To:             to,
+		OpeningBalance: opening,
+		ClosingBalance: opening,
+		TotalCredits:   NewMoney(0, opening.Currency),
+		TotalDebits:    NewMoney(0, opening.Currency),
+		Movements:      movements,
+	}
+	if statement.Movements == nil {
+		statement.Movements = []StatementLine{}
+	}
+	for i := range statement.Movements {
+		line := &statement.Movements[i]
+		statement.ClosingBalance = statement.ClosingBalance.Add(line.Amount)
+		line.Balance = statement.ClosingBalance
+		if line.Amount.IsNegative() {
+			statement.TotalDebits = statement.TotalDebits.Add(line.Amount.Neg())
+		} else {
+			statement.TotalCredits = statement.TotalCredits.Add(line.Amount)
+		}
+	}
+	return statement
+}
@@ -2,11 +2,54 @@ package models
 
 import "time"
 
+// Status de uma transferência
+const (
+	TransferStatusSuccess = "success"
+	TransferStatusFailed  = "failed"
+)
+
+// Tipos de movimentação registrados na tabela transfers
+const (
+	TransferTypeTransfer   = "transfer"
+	TransferTypeDeposit    = "deposit"
+	TransferTypeWithdrawal = "withdrawal"
+	TransferTypeFunding    = "funding"
+	TransferTypeReversal   = "reversal"
+	// TransferTypeOverdraftInterest é a cobrança diária de juros do cheque especial
+	TransferTypeOverdraftInterest = "overdraft_interest"
+	// TransferTypeInterest é a capitalização mensal dos juros pagos à conta
+	TransferTypeInterest = "interest"
+	// TransferTypeFee é a tarifa cobrada por uma transferência
+	TransferTypeFee = "fee"
+)
+
+// Motivos de falha registrados nas transferências recusadas
+const (
+	FailureInvalidAmount       = "invalid_amount"
+	FailureLimitExceeded       = "limit_exceeded"
+	FailureInsufficientBalance = "insufficient_balance"
+	FailureSourceNotFound      = "source_account_not_found"
+	FailureDestinationNotFound = "destination_account_not_found"
+	FailureCurrencyMismatch    = "currency_mismatch"
+	FailureDebitsNotAllowed    = "debits_not_allowed"
+	FailureCreditsNotAllowed   = "credits_not_allowed"
+	FailureNotReversible       = "transfer_not_reversible"
+	FailureReversalExceeded    = "reversal_exceeds_original"
+	FailureAlreadyFunded       = "already_funded"
+	FailureInternalError       = "internal_error"
+)
+
 type Transfer struct {
 	ID             int       `json:"id"`
 	FromAccountNum string    `json:"from_account_num"`
 	ToAccountNum   string    `json:"to_account_num"`
-	Amount         float64   `json:"amount"`
-	Status         string    `json:"status"` // "success" ou "failed"
+	Amount         Money     `json:"amount"`
+	Type           string    `json:"type"`                      // "transfer", "deposit", "withdrawal", "funding", "reversal", "overdraft_interest", "interest" ou "fee"
+	Status         string    `json:"status"`                    // "success" ou "failed"
+	FailureReason  string    `json:"failure_reason,omitempty"`  // preenchido apenas quando Status é "failed"
+	ReversalOf     *int      `json:"reversal_of,omitempty"`     // transferência estornada, quando Type é "reversal"
+	ReversedAmount *Money    `json:"reversed_amount,omitempty"` // total já estornado desta transferência
+	FeeOf          *int      `json:"fee_of,omitempty"`          // transferência tarifada, quando Type é "fee"
+	Fee            *Money    `json:"fee,omitempty"`             // tarifa cobrada por esta transferência
 	CreatedAt      time.Time `json:"created_at"`
 }
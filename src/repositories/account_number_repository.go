@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"database/sql"
+)
+
+// AccountNumberRepository define a interface para a sequência dos números de
+// conta de cada agência
+type AccountNumberRepository interface {
+	NextAccountNumber(branch string) (int64, error)
+}
+
+type AccountNumberRepositoryImpl struct {
+	db DBTX
+}
+
+func NewAccountNumberRepository(db *sql.DB) *AccountNumberRepositoryImpl {
+	return &AccountNumberRepositoryImpl{db: db}
+}
+
+// Implementação do método NextAccountNumber: avança a sequência da agência e
+// retorna o novo número, começando em 1. A leitura e o incremento são o mesmo
+// comando, então dois pedidos nunca recebem o mesmo número.
+func (repo *AccountNumberRepositoryImpl) NextAccountNumber(branch string) (int64, error) {
+	var number int64
+	err := repo.db.QueryRow(`INSERT INTO account_number_sequences (branch, last_number) VALUES (?, 1)
+		ON CONFLICT (branch) DO UPDATE SET last_number = last_number + 1 RETURNING last_number`, branch).Scan(&number)
+	return number, err
+}
@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"banking/src/models"
+	"database/sql"
+)
+
+// AccountStatusRepository define a interface para o histórico de status das contas
+type AccountStatusRepository interface {
+	CreateStatusChange(change *models.AccountStatusChange) error
+	GetStatusHistory(accountNum string) ([]models.AccountStatusChange, error)
+}
+
+type AccountStatusRepositoryImpl struct {
+	db DBTX
+}
+
+func NewAccountStatusRepository(db *sql.DB) *AccountStatusRepositoryImpl {
+	return &AccountStatusRepositoryImpl{db: db}
+}
+
+// Implementação do método CreateStatusChange
+func (repo *AccountStatusRepositoryImpl) CreateStatusChange(change *models.AccountStatusChange) error {
+	return repo.db.QueryRow("INSERT INTO account_status_history (account_num, from_status, to_status, reason) VALUES (?, ?, ?, ?) RETURNING id, created_at",
+		change.AccountNum, change.FromStatus, change.ToStatus, change.Reason).
+		Scan(&change.ID, &change.CreatedAt)
+}
+
+// Implementação do método GetStatusHistory: mudanças de status da conta, das
+// mais recentes para as mais antigas
+func (repo *AccountStatusRepositoryImpl) GetStatusHistory(accountNum string) ([]models.AccountStatusChange, error) {
+	rows, err := repo.db.Query("SELECT id, account_num, from_status, to_status, reason, created_at FROM account_status_history WHERE account_num = ? ORDER BY id DESC", accountNum)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := []models.AccountStatusChange{}
+	for rows.Next() {
+		var change models.AccountStatusChange
+		if err := rows.Scan(&change.ID, &change.AccountNum, &change.FromStatus, &change.ToStatus, &change.Reason, &change.CreatedAt); err != nil {
+			return nil, err
+		}
+		history = append(history, change)
+	}
+	return history, rows.Err()
+}
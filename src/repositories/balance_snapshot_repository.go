@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"banking/src/models"
+	"database/sql"
+	"time"
+)
+
+// BalanceSnapshotRepository define a interface para os saldos de fim de dia
+type BalanceSnapshotRepository interface {
+	CreateSnapshots(date string, asOf time.Time) (int, error)
+	GetLatestSnapshotDate() (string, error)
+	GetSnapshots(accountNum, from, to string) ([]models.BalanceSnapshot, error)
+}
+
+type BalanceSnapshotRepositoryImpl struct {
+	db DBTX
+}
+
+func NewBalanceSnapshotRepository(db *sql.DB) *BalanceSnapshotRepositoryImpl {
+	return &BalanceSnapshotRepositoryImpl{db: db}
+}
+
+// Implementação do método CreateSnapshots: guarda o saldo de cada conta de
+// cliente, na moeda da conta, no instante asOf que encerra o dia date. O saldo
+// parte do último saldo guardado antes de asOf e soma só as partidas lançadas
+// depois dele. Contas que já têm o saldo do dia ficam como estão, então
+// executar o mesmo dia de novo não muda nada. Retorna o número de saldos
+// guardados nesta execução.
+func (repo *BalanceSnapshotRepositoryImpl) CreateSnapshots(date string, asOf time.Time) (int, error) {
+	end := timestamp(asOf)
+	result, err := repo.db.Exec(`
+		INSERT INTO balance_snapshots (account_num, snapshot_date, as_of, balance, currency)
+		SELECT c.account_num, ?, ?,
+			COALESCE(s.balance, 0) + COALESCE((
+				SELECT SUM(p.amount) FROM postings p JOIN journal_entries e ON e.id = p.entry_id
+				WHERE p.account_num = c.account_num AND p.currency = c.currency
+					AND e.created_at >= COALESCE(s.as_of, '') AND e.created_at < ?
+			), 0),
+			c.currency
+		FROM clients c
+		LEFT JOIN balance_snapshots s ON s.account_num = c.account_num AND s.currency = c.currency AND s.as_of = (
+			SELECT MAX(l.as_of) FROM balance_snapshots l
+			WHERE l.account_num = c.account_num AND l.currency = c.currency AND l.as_of <= ?
+		)
+		WHERE true
+		ON CONFLICT (account_num, currency, snapshot_date) DO NOTHING`,
+		date, end, end, end)
+	if err != nil {
+		return 0, err
+	}
+	created, err := result.RowsAffected()
+	return int(created), err
+}
+
+// Implementação do método GetLatestSnapshotDate: o dia mais recente com saldos
+// guardados, ou vazio se não houver nenhum
+func (repo *BalanceSnapshotRepositoryImpl) GetLatestSnapshotDate() (string, error) {
+	var date sql.NullString
+	err := repo.db.QueryRow("SELECT MAX(snapshot_date) FROM balance_snapshots").Scan(&date)
+	return date.String, err
+}
+
+// Implementação do método GetSnapshots: os saldos de fim de dia da conta entre
+// as datas from e to (inclusive), do mais antigo para o mais recente
+func (repo *BalanceSnapshotRepositoryImpl) GetSnapshots(accountNum, from, to string) ([]models.BalanceSnapshot, error) {
+	rows, err := repo.db.Query(`SELECT account_num, snapshot_date, as_of, balance, currency
+		FROM balance_snapshots
+		WHERE account_num = ? AND snapshot_date BETWEEN ? AND ?
+		ORDER BY snapshot_date`, accountNum, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	snapshots := []models.BalanceSnapshot{}
+	for rows.Next() {
+		var snapshot models.BalanceSnapshot
+		if err := rows.Scan(&snapshot.AccountNum, &snapshot.Date, &snapshot.AsOf,
+			&snapshot.Balance.Cents, &snapshot.Balance.Currency); err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots, rows.Err()
+}
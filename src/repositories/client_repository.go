@@ -4,18 +4,57 @@ import (
 	"banking/src/models"
 	"database/sql"
 	"errors"
+	"fmt"
 )
 
+var (
+	// ErrClientNotFound é retornado quando não existe cliente com o número de conta informado
+	ErrClientNotFound = errors.New("client not found")
+	// ErrInsufficientBalance é retornado quando um débito deixaria o saldo abaixo
+	// do permitido (zero, ou -limite com cheque especial)
+	ErrInsufficientBalance = errors.New("insufficient balance")
+	// ErrOverdraftInUse é retornado ao reduzir o limite do cheque especial abaixo
+	// do valor já em uso
+	ErrOverdraftInUse = errors.New("overdraft limit is below the amount in use")
+	// ErrVersionConflict é o erro base de VersionConflictError
+	ErrVersionConflict = errors.New("client was modified by another request")
+)
+
+// VersionConflictError é retornado quando o cliente foi alterado depois de lido:
+// a versão esperada não é mais a versão armazenada
+type VersionConflictError struct {
+	AccountNum      string
+	ExpectedVersion int
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("%s: account %s is no longer at version %d", ErrVersionConflict, e.AccountNum, e.ExpectedVersion)
+}
+
+func (e *VersionConflictError) Unwrap() error {
+	return ErrVersionConflict
+}
+
 type ClientRepository interface {
 	GetClientByAccountNum(accountNum string) (*models.Client, error)
+	UpdateClient(client *models.Client) error
 	UpdateClientBalance(client *models.Client) error
+	DebitClientBalance(accountNum string, amount models.Money) (models.Money, error)
+	CreditClientBalance(accountNum string, amount models.Money) (models.Money, error)
+	ChargeClientBalance(accountNum string, amount models.Money) (models.Money, error)
+	UpdateOverdraft(accountNum string, limit models.Money, rateBps int) (*models.Client, error)
+	UpdateClientStatus(accountNum, status string) (*models.Client, error)
+	HoldClientBalance(accountNum string, amount models.Money) (models.Money, error)
+	ReleaseClientHold(accountNum string, amount models.Money) (models.Money, error)
 	CreateClient(client *models.Client) error
 	GetClients() ([]models.Client, error)
+	GetClientsByCustomer(customerID int) ([]models.Client, error)
+	GetTotalBalance(currency string) (models.Money, error)
 }
 
 // ClientRepositoryImpl é a implementação concreta do repositório
 type ClientRepositoryImpl struct {
-	db *sql.DB // Supondo que você tenha uma conexão de banco de dados SQL
+	db DBTX // Conexão com o banco ou transação em andamento
 }
 
 // NewClientRepository cria uma nova instância de ClientRepositoryImpl
@@ -23,47 +62,238 @@ func NewClientRepository(db *sql.DB) *ClientRepositoryImpl {
 	return &ClientRepositoryImpl{db: db}
 }
 
+const clientColumns = "id, COALESCE(customer_id, 0), name, account_num, balance, currency, version, overdraft_limit, overdraft_rate_bps, held, account_type, status"
+
+func scanClient(row interface{ Scan(dest ...any) error }) (models.Client, error) {
+	var client models.Client
+	err := row.Scan(&client.ID, &client.CustomerID, &client.Name, &client.AccountNum, &client.Balance.Cents, &client.Balance.Currency, &client.Version,
+		&client.OverdraftLimit.Cents, &client.OverdraftRateBps, &client.Held.Cents, &client.AccountType, &client.Status)
+	client.OverdraftLimit.Currency = client.Balance.Currency
+	client.SetOverdraftUsage()
+	client.SetAvailableBalance()
+	return client, err
+}
+
 // Implementação do método GetClientByAccountNum
 func (repo *ClientRepositoryImpl) GetClientByAccountNum(accountNum string) (*models.Client, error) {
-	var client models.Client
-	err := repo.db.QueryRow("SELECT id, name, account_num, balance FROM clients WHERE account_num = ?", accountNum).
-		Scan(&client.ID, &client.Name, &client.AccountNum, &client.Balance)
+	client, err := scanClient(repo.db.QueryRow("SELECT "+clientColumns+" FROM clients WHERE account_num = ?", accountNum))
 	if err == sql.ErrNoRows {
-		return nil, errors.New("client not found")
+		return nil, ErrClientNotFound
 	} else if err != nil {
 		return nil, err
 	}
 	return &client, nil
 }
 
-// Implementação do método UpdateClientBalance
+// Implementação do método UpdateClient: altera os dados cadastrais se o
+// cliente ainda estiver na versão client.Version, que é incrementada
+func (repo *ClientRepositoryImpl) UpdateClient(client *models.Client) error {
+	result, err := repo.db.Exec("UPDATE clients SET name = ?, version = version + 1 WHERE account_num = ? AND version = ?",
+		client.Name, client.AccountNum, client.Version)
+	return repo.checkVersionedUpdate(result, err, client)
+}
+
+// Implementação do método UpdateClientBalance: sobrescreve o saldo se o
+// cliente ainda estiver na versão client.Version, que é incrementada
 func (repo *ClientRepositoryImpl) UpdateClientBalance(client *models.Client) error {
-	_, err := repo.db.Exec("UPDATE clients SET balance = ? WHERE account_num = ?", client.Balance, client.AccountNum)
-	return err
+	result, err := repo.db.Exec("UPDATE clients SET balance = ?, currency = ?, version = version + 1 WHERE account_num = ? AND version = ?",
+		client.Balance.Cents, client.Balance.Currency, client.AccountNum, client.Version)
+	return repo.checkVersionedUpdate(result, err, client)
 }
 
-// Implementação do método CreateClient
+// checkVersionedUpdate converte um UPDATE que não alterou nenhuma linha em
+// ErrClientNotFound ou VersionConflictError
+func (repo *ClientRepositoryImpl) checkVersionedUpdate(result sql.Result, err error, client *models.Client) error {
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		if _, err := repo.GetClientByAccountNum(client.AccountNum); err != nil {
+			return err
+		}
+		return &VersionConflictError{AccountNum: client.AccountNum, ExpectedVersion: client.Version}
+	}
+	client.Version++
+	return nil
+}
+
+// Implementação do método DebitClientBalance. O saldo disponível (saldo menos
+// reservas) é verificado e alterado no mesmo UPDATE, então nem outra instância
+// da aplicação usando o mesmo banco consegue deixá-lo abaixo de
+// -overdraft_limit entre a leitura e a escrita. Retorna o novo saldo.
+func (repo *ClientRepositoryImpl) DebitClientBalance(accountNum string, amount models.Money) (models.Money, error) {
+	var balance int64
+	err := repo.db.QueryRow("UPDATE clients SET balance = balance - ?, version = version + 1 WHERE account_num = ? AND currency = ? AND balance - held - ? >= -overdraft_limit RETURNING balance",
+		amount.Cents, accountNum, amount.Currency, amount.Cents).Scan(&balance)
+	if err == sql.ErrNoRows {
+		return models.Money{}, repo.balanceUpdateError(accountNum, amount, ErrInsufficientBalance)
+	} else if err != nil {
+		return models.Money{}, err
+	}
+	return models.NewMoney(balance, amount.Currency), nil
+}
+
+// Implementação do método CreditClientBalance. Retorna o novo saldo.
+func (repo *ClientRepositoryImpl) CreditClientBalance(accountNum string, amount models.Money) (models.Money, error) {
+	var balance int64
+	err := repo.db.QueryRow("UPDATE clients SET balance = balance + ?, version = version + 1 WHERE account_num = ? AND currency = ? RETURNING balance",
+		amount.Cents, accountNum, amount.Currency).Scan(&balance)
+	if err == sql.ErrNoRows {
+		return models.Money{}, repo.balanceUpdateError(accountNum, amount, nil)
+	} else if err != nil {
+		return models.Money{}, err
+	}
+	return models.NewMoney(balance, amount.Currency), nil
+}
+
+// Implementação do método ChargeClientBalance: debita encargos do banco (como
+// juros) sem verificar o saldo, então a conta pode ficar abaixo do limite do
+// cheque especial. Retorna o novo saldo.
+func (repo *ClientRepositoryImpl) ChargeClientBalance(accountNum string, amount models.Money) (models.Money, error) {
+	return repo.CreditClientBalance(accountNum, amount.Neg())
+}
+
+// Implementação do método UpdateOverdraft: define o limite e a taxa do cheque
+// especial. O limite não pode ficar abaixo do que já está em uso, contando as
+// reservas.
+func (repo *ClientRepositoryImpl) UpdateOverdraft(accountNum string, limit models.Money, rateBps int) (*models.Client, error) {
+	client, err := scanClient(repo.db.QueryRow("UPDATE clients SET overdraft_limit = ?, overdraft_rate_bps = ?, version = version + 1 WHERE account_num = ? AND currency = ? AND balance - held >= -? RETURNING "+clientColumns,
+		limit.Cents, rateBps, accountNum, limit.Currency, limit.Cents))
+	if err == sql.ErrNoRows {
+		return nil, repo.balanceUpdateError(accountNum, limit, ErrOverdraftInUse)
+	} else if err != nil {
+		return nil, err
+	}
+	return &client, nil
+}
+
+// Implementação do método UpdateClientStatus: altera o status da conta sem
+// verificar a transição, que é responsabilidade de quem chama
+func (repo *ClientRepositoryImpl) UpdateClientStatus(accountNum, status string) (*models.Client, error) {
+	client, err := scanClient(repo.db.QueryRow("UPDATE clients SET status = ?, version = version + 1 WHERE account_num = ? RETURNING "+clientColumns,
+		status, accountNum))
+	if err == sql.ErrNoRows {
+		return nil, ErrClientNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return &client, nil
+}
+
+// Implementação do método HoldClientBalance: reserva amount do saldo
+// disponível, com a mesma verificação atômica de DebitClientBalance. O saldo
+// atual não muda. Retorna o novo saldo disponível.
+func (repo *ClientRepositoryImpl) HoldClientBalance(accountNum string, amount models.Money) (models.Money, error) {
+	var available int64
+	err := repo.db.QueryRow("UPDATE clients SET held = held + ?, version = version + 1 WHERE account_num = ? AND currency = ? AND balance - held - ? >= -overdraft_limit RETURNING balance - held",
+		amount.Cents, accountNum, amount.Currency, amount.Cents).Scan(&available)
+	if err == sql.ErrNoRows {
+		return models.Money{}, repo.balanceUpdateError(accountNum, amount, ErrInsufficientBalance)
+	} else if err != nil {
+		return models.Money{}, err
+	}
+	return models.NewMoney(available, amount.Currency), nil
+}
+
+// Implementação do método ReleaseClientHold: devolve amount reservado ao saldo
+// disponível. Retorna o novo saldo disponível.
+func (repo *ClientRepositoryImpl) ReleaseClientHold(accountNum string, amount models.Money) (models.Money, error) {
+	var available int64
+	err := repo.db.QueryRow("UPDATE clients SET held = held - ?, version = version + 1 WHERE account_num = ? AND currency = ? AND held >= ? RETURNING balance - held",
+		amount.Cents, accountNum, amount.Currency, amount.Cents).Scan(&available)
+	if err == sql.ErrNoRows {
+		return models.Money{}, repo.balanceUpdateError(accountNum, amount, nil)
+	} else if err != nil {
+		return models.Money{}, err
+	}
+	return models.NewMoney(available, amount.Currency), nil
+}
+
+// balanceUpdateError explica por que um UPDATE condicional de saldo não alterou
+// nenhuma linha: conta inexistente, moeda diferente ou, por fim, fallback
+func (repo *ClientRepositoryImpl) balanceUpdateError(accountNum string, amount models.Money, fallback error) error {
+	client, err := repo.GetClientByAccountNum(accountNum)
+	if err != nil {
+		return err
+	}
+	if !client.Balance.SameCurrency(amount) {
+		return models.ErrCurrencyMismatch
+	}
+	if fallback == nil {
+		return fmt.Errorf("balance of account %s was not updated", accountNum)
+	}
+	return fallback
+}
+
+// Implementação do método CreateClient. Com CustomerID zero, a conta fica sem
+// cliente; os serviços sempre informam o cliente da conta.
 func (repo *ClientRepositoryImpl) CreateClient(client *models.Client) error {
-	_, err := repo.db.Exec("INSERT INTO clients (name, account_num, balance) VALUES (?, ?, ?)",
-		client.Name, client.AccountNum, client.Balance)
-	return err
+	result, err := repo.db.Exec("INSERT INTO clients (customer_id, name, account_num, balance, currency, account_type, status) VALUES (NULLIF(?, 0), ?, ?, ?, ?, ?, ?)",
+		client.CustomerID, client.Name, client.AccountNum, client.Balance.Cents, client.Balance.Currency, client.AccountType, client.Status)
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	client.ID = int(id)
+	client.Version = 1
+	client.OverdraftLimit = models.NewMoney(0, client.Balance.Currency)
+	client.OverdraftRateBps = 0
+	client.Held = models.NewMoney(0, client.Balance.Currency)
+	client.SetOverdraftUsage()
+	client.SetAvailableBalance()
+	return nil
 }
 
 // Implementação do método GetClients
 func (repo *ClientRepositoryImpl) GetClients() ([]models.Client, error) {
-	rows, err := repo.db.Query("SELECT id, name, account_num, balance FROM clients")
+	rows, err := repo.db.Query("SELECT " + clientColumns + " FROM clients")
+	if err != nil {
+		return nil, err
+	}
+	return scanClients(rows)
+}
+
+// Implementação do método GetClientsByCustomer: contas do cliente, na ordem em
+// que foram abertas
+func (repo *ClientRepositoryImpl) GetClientsByCustomer(customerID int) ([]models.Client, error) {
+	rows, err := repo.db.Query("SELECT "+clientColumns+" FROM clients WHERE customer_id = ? ORDER BY id", customerID)
 	if err != nil {
 		return nil, err
 	}
+	clients, err := scanClients(rows)
+	if clients == nil && err == nil {
+		clients = []models.Client{}
+	}
+	return clients, err
+}
+
+func scanClients(rows *sql.Rows) ([]models.Client, error) {
 	defer rows.Close()
 
 	var clients []models.Client
 	for rows.Next() {
-		var client models.Client
-		if err := rows.Scan(&client.ID, &client.Name, &client.AccountNum, &client.Balance); err != nil {
+		client, err := scanClient(rows)
+		if err != nil {
 			return nil, err
 		}
 		clients = append(clients, client)
 	}
-	return clients, nil
+	return clients, rows.Err()
+}
+
+// Implementação do método GetTotalBalance: soma dos saldos dos clientes na moeda
+func (repo *ClientRepositoryImpl) GetTotalBalance(currency string) (models.Money, error) {
+	var cents int64
+	err := repo.db.QueryRow("SELECT COALESCE(SUM(balance), 0) FROM clients WHERE currency = ?", currency).Scan(&cents)
+	if err != nil {
+		return models.Money{}, err
+	}
+	return models.NewMoney(cents, currency), nil
 }
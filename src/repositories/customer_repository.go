@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"banking/src/models"
+	"database/sql"
+	"errors"
+)
+
+// ErrCustomerNotFound é retornado quando não existe cliente com o id informado
+var ErrCustomerNotFound = errors.New("customer not found")
+
+// CustomerRepository define a interface para os titulares das contas
+type CustomerRepository interface {
+	CreateCustomer(customer *models.Customer) error
+	GetCustomerByID(id int) (*models.Customer, error)
+	UpdateCustomerName(id int, name string) error
+}
+
+type CustomerRepositoryImpl struct {
+	db DBTX
+}
+
+func NewCustomerRepository(db *sql.DB) *CustomerRepositoryImpl {
+	return &CustomerRepositoryImpl{db: db}
+}
+
+// Implementação do método CreateCustomer
+func (repo *CustomerRepositoryImpl) CreateCustomer(customer *models.Customer) error {
+	return repo.db.QueryRow("INSERT INTO customers (name) VALUES (?) RETURNING id, created_at", customer.Name).
+		Scan(&customer.ID, &customer.CreatedAt)
+}
+
+// Implementação do método GetCustomerByID
+func (repo *CustomerRepositoryImpl) GetCustomerByID(id int) (*models.Customer, error) {
+	var customer models.Customer
+	err := repo.db.QueryRow("SELECT id, name, created_at FROM customers WHERE id = ?", id).
+		Scan(&customer.ID, &customer.Name, &customer.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrCustomerNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return &customer, nil
+}
+
+// Implementação do método UpdateCustomerName: renomeia o cliente e repete o
+// nome nas suas contas, incrementando a versão das que mudaram
+func (repo *CustomerRepositoryImpl) UpdateCustomerName(id int, name string) error {
+	result, err := repo.db.Exec("UPDATE customers SET name = ? WHERE id = ?", name, id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrCustomerNotFound
+	}
+	_, err = repo.db.Exec("UPDATE clients SET name = ?, version = version + 1 WHERE customer_id = ? AND name <> ?", name, id, name)
+	return err
+}
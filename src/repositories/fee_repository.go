@@ -0,0 +1,131 @@
+package repositories
+
+import (
+	"banking/src/models"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// ErrFeeScheduleNotFound é retornado quando o tipo de conta não tem tabela de tarifas
+var ErrFeeScheduleNotFound = errors.New("fee schedule not found")
+
+// FeeRepository define a interface para as tabelas de tarifas
+type FeeRepository interface {
+	GetSchedules() ([]models.FeeSchedule, error)
+	GetSchedule(accountType string) (*models.FeeSchedule, error)
+	SaveSchedule(schedule *models.FeeSchedule) error
+	CountTransfersSince(accountNum string, since time.Time) (int, error)
+}
+
+type FeeRepositoryImpl struct {
+	db DBTX
+}
+
+func NewFeeRepository(db *sql.DB) *FeeRepositoryImpl {
+	return &FeeRepositoryImpl{db: db}
+}
+
+// Implementação do método GetSchedules
+func (repo *FeeRepositoryImpl) GetSchedules() ([]models.FeeSchedule, error) {
+	rows, err := repo.db.Query("SELECT account_type FROM fee_schedules ORDER BY account_type")
+	if err != nil {
+		return nil, err
+	}
+	var accountTypes []string
+	for rows.Next() {
+		var accountType string
+		if err := rows.Scan(&accountType); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		accountTypes = append(accountTypes, accountType)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	schedules := []models.FeeSchedule{}
+	for _, accountType := range accountTypes {
+		schedule, err := repo.GetSchedule(accountType)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, *schedule)
+	}
+	return schedules, nil
+}
+
+// Implementação do método GetSchedule: a tabela e as suas faixas, ordenadas
+// por valor mínimo
+func (repo *FeeRepositoryImpl) GetSchedule(accountType string) (*models.FeeSchedule, error) {
+	var schedule models.FeeSchedule
+	err := repo.db.QueryRow(`SELECT account_type, currency, flat, percent_bps, free_transfers, updated_at
+		FROM fee_schedules WHERE account_type = ?`, accountType).
+		Scan(&schedule.AccountType, &schedule.Currency, &schedule.Flat.Cents, &schedule.PercentBps, &schedule.FreeTransfers, &schedule.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrFeeScheduleNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	schedule.Flat.Currency = schedule.Currency
+
+	rows, err := repo.db.Query(`SELECT min_amount, flat, percent_bps FROM fee_tiers
+		WHERE account_type = ? ORDER BY min_amount`, accountType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	schedule.Tiers = []models.FeeTier{}
+	for rows.Next() {
+		tier := models.FeeTier{
+			MinAmount: models.NewMoney(0, schedule.Currency),
+			Flat:      models.NewMoney(0, schedule.Currency),
+		}
+		if err := rows.Scan(&tier.MinAmount.Cents, &tier.Flat.Cents, &tier.PercentBps); err != nil {
+			return nil, err
+		}
+		schedule.Tiers = append(schedule.Tiers, tier)
+	}
+	return &schedule, rows.Err()
+}
+
+// Implementação do método SaveSchedule: grava a tabela e substitui as suas
+// faixas. Deve executar dentro de uma unidade de trabalho, para que a tabela
+// nunca seja lida com parte das faixas.
+func (repo *FeeRepositoryImpl) SaveSchedule(schedule *models.FeeSchedule) error {
+	err := repo.db.QueryRow(`INSERT INTO fee_schedules (account_type, currency, flat, percent_bps, free_transfers)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (account_type) DO UPDATE SET currency = excluded.currency, flat = excluded.flat,
+			percent_bps = excluded.percent_bps, free_transfers = excluded.free_transfers, updated_at = CURRENT_TIMESTAMP
+		RETURNING updated_at`,
+		schedule.AccountType, schedule.Currency, schedule.Flat.Cents, schedule.PercentBps, schedule.FreeTransfers).
+		Scan(&schedule.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	if _, err := repo.db.Exec("DELETE FROM fee_tiers WHERE account_type = ?", schedule.AccountType); err != nil {
+		return err
+	}
+	for _, tier := range schedule.Tiers {
+		_, err := repo.db.Exec("INSERT INTO fee_tiers (account_type, min_amount, flat, percent_bps) VALUES (?, ?, ?, ?)",
+			schedule.AccountType, tier.MinAmount.Cents, tier.Flat.Cents, tier.PercentBps)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Implementação do método CountTransfersSince: transferências bem-sucedidas
+// feitas pela conta a partir de since
+func (repo *FeeRepositoryImpl) CountTransfersSince(accountNum string, since time.Time) (int, error) {
+	var count int
+	err := repo.db.QueryRow(`SELECT COUNT(*) FROM transfers
+		WHERE from_account_num = ? AND type = ? AND status = ? AND created_at >= ?`,
+		accountNum, models.TransferTypeTransfer, models.TransferStatusSuccess, timestamp(since)).Scan(&count)
+	return count, err
+}
@@ -0,0 +1,118 @@
+package repositories
+
+import (
+	"banking/src/models"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrHoldNotFound é retornado quando não existe reserva com o ID informado
+	ErrHoldNotFound = errors.New("hold not found")
+	// ErrHoldNotActive é retornado ao capturar, cancelar ou expirar uma reserva
+	// que já foi capturada, cancelada ou expirou
+	ErrHoldNotActive = errors.New("hold is no longer active")
+)
+
+// HoldRepository define a interface para as reservas de saldo
+type HoldRepository interface {
+	CreateHold(hold *models.Hold) error
+	GetHoldByID(id int) (*models.Hold, error)
+	GetHoldsByAccountNum(accountNum, status string) ([]models.Hold, error)
+	GetExpiredHolds(now time.Time, limit int) ([]models.Hold, error)
+	UpdateHold(hold *models.Hold) error
+}
+
+type HoldRepositoryImpl struct {
+	db DBTX
+}
+
+func NewHoldRepository(db *sql.DB) *HoldRepositoryImpl {
+	return &HoldRepositoryImpl{db: db}
+}
+
+const holdColumns = "id, account_num, to_account_num, amount, captured_amount, currency, status, expires_at, created_at, updated_at"
+
+func scanHold(row interface{ Scan(dest ...any) error }) (models.Hold, error) {
+	var hold models.Hold
+	err := row.Scan(&hold.ID, &hold.AccountNum, &hold.ToAccountNum, &hold.Amount.Cents, &hold.CapturedAmount.Cents, &hold.Amount.Currency,
+		&hold.Status, &hold.ExpiresAt, &hold.CreatedAt, &hold.UpdatedAt)
+	hold.CapturedAmount.Currency = hold.Amount.Currency
+	return hold, err
+}
+
+// Implementação do método CreateHold. ExpiresAt é gravado em UTC para que a
+// comparação com a hora atual em GetExpiredHolds seja consistente.
+func (repo *HoldRepositoryImpl) CreateHold(hold *models.Hold) error {
+	if hold.Status == "" {
+		hold.Status = models.HoldStatusActive
+	}
+	hold.ExpiresAt = hold.ExpiresAt.UTC()
+	hold.CapturedAmount = models.NewMoney(0, hold.Amount.Currency)
+	return repo.db.QueryRow(`INSERT INTO holds (account_num, to_account_num, amount, currency, status, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?) RETURNING id, created_at, updated_at`,
+		hold.AccountNum, hold.ToAccountNum, hold.Amount.Cents, hold.Amount.Currency, hold.Status, hold.ExpiresAt).
+		Scan(&hold.ID, &hold.CreatedAt, &hold.UpdatedAt)
+}
+
+// Implementação do método GetHoldByID
+func (repo *HoldRepositoryImpl) GetHoldByID(id int) (*models.Hold, error) {
+	hold, err := scanHold(repo.db.QueryRow("SELECT "+holdColumns+" FROM holds WHERE id = ?", id))
+	if err == sql.ErrNoRows {
+		return nil, ErrHoldNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return &hold, nil
+}
+
+// Implementação do método GetHoldsByAccountNum: reservas da conta, das mais
+// recentes para as mais antigas, filtradas por status quando informado
+func (repo *HoldRepositoryImpl) GetHoldsByAccountNum(accountNum, status string) ([]models.Hold, error) {
+	return repo.query("SELECT "+holdColumns+` FROM holds
+		WHERE account_num = ? AND (? = '' OR status = ?)
+		ORDER BY id DESC`, accountNum, status, status)
+}
+
+// Implementação do método GetExpiredHolds: reservas ativas cujo prazo já
+// venceu, das mais antigas para as mais novas
+func (repo *HoldRepositoryImpl) GetExpiredHolds(now time.Time, limit int) ([]models.Hold, error) {
+	return repo.query("SELECT "+holdColumns+` FROM holds
+		WHERE status = ? AND expires_at <= ?
+		ORDER BY expires_at, id LIMIT ?`, models.HoldStatusActive, now.UTC(), limit)
+}
+
+func (repo *HoldRepositoryImpl) query(query string, args ...any) ([]models.Hold, error) {
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	holds := []models.Hold{}
+	for rows.Next() {
+		hold, err := scanHold(rows)
+		if err != nil {
+			return nil, err
+		}
+		holds = append(holds, hold)
+	}
+	return holds, rows.Err()
+}
+
+// Implementação do método UpdateHold: grava o valor capturado e o status. A
+// alteração só vale para reservas ainda ativas, então uma captura e um
+// cancelamento concorrentes nunca são aplicados os dois.
+func (repo *HoldRepositoryImpl) UpdateHold(hold *models.Hold) error {
+	err := repo.db.QueryRow(`UPDATE holds SET captured_amount = ?, status = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND status = ? RETURNING updated_at`,
+		hold.CapturedAmount.Cents, hold.Status, hold.ID, models.HoldStatusActive).Scan(&hold.UpdatedAt)
+	if err == sql.ErrNoRows {
+		if _, err := repo.GetHoldByID(hold.ID); err != nil {
+			return err
+		}
+		return ErrHoldNotActive
+	}
+	return err
+}
@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"banking/src/models"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+var (
+	ErrIdempotencyKeyNotFound = errors.New("idempotency key not found")
+	ErrIdempotencyKeyExists   = errors.New("idempotency key already exists")
+)
+
+// IdempotencyRepository define a interface para as chaves de idempotência
+type IdempotencyRepository interface {
+	GetByKey(key string) (*models.IdempotencyRecord, error)
+	Create(record *models.IdempotencyRecord) error
+	SaveResponse(key string, statusCode int, body []byte) error
+	Reclaim(record *models.IdempotencyRecord, reservedBefore time.Time) error
+	Release(key string) error
+	DeleteExpired(now time.Time) error
+}
+
+type IdempotencyRepositoryImpl struct {
+	db DBTX
+}
+
+func NewIdempotencyRepository(db *sql.DB) *IdempotencyRepositoryImpl {
+	return &IdempotencyRepositoryImpl{db: db}
+}
+
+// Implementação do método GetByKey
+func (repo *IdempotencyRepositoryImpl) GetByKey(key string) (*models.IdempotencyRecord, error) {
+	var record models.IdempotencyRecord
+	var body []byte
+	err := repo.db.QueryRow("SELECT key, request_hash, status_code, response_body, created_at, expires_at FROM idempotency_keys WHERE key = ?", key).
+		Scan(&record.Key, &record.RequestHash, &record.StatusCode, &body, &record.CreatedAt, &record.ExpiresAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrIdempotencyKeyNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	record.ResponseBody = body
+	return &record, nil
+}
+
+// Implementação do método Create. A chave é a chave primária da tabela, então
+// apenas uma requisição concorrente consegue reservá-la.
+func (repo *IdempotencyRepositoryImpl) Create(record *models.IdempotencyRecord) error {
+	result, err := repo.db.Exec("INSERT OR IGNORE INTO idempotency_keys (key, request_hash, status_code, response_body, created_at, expires_at) VALUES (?, ?, ?, ?, ?, ?)",
+		record.Key, record.RequestHash, record.StatusCode, record.ResponseBody, record.CreatedAt, record.ExpiresAt)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrIdempotencyKeyExists
+	}
+	return nil
+}
+
+// Implementação do método SaveResponse
+func (repo *IdempotencyRepositoryImpl) SaveResponse(key string, statusCode int, body []byte) error {
+	_, err := repo.db.Exec("UPDATE idempotency_keys SET status_code = ?, response_body = ? WHERE key = ?", statusCode, body, key)
+	return err
+}
+
+// Implementação do método Reclaim: reserva de novo uma chave ainda em
+// processamento para a mesma requisição, se ela tiver sido reservada antes de
+// reservedBefore. Como o UPDATE verifica a reserva antiga, apenas uma
+// requisição concorrente consegue retomá-la; as demais recebem
+// ErrIdempotencyKeyExists.
+func (repo *IdempotencyRepositoryImpl) Reclaim(record *models.IdempotencyRecord, reservedBefore time.Time) error {
+	result, err := repo.db.Exec("UPDATE idempotency_keys SET created_at = ?, expires_at = ? WHERE key = ? AND request_hash = ? AND status_code = 0 AND created_at <= ?",
+		record.CreatedAt, record.ExpiresAt, record.Key, record.RequestHash, reservedBefore)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrIdempotencyKeyExists
+	}
+	return nil
+}
+
+// Implementação do método Release: libera uma chave ainda em processamento,
+// sem resposta armazenada. Chaves já concluídas não são alteradas.
+func (repo *IdempotencyRepositoryImpl) Release(key string) error {
+	_, err := repo.db.Exec("DELETE FROM idempotency_keys WHERE key = ? AND status_code = 0", key)
+	return err
+}
+
+// Implementação do método DeleteExpired
+func (repo *IdempotencyRepositoryImpl) DeleteExpired(now time.Time) error {
+	_, err := repo.db.Exec("DELETE FROM idempotency_keys WHERE expires_at <= ?", now)
+	return err
+}
@@ -0,0 +1,225 @@
+package repositories
+
+import (
+	"banking/src/models"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrAccrualExists é retornado quando os juros da conta no dia já foram acumulados
+	ErrAccrualExists = errors.New("interest already accrued for this date")
+	// ErrPeriodCapitalized é retornado quando os juros da conta no mês já foram capitalizados
+	ErrPeriodCapitalized = errors.New("interest already capitalized for this period")
+)
+
+// InterestRepository define a interface para as taxas, o acúmulo e a
+// capitalização dos juros das contas
+type InterestRepository interface {
+	GetRates() ([]models.InterestRate, error)
+	SaveRate(rate *models.InterestRate) error
+	GetAccrualCandidates(before time.Time) ([]models.InterestAccrual, error)
+	CreateAccrual(accrual *models.InterestAccrual) error
+	GetLatestAccrualDate() (string, error)
+	GetAccruedInterest(to string) (map[string]models.Money, error)
+	CreateCapitalization(capitalization *models.InterestCapitalization) error
+	MarkAccrualsCapitalized(accountNum, to string, capitalizationID int) error
+	GetAccrualsByAccountNum(accountNum string) ([]models.InterestAccrual, error)
+	GetCapitalizationsByAccountNum(accountNum string) ([]models.InterestCapitalization, error)
+}
+
+type InterestRepositoryImpl struct {
+	db DBTX
+}
+
+func NewInterestRepository(db *sql.DB) *InterestRepositoryImpl {
+	return &InterestRepositoryImpl{db: db}
+}
+
+// Implementação do método GetRates
+func (repo *InterestRepositoryImpl) GetRates() ([]models.InterestRate, error) {
+	rows, err := repo.db.Query("SELECT account_type, rate_bps, updated_at FROM interest_rates ORDER BY account_type")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rates := []models.InterestRate{}
+	for rows.Next() {
+		var rate models.InterestRate
+		if err := rows.Scan(&rate.AccountType, &rate.RateBps, &rate.UpdatedAt); err != nil {
+			return nil, err
+		}
+		rates = append(rates, rate)
+	}
+	return rates, rows.Err()
+}
+
+// Implementação do método SaveRate
+func (repo *InterestRepositoryImpl) SaveRate(rate *models.InterestRate) error {
+	return repo.db.QueryRow(`INSERT INTO interest_rates (account_type, rate_bps) VALUES (?, ?)
+		ON CONFLICT (account_type) DO UPDATE SET rate_bps = excluded.rate_bps, updated_at = CURRENT_TIMESTAMP
+		RETURNING updated_at`, rate.AccountType, rate.RateBps).Scan(&rate.UpdatedAt)
+}
+
+// Implementação do método GetAccrualCandidates: o saldo, pelo livro-razão, das
+// contas de clientes que estavam positivas no instante before, com a taxa
+// atual do tipo de cada conta. Contas cujo tipo não rende juros ficam de fora.
+func (repo *InterestRepositoryImpl) GetAccrualCandidates(before time.Time) ([]models.InterestAccrual, error) {
+	rows, err := repo.db.Query(`SELECT p.account_num, p.currency, SUM(p.amount), r.rate_bps
+		FROM postings p
+		JOIN journal_entries e ON e.id = p.entry_id
+		JOIN clients c ON c.account_num = p.account_num
+		JOIN interest_rates r ON r.account_type = c.account_type
+		WHERE e.created_at < ? AND r.rate_bps > 0
+		GROUP BY p.account_num, p.currency, r.rate_bps
+		HAVING SUM(p.amount) > 0
+		ORDER BY p.account_num`, timestamp(before))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	accruals := []models.InterestAccrual{}
+	for rows.Next() {
+		var accrual models.InterestAccrual
+		if err := rows.Scan(&accrual.AccountNum, &accrual.Balance.Currency, &accrual.Balance.Cents, &accrual.RateBps); err != nil {
+			return nil, err
+		}
+		accruals = append(accruals, accrual)
+	}
+	return accruals, rows.Err()
+}
+
+// Implementação do método CreateAccrual. A conta e a data são únicas, então
+// executar o acúmulo do mesmo dia de novo não acumula nada.
+func (repo *InterestRepositoryImpl) CreateAccrual(accrual *models.InterestAccrual) error {
+	err := repo.db.QueryRow(`INSERT INTO interest_accruals (account_num, accrual_date, balance, currency, rate_bps)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (account_num, accrual_date) DO NOTHING
+		RETURNING id, created_at`,
+		accrual.AccountNum, accrual.AccrualDate, accrual.Balance.Cents, accrual.Balance.Currency, accrual.RateBps).
+		Scan(&accrual.ID, &accrual.CreatedAt)
+	if err == sql.ErrNoRows {
+		return ErrAccrualExists
+	}
+	if err != nil {
+		return err
+	}
+	accrual.Interest = models.DailyInterest(accrual.Balance, accrual.RateBps)
+	return nil
+}
+
+// Implementação do método GetLatestAccrualDate: o dia mais recente com juros
+// acumulados, ou vazio se não houver nenhum
+func (repo *InterestRepositoryImpl) GetLatestAccrualDate() (string, error) {
+	var date sql.NullString
+	err := repo.db.QueryRow("SELECT MAX(accrual_date) FROM interest_accruals").Scan(&date)
+	return date.String, err
+}
+
+// Implementação do método GetAccruedInterest: por conta, os juros ainda não
+// capitalizados acumulados até a data to (inclusive), somando o valor exato de
+// cada dia e arredondando só o total
+func (repo *InterestRepositoryImpl) GetAccruedInterest(to string) (map[string]models.Money, error) {
+	rows, err := repo.db.Query(`SELECT account_num, currency, SUM(balance * rate_bps)
+		FROM interest_accruals
+		WHERE capitalization_id IS NULL AND accrual_date <= ?
+		GROUP BY account_num, currency`, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	interest := make(map[string]models.Money)
+	for rows.Next() {
+		var accountNum, currency string
+		var accrued int64
+		if err := rows.Scan(&accountNum, &currency, &accrued); err != nil {
+			return nil, err
+		}
+		interest[accountNum] = models.CapitalizedInterest(accrued, currency)
+	}
+	return interest, rows.Err()
+}
+
+// Implementação do método CreateCapitalization. A conta e o mês são únicos,
+// então executores concorrentes nunca capitalizam o mesmo mês duas vezes.
+func (repo *InterestRepositoryImpl) CreateCapitalization(capitalization *models.InterestCapitalization) error {
+	err := repo.db.QueryRow(`INSERT INTO interest_capitalizations (account_num, period, interest, currency, transfer_id)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (account_num, period) DO NOTHING
+		RETURNING id, created_at`,
+		capitalization.AccountNum, capitalization.Period, capitalization.Interest.Cents, capitalization.Interest.Currency, capitalization.TransferID).
+		Scan(&capitalization.ID, &capitalization.CreatedAt)
+	if err == sql.ErrNoRows {
+		return ErrPeriodCapitalized
+	}
+	return err
+}
+
+// Implementação do método MarkAccrualsCapitalized
+func (repo *InterestRepositoryImpl) MarkAccrualsCapitalized(accountNum, to string, capitalizationID int) error {
+	_, err := repo.db.Exec(`UPDATE interest_accruals SET capitalization_id = ?
+		WHERE account_num = ? AND capitalization_id IS NULL AND accrual_date <= ?`,
+		capitalizationID, accountNum, to)
+	return err
+}
+
+// Implementação do método GetAccrualsByAccountNum: juros acumulados da conta,
+// dos mais recentes para os mais antigos
+func (repo *InterestRepositoryImpl) GetAccrualsByAccountNum(accountNum string) ([]models.InterestAccrual, error) {
+	rows, err := repo.db.Query(`SELECT id, account_num, accrual_date, balance, currency, rate_bps, capitalization_id, created_at
+		FROM interest_accruals WHERE account_num = ? ORDER BY accrual_date DESC`, accountNum)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	accruals := []models.InterestAccrual{}
+	for rows.Next() {
+		var accrual models.InterestAccrual
+		var capitalizationID sql.NullInt64
+		err := rows.Scan(&accrual.ID, &accrual.AccountNum, &accrual.AccrualDate, &accrual.Balance.Cents, &accrual.Balance.Currency,
+			&accrual.RateBps, &capitalizationID, &accrual.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		accrual.Interest = models.DailyInterest(accrual.Balance, accrual.RateBps)
+		if capitalizationID.Valid {
+			id := int(capitalizationID.Int64)
+			accrual.CapitalizationID = &id
+		}
+		accruals = append(accruals, accrual)
+	}
+	return accruals, rows.Err()
+}
+
+// Implementação do método GetCapitalizationsByAccountNum: capitalizações da
+// conta, das mais recentes para as mais antigas
+func (repo *InterestRepositoryImpl) GetCapitalizationsByAccountNum(accountNum string) ([]models.InterestCapitalization, error) {
+	rows, err := repo.db.Query(`SELECT id, account_num, period, interest, currency, transfer_id, created_at
+		FROM interest_capitalizations WHERE account_num = ? ORDER BY period DESC`, accountNum)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	capitalizations := []models.InterestCapitalization{}
+	for rows.Next() {
+		var capitalization models.InterestCapitalization
+		var transferID sql.NullInt64
+		err := rows.Scan(&capitalization.ID, &capitalization.AccountNum, &capitalization.Period, &capitalization.Interest.Cents,
+			&capitalization.Interest.Currency, &transferID, &capitalization.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		if transferID.Valid {
+			id := int(transferID.Int64)
+			capitalization.TransferID = &id
+		}
+		capitalizations = append(capitalizations, capitalization)
+	}
+	return capitalizations, rows.Err()
+}
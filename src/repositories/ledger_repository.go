@@ -0,0 +1,214 @@
+package repositories
+
+import (
+	"banking/src/models"
+	"database/sql"
+	"time"
+)
+
+// LedgerRepository define a interface para o livro-razão (lançamentos e partidas)
+type LedgerRepository interface {
+	CreateEntry(entry *models.JournalEntry) error
+	GetAccountBalance(accountNum, currency string) (models.Money, error)
+	GetAccountBalances(currency string) (map[string]models.Money, error)
+	GetTransfersByAccountNum(accountNum string) ([]models.Transfer, error)
+	GetBalanceAt(accountNum, currency string, at time.Time) (models.Money, error)
+	GetStatementLines(accountNum, currency string, from, to time.Time) ([]models.StatementLine, error)
+}
+
+type LedgerRepositoryImpl struct {
+	db DBTX
+}
+
+func NewLedgerRepository(db *sql.DB) *LedgerRepositoryImpl {
+	return &LedgerRepositoryImpl{db: db}
+}
+
+// Implementação do método CreateEntry. Lançamentos desbalanceados são
+// rejeitados antes de qualquer escrita.
+func (repo *LedgerRepositoryImpl) CreateEntry(entry *models.JournalEntry) error {
+	if err := entry.Validate(); err != nil {
+		return err
+	}
+
+	result, err := repo.db.Exec("INSERT INTO journal_entries (transfer_id, description) VALUES (?, ?)",
+		entry.TransferID, entry.Description)
+	if err != nil {
+		return err
+	}
+	entryID, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	entry.ID = int(entryID)
+
+	for i := range entry.Postings {
+		posting := &entry.Postings[i]
+		result, err := repo.db.Exec("INSERT INTO postings (entry_id, account_num, amount, currency) VALUES (?, ?, ?, ?)",
+			entry.ID, posting.AccountNum, posting.Amount.Cents, posting.Amount.Currency)
+		if err != nil {
+			return err
+		}
+		postingID, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+		posting.ID = int(postingID)
+		posting.EntryID = entry.ID
+	}
+	return nil
+}
+
+// Implementação do método GetAccountBalance: o saldo é a soma das partidas da conta
+func (repo *LedgerRepositoryImpl) GetAccountBalance(accountNum, currency string) (models.Money, error) {
+	var cents int64
+	err := repo.db.QueryRow("SELECT COALESCE(SUM(amount), 0) FROM postings WHERE account_num = ? AND currency = ?",
+		accountNum, currency).Scan(&cents)
+	if err != nil {
+		return models.Money{}, err
+	}
+	return models.NewMoney(cents, currency), nil
+}
+
+// Implementação do método GetAccountBalances: o saldo de cada conta com
+// partidas na moeda informada
+func (repo *LedgerRepositoryImpl) GetAccountBalances(currency string) (map[string]models.Money, error) {
+	rows, err := repo.db.Query("SELECT account_num, SUM(amount) FROM postings WHERE currency = ? GROUP BY account_num", currency)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	balances := make(map[string]models.Money)
+	for rows.Next() {
+		var accountNum string
+		var cents int64
+		if err := rows.Scan(&accountNum, &cents); err != nil {
+			return nil, err
+		}
+		balances[accountNum] = models.NewMoney(cents, currency)
+	}
+	return balances, rows.Err()
+}
+
+// Implementação do método GetTransfersByAccountNum: retorna as movimentações
+// que possuem partidas lançadas na conta e as tentativas recusadas em que ela
+// era a conta de origem ou a conta creditada por um depósito ou financiamento.
+// Estornos trazem a transferência original em ReversalOf e as transferências
+// estornadas trazem o total estornado em ReversedAmount. Tarifas são linhas
+// próprias, ligadas à transferência tarifada por FeeOf, e a transferência
+// tarifada traz a tarifa em Fee.
+func (repo *LedgerRepositoryImpl) GetTransfersByAccountNum(accountNum string) ([]models.Transfer, error) {
+	rows, err := repo.db.Query(`
+		SELECT t.id, t.from_account_num, t.to_account_num, t.amount, t.currency, t.type, t.status, t.failure_reason, t.reversal_of,
+			(SELECT SUM(r.amount) FROM transfers r WHERE r.reversal_of = t.id AND r.status = ?),
+			t.fee_of,
+			(SELECT SUM(f.amount) FROM transfers f WHERE f.fee_of = t.id AND f.status = ?),
+			t.created_at
+		FROM transfers t
+		WHERE EXISTS (
+			SELECT 1 FROM journal_entries e JOIN postings p ON p.entry_id = e.id
+			WHERE e.transfer_id = t.id AND p.account_num = ?
+		) OR (t.status = ? AND (t.from_account_num = ? OR (t.type IN (?, ?) AND t.to_account_num = ?)))
+		ORDER BY t.created_at DESC, t.id DESC`,
+		models.TransferStatusSuccess, models.TransferStatusSuccess,
+		accountNum, models.TransferStatusFailed, accountNum, models.TransferTypeDeposit, models.TransferTypeFunding, accountNum)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transfers []models.Transfer
+	for rows.Next() {
+		var transfer models.Transfer
+		var reversalOf, reversedCents, feeOf, feeCents sql.NullInt64
+		if err := rows.Scan(&transfer.ID, &transfer.FromAccountNum, &transfer.ToAccountNum,
+			&transfer.Amount.Cents, &transfer.Amount.Currency, &transfer.Type, &transfer.Status, &transfer.FailureReason,
+			&reversalOf, &reversedCents, &feeOf, &feeCents, &transfer.CreatedAt); err != nil {
+			return nil, err
+		}
+		if reversalOf.Valid {
+			original := int(reversalOf.Int64)
+			transfer.ReversalOf = &original
+		}
+		if reversedCents.Valid {
+			reversed := models.NewMoney(reversedCents.Int64, transfer.Amount.Currency)
+			transfer.ReversedAmount = &reversed
+		}
+		if feeOf.Valid {
+			charged := int(feeOf.Int64)
+			transfer.FeeOf = &charged
+		}
+		if feeCents.Valid {
+			fee := models.NewMoney(feeCents.Int64, transfer.Amount.Currency)
+			transfer.Fee = &fee
+		}
+		transfers = append(transfers, transfer)
+	}
+	return transfers, rows.Err()
+}
+
+// Implementação do método GetBalanceAt: o saldo da conta pelas partidas
+// lançadas antes do instante at. A soma parte do último saldo de fim de dia
+// guardado até at (tabela balance_snapshots), quando houver, e percorre só as
+// partidas lançadas depois dele.
+func (repo *LedgerRepositoryImpl) GetBalanceAt(accountNum, currency string, at time.Time) (models.Money, error) {
+	before := timestamp(at)
+	var cents int64
+	err := repo.db.QueryRow(`
+		WITH snapshot AS (
+			SELECT balance, as_of FROM balance_snapshots
+			WHERE account_num = ? AND currency = ? AND as_of <= ?
+			ORDER BY as_of DESC LIMIT 1
+		)
+		SELECT COALESCE((SELECT balance FROM snapshot), 0) + COALESCE((
+			SELECT SUM(p.amount) FROM postings p JOIN journal_entries e ON e.id = p.entry_id
+			WHERE p.account_num = ? AND p.currency = ?
+				AND e.created_at >= COALESCE((SELECT as_of FROM snapshot), '') AND e.created_at < ?
+		), 0)`,
+		accountNum, currency, before, accountNum, currency, before).Scan(&cents)
+	if err != nil {
+		return models.Money{}, err
+	}
+	return models.NewMoney(cents, currency), nil
+}
+
+// Implementação do método GetStatementLines: um item por lançamento com
+// partidas na conta entre from (inclusive) e to (exclusive), em ordem
+// cronológica, com o valor líquido das partidas da conta, o tipo da
+// transferência do lançamento e a outra conta movimentada. Balance fica em
+// branco: quem monta o extrato o preenche a partir do saldo de abertura.
+func (repo *LedgerRepositoryImpl) GetStatementLines(accountNum, currency string, from, to time.Time) ([]models.StatementLine, error) {
+	rows, err := repo.db.Query(`
+		SELECT e.id, e.transfer_id, COALESCE(t.type, ''), e.description,
+			COALESCE((SELECT o.account_num FROM postings o WHERE o.entry_id = e.id AND o.account_num <> p.account_num ORDER BY o.id LIMIT 1), ''),
+			SUM(p.amount), e.created_at
+		FROM postings p
+		JOIN journal_entries e ON e.id = p.entry_id
+		LEFT JOIN transfers t ON t.id = e.transfer_id
+		WHERE p.account_num = ? AND p.currency = ? AND e.created_at >= ? AND e.created_at < ?
+		GROUP BY e.id
+		ORDER BY e.created_at, e.id`,
+		accountNum, currency, timestamp(from), timestamp(to))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	lines := []models.StatementLine{}
+	for rows.Next() {
+		var line models.StatementLine
+		var transferID sql.NullInt64
+		if err := rows.Scan(&line.EntryID, &transferID, &line.Type, &line.Description, &line.Counterparty,
+			&line.Amount.Cents, &line.CreatedAt); err != nil {
+			return nil, err
+		}
+		line.Amount.Currency = currency
+		if transferID.Valid {
+			id := int(transferID.Int64)
+			line.TransferID = &id
+		}
+		lines = append(lines, line)
+	}
+	return lines, rows.Err()
+}
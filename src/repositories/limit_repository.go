@@ -0,0 +1,124 @@
+package repositories
+
+import (
+	"banking/src/models"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// ErrLimitsNotFound é retornado quando a conta não tem limites próprios
+var ErrLimitsNotFound = errors.New("transfer limits not found")
+
+// LimitRepository define a interface para os limites de transferência
+type LimitRepository interface {
+	GetLimits(accountNum string) (*models.TransferLimits, error)
+	SaveLimits(limits *models.TransferLimits) error
+	DeleteLimits(accountNum string) error
+	GetUsage(accountNum, currency string, now time.Time) (models.LimitUsage, error)
+}
+
+type LimitRepositoryImpl struct {
+	db DBTX
+}
+
+func NewLimitRepository(db *sql.DB) *LimitRepositoryImpl {
+	return &LimitRepositoryImpl{db: db}
+}
+
+// Implementação do método GetLimits
+func (repo *LimitRepositoryImpl) GetLimits(accountNum string) (*models.TransferLimits, error) {
+	var limits models.TransferLimits
+	var currency string
+	err := repo.db.QueryRow(`SELECT account_num, currency, per_transfer, daily, monthly, daily_count, night_per_transfer, night_total, updated_at
+		FROM transfer_limits WHERE account_num = ?`, accountNum).
+		Scan(&limits.AccountNum, &currency, &limits.PerTransfer.Cents, &limits.Daily.Cents, &limits.Monthly.Cents, &limits.DailyCount,
+			&limits.NightPerTransfer.Cents, &limits.NightTotal.Cents, &limits.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrLimitsNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	limits.PerTransfer.Currency = currency
+	limits.Daily.Currency = currency
+	limits.Monthly.Currency = currency
+	limits.NightPerTransfer.Currency = currency
+	limits.NightTotal.Currency = currency
+	return &limits, nil
+}
+
+// Implementação do método SaveLimits: cria ou substitui os limites da conta.
+// Os valores devem estar todos na mesma moeda (veja TransferLimits.Validate).
+func (repo *LimitRepositoryImpl) SaveLimits(limits *models.TransferLimits) error {
+	return repo.db.QueryRow(`INSERT INTO transfer_limits (account_num, currency, per_transfer, daily, monthly, daily_count, night_per_transfer, night_total)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (account_num) DO UPDATE SET currency = excluded.currency, per_transfer = excluded.per_transfer,
+			daily = excluded.daily, monthly = excluded.monthly, daily_count = excluded.daily_count,
+			night_per_transfer = excluded.night_per_transfer, night_total = excluded.night_total, updated_at = CURRENT_TIMESTAMP
+		RETURNING updated_at`,
+		limits.AccountNum, limits.PerTransfer.Currency, limits.PerTransfer.Cents, limits.Daily.Cents, limits.Monthly.Cents, limits.DailyCount,
+		limits.NightPerTransfer.Cents, limits.NightTotal.Cents).
+		Scan(&limits.UpdatedAt)
+}
+
+// Implementação do método DeleteLimits
+func (repo *LimitRepositoryImpl) DeleteLimits(accountNum string) error {
+	result, err := repo.db.Exec("DELETE FROM transfer_limits WHERE account_num = ?", accountNum)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrLimitsNotFound
+	}
+	return nil
+}
+
+// Implementação do método GetUsage: soma as transferências de saída
+// bem-sucedidas da conta e o valor ainda não capturado das suas reservas
+// ativas no dia, no mês e no período noturno que contêm now. A captura vira
+// uma transferência, então cada reserva conta uma vez na quantidade diária,
+// enquanto nada foi capturado. Depósitos, saques e estornos não contam para os
+// limites.
+func (repo *LimitRepositoryImpl) GetUsage(accountNum, currency string, now time.Time) (models.LimitUsage, error) {
+	// Fora do período noturno, NightStart é o início do próximo período, então
+	// o total noturno é zero. De madrugada no dia 1º, o período noturno começou
+	// no mês anterior.
+	windows := models.NewLimitWindows(now)
+	since := windows.MonthStart
+	if windows.NightStart.Before(since) {
+		since = windows.NightStart
+	}
+
+	usage := models.LimitUsage{
+		Daily:      models.NewMoney(0, currency),
+		Monthly:    models.NewMoney(0, currency),
+		NightTotal: models.NewMoney(0, currency),
+	}
+	err := repo.db.QueryRow(`SELECT
+			COALESCE(SUM(CASE WHEN created_at >= ? THEN amount END), 0),
+			COALESCE(SUM(CASE WHEN created_at >= ? THEN amount END), 0),
+			COUNT(CASE WHEN created_at >= ? AND counted THEN 1 END),
+			COALESCE(SUM(CASE WHEN created_at >= ? THEN amount END), 0)
+		FROM (
+			SELECT amount, created_at, 1 AS counted FROM transfers
+			WHERE from_account_num = ? AND currency = ? AND type = ? AND status = ? AND created_at >= ?
+			UNION ALL
+			SELECT amount - captured_amount, created_at, captured_amount = 0 FROM holds
+			WHERE account_num = ? AND currency = ? AND status = ? AND expires_at > ? AND created_at >= ?
+		)`,
+		timestamp(windows.DayStart), timestamp(windows.MonthStart), timestamp(windows.DayStart), timestamp(windows.NightStart),
+		accountNum, currency, models.TransferTypeTransfer, models.TransferStatusSuccess, timestamp(since),
+		accountNum, currency, models.HoldStatusActive, now.UTC(), timestamp(since)).
+		Scan(&usage.Daily.Cents, &usage.Monthly.Cents, &usage.DailyCount, &usage.NightTotal.Cents)
+	return usage, err
+}
+
+// timestamp formata t como o CURRENT_TIMESTAMP do SQLite, para comparar com
+// colunas preenchidas por ele
+func timestamp(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04:05")
+}
@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"banking/src/models"
+	"database/sql"
+)
+
+// NotificationRepository define a interface para as notificações das contas
+type NotificationRepository interface {
+	CreateNotification(notification *models.Notification) error
+	GetNotificationsByAccountNum(accountNum string) ([]models.Notification, error)
+}
+
+type NotificationRepositoryImpl struct {
+	db DBTX
+}
+
+func NewNotificationRepository(db *sql.DB) *NotificationRepositoryImpl {
+	return &NotificationRepositoryImpl{db: db}
+}
+
+// Implementação do método CreateNotification
+func (repo *NotificationRepositoryImpl) CreateNotification(notification *models.Notification) error {
+	return repo.db.QueryRow("INSERT INTO notifications (account_num, type, message, balance, currency) VALUES (?, ?, ?, ?, ?) RETURNING id, created_at",
+		notification.AccountNum, notification.Type, notification.Message, notification.Balance.Cents, notification.Balance.Currency).
+		Scan(&notification.ID, &notification.CreatedAt)
+}
+
+// Implementação do método GetNotificationsByAccountNum: notificações da conta,
+// das mais recentes para as mais antigas
+func (repo *NotificationRepositoryImpl) GetNotificationsByAccountNum(accountNum string) ([]models.Notification, error) {
+	rows, err := repo.db.Query("SELECT id, account_num, type, message, balance, currency, created_at FROM notifications WHERE account_num = ? ORDER BY id DESC", accountNum)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	notifications := []models.Notification{}
+	for rows.Next() {
+		var notification models.Notification
+		err := rows.Scan(&notification.ID, &notification.AccountNum, &notification.Type, &notification.Message,
+			&notification.Balance.Cents, &notification.Balance.Currency, &notification.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, notification)
+	}
+	return notifications, rows.Err()
+}
@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"banking/src/models"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// ErrInterestAlreadyAccrued é retornado quando os juros da conta no dia já foram cobrados
+var ErrInterestAlreadyAccrued = errors.New("overdraft interest already accrued for this date")
+
+// OverdraftRepository define a interface para a cobrança de juros do cheque especial
+type OverdraftRepository interface {
+	GetOverdrawnBalances(before time.Time) (map[string]models.Money, error)
+	CreateInterest(interest *models.OverdraftInterest) error
+	GetLatestInterestDate() (string, error)
+	GetInterestByAccountNum(accountNum string) ([]models.OverdraftInterest, error)
+}
+
+type OverdraftRepositoryImpl struct {
+	db DBTX
+}
+
+func NewOverdraftRepository(db *sql.DB) *OverdraftRepositoryImpl {
+	return &OverdraftRepositoryImpl{db: db}
+}
+
+// Implementação do método GetOverdrawnBalances: o saldo, pelo livro-razão, das
+// contas de clientes que estavam negativas no instante before
+func (repo *OverdraftRepositoryImpl) GetOverdrawnBalances(before time.Time) (map[string]models.Money, error) {
+	rows, err := repo.db.Query(`SELECT p.account_num, p.currency, SUM(p.amount)
+		FROM postings p JOIN journal_entries e ON e.id = p.entry_id
+		WHERE e.created_at < ? AND p.account_num NOT LIKE ?
+		GROUP BY p.account_num, p.currency
+		HAVING SUM(p.amount) < 0`, timestamp(before), models.InternalAccountPrefix+"%")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	balances := make(map[string]models.Money)
+	for rows.Next() {
+		var accountNum string
+		var balance models.Money
+		if err := rows.Scan(&accountNum, &balance.Currency, &balance.Cents); err != nil {
+			return nil, err
+		}
+		balances[accountNum] = balance
+	}
+	return balances, rows.Err()
+}
+
+// Implementação do método CreateInterest. A conta e a data são únicas, então
+// executores concorrentes nunca cobram os juros do mesmo dia duas vezes.
+func (repo *OverdraftRepositoryImpl) CreateInterest(interest *models.OverdraftInterest) error {
+	err := repo.db.QueryRow(`INSERT INTO overdraft_interest (account_num, accrual_date, balance, currency, rate_bps, interest, transfer_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (account_num, accrual_date) DO NOTHING
+		RETURNING id, created_at`,
+		interest.AccountNum, interest.AccrualDate, interest.Balance.Cents, interest.Balance.Currency, interest.RateBps, interest.Interest.Cents, interest.TransferID).
+		Scan(&interest.ID, &interest.CreatedAt)
+	if err == sql.ErrNoRows {
+		return ErrInterestAlreadyAccrued
+	}
+	return err
+}
+
+// Implementação do método GetLatestInterestDate: o dia mais recente com juros
+// cobrados, ou vazio se não houver nenhum
+func (repo *OverdraftRepositoryImpl) GetLatestInterestDate() (string, error) {
+	var date sql.NullString
+	err := repo.db.QueryRow("SELECT MAX(accrual_date) FROM overdraft_interest").Scan(&date)
+	return date.String, err
+}
+
+// Implementação do método GetInterestByAccountNum: cobranças da conta, das mais
+// recentes para as mais antigas
+func (repo *OverdraftRepositoryImpl) GetInterestByAccountNum(accountNum string) ([]models.OverdraftInterest, error) {
+	rows, err := repo.db.Query(`SELECT id, account_num, accrual_date, balance, currency, rate_bps, interest, transfer_id, created_at
+		FROM overdraft_interest WHERE account_num = ? ORDER BY accrual_date DESC`, accountNum)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	charges := []models.OverdraftInterest{}
+	for rows.Next() {
+		var interest models.OverdraftInterest
+		var transferID sql.NullInt64
+		err := rows.Scan(&interest.ID, &interest.AccountNum, &interest.AccrualDate, &interest.Balance.Cents, &interest.Balance.Currency,
+			&interest.RateBps, &interest.Interest.Cents, &transferID, &interest.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		interest.Interest.Currency = interest.Balance.Currency
+		if transferID.Valid {
+			id := int(transferID.Int64)
+			interest.TransferID = &id
+		}
+		charges = append(charges, interest)
+	}
+	return charges, rows.Err()
+}
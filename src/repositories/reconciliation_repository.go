@@ -0,0 +1,330 @@
+package repositories
+
+import (
+	"banking/src/models"
+	"database/sql"
+	"fmt"
+)
+
+// ReconciliationRepository define a interface para as verificações de
+// integridade do banco usadas pela conciliação. Cada verificação retorna as
+// divergências que encontrou.
+type ReconciliationRepository interface {
+	CountRecords() (accounts, transfers, entries int, err error)
+	CheckBalances() ([]models.Discrepancy, error)
+	CheckLedger() ([]models.Discrepancy, error)
+	CheckReferences() ([]models.Discrepancy, error)
+	CheckDuplicateAccounts() ([]models.Discrepancy, error)
+	CheckForeignKeys() ([]models.Discrepancy, error)
+}
+
+type ReconciliationRepositoryImpl struct {
+	db DBTX
+}
+
+func NewReconciliationRepository(db *sql.DB) *ReconciliationRepositoryImpl {
+	return &ReconciliationRepositoryImpl{db: db}
+}
+
+// knownAccount é a condição SQL de uma conta de cliente ou interna
+const knownAccount = `(%[1]s LIKE '` + models.InternalAccountPrefix + `%%' OR EXISTS (SELECT 1 FROM clients k WHERE k.account_num = %[1]s))`
+
+// Implementação do método CountRecords
+func (repo *ReconciliationRepositoryImpl) CountRecords() (accounts, transfers, entries int, err error) {
+	err = repo.db.QueryRow(`SELECT (SELECT COUNT(*) FROM clients), (SELECT COUNT(*) FROM transfers), (SELECT COUNT(*) FROM journal_entries)`).
+		Scan(&accounts, &transfers, &entries)
+	return accounts, transfers, entries, err
+}
+
+// Implementação do método CheckBalances: recalcula o saldo de cada conta de
+// cliente, na moeda da conta, pelas partidas do livro-razão e pelas
+// transferências bem-sucedidas (mais os saldos de abertura, que não têm
+// transferência), e o valor reservado pelas reservas ativas, e compara com o
+// que está armazenado em clients
+func (repo *ReconciliationRepositoryImpl) CheckBalances() ([]models.Discrepancy, error) {
+	rows, err := repo.db.Query(`
+		SELECT c.account_num, c.currency, c.balance, c.held,
+			COALESCE((SELECT SUM(p.amount) FROM postings p WHERE p.account_num = c.account_num AND p.currency = c.currency), 0),
+			COALESCE((SELECT SUM(CASE WHEN t.to_account_num = c.account_num THEN t.amount ELSE 0 END)
+					- SUM(CASE WHEN t.from_account_num = c.account_num THEN t.amount ELSE 0 END)
+				FROM transfers t
+				WHERE t.status = ? AND t.currency = c.currency AND (t.from_account_num = c.account_num OR t.to_account_num = c.account_num)), 0)
+			+ COALESCE((SELECT SUM(p.amount) FROM postings p JOIN journal_entries e ON e.id = p.entry_id
+				WHERE e.transfer_id IS NULL AND p.account_num = c.account_num AND p.currency = c.currency), 0),
+			COALESCE((SELECT SUM(h.amount - h.captured_amount) FROM holds h
+				WHERE h.account_num = c.account_num AND h.currency = c.currency AND h.status = ?), 0)
+		FROM clients c
+		ORDER BY c.account_num`, models.TransferStatusSuccess, models.HoldStatusActive)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	discrepancies := []models.Discrepancy{}
+	for rows.Next() {
+		var accountNum, currency string
+		var balance, held, ledger, movements, holds int64
+		if err := rows.Scan(&accountNum, &currency, &balance, &held, &ledger, &movements, &holds); err != nil {
+			return nil, err
+		}
+		check := func(kind string, expected, actual int64, detail string) {
+			if expected != actual {
+				discrepancies = append(discrepancies, moneyDiscrepancy(kind, accountNum, currency, expected, actual, detail))
+			}
+		}
+		check(models.DiscrepancyLedgerBalance, ledger, balance, "stored balance differs from the sum of ledger postings")
+		check(models.DiscrepancyTransferBalance, movements, balance, "stored balance differs from the balance recomputed from successful transfers")
+		check(models.DiscrepancyHeldBalance, holds, held, "stored held amount differs from the remaining amount of active holds")
+	}
+	return discrepancies, rows.Err()
+}
+
+// Implementação do método CheckLedger: confere as partidas dobradas de cada
+// lançamento, a conservação do dinheiro em cada moeda e a correspondência
+// entre as transferências bem-sucedidas e os seus lançamentos
+func (repo *ReconciliationRepositoryImpl) CheckLedger() ([]models.Discrepancy, error) {
+	discrepancies := []models.Discrepancy{}
+
+	// Lançamentos desbalanceados ou com menos de duas partidas
+	rows, err := repo.db.Query(`
+		SELECT e.id, COALESCE(p.currency, ''), COALESCE(SUM(p.amount), 0), COUNT(p.id)
+		FROM journal_entries e LEFT JOIN postings p ON p.entry_id = e.id
+		GROUP BY e.id, p.currency
+		HAVING COALESCE(SUM(p.amount), 0) <> 0 OR (SELECT COUNT(*) FROM postings a WHERE a.entry_id = e.id) < 2
+		ORDER BY e.id`)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var entryID, count int
+		var currency string
+		var sum int64
+		if err := rows.Scan(&entryID, &currency, &sum, &count); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		id := entryID
+		discrepancies = append(discrepancies, models.Discrepancy{
+			Type:    models.DiscrepancyUnbalancedEntry,
+			EntryID: &id,
+			Detail:  fmt.Sprintf("%d postings in %q sum to %d cents", count, currency, sum),
+		})
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Conservação: tudo o que sai de uma conta entra em outra
+	rows, err = repo.db.Query(`SELECT currency, SUM(amount) FROM postings GROUP BY currency HAVING SUM(amount) <> 0 ORDER BY currency`)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var currency string
+		var sum int64
+		if err := rows.Scan(&currency, &sum); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		discrepancies = append(discrepancies, moneyDiscrepancy(models.DiscrepancyMoneyNotConserved, "", currency, 0, sum,
+			"ledger postings of all accounts do not sum to zero"))
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Transferências bem-sucedidas sem lançamento
+	missing, err := repo.transferDiscrepancies(models.DiscrepancyMissingEntry, "successful transfer has no ledger entry", `
+		SELECT t.id FROM transfers t
+		WHERE t.status = ? AND NOT EXISTS (SELECT 1 FROM journal_entries e WHERE e.transfer_id = t.id)
+		ORDER BY t.id`, models.TransferStatusSuccess)
+	if err != nil {
+		return nil, err
+	}
+	discrepancies = append(discrepancies, missing...)
+
+	// Lançamentos que não movimentam a transferência como ela foi registrada
+	mismatched, err := repo.transferDiscrepancies(models.DiscrepancyEntryMismatch, "ledger entry does not match the transfer accounts, amount or status", `
+		SELECT DISTINCT t.id FROM transfers t JOIN journal_entries e ON e.transfer_id = t.id
+		WHERE t.status <> ?
+			OR NOT EXISTS (SELECT 1 FROM postings p WHERE p.entry_id = e.id AND p.account_num = t.from_account_num AND p.amount = -t.amount AND p.currency = t.currency)
+			OR NOT EXISTS (SELECT 1 FROM postings p WHERE p.entry_id = e.id AND p.account_num = t.to_account_num AND p.amount = t.amount AND p.currency = t.currency)
+		ORDER BY t.id`, models.TransferStatusSuccess)
+	if err != nil {
+		return nil, err
+	}
+	return append(discrepancies, mismatched...), nil
+}
+
+// Implementação do método CheckReferences: procura transferências
+// bem-sucedidas, lançamentos e partidas que apontam para contas,
+// transferências ou lançamentos inexistentes. Tentativas recusadas podem
+// apontar para contas inexistentes e não são conferidas.
+func (repo *ReconciliationRepositoryImpl) CheckReferences() ([]models.Discrepancy, error) {
+	discrepancies := []models.Discrepancy{}
+
+	rows, err := repo.db.Query(fmt.Sprintf(`
+		SELECT t.id, t.from_account_num, t.to_account_num, %s, %s,
+			t.reversal_of IS NOT NULL AND NOT EXISTS (SELECT 1 FROM transfers r WHERE r.id = t.reversal_of),
+			t.fee_of IS NOT NULL AND NOT EXISTS (SELECT 1 FROM transfers f WHERE f.id = t.fee_of)
+		FROM transfers t
+		WHERE t.status = ?
+		ORDER BY t.id`, fmt.Sprintf(knownAccount, "t.from_account_num"), fmt.Sprintf(knownAccount, "t.to_account_num")),
+		models.TransferStatusSuccess)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var transferID int
+		var from, to string
+		var fromKnown, toKnown, missingOriginal, missingCharged bool
+		if err := rows.Scan(&transferID, &from, &to, &fromKnown, &toKnown, &missingOriginal, &missingCharged); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		orphan := func(accountNum, detail string) {
+			id := transferID
+			discrepancies = append(discrepancies, models.Discrepancy{
+				Type: models.DiscrepancyOrphanTransfer, AccountNum: accountNum, TransferID: &id, Detail: detail,
+			})
+		}
+		if !fromKnown {
+			orphan(from, "source account does not exist")
+		}
+		if !toKnown {
+			orphan(to, "destination account does not exist")
+		}
+		if missingOriginal {
+			orphan("", "reversed transfer does not exist")
+		}
+		if missingCharged {
+			orphan("", "charged transfer of the fee does not exist")
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = repo.db.Query(fmt.Sprintf(`
+		SELECT e.id, e.transfer_id, '', 'ledger entry references a missing transfer' FROM journal_entries e
+		WHERE e.transfer_id IS NOT NULL AND NOT EXISTS (SELECT 1 FROM transfers t WHERE t.id = e.transfer_id)
+		UNION ALL
+		SELECT p.entry_id, NULL, p.account_num, 'posting references a missing ledger entry' FROM postings p
+		WHERE NOT EXISTS (SELECT 1 FROM journal_entries e WHERE e.id = p.entry_id)
+		UNION ALL
+		SELECT p.entry_id, NULL, p.account_num, 'posting moves an account that does not exist' FROM postings p
+		WHERE NOT %s
+		ORDER BY 1`, fmt.Sprintf(knownAccount, "p.account_num")))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var entryID int
+		var transferID sql.NullInt64
+		var discrepancy models.Discrepancy
+		if err := rows.Scan(&entryID, &transferID, &discrepancy.AccountNum, &discrepancy.Detail); err != nil {
+			return nil, err
+		}
+		discrepancy.Type = models.DiscrepancyOrphanEntry
+		discrepancy.EntryID = &entryID
+		if transferID.Valid {
+			id := int(transferID.Int64)
+			discrepancy.TransferID = &id
+		}
+		discrepancies = append(discrepancies, discrepancy)
+	}
+	return discrepancies, rows.Err()
+}
+
+// Implementação do método CheckDuplicateAccounts: números de conta que se
+// repetem ignorando maiúsculas e espaços nas pontas. A coluna é única, mas só
+// para o texto exato.
+func (repo *ReconciliationRepositoryImpl) CheckDuplicateAccounts() ([]models.Discrepancy, error) {
+	rows, err := repo.db.Query(`
+		SELECT LOWER(TRIM(account_num)), COUNT(*), GROUP_CONCAT(QUOTE(account_num), ', ')
+		FROM clients
+		GROUP BY LOWER(TRIM(account_num))
+		HAVING COUNT(*) > 1
+		ORDER BY 1`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	discrepancies := []models.Discrepancy{}
+	for rows.Next() {
+		var accountNum, accounts string
+		var count int
+		if err := rows.Scan(&accountNum, &count, &accounts); err != nil {
+			return nil, err
+		}
+		discrepancies = append(discrepancies, models.Discrepancy{
+			Type:       models.DiscrepancyDuplicateAccount,
+			AccountNum: accountNum,
+			Detail:     fmt.Sprintf("%d clients share the account number: %s", count, accounts),
+		})
+	}
+	return discrepancies, rows.Err()
+}
+
+// Implementação do método CheckForeignKeys: linhas que violam as chaves
+// estrangeiras do esquema, gravadas antes de a verificação ser ligada
+func (repo *ReconciliationRepositoryImpl) CheckForeignKeys() ([]models.Discrepancy, error) {
+	rows, err := repo.db.Query("PRAGMA foreign_key_check")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	discrepancies := []models.Discrepancy{}
+	for rows.Next() {
+		var table, parent string
+		var rowID sql.NullInt64
+		var fkID int
+		if err := rows.Scan(&table, &rowID, &parent, &fkID); err != nil {
+			return nil, err
+		}
+		discrepancies = append(discrepancies, models.Discrepancy{
+			Type:   models.DiscrepancyForeignKey,
+			Detail: fmt.Sprintf("%s row %d references a missing row in %s", table, rowID.Int64, parent),
+		})
+	}
+	return discrepancies, rows.Err()
+}
+
+// transferDiscrepancies executa uma consulta que retorna IDs de
+// transferências e cria uma divergência do tipo kind para cada uma
+func (repo *ReconciliationRepositoryImpl) transferDiscrepancies(kind, detail, query string, args ...interface{}) ([]models.Discrepancy, error) {
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	discrepancies := []models.Discrepancy{}
+	for rows.Next() {
+		var transferID int
+		if err := rows.Scan(&transferID); err != nil {
+			return nil, err
+		}
+		discrepancies = append(discrepancies, models.Discrepancy{Type: kind, TransferID: &transferID, Detail: detail})
+	}
+	return discrepancies, rows.Err()
+}
+
+// moneyDiscrepancy cria uma divergência de valor
+func moneyDiscrepancy(kind, accountNum, currency string, expected, actual int64, detail string) models.Discrepancy {
+	expectedMoney := models.NewMoney(expected, currency)
+	actualMoney := models.NewMoney(actual, currency)
+	return models.Discrepancy{
+		Type:       kind,
+		AccountNum: accountNum,
+		Expected:   &expectedMoney,
+		Actual:     &actualMoney,
+		Detail:     detail,
+	}
+}
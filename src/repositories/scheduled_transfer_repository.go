@@ -0,0 +1,183 @@
+package repositories
+
+import (
+	"banking/src/models"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrScheduledTransferNotFound é retornado quando não existe agendamento com o ID informado
+	ErrScheduledTransferNotFound = errors.New("scheduled transfer not found")
+	// ErrScheduledTransferNotPending é retornado ao tentar cancelar ou executar
+	// um agendamento que já foi executado, cancelado ou está em execução
+	ErrScheduledTransferNotPending = errors.New("scheduled transfer is no longer pending")
+)
+
+// ScheduledTransferRepository define a interface para as transferências agendadas
+type ScheduledTransferRepository interface {
+	CreateScheduledTransfer(scheduled *models.ScheduledTransfer) error
+	GetScheduledTransferByID(id int) (*models.ScheduledTransfer, error)
+	GetScheduledTransfersByAccountNum(accountNum, status string) ([]models.ScheduledTransfer, error)
+	GetDueScheduledTransfers(now, staleBefore time.Time, limit int) ([]models.ScheduledTransfer, error)
+	ClaimScheduledTransfer(id int, now, staleBefore time.Time) error
+	ReleaseScheduledTransfer(id int, claimedAt time.Time) error
+	CompleteScheduledTransfer(id int, claimedAt time.Time, status, failureReason string, executedAt time.Time) error
+	CancelScheduledTransfer(id int) error
+}
+
+type ScheduledTransferRepositoryImpl struct {
+	db DBTX
+}
+
+func NewScheduledTransferRepository(db *sql.DB) *ScheduledTransferRepositoryImpl {
+	return &ScheduledTransferRepositoryImpl{db: db}
+}
+
+const scheduledTransferColumns = "id, from_account_num, to_account_num, amount, currency, execute_at, status, failure_reason, created_at, executed_at"
+
+func scanScheduledTransfer(row interface{ Scan(dest ...any) error }) (models.ScheduledTransfer, error) {
+	var scheduled models.ScheduledTransfer
+	var executedAt sql.NullTime
+	err := row.Scan(&scheduled.ID, &scheduled.FromAccountNum, &scheduled.ToAccountNum, &scheduled.Amount.Cents, &scheduled.Amount.Currency,
+		&scheduled.ExecuteAt, &scheduled.Status, &scheduled.FailureReason, &scheduled.CreatedAt, &executedAt)
+	if executedAt.Valid {
+		scheduled.ExecutedAt = &executedAt.Time
+	}
+	return scheduled, err
+}
+
+// Implementação do método CreateScheduledTransfer. ExecuteAt é gravado em UTC
+// para que a comparação com a hora atual em GetDueScheduledTransfers seja
+// consistente.
+func (repo *ScheduledTransferRepositoryImpl) CreateScheduledTransfer(scheduled *models.ScheduledTransfer) error {
+	if scheduled.Status == "" {
+		scheduled.Status = models.ScheduledStatusPending
+	}
+	scheduled.ExecuteAt = scheduled.ExecuteAt.UTC()
+	return repo.db.QueryRow("INSERT INTO scheduled_transfers (from_account_num, to_account_num, amount, currency, execute_at, status) VALUES (?, ?, ?, ?, ?, ?) RETURNING id, created_at",
+		scheduled.FromAccountNum, scheduled.ToAccountNum, scheduled.Amount.Cents, scheduled.Amount.Currency, scheduled.ExecuteAt, scheduled.Status).
+		Scan(&scheduled.ID, &scheduled.CreatedAt)
+}
+
+// Implementação do método GetScheduledTransferByID
+func (repo *ScheduledTransferRepositoryImpl) GetScheduledTransferByID(id int) (*models.ScheduledTransfer, error) {
+	scheduled, err := scanScheduledTransfer(repo.db.QueryRow("SELECT "+scheduledTransferColumns+" FROM scheduled_transfers WHERE id = ?", id))
+	if err == sql.ErrNoRows {
+		return nil, ErrScheduledTransferNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return &scheduled, nil
+}
+
+// Implementação do método GetScheduledTransfersByAccountNum: agendamentos em
+// que a conta é origem ou destino, filtrados por status quando informado
+func (repo *ScheduledTransferRepositoryImpl) GetScheduledTransfersByAccountNum(accountNum, status string) ([]models.ScheduledTransfer, error) {
+	return repo.query("SELECT "+scheduledTransferColumns+` FROM scheduled_transfers
+		WHERE (from_account_num = ? OR to_account_num = ?) AND (? = '' OR status = ?)
+		ORDER BY execute_at, id`, accountNum, accountNum, status, status)
+}
+
+// claimableScheduledTransfer é a condição de um agendamento que pode ser
+// reivindicado: pendente, ou em execução com a reivindicação feita antes de
+// staleBefore (o executor que o reivindicou parou no meio da execução)
+const claimableScheduledTransfer = "(status = ? OR (status = ? AND (claimed_at IS NULL OR claimed_at < ?)))"
+
+// Implementação do método GetDueScheduledTransfers: agendamentos que podem ser
+// reivindicados e cuja data de execução já chegou, dos mais antigos para os
+// mais novos
+func (repo *ScheduledTransferRepositoryImpl) GetDueScheduledTransfers(now, staleBefore time.Time, limit int) ([]models.ScheduledTransfer, error) {
+	return repo.query("SELECT "+scheduledTransferColumns+" FROM scheduled_transfers WHERE "+claimableScheduledTransfer+`
+		AND execute_at <= ? ORDER BY execute_at, id LIMIT ?`,
+		models.ScheduledStatusPending, models.ScheduledStatusProcessing, staleBefore.UTC(), now.UTC(), limit)
+}
+
+func (repo *ScheduledTransferRepositoryImpl) query(query string, args ...any) ([]models.ScheduledTransfer, error) {
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	scheduledTransfers := []models.ScheduledTransfer{}
+	for rows.Next() {
+		scheduled, err := scanScheduledTransfer(rows)
+		if err != nil {
+			return nil, err
+		}
+		scheduledTransfers = append(scheduledTransfers, scheduled)
+	}
+	return scheduledTransfers, rows.Err()
+}
+
+// Implementação do método ClaimScheduledTransfer. A troca para "processing" é
+// condicional, então apenas um executor consegue reivindicar o agendamento, e
+// um cancelamento concorrente não é perdido. O horário da reivindicação é
+// gravado para que um agendamento abandonado possa ser reivindicado de novo
+// depois de staleBefore.
+func (repo *ScheduledTransferRepositoryImpl) ClaimScheduledTransfer(id int, now, staleBefore time.Time) error {
+	result, err := repo.db.Exec("UPDATE scheduled_transfers SET status = ?, claimed_at = ? WHERE id = ? AND "+claimableScheduledTransfer,
+		models.ScheduledStatusProcessing, now.UTC(), id, models.ScheduledStatusPending, models.ScheduledStatusProcessing, staleBefore.UTC())
+	if err != nil {
+		return err
+	}
+	return repo.checkTransition(id, result)
+}
+
+// Implementação do método ReleaseScheduledTransfer: devolve para "pending" um
+// agendamento reivindicado em claimedAt, para que seja executado de novo no
+// próximo ciclo
+func (repo *ScheduledTransferRepositoryImpl) ReleaseScheduledTransfer(id int, claimedAt time.Time) error {
+	result, err := repo.db.Exec("UPDATE scheduled_transfers SET status = ?, claimed_at = NULL WHERE id = ? AND status = ? AND claimed_at = ?",
+		models.ScheduledStatusPending, id, models.ScheduledStatusProcessing, claimedAt.UTC())
+	if err != nil {
+		return err
+	}
+	return repo.checkTransition(id, result)
+}
+
+// Implementação do método CompleteScheduledTransfer: registra o resultado de
+// um agendamento reivindicado em claimedAt. Se a reivindicação expirou e outro
+// executor reivindicou o agendamento, retorna ErrScheduledTransferNotPending.
+func (repo *ScheduledTransferRepositoryImpl) CompleteScheduledTransfer(id int, claimedAt time.Time, status, failureReason string, executedAt time.Time) error {
+	result, err := repo.db.Exec("UPDATE scheduled_transfers SET status = ?, failure_reason = ?, executed_at = ? WHERE id = ? AND status = ? AND claimed_at = ?",
+		status, failureReason, executedAt.UTC(), id, models.ScheduledStatusProcessing, claimedAt.UTC())
+	if err != nil {
+		return err
+	}
+	return repo.checkTransition(id, result)
+}
+
+// Implementação do método CancelScheduledTransfer
+func (repo *ScheduledTransferRepositoryImpl) CancelScheduledTransfer(id int) error {
+	return repo.transition(id, models.ScheduledStatusCancelled)
+}
+
+// transition muda o status de um agendamento que ainda está pendente
+func (repo *ScheduledTransferRepositoryImpl) transition(id int, status string) error {
+	result, err := repo.db.Exec("UPDATE scheduled_transfers SET status = ? WHERE id = ? AND status = ?",
+		status, id, models.ScheduledStatusPending)
+	if err != nil {
+		return err
+	}
+	return repo.checkTransition(id, result)
+}
+
+// checkTransition retorna ErrScheduledTransferNotFound ou
+// ErrScheduledTransferNotPending quando a mudança de status não alterou nenhuma
+// linha
+func (repo *ScheduledTransferRepositoryImpl) checkTransition(id int, result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected > 0 {
+		return nil
+	}
+	if _, err := repo.GetScheduledTransferByID(id); err != nil {
+		return err
+	}
+	return ErrScheduledTransferNotPending
+}
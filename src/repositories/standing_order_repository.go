@@ -0,0 +1,250 @@
+package repositories
+
+import (
+	"banking/src/models"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrStandingOrderNotFound é retornado quando não existe ordem permanente com o ID informado
+	ErrStandingOrderNotFound = errors.New("standing order not found")
+	// ErrStandingOrderInvalidState é retornado quando a ordem não está em um
+	// status que permite a alteração (por exemplo, retomar uma ordem cancelada)
+	ErrStandingOrderInvalidState = errors.New("standing order cannot be changed in its current status")
+	// ErrStandingOrderNotDue é retornado ao reivindicar uma ordem que não está
+	// mais ativa, ainda não venceu ou já foi reivindicada por outro executor, e
+	// ao gravar o andamento de uma ordem cuja reivindicação foi substituída
+	ErrStandingOrderNotDue = errors.New("standing order is not due")
+)
+
+// StandingOrderRepository define a interface para as ordens permanentes e suas execuções
+type StandingOrderRepository interface {
+	CreateStandingOrder(order *models.StandingOrder) error
+	GetStandingOrderByID(id int) (*models.StandingOrder, error)
+	GetStandingOrdersByAccountNum(accountNum, status string) ([]models.StandingOrder, error)
+	GetDueStandingOrders(now, staleBefore time.Time, limit int) ([]models.StandingOrder, error)
+	ClaimStandingOrder(id int, now, staleBefore time.Time) error
+	UpdateStandingOrderSchedule(order *models.StandingOrder, claimedAt time.Time) error
+	SetStandingOrderStatus(id int, status string, fromStatuses ...string) error
+	ResumeStandingOrder(order *models.StandingOrder) error
+	CreateStandingOrderRun(run *models.StandingOrderRun) error
+	GetStandingOrderRuns(standingOrderID int) ([]models.StandingOrderRun, error)
+}
+
+type StandingOrderRepositoryImpl struct {
+	db DBTX
+}
+
+func NewStandingOrderRepository(db *sql.DB) *StandingOrderRepositoryImpl {
+	return &StandingOrderRepositoryImpl{db: db}
+}
+
+const standingOrderColumns = `id, from_account_num, to_account_num, amount, currency, frequency, interval_count, cron,
+	start_at, end_at, max_occurrences, occurrences, next_occurrence_at, next_run_at, retries, status, created_at`
+
+func scanStandingOrder(row interface{ Scan(dest ...any) error }) (models.StandingOrder, error) {
+	var order models.StandingOrder
+	var endAt, nextRunAt sql.NullTime
+	err := row.Scan(&order.ID, &order.FromAccountNum, &order.ToAccountNum, &order.Amount.Cents, &order.Amount.Currency,
+		&order.Frequency, &order.Interval, &order.Cron, &order.StartAt, &endAt, &order.MaxOccurrences, &order.Occurrences,
+		&order.NextOccurrenceAt, &nextRunAt, &order.Retries, &order.Status, &order.CreatedAt)
+	if endAt.Valid {
+		order.EndAt = &endAt.Time
+	}
+	if nextRunAt.Valid {
+		order.NextRunAt = &nextRunAt.Time
+	}
+	return order, err
+}
+
+// utc converte um horário opcional para UTC, mantendo nil como NULL
+func utc(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return t.UTC()
+}
+
+// Implementação do método CreateStandingOrder. Os horários são gravados em UTC
+// para que a comparação com a hora atual em GetDueStandingOrders seja consistente.
+func (repo *StandingOrderRepositoryImpl) CreateStandingOrder(order *models.StandingOrder) error {
+	if order.Status == "" {
+		order.Status = models.StandingOrderActive
+	}
+	return repo.db.QueryRow(`INSERT INTO standing_orders (from_account_num, to_account_num, amount, currency, frequency, interval_count, cron,
+		start_at, end_at, max_occurrences, occurrences, next_occurrence_at, next_run_at, retries, status)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id, created_at`,
+		order.FromAccountNum, order.ToAccountNum, order.Amount.Cents, order.Amount.Currency, order.Frequency, order.Interval, order.Cron,
+		order.StartAt.UTC(), utc(order.EndAt), order.MaxOccurrences, order.Occurrences, order.NextOccurrenceAt.UTC(), utc(order.NextRunAt),
+		order.Retries, order.Status).
+		Scan(&order.ID, &order.CreatedAt)
+}
+
+// Implementação do método GetStandingOrderByID
+func (repo *StandingOrderRepositoryImpl) GetStandingOrderByID(id int) (*models.StandingOrder, error) {
+	order, err := scanStandingOrder(repo.db.QueryRow("SELECT "+standingOrderColumns+" FROM standing_orders WHERE id = ?", id))
+	if err == sql.ErrNoRows {
+		return nil, ErrStandingOrderNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// Implementação do método GetStandingOrdersByAccountNum: ordens em que a conta
+// é origem ou destino, filtradas por status quando informado
+func (repo *StandingOrderRepositoryImpl) GetStandingOrdersByAccountNum(accountNum, status string) ([]models.StandingOrder, error) {
+	return repo.query("SELECT "+standingOrderColumns+` FROM standing_orders
+		WHERE (from_account_num = ? OR to_account_num = ?) AND (? = '' OR status = ?)
+		ORDER BY id`, accountNum, accountNum, status, status)
+}
+
+// Implementação do método GetDueStandingOrders: ordens ativas, não
+// reivindicadas (ou reivindicadas antes de staleBefore por um executor que
+// parou no meio da execução), cuja próxima tentativa já chegou
+func (repo *StandingOrderRepositoryImpl) GetDueStandingOrders(now, staleBefore time.Time, limit int) ([]models.StandingOrder, error) {
+	return repo.query("SELECT "+standingOrderColumns+` FROM standing_orders
+		WHERE status = ? AND (claimed_at IS NULL OR claimed_at < ?) AND next_run_at <= ?
+		ORDER BY next_run_at, id LIMIT ?`, models.StandingOrderActive, staleBefore.UTC(), now.UTC(), limit)
+}
+
+func (repo *StandingOrderRepositoryImpl) query(query string, args ...any) ([]models.StandingOrder, error) {
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	orders := []models.StandingOrder{}
+	for rows.Next() {
+		order, err := scanStandingOrder(rows)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, order)
+	}
+	return orders, rows.Err()
+}
+
+// Implementação do método ClaimStandingOrder. A reivindicação é condicional,
+// então apenas um executor processa cada ocorrência. Uma reivindicação feita
+// antes de staleBefore é considerada abandonada e pode ser substituída.
+func (repo *StandingOrderRepositoryImpl) ClaimStandingOrder(id int, now, staleBefore time.Time) error {
+	result, err := repo.db.Exec("UPDATE standing_orders SET claimed_at = ? WHERE id = ? AND status = ? AND (claimed_at IS NULL OR claimed_at < ?) AND next_run_at <= ?",
+		now.UTC(), id, models.StandingOrderActive, staleBefore.UTC(), now.UTC())
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrStandingOrderNotDue
+	}
+	return nil
+}
+
+// Implementação do método UpdateStandingOrderSchedule: grava o andamento da
+// ordem depois de uma execução e libera a reivindicação feita em claimedAt. O
+// status só é alterado para "completed" se a ordem ainda estiver ativa, para
+// não desfazer uma pausa ou um cancelamento feito durante a execução. Se a
+// reivindicação expirou e foi substituída por outro executor, nada é gravado e
+// o erro é ErrStandingOrderNotDue.
+func (repo *StandingOrderRepositoryImpl) UpdateStandingOrderSchedule(order *models.StandingOrder, claimedAt time.Time) error {
+	result, err := repo.db.Exec(`UPDATE standing_orders SET occurrences = ?, next_occurrence_at = ?, next_run_at = ?, retries = ?, claimed_at = NULL,
+		status = CASE WHEN status = ? AND ? = ? THEN ? ELSE status END
+		WHERE id = ? AND claimed_at = ?`,
+		order.Occurrences, order.NextOccurrenceAt.UTC(), utc(order.NextRunAt), order.Retries,
+		models.StandingOrderActive, order.Status, models.StandingOrderCompleted, models.StandingOrderCompleted,
+		order.ID, claimedAt.UTC())
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrStandingOrderNotDue
+	}
+	return nil
+}
+
+// Implementação do método SetStandingOrderStatus: muda o status apenas se o
+// atual for um de fromStatuses
+func (repo *StandingOrderRepositoryImpl) SetStandingOrderStatus(id int, status string, fromStatuses ...string) error {
+	args := []any{status, id}
+	for _, from := range fromStatuses {
+		args = append(args, from)
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(fromStatuses)), ", ")
+	result, err := repo.db.Exec("UPDATE standing_orders SET status = ? WHERE id = ? AND status IN ("+placeholders+")", args...)
+	if err != nil {
+		return err
+	}
+	return repo.checkTransition(id, result)
+}
+
+// Implementação do método ResumeStandingOrder: reativa uma ordem pausada com o
+// novo agendamento calculado pelo serviço
+func (repo *StandingOrderRepositoryImpl) ResumeStandingOrder(order *models.StandingOrder) error {
+	result, err := repo.db.Exec("UPDATE standing_orders SET status = ?, next_occurrence_at = ?, next_run_at = ?, retries = ? WHERE id = ? AND status = ?",
+		models.StandingOrderActive, order.NextOccurrenceAt.UTC(), utc(order.NextRunAt), order.Retries, order.ID, models.StandingOrderPaused)
+	if err != nil {
+		return err
+	}
+	return repo.checkTransition(order.ID, result)
+}
+
+// checkTransition distingue uma ordem inexistente de uma ordem em outro status
+// quando uma alteração condicional não afeta nenhuma linha
+func (repo *StandingOrderRepositoryImpl) checkTransition(id int, result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected > 0 {
+		return nil
+	}
+	if _, err := repo.GetStandingOrderByID(id); err != nil {
+		return err
+	}
+	return ErrStandingOrderInvalidState
+}
+
+// Implementação do método CreateStandingOrderRun
+func (repo *StandingOrderRepositoryImpl) CreateStandingOrderRun(run *models.StandingOrderRun) error {
+	return repo.db.QueryRow(`INSERT INTO standing_order_runs (standing_order_id, occurrence_at, attempt, status, failure_reason, retry_at, executed_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?) RETURNING id`,
+		run.StandingOrderID, run.OccurrenceAt.UTC(), run.Attempt, run.Status, run.FailureReason, utc(run.RetryAt), run.ExecutedAt.UTC()).
+		Scan(&run.ID)
+}
+
+// Implementação do método GetStandingOrderRuns: execuções da ordem, da mais
+// recente para a mais antiga
+func (repo *StandingOrderRepositoryImpl) GetStandingOrderRuns(standingOrderID int) ([]models.StandingOrderRun, error) {
+	rows, err := repo.db.Query(`SELECT id, standing_order_id, occurrence_at, attempt, status, failure_reason, retry_at, executed_at
+		FROM standing_order_runs WHERE standing_order_id = ? ORDER BY id DESC`, standingOrderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	runs := []models.StandingOrderRun{}
+	for rows.Next() {
+		var run models.StandingOrderRun
+		var retryAt sql.NullTime
+		if err := rows.Scan(&run.ID, &run.StandingOrderID, &run.OccurrenceAt, &run.Attempt, &run.Status, &run.FailureReason, &retryAt, &run.ExecutedAt); err != nil {
+			return nil, err
+		}
+		if retryAt.Valid {
+			run.RetryAt = &retryAt.Time
+		}
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
+}
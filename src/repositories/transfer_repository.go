@@ -3,44 +3,79 @@ package repositories
 import (
 	"banking/src/models"
 	"database/sql"
+	"errors"
 )
 
+// ErrTransferNotFound é retornado quando não existe transferência com o ID informado
+var ErrTransferNotFound = errors.New("transfer not found")
+
 // TransferRepository define a interface para operações de transferência
 type TransferRepository interface {
 	CreateTransfer(transfer *models.Transfer) error
-	GetTransfersByAccountNum(accountNum string) ([]models.Transfer, error)
+	GetTransferByID(id int) (*models.Transfer, error)
+	GetReversedAmount(transferID int, currency string) (models.Money, error)
+	HasFunding(accountNum string) (bool, error)
 }
 
 type TransferRepositoryImpl struct {
-	db *sql.DB
+	db DBTX
 }
 
 func NewTransferRepository(db *sql.DB) *TransferRepositoryImpl {
 	return &TransferRepositoryImpl{db: db}
 }
 
-// Implementação do método CreateTransfer
+// Implementação do método CreateTransfer: preenche o ID e a data de criação
+// gerados pelo banco
 func (repo *TransferRepositoryImpl) CreateTransfer(transfer *models.Transfer) error {
-	_, err := repo.db.Exec("INSERT INTO transfers (from_account_num, to_account_num, amount, status) VALUES (?, ?, ?, ?)",
-		transfer.FromAccountNum, transfer.ToAccountNum, transfer.Amount, transfer.Status)
-	return err
+	if transfer.Type == "" {
+		transfer.Type = models.TransferTypeTransfer
+	}
+	return repo.db.QueryRow("INSERT INTO transfers (from_account_num, to_account_num, amount, currency, type, status, failure_reason, reversal_of, fee_of) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id, created_at",
+		transfer.FromAccountNum, transfer.ToAccountNum, transfer.Amount.Cents, transfer.Amount.Currency, transfer.Type, transfer.Status, transfer.FailureReason, transfer.ReversalOf, transfer.FeeOf).
+		Scan(&transfer.ID, &transfer.CreatedAt)
 }
 
-// Implementação do método GetTransfersByAccountNum
-func (repo *TransferRepositoryImpl) GetTransfersByAccountNum(accountNum string) ([]models.Transfer, error) {
-	rows, err := repo.db.Query("SELECT from_account_num, to_account_num, amount, status FROM transfers WHERE from_account_num = ? OR to_account_num = ? ORDER BY created_at DESC", accountNum, accountNum)
-	if err != nil {
+// Implementação do método GetTransferByID
+func (repo *TransferRepositoryImpl) GetTransferByID(id int) (*models.Transfer, error) {
+	var transfer models.Transfer
+	var reversalOf, feeOf sql.NullInt64
+	err := repo.db.QueryRow("SELECT id, from_account_num, to_account_num, amount, currency, type, status, failure_reason, reversal_of, fee_of, created_at FROM transfers WHERE id = ?", id).
+		Scan(&transfer.ID, &transfer.FromAccountNum, &transfer.ToAccountNum, &transfer.Amount.Cents, &transfer.Amount.Currency,
+			&transfer.Type, &transfer.Status, &transfer.FailureReason, &reversalOf, &feeOf, &transfer.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrTransferNotFound
+	} else if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-
-	var transfers []models.Transfer
-	for rows.Next() {
-		var transfer models.Transfer
-		if err := rows.Scan(&transfer.FromAccountNum, &transfer.ToAccountNum, &transfer.Amount, &transfer.Status); err != nil {
-			return nil, err
-		}
-		transfers = append(transfers, transfer)
+	if reversalOf.Valid {
+		original := int(reversalOf.Int64)
+		transfer.ReversalOf = &original
+	}
+	if feeOf.Valid {
+		charged := int(feeOf.Int64)
+		transfer.FeeOf = &charged
+	}
+	return &transfer, nil
+}
+
+// Implementação do método GetReversedAmount: soma dos estornos bem-sucedidos
+// da transferência
+func (repo *TransferRepositoryImpl) GetReversedAmount(transferID int, currency string) (models.Money, error) {
+	var cents int64
+	err := repo.db.QueryRow("SELECT COALESCE(SUM(amount), 0) FROM transfers WHERE reversal_of = ? AND status = ? AND currency = ?",
+		transferID, models.TransferStatusSuccess, currency).Scan(&cents)
+	if err != nil {
+		return models.Money{}, err
 	}
-	return transfers, nil
+	return models.NewMoney(cents, currency), nil
+}
+
+// Implementação do método HasFunding: indica se a conta já recebeu um
+// financiamento bem-sucedido da tesouraria
+func (repo *TransferRepositoryImpl) HasFunding(accountNum string) (bool, error) {
+	var funded bool
+	err := repo.db.QueryRow("SELECT EXISTS (SELECT 1 FROM transfers WHERE to_account_num = ? AND type = ? AND status = ?)",
+		accountNum, models.TransferTypeFunding, models.TransferStatusSuccess).Scan(&funded)
+	return funded, err
 }
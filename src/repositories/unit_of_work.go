@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"database/sql"
+)
+
+// DBTX é satisfeito por *sql.DB e *sql.Tx, permitindo que os repositórios
+// executem dentro ou fora de uma transação
+type DBTX interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// Repositories agrupa os repositórios que participam de uma unidade de trabalho
+type Repositories struct {
+	Clients            ClientRepository
+	Transfers          TransferRepository
+	Ledger             LedgerRepository
+	Limits             LimitRepository
+	Overdraft          OverdraftRepository
+	Notifications      NotificationRepository
+	Holds              HoldRepository
+	Interest           InterestRepository
+	Fees               FeeRepository
+	Statuses           AccountStatusRepository
+	Customers          CustomerRepository
+	AccountNumbers     AccountNumberRepository
+	ScheduledTransfers ScheduledTransferRepository
+	StandingOrders     StandingOrderRepository
+}
+
+// UnitOfWork executa operações de vários repositórios de forma atômica
+type UnitOfWork interface {
+	// Do executa fn dentro de uma transação. Se fn retornar erro (ou entrar em
+	// pânico) todas as alterações são desfeitas; caso contrário são confirmadas.
+	Do(fn func(repos Repositories) error) error
+}
+
+// SQLUnitOfWork é a implementação de UnitOfWork baseada em sql.Tx
+type SQLUnitOfWork struct {
+	db *sql.DB
+}
+
+// NewUnitOfWork cria uma nova instância de SQLUnitOfWork
+func NewUnitOfWork(db *sql.DB) *SQLUnitOfWork {
+	return &SQLUnitOfWork{db: db}
+}
+
+// Implementação do método Do
+func (uow *SQLUnitOfWork) Do(fn func(repos Repositories) error) error {
+	tx, err := uow.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	repos := Repositories{
+		Clients:            &ClientRepositoryImpl{db: tx},
+		Transfers:          &TransferRepositoryImpl{db: tx},
+		Ledger:             &LedgerRepositoryImpl{db: tx},
+		Limits:             &LimitRepositoryImpl{db: tx},
+		Overdraft:          &OverdraftRepositoryImpl{db: tx},
+		Notifications:      &NotificationRepositoryImpl{db: tx},
+		Holds:              &HoldRepositoryImpl{db: tx},
+		Interest:           &InterestRepositoryImpl{db: tx},
+		Fees:               &FeeRepositoryImpl{db: tx},
+		Statuses:           &AccountStatusRepositoryImpl{db: tx},
+		Customers:          &CustomerRepositoryImpl{db: tx},
+		AccountNumbers:     &AccountNumberRepositoryImpl{db: tx},
+		ScheduledTransfers: &ScheduledTransferRepositoryImpl{db: tx},
+		StandingOrders:     &StandingOrderRepositoryImpl{db: tx},
+	}
+	if err := fn(repos); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,139 @@
+// src/services/account_closure_service.go
+package services
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrAccountHasPendingItems é retornado ao encerrar uma conta com reservas
+	// ativas, transferências agendadas pendentes ou ordens permanentes vigentes
+	ErrAccountHasPendingItems = errors.New("account has pending holds, scheduled transfers or standing orders")
+	// ErrSweepAccountRequired é retornado ao encerrar uma conta com saldo sem
+	// informar a conta que deve recebê-lo
+	ErrSweepAccountRequired = errors.New("to_account is required to close an account with balance")
+)
+
+// AccountClosureServiceInterface define o encerramento de contas
+type AccountClosureServiceInterface interface {
+	CloseAccount(accountNum, toAccountNum, reason string) (*models.AccountClosure, error)
+}
+
+// AccountClosureService é a implementação concreta do AccountClosureServiceInterface
+type AccountClosureService struct {
+	clientRepo      repositories.ClientRepository
+	uow             repositories.UnitOfWork
+	transferService TransferServiceInterface
+}
+
+// Certifique-se de que AccountClosureService implementa AccountClosureServiceInterface
+var _ AccountClosureServiceInterface = (*AccountClosureService)(nil)
+
+// NewAccountClosureService cria uma nova instância de AccountClosureService
+func NewAccountClosureService(clientRepo repositories.ClientRepository, uow repositories.UnitOfWork, transferService TransferServiceInterface) *AccountClosureService {
+	return &AccountClosureService{
+		clientRepo:      clientRepo,
+		uow:             uow,
+		transferService: transferService,
+	}
+}
+
+// CloseAccount encerra a conta accountNum. A conta precisa estar ativa, sem
+// saldo negativo, sem reservas ativas e sem transferências agendadas ou ordens
+// permanentes em aberto, de ou para ela. O saldo restante é transferido para
+// toAccountNum sem tarifa e sem os limites da conta, e a conta é encerrada,
+// com reason (ou DefaultClosureReason) no histórico de status, na mesma
+// transação da transferência, depois de verificar de novo as pendências. Se
+// algo mudar na conta antes dessa transação, nada é movimentado e o
+// encerramento pode ser repetido.
+func (s *AccountClosureService) CloseAccount(accountNum, toAccountNum, reason string) (*models.AccountClosure, error) {
+	if strings.TrimSpace(reason) == "" {
+		reason = models.DefaultClosureReason
+	}
+
+	client, err := s.clientRepo.GetClientByAccountNum(accountNum)
+	if err != nil {
+		return nil, err
+	}
+	if err := models.CheckStatusTransition(client.Status, models.AccountStatusClosed); err != nil {
+		return nil, err
+	}
+	if client.Balance.IsNegative() {
+		return nil, fmt.Errorf("%w: balance %s", ErrAccountNotEmpty, client.Balance)
+	}
+	// As pendências são verificadas antes para que uma conta com pendências
+	// não deixe uma transferência recusada no histórico
+	if err := s.uow.Do(func(repos repositories.Repositories) error {
+		return checkPendingItems(repos, accountNum)
+	}); err != nil {
+		return nil, err
+	}
+
+	closure := &models.AccountClosure{}
+	closeAccount := func(repos repositories.Repositories) error {
+		if err := checkPendingItems(repos, accountNum); err != nil {
+			return err
+		}
+		var err error
+		closure.Account, err = changeAccountStatus(repos, accountNum, models.AccountStatusClosed, reason)
+		return err
+	}
+
+	if !client.Balance.IsPositive() {
+		if err := s.uow.Do(closeAccount); err != nil {
+			return nil, err
+		}
+		return closure, nil
+	}
+
+	if toAccountNum == "" {
+		return nil, ErrSweepAccountRequired
+	}
+	closure.Sweep, err = s.transferService.TransferFundsWith(accountNum, toAccountNum, client.Balance, TransferOptions{
+		Exempt: true,
+		Then: func(repos repositories.Repositories, transfer *models.Transfer) error {
+			return closeAccount(repos)
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return closure, nil
+}
+
+// checkPendingItems recusa o encerramento enquanto houver reservas ativas da
+// conta ou agendamentos e ordens permanentes que ainda possam movimentá-la
+func checkPendingItems(repos repositories.Repositories, accountNum string) error {
+	holds, err := repos.Holds.GetHoldsByAccountNum(accountNum, models.HoldStatusActive)
+	if err != nil {
+		return err
+	}
+
+	scheduled := 0
+	for _, status := range []string{models.ScheduledStatusPending, models.ScheduledStatusProcessing} {
+		transfers, err := repos.ScheduledTransfers.GetScheduledTransfersByAccountNum(accountNum, status)
+		if err != nil {
+			return err
+		}
+		scheduled += len(transfers)
+	}
+
+	orders := 0
+	for _, status := range []string{models.StandingOrderActive, models.StandingOrderPaused} {
+		standingOrders, err := repos.StandingOrders.GetStandingOrdersByAccountNum(accountNum, status)
+		if err != nil {
+			return err
+		}
+		orders += len(standingOrders)
+	}
+
+	if len(holds) > 0 || scheduled > 0 || orders > 0 {
+		return fmt.Errorf("%w: %d holds, %d scheduled transfers, %d standing orders",
+			ErrAccountHasPendingItems, len(holds), scheduled, orders)
+	}
+	return nil
+}
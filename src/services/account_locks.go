@@ -0,0 +1,79 @@
+// src/services/account_locks.go
+package services
+
+import (
+	"sort"
+	"sync"
+)
+
+// AccountLocks fornece um mutex por número de conta. Transferências entre
+// contas distintas não esperam umas pelas outras; os mutexes de uma operação
+// são sempre adquiridos em ordem crescente de número de conta, o que evita
+// deadlocks entre transferências A→B e B→A simultâneas.
+type AccountLocks struct {
+	mu    sync.Mutex
+	locks map[string]*accountLock
+}
+
+// accountLock conta quantas operações estão usando ou esperando o mutex, para
+// que ele seja removido do mapa quando ninguém mais precisar dele
+type accountLock struct {
+	sync.Mutex
+	refs int
+}
+
+// NewAccountLocks cria uma nova instância de AccountLocks
+func NewAccountLocks() *AccountLocks {
+	return &AccountLocks{locks: make(map[string]*accountLock)}
+}
+
+// Lock bloqueia as contas informadas e retorna a função que as libera.
+// Números repetidos são bloqueados uma única vez.
+func (l *AccountLocks) Lock(accountNums ...string) (unlock func()) {
+	keys := sortedUnique(accountNums)
+
+	l.mu.Lock()
+	held := make([]*accountLock, len(keys))
+	for i, key := range keys {
+		lock, ok := l.locks[key]
+		if !ok {
+			lock = &accountLock{}
+			l.locks[key] = lock
+		}
+		lock.refs++
+		held[i] = lock
+	}
+	l.mu.Unlock()
+
+	for _, lock := range held {
+		lock.Lock()
+	}
+
+	return func() {
+		for i := len(held) - 1; i >= 0; i-- {
+			held[i].Unlock()
+		}
+
+		l.mu.Lock()
+		for i, key := range keys {
+			held[i].refs--
+			if held[i].refs == 0 {
+				delete(l.locks, key)
+			}
+		}
+		l.mu.Unlock()
+	}
+}
+
+func sortedUnique(values []string) []string {
+	unique := make([]string, 0, len(values))
+	seen := make(map[string]bool, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	sort.Strings(unique)
+	return unique
+}
@@ -0,0 +1,42 @@
+// src/services/account_numbers.go
+package services
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrAccountNumbersExhausted é retornado quando a agência já usou todos os
+// números de conta de 8 dígitos
+var ErrAccountNumbersExhausted = errors.New("no account numbers left in branch")
+
+// maxAccountSequence é o maior número de conta que cabe em AccountDigits
+var maxAccountSequence = int64(math.Pow10(models.AccountDigits)) - 1
+
+// allocateAccountNumber gera o próximo número de conta da agência de format,
+// dentro da unidade de trabalho de quem abre a conta. A sequência é
+// incrementada no banco, então pedidos concorrentes, mesmo de outras
+// instâncias da aplicação, recebem números diferentes; números que já existam
+// (informados antes da geração pelo servidor) são pulados.
+func allocateAccountNumber(repos repositories.Repositories, format models.AccountNumberFormat) (string, error) {
+	for {
+		sequence, err := repos.AccountNumbers.NextAccountNumber(format.Branch)
+		if err != nil {
+			return "", err
+		}
+		if sequence > maxAccountSequence {
+			return "", fmt.Errorf("%w %s", ErrAccountNumbersExhausted, format.Branch)
+		}
+
+		accountNum := format.Format(sequence)
+		_, err = repos.Clients.GetClientByAccountNum(accountNum)
+		if errors.Is(err, repositories.ErrClientNotFound) {
+			return accountNum, nil
+		} else if err != nil {
+			return "", err
+		}
+	}
+}
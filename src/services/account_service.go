@@ -0,0 +1,178 @@
+// src/services/account_service.go
+package services
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"errors"
+)
+
+var errNonPositiveAmount = errors.New("amount must be greater than zero")
+
+// ErrAccountAlreadyFunded é retornado ao financiar uma conta que já recebeu o
+// saldo inicial da tesouraria
+var ErrAccountAlreadyFunded = errors.New("account has already been funded")
+
+// AccountServiceInterface define as operações sobre uma conta
+type AccountServiceInterface interface {
+	Deposit(accountNum string, amount models.Money) (*models.Transfer, error)
+	Withdraw(accountNum string, amount models.Money) (*models.Transfer, error)
+	Fund(accountNum string, amount models.Money) (*models.Transfer, error)
+}
+
+// AccountService é a implementação concreta do AccountServiceInterface
+type AccountService struct {
+	transferRepo repositories.TransferRepository
+	uow          repositories.UnitOfWork
+	accountLocks *AccountLocks
+}
+
+// Certifique-se de que AccountService implementa AccountServiceInterface
+var _ AccountServiceInterface = (*AccountService)(nil)
+
+// NewAccountService cria uma nova instância de AccountService. accountLocks
+// deve ser o mesmo usado pelo TransferService, para que depósitos e saques
+// esperem as transferências em andamento nas mesmas contas.
+func NewAccountService(transferRepo repositories.TransferRepository, uow repositories.UnitOfWork, accountLocks *AccountLocks) *AccountService {
+	return &AccountService{
+		transferRepo: transferRepo,
+		uow:          uow,
+		accountLocks: accountLocks,
+	}
+}
+
+// Deposit credita amount na conta. O dinheiro entra pela conta interna
+// models.CashAccountNum e o depósito aparece no histórico da conta.
+func (s *AccountService) Deposit(accountNum string, amount models.Money) (*models.Transfer, error) {
+	return s.move(models.Transfer{
+		FromAccountNum: models.CashAccountNum,
+		ToAccountNum:   accountNum,
+		Amount:         amount,
+		Type:           models.TransferTypeDeposit,
+	}, accountNum)
+}
+
+// Withdraw debita amount da conta, que não pode ficar negativa. O dinheiro sai
+// para a conta interna models.CashAccountNum.
+func (s *AccountService) Withdraw(accountNum string, amount models.Money) (*models.Transfer, error) {
+	return s.move(models.Transfer{
+		FromAccountNum: accountNum,
+		ToAccountNum:   models.CashAccountNum,
+		Amount:         amount,
+		Type:           models.TransferTypeWithdrawal,
+	}, accountNum)
+}
+
+// Fund financia a conta com dinheiro da tesouraria (models.TreasuryAccountNum).
+// É a única forma de colocar saldo em uma conta nova sem um depósito externo,
+// e por isso só é permitido uma vez por conta: o saldo inicial.
+func (s *AccountService) Fund(accountNum string, amount models.Money) (*models.Transfer, error) {
+	return s.move(models.Transfer{
+		FromAccountNum: models.TreasuryAccountNum,
+		ToAccountNum:   accountNum,
+		Amount:         amount,
+		Type:           models.TransferTypeFunding,
+	}, accountNum)
+}
+
+// movementEntries é a descrição do lançamento de cada tipo de movimentação
+var movementEntries = map[string]string{
+	models.TransferTypeDeposit:    models.EntryDeposit,
+	models.TransferTypeWithdrawal: models.EntryWithdrawal,
+	models.TransferTypeFunding:    models.EntryFunding,
+}
+
+// move executa um depósito, saque ou financiamento na conta do cliente
+// accountNum, creditando-a quando ela é o destino e debitando-a quando é a
+// origem. Assim como
+// nas transferências, tentativas recusadas são registradas com status "failed".
+func (s *AccountService) move(movement models.Transfer, accountNum string) (*models.Transfer, error) {
+	if movement.Amount.Currency == "" {
+		movement.Amount.Currency = models.DefaultCurrency
+	}
+
+	err := s.applyMovement(&movement, accountNum)
+	if err != nil {
+		recordFailedTransfer(s.transferRepo, movement, err)
+		return nil, err
+	}
+	return &movement, nil
+}
+
+func (s *AccountService) applyMovement(movement *models.Transfer, accountNum string) error {
+	amount := movement.Amount
+	if !amount.IsPositive() {
+		return declineTransfer(models.FailureInvalidAmount, errNonPositiveAmount)
+	}
+
+	isCredit := movement.ToAccountNum == accountNum
+	notFoundReason := models.FailureSourceNotFound
+	if isCredit {
+		notFoundReason = models.FailureDestinationNotFound
+	}
+
+	unlock := s.accountLocks.Lock(accountNum)
+	defer unlock()
+
+	return s.uow.Do(func(repos repositories.Repositories) error {
+		client, err := repos.Clients.GetClientByAccountNum(accountNum)
+		if errors.Is(err, repositories.ErrClientNotFound) {
+			return declineTransfer(notFoundReason, err)
+		}
+		if err != nil {
+			return err
+		}
+
+		if !client.Balance.SameCurrency(amount) {
+			return declineTransfer(models.FailureCurrencyMismatch, models.ErrCurrencyMismatch)
+		}
+		checkStatus := checkDebitAllowed
+		if isCredit {
+			checkStatus = checkCreditAllowed
+		}
+		if err := checkStatus(client); err != nil {
+			return err
+		}
+		if movement.Type == models.TransferTypeFunding {
+			funded, err := repos.Transfers.HasFunding(accountNum)
+			if err != nil {
+				return err
+			}
+			if funded {
+				return declineTransfer(models.FailureAlreadyFunded, ErrAccountAlreadyFunded)
+			}
+		}
+
+		delta := amount
+		if isCredit {
+			client.Balance, err = repos.Clients.CreditClientBalance(accountNum, amount)
+		} else {
+			delta = amount.Neg()
+			client.Balance, err = repos.Clients.DebitClientBalance(accountNum, amount)
+		}
+		if errors.Is(err, repositories.ErrInsufficientBalance) {
+			return declineTransfer(models.FailureInsufficientBalance, err)
+		}
+		if err != nil {
+			return err
+		}
+		if err := notifyOverdraftChange(repos.Notifications, accountNum, client.Balance, delta); err != nil {
+			return err
+		}
+
+		movement.Status = models.TransferStatusSuccess
+		err = repos.Transfers.CreateTransfer(movement)
+		if err != nil {
+			return err
+		}
+
+		entry := models.NewTransferEntry(movementEntries[movement.Type], movement.FromAccountNum, movement.ToAccountNum, amount)
+		entry.TransferID = &movement.ID
+		err = repos.Ledger.CreateEntry(&entry)
+		if err != nil {
+			return err
+		}
+
+		return verifyLedgerBalances(repos.Ledger, client)
+	})
+}
@@ -0,0 +1,82 @@
+// src/services/account_status_service.go
+package services
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"errors"
+	"fmt"
+)
+
+// ErrAccountNotEmpty é retornado ao encerrar uma conta com saldo ou reservas
+var ErrAccountNotEmpty = errors.New("account still has balance or held funds")
+
+// AccountStatusServiceInterface define a administração do status das contas
+type AccountStatusServiceInterface interface {
+	ChangeStatus(accountNum, status, reason string) (*models.Client, error)
+	GetStatusHistory(accountNum string) ([]models.AccountStatusChange, error)
+}
+
+// AccountStatusService é a implementação concreta do AccountStatusServiceInterface
+type AccountStatusService struct {
+	clientRepo repositories.ClientRepository
+	statusRepo repositories.AccountStatusRepository
+	uow        repositories.UnitOfWork
+}
+
+// Certifique-se de que AccountStatusService implementa AccountStatusServiceInterface
+var _ AccountStatusServiceInterface = (*AccountStatusService)(nil)
+
+// NewAccountStatusService cria uma nova instância de AccountStatusService
+func NewAccountStatusService(clientRepo repositories.ClientRepository, statusRepo repositories.AccountStatusRepository, uow repositories.UnitOfWork) *AccountStatusService {
+	return &AccountStatusService{clientRepo: clientRepo, statusRepo: statusRepo, uow: uow}
+}
+
+// ChangeStatus muda o status da conta, se a transição for permitida, e
+// registra a mudança com o motivo no histórico. Só contas sem saldo e sem
+// reservas podem ser encerradas.
+func (s *AccountStatusService) ChangeStatus(accountNum, status, reason string) (*models.Client, error) {
+	var client *models.Client
+	err := s.uow.Do(func(repos repositories.Repositories) error {
+		var err error
+		client, err = changeAccountStatus(repos, accountNum, status, reason)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// changeAccountStatus faz a mudança de status de ChangeStatus dentro de uma
+// unidade de trabalho já aberta
+func changeAccountStatus(repos repositories.Repositories, accountNum, status, reason string) (*models.Client, error) {
+	client, err := repos.Clients.GetClientByAccountNum(accountNum)
+	if err != nil {
+		return nil, err
+	}
+
+	change, err := models.NewAccountStatusChange(accountNum, client.Status, status, reason)
+	if err != nil {
+		return nil, err
+	}
+	if status == models.AccountStatusClosed && (!client.Balance.IsZero() || !client.Held.IsZero()) {
+		return nil, fmt.Errorf("%w: balance %s, held %s", ErrAccountNotEmpty, client.Balance, client.Held)
+	}
+
+	if client, err = repos.Clients.UpdateClientStatus(accountNum, status); err != nil {
+		return nil, err
+	}
+	if err := repos.Statuses.CreateStatusChange(change); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// GetStatusHistory lista as mudanças de status da conta
+func (s *AccountStatusService) GetStatusHistory(accountNum string) ([]models.AccountStatusChange, error) {
+	if _, err := s.clientRepo.GetClientByAccountNum(accountNum); err != nil {
+		return nil, err
+	}
+	return s.statusRepo.GetStatusHistory(accountNum)
+}
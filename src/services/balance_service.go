@@ -0,0 +1,122 @@
+// src/services/balance_service.go
+package services
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"sync"
+	"time"
+)
+
+// DefaultBalanceHistoryDays é o período padrão, em dias, dos saldos diários
+const DefaultBalanceHistoryDays = 30
+
+// BalanceServiceInterface define a consulta dos saldos históricos das contas
+type BalanceServiceInterface interface {
+	GetBalance(accountNum string, asOf time.Time) (*models.AccountBalance, error)
+	GetDailyBalances(accountNum string, from, to time.Time) ([]models.BalanceSnapshot, error)
+}
+
+// BalanceService é a implementação concreta do BalanceServiceInterface
+type BalanceService struct {
+	clientRepo   repositories.ClientRepository
+	ledgerRepo   repositories.LedgerRepository
+	snapshotRepo repositories.BalanceSnapshotRepository
+
+	mu      sync.Mutex
+	lastRun string // último dia processado por SnapshotDue nesta instância
+}
+
+// Certifique-se de que BalanceService implementa BalanceServiceInterface
+var _ BalanceServiceInterface = (*BalanceService)(nil)
+
+// NewBalanceService cria uma nova instância de BalanceService
+func NewBalanceService(clientRepo repositories.ClientRepository, ledgerRepo repositories.LedgerRepository, snapshotRepo repositories.BalanceSnapshotRepository) *BalanceService {
+	return &BalanceService{clientRepo: clientRepo, ledgerRepo: ledgerRepo, snapshotRepo: snapshotRepo}
+}
+
+// GetBalance retorna o saldo da conta, pelo livro-razão e na moeda da conta,
+// considerando só o que foi lançado antes de asOf. Sem asOf, retorna o saldo
+// atual.
+func (s *BalanceService) GetBalance(accountNum string, asOf time.Time) (*models.AccountBalance, error) {
+	client, err := s.clientRepo.GetClientByAccountNum(accountNum)
+	if err != nil {
+		return nil, err
+	}
+	if asOf.IsZero() {
+		// O livro-razão registra os instantes com precisão de segundos, então o
+		// saldo atual inclui o segundo corrente
+		asOf = time.Now().Truncate(time.Second).Add(time.Second)
+	}
+
+	balance, err := s.ledgerRepo.GetBalanceAt(accountNum, client.Balance.Currency, asOf)
+	if err != nil {
+		return nil, err
+	}
+	return &models.AccountBalance{AccountNum: accountNum, AsOf: asOf, Balance: balance}, nil
+}
+
+// GetDailyBalances lista os saldos de fim de dia guardados da conta entre os
+// dias de from e to (inclusive, em models.BusinessLocation). Sem to, vai até
+// ontem; sem from, começa DefaultBalanceHistoryDays dias antes de to.
+func (s *BalanceService) GetDailyBalances(accountNum string, from, to time.Time) ([]models.BalanceSnapshot, error) {
+	if _, err := s.clientRepo.GetClientByAccountNum(accountNum); err != nil {
+		return nil, err
+	}
+	if to.IsZero() {
+		to = models.StartOfBusinessDay(time.Now()).AddDate(0, 0, -1)
+	}
+	if from.IsZero() {
+		from = models.StartOfBusinessDay(to).AddDate(0, 0, 1-DefaultBalanceHistoryDays)
+	}
+	if to.Before(from) {
+		return nil, models.ErrInvalidPeriod
+	}
+	return s.snapshotRepo.GetSnapshots(accountNum,
+		models.StartOfBusinessDay(from).Format(time.DateOnly), models.StartOfBusinessDay(to).Format(time.DateOnly))
+}
+
+// SnapshotDue guarda os saldos de fim de dia que faltam até o dia anterior a
+// now: os dias depois do último já guardado ou, se nenhum foi guardado, só o
+// dia anterior. É chamado periodicamente pelo executor em segundo plano e faz
+// o trabalho no máximo uma vez por dia nesta instância; as gravações são
+// idempotentes, então várias instâncias podem executá-lo ao mesmo tempo.
+func (s *BalanceService) SnapshotDue(now time.Time) error {
+	today := models.StartOfBusinessDay(now)
+	date := today.Format(time.DateOnly)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.lastRun == date {
+		return nil
+	}
+
+	latest, err := s.snapshotRepo.GetLatestSnapshotDate()
+	if err != nil {
+		return err
+	}
+	day, err := firstDueDay(latest, today)
+	if err != nil {
+		return err
+	}
+	for ; day.Before(today); day = day.AddDate(0, 0, 1) {
+		if _, err := s.SnapshotBalances(day, now); err != nil {
+			return err
+		}
+	}
+	s.lastRun = date
+	return nil
+}
+
+// SnapshotBalances guarda o saldo de cada conta no fim do dia day. Cada conta
+// tem no máximo um saldo por dia, então executar de novo o mesmo dia não muda
+// nada. Dias que ainda não terminaram são recusados. Retorna o número de
+// saldos guardados nesta execução.
+func (s *BalanceService) SnapshotBalances(day, now time.Time) (int, error) {
+	day = models.StartOfBusinessDay(day)
+	end := day.AddDate(0, 0, 1)
+	if end.After(now) {
+		return 0, ErrPeriodNotEnded
+	}
+	return s.snapshotRepo.CreateSnapshots(day.Format(time.DateOnly), end)
+}
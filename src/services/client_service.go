@@ -12,27 +12,93 @@ type ClientServiceInterface interface {
 	CreateClient(client *models.Client) error
 	GetClients() ([]models.Client, error)
 	GetClientByAccountNum(accountNum string) (*models.Client, error)
+	UpdateClient(accountNum, name string, expectedVersion int) (*models.Client, error)
 }
 
 // ClientService é a implementação concreta que atende a ClientServiceInterface
 type ClientService struct {
-	repo repositories.ClientRepository // Interface do repositório de cliente
+	repo           repositories.ClientRepository // Interface do repositório de cliente
+	uow            repositories.UnitOfWork
+	accountNumbers models.AccountNumberFormat // Formato dos números de conta gerados
 }
 
 // Certifique-se de que ClientService implementa ClientServiceInterface
 var _ ClientServiceInterface = (*ClientService)(nil)
 
 // NewClientService cria uma nova instância de ClientService
-func NewClientService(repo repositories.ClientRepository) *ClientService {
-	return &ClientService{repo: repo}
+func NewClientService(repo repositories.ClientRepository, uow repositories.UnitOfWork, accountNumbers models.AccountNumberFormat) *ClientService {
+	return &ClientService{repo: repo, uow: uow, accountNumbers: accountNumbers}
 }
 
-// CreateClient cria um novo cliente, verificando os campos necessários
+// ErrInitialBalanceNotAllowed é retornado quando um cliente é criado com saldo:
+// contas novas começam zeradas e são financiadas pela tesouraria
+var ErrInitialBalanceNotAllowed = errors.New("new clients start with a zero balance; fund the account through the treasury")
+
+// CreateClient cria um novo cliente (Customer) com uma conta, verificando os
+// campos necessários. Para abrir outra conta para um cliente existente, use
+// CustomerService.OpenAccount; client.CustomerID é ignorado. Sem AccountNum, o
+// número da conta é gerado no formato agência-conta-DV.
 func (s *ClientService) CreateClient(client *models.Client) error {
-	if client.Name == "" || client.AccountNum == "" {
+	if client.Name == "" {
 		return errors.New("missing required fields")
 	}
-	return s.repo.CreateClient(client)
+	customer, err := models.NewCustomer(client.Name)
+	if err != nil {
+		return err
+	}
+	if err := prepareNewAccount(client, s.accountNumbers); err != nil {
+		return err
+	}
+
+	return s.uow.Do(func(repos repositories.Repositories) error {
+		if err := repos.Customers.CreateCustomer(customer); err != nil {
+			return err
+		}
+		client.CustomerID = customer.ID
+		client.Name = customer.Name
+		return createAccount(repos, client, s.accountNumbers)
+	})
+}
+
+// prepareNewAccount valida e completa uma conta nova. A conta começa com
+// saldo zero na moeda informada (BRL por padrão) e é conta corrente, salvo se
+// account_type pedir outro tipo. Toda conta nova é ativa. Um número de conta
+// informado, o que só chamadas internas fazem, precisa ser legado ou ter o DV
+// correto pelo algoritmo configurado.
+func prepareNewAccount(account *models.Account, format models.AccountNumberFormat) error {
+	if account.AccountNum != "" && !models.IsLegacyAccountNumber(account.AccountNum) {
+		if err := format.Validate(account.AccountNum); err != nil {
+			return err
+		}
+	}
+	if !account.Balance.IsZero() {
+		return ErrInitialBalanceNotAllowed
+	}
+	currency, err := models.NormalizeCurrency(account.Balance.Currency)
+	if err != nil {
+		return err
+	}
+	account.Balance.Currency = currency
+	if account.AccountType == "" {
+		account.AccountType = models.AccountTypeChecking
+	}
+	if !models.IsValidAccountType(account.AccountType) {
+		return models.ErrInvalidAccountType
+	}
+	account.Status = models.AccountStatusActive
+	return nil
+}
+
+// createAccount grava a conta, gerando o seu número se ela ainda não tiver um
+func createAccount(repos repositories.Repositories, account *models.Account, format models.AccountNumberFormat) error {
+	if account.AccountNum == "" {
+		accountNum, err := allocateAccountNumber(repos, format)
+		if err != nil {
+			return err
+		}
+		account.AccountNum = accountNum
+	}
+	return repos.Clients.CreateClient(account)
 }
 
 // GetClients retorna todos os clientes
@@ -43,3 +109,37 @@ func (s *ClientService) GetClients() ([]models.Client, error) {
 func (s *ClientService) GetClientByAccountNum(accountNum string) (*models.Client, error) {
 	return s.repo.GetClientByAccountNum(accountNum)
 }
+
+// UpdateClient altera o nome do titular da conta, que é repetido nas outras
+// contas do mesmo cliente. Se expectedVersion for diferente de
+// zero, a alteração só é feita se o cliente ainda estiver nessa versão; caso
+// contrário retorna *repositories.VersionConflictError. Alterações concorrentes
+// entre a leitura e a escrita também resultam em conflito.
+func (s *ClientService) UpdateClient(accountNum, name string, expectedVersion int) (*models.Client, error) {
+	if name == "" {
+		return nil, errors.New("missing required fields")
+	}
+
+	client, err := s.repo.GetClientByAccountNum(accountNum)
+	if err != nil {
+		return nil, err
+	}
+	if expectedVersion != 0 && client.Version != expectedVersion {
+		return nil, &repositories.VersionConflictError{AccountNum: accountNum, ExpectedVersion: expectedVersion}
+	}
+
+	client.Name = name
+	err = s.uow.Do(func(repos repositories.Repositories) error {
+		if err := repos.Clients.UpdateClient(client); err != nil {
+			return err
+		}
+		if client.CustomerID == 0 {
+			return nil
+		}
+		return repos.Customers.UpdateCustomerName(client.CustomerID, name)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
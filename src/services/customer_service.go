@@ -0,0 +1,75 @@
+// src/services/customer_service.go
+package services
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+)
+
+// CustomerServiceInterface define as operações dos clientes e das suas contas
+type CustomerServiceInterface interface {
+	CreateCustomer(name string) (*models.Customer, error)
+	GetCustomer(id int) (*models.Customer, error)
+	GetAccounts(customerID int) ([]models.Account, error)
+	OpenAccount(customerID int, account *models.Account) error
+}
+
+// CustomerService é a implementação concreta do CustomerServiceInterface
+type CustomerService struct {
+	customerRepo   repositories.CustomerRepository
+	clientRepo     repositories.ClientRepository
+	uow            repositories.UnitOfWork
+	accountNumbers models.AccountNumberFormat
+}
+
+// Certifique-se de que CustomerService implementa CustomerServiceInterface
+var _ CustomerServiceInterface = (*CustomerService)(nil)
+
+// NewCustomerService cria uma nova instância de CustomerService
+func NewCustomerService(customerRepo repositories.CustomerRepository, clientRepo repositories.ClientRepository, uow repositories.UnitOfWork, accountNumbers models.AccountNumberFormat) *CustomerService {
+	return &CustomerService{customerRepo: customerRepo, clientRepo: clientRepo, uow: uow, accountNumbers: accountNumbers}
+}
+
+// CreateCustomer cria um cliente ainda sem contas
+func (s *CustomerService) CreateCustomer(name string) (*models.Customer, error) {
+	customer, err := models.NewCustomer(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.customerRepo.CreateCustomer(customer); err != nil {
+		return nil, err
+	}
+	return customer, nil
+}
+
+// GetCustomer retorna o cliente com o id informado
+func (s *CustomerService) GetCustomer(id int) (*models.Customer, error) {
+	return s.customerRepo.GetCustomerByID(id)
+}
+
+// GetAccounts retorna as contas do cliente, inclusive as encerradas
+func (s *CustomerService) GetAccounts(customerID int) ([]models.Account, error) {
+	if _, err := s.customerRepo.GetCustomerByID(customerID); err != nil {
+		return nil, err
+	}
+	return s.clientRepo.GetClientsByCustomer(customerID)
+}
+
+// OpenAccount abre mais uma conta para o cliente, com as mesmas regras das
+// contas criadas por ClientService.CreateClient. O nome do titular vem do
+// cliente e, sem AccountNum, o número da conta é gerado.
+func (s *CustomerService) OpenAccount(customerID int, account *models.Account) error {
+	if err := prepareNewAccount(account, s.accountNumbers); err != nil {
+		return err
+	}
+
+	return s.uow.Do(func(repos repositories.Repositories) error {
+		customer, err := repos.Customers.GetCustomerByID(customerID)
+		if err != nil {
+			return err
+		}
+		account.CustomerID = customer.ID
+		account.Name = customer.Name
+		return createAccount(repos, account, s.accountNumbers)
+	})
+}
@@ -0,0 +1,58 @@
+// src/services/fee_service.go
+package services
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidFeeSchedule envolve os erros de validação das tabelas de tarifas
+var ErrInvalidFeeSchedule = errors.New("invalid fee schedule")
+
+// FeeServiceInterface define a administração das tabelas de tarifas
+type FeeServiceInterface interface {
+	GetSchedules() ([]models.FeeSchedule, error)
+	SetSchedule(schedule models.FeeSchedule) (*models.FeeSchedule, error)
+}
+
+// FeeService é a implementação concreta do FeeServiceInterface
+type FeeService struct {
+	feeRepo repositories.FeeRepository
+	uow     repositories.UnitOfWork
+}
+
+// Certifique-se de que FeeService implementa FeeServiceInterface
+var _ FeeServiceInterface = (*FeeService)(nil)
+
+// NewFeeService cria uma nova instância de FeeService
+func NewFeeService(feeRepo repositories.FeeRepository, uow repositories.UnitOfWork) *FeeService {
+	return &FeeService{feeRepo: feeRepo, uow: uow}
+}
+
+// GetSchedules lista as tabelas de tarifas de cada tipo de conta
+func (s *FeeService) GetSchedules() ([]models.FeeSchedule, error) {
+	return s.feeRepo.GetSchedules()
+}
+
+// SetSchedule substitui a tabela de tarifas do tipo de conta da tabela. Valores
+// sem moeda assumem a moeda da tabela (BRL por padrão). A nova tabela vale a
+// partir da próxima transferência.
+func (s *FeeService) SetSchedule(schedule models.FeeSchedule) (*models.FeeSchedule, error) {
+	if !models.IsValidAccountType(schedule.AccountType) {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFeeSchedule, models.ErrInvalidAccountType)
+	}
+	schedule.Normalize()
+	if err := schedule.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFeeSchedule, err)
+	}
+
+	err := s.uow.Do(func(repos repositories.Repositories) error {
+		return repos.Fees.SaveSchedule(&schedule)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
@@ -0,0 +1,243 @@
+// src/services/hold_service.go
+package services
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"errors"
+	"log"
+	"time"
+)
+
+// ErrExpiresAtNotInFuture é retornado ao criar uma reserva que já nasceria vencida
+var ErrExpiresAtNotInFuture = errors.New("expires_at must be in the future")
+
+// holdExpiryBatchSize é o número máximo de reservas expiradas a cada ciclo
+const holdExpiryBatchSize = 100
+
+// HoldServiceInterface define os métodos do serviço de reservas de saldo
+type HoldServiceInterface interface {
+	PlaceHold(accountNum, toAccountNum string, amount models.Money, expiresAt time.Time) (*models.Hold, error)
+	GetHold(id int) (*models.Hold, error)
+	GetHolds(accountNum, status string) ([]models.Hold, error)
+	CaptureHold(id int, amount models.Money) (*models.Hold, *models.Transfer, error)
+	VoidHold(id int) (*models.Hold, error)
+}
+
+// HoldService é a implementação concreta do HoldServiceInterface
+type HoldService struct {
+	clientRepo      repositories.ClientRepository
+	holdRepo        repositories.HoldRepository
+	uow             repositories.UnitOfWork
+	transferService TransferServiceInterface
+}
+
+// Certifique-se de que HoldService implementa HoldServiceInterface
+var _ HoldServiceInterface = (*HoldService)(nil)
+
+// NewHoldService cria uma nova instância de HoldService. As capturas são
+// feitas por transferService.
+func NewHoldService(clientRepo repositories.ClientRepository, holdRepo repositories.HoldRepository, uow repositories.UnitOfWork, transferService TransferServiceInterface) *HoldService {
+	return &HoldService{clientRepo: clientRepo, holdRepo: holdRepo, uow: uow, transferService: transferService}
+}
+
+// PlaceHold reserva amount do saldo disponível de accountNum em favor de
+// toAccountNum até expiresAt (ou por models.DefaultHoldTTL, se expiresAt for
+// zero). As contas, a moeda e os limites de transferência da conta são
+// verificados agora, como em uma transferência; o valor não capturado de uma
+// reserva ativa conta no uso dos limites, então a captura não os verifica de
+// novo.
+func (s *HoldService) PlaceHold(accountNum, toAccountNum string, amount models.Money, expiresAt time.Time) (*models.Hold, error) {
+	if amount.Currency == "" {
+		amount.Currency = models.DefaultCurrency
+	}
+	now := time.Now()
+	if expiresAt.IsZero() {
+		expiresAt = now.Add(models.DefaultHoldTTL)
+	}
+	if !expiresAt.After(now) {
+		return nil, ErrExpiresAtNotInFuture
+	}
+	if err := checkTransferAmount(amount); err != nil {
+		return nil, err
+	}
+
+	hold := &models.Hold{
+		AccountNum:   accountNum,
+		ToAccountNum: toAccountNum,
+		Amount:       amount,
+		ExpiresAt:    expiresAt,
+	}
+	err := s.uow.Do(func(repos repositories.Repositories) error {
+		if err := checkTransferAccounts(repos.Clients, accountNum, toAccountNum, amount); err != nil {
+			return err
+		}
+		if err := checkTransferLimits(repos.Limits, accountNum, amount, now); err != nil {
+			return err
+		}
+
+		_, err := repos.Clients.HoldClientBalance(accountNum, amount)
+		if errors.Is(err, repositories.ErrInsufficientBalance) {
+			return declineTransfer(models.FailureInsufficientBalance, err)
+		}
+		if err != nil {
+			return err
+		}
+		return repos.Holds.CreateHold(hold)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return hold, nil
+}
+
+// GetHold retorna uma reserva pelo ID
+func (s *HoldService) GetHold(id int) (*models.Hold, error) {
+	return s.holdRepo.GetHoldByID(id)
+}
+
+// GetHolds lista as reservas de uma conta, opcionalmente filtradas por status
+func (s *HoldService) GetHolds(accountNum, status string) ([]models.Hold, error) {
+	if _, err := s.clientRepo.GetClientByAccountNum(accountNum); err != nil {
+		return nil, err
+	}
+	return s.holdRepo.GetHoldsByAccountNum(accountNum, status)
+}
+
+// CaptureHold transforma amount da reserva (ou todo o restante, se amount for
+// zero) em uma transferência comum para a conta de destino da reserva, pelo
+// TransferService: as contas são bloqueadas, a tarifa é cobrada e uma captura
+// recusada é registrada como transferência "failed". A liberação do valor
+// reservado, a transferência e a atualização da reserva são confirmadas ou
+// desfeitas juntas. Enquanto sobrar valor não capturado, a reserva continua
+// ativa.
+func (s *HoldService) CaptureHold(id int, amount models.Money) (*models.Hold, *models.Transfer, error) {
+	if amount.IsNegative() {
+		return nil, nil, declineTransfer(models.FailureInvalidAmount, errNonPositiveAmount)
+	}
+
+	// A reserva é lida antes para saber as contas e o valor da transferência,
+	// e relida dentro da transação, que é desfeita se ela tiver mudado
+	hold, err := activeHold(s.holdRepo, id, time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+	amount, err = captureAmount(hold, amount)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	transfer, err := s.transferService.TransferFundsWith(hold.AccountNum, hold.ToAccountNum, amount, TransferOptions{
+		FundsHeld: true,
+		Then: func(repos repositories.Repositories, transfer *models.Transfer) error {
+			current, err := activeHold(repos.Holds, id, time.Now())
+			if err != nil {
+				return err
+			}
+			if amount.GreaterThan(current.Remaining()) {
+				return models.ErrCaptureExceedsHold
+			}
+
+			current.CapturedAmount = current.CapturedAmount.Add(amount)
+			if !current.Remaining().IsPositive() {
+				current.Status = models.HoldStatusCaptured
+			}
+			hold = current
+			return repos.Holds.UpdateHold(current)
+		},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return hold, transfer, nil
+}
+
+// captureAmount valida o valor de uma captura da reserva. Sem moeda, vale a
+// moeda da reserva; zero captura todo o valor ainda não capturado.
+func captureAmount(hold *models.Hold, amount models.Money) (models.Money, error) {
+	remaining := hold.Remaining()
+	if amount.Currency == "" {
+		amount.Currency = hold.Amount.Currency
+	}
+	if !amount.SameCurrency(remaining) {
+		return models.Money{}, declineTransfer(models.FailureCurrencyMismatch, models.ErrCurrencyMismatch)
+	}
+	if amount.IsZero() {
+		amount = remaining
+	}
+	if amount.GreaterThan(remaining) {
+		return models.Money{}, models.ErrCaptureExceedsHold
+	}
+	return amount, nil
+}
+
+// VoidHold cancela a reserva e devolve o valor não capturado ao saldo disponível
+func (s *HoldService) VoidHold(id int) (*models.Hold, error) {
+	var hold *models.Hold
+	err := s.uow.Do(func(repos repositories.Repositories) error {
+		var err error
+		hold, err = activeHold(repos.Holds, id, time.Now())
+		if err != nil {
+			return err
+		}
+		return releaseHold(repos, hold, models.HoldStatusVoided)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return hold, nil
+}
+
+// ExpireDue marca como expiradas as reservas ativas vencidas e devolve o valor
+// não capturado ao saldo disponível. É chamado periodicamente pelo executor em
+// segundo plano; reservas capturadas ou canceladas por outra requisição no
+// meio do caminho são ignoradas.
+func (s *HoldService) ExpireDue(now time.Time) error {
+	expired, err := s.holdRepo.GetExpiredHolds(now, holdExpiryBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, candidate := range expired {
+		err := s.uow.Do(func(repos repositories.Repositories) error {
+			hold, err := repos.Holds.GetHoldByID(candidate.ID)
+			if err != nil {
+				return err
+			}
+			if hold.Status != models.HoldStatusActive {
+				return nil
+			}
+			return releaseHold(repos, hold, models.HoldStatusExpired)
+		})
+		if err != nil {
+			return err
+		}
+		log.Printf("Hold %d on account %s expired", candidate.ID, candidate.AccountNum)
+	}
+	return nil
+}
+
+// activeHold lê a reserva dentro da transação e verifica se ela ainda pode ser
+// capturada ou cancelada
+func activeHold(repo repositories.HoldRepository, id int, now time.Time) (*models.Hold, error) {
+	hold, err := repo.GetHoldByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if !hold.IsActive(now) {
+		return nil, repositories.ErrHoldNotActive
+	}
+	return hold, nil
+}
+
+// releaseHold devolve o valor não capturado da reserva ao saldo disponível e a
+// encerra com status
+func releaseHold(repos repositories.Repositories, hold *models.Hold, status string) error {
+	if remaining := hold.Remaining(); remaining.IsPositive() {
+		if _, err := repos.Clients.ReleaseClientHold(hold.AccountNum, remaining); err != nil {
+			return err
+		}
+	}
+	hold.Status = status
+	return repos.Holds.UpdateHold(hold)
+}
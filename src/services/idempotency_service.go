@@ -0,0 +1,104 @@
+// src/services/idempotency_service.go
+package services
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"errors"
+	"time"
+)
+
+// DefaultIdempotencyKeyTTL é o tempo padrão em que uma chave de idempotência é lembrada
+const DefaultIdempotencyKeyTTL = 24 * time.Hour
+
+// IdempotencyKeyLease é por quanto tempo uma chave reservada fica com a
+// requisição que a reservou. Passado esse tempo sem resposta armazenada (a
+// aplicação parou no meio da requisição, por exemplo), uma repetição da mesma
+// requisição pode retomá-la.
+const IdempotencyKeyLease = time.Minute
+
+var (
+	ErrIdempotencyKeyConflict   = errors.New("idempotency key was already used with a different request")
+	ErrIdempotencyKeyInProgress = errors.New("a request with this idempotency key is still being processed")
+)
+
+// IdempotencyServiceInterface define os métodos do serviço de idempotência
+type IdempotencyServiceInterface interface {
+	Reserve(key, requestHash string) (*models.IdempotencyRecord, error)
+	Complete(key string, statusCode int, body []byte) error
+	Release(key string) error
+}
+
+// IdempotencyService é a implementação concreta do IdempotencyServiceInterface
+type IdempotencyService struct {
+	repo repositories.IdempotencyRepository
+	ttl  time.Duration
+}
+
+// Certifique-se de que IdempotencyService implementa IdempotencyServiceInterface
+var _ IdempotencyServiceInterface = (*IdempotencyService)(nil)
+
+// NewIdempotencyService cria uma nova instância de IdempotencyService; as chaves
+// expiram após ttl
+func NewIdempotencyService(repo repositories.IdempotencyRepository, ttl time.Duration) *IdempotencyService {
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyKeyTTL
+	}
+	return &IdempotencyService{repo: repo, ttl: ttl}
+}
+
+// Reserve reserva a chave para uma nova requisição. Se a chave já tiver uma
+// resposta armazenada para a mesma requisição, o registro é retornado para ser
+// reproduzido; se a reserva for nova, o retorno é nil. Chaves reutilizadas com
+// outro conteúdo retornam ErrIdempotencyKeyConflict e chaves cuja requisição
+// ainda não terminou retornam ErrIdempotencyKeyInProgress, até que a reserva
+// tenha mais de IdempotencyKeyLease e seja retomada pela nova requisição.
+func (s *IdempotencyService) Reserve(key, requestHash string) (*models.IdempotencyRecord, error) {
+	now := time.Now().UTC()
+	if err := s.repo.DeleteExpired(now); err != nil {
+		return nil, err
+	}
+
+	record := &models.IdempotencyRecord{
+		Key:         key,
+		RequestHash: requestHash,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(s.ttl),
+	}
+	err := s.repo.Create(record)
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, repositories.ErrIdempotencyKeyExists) {
+		return nil, err
+	}
+
+	existing, err := s.repo.GetByKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if existing.RequestHash != requestHash {
+		return nil, ErrIdempotencyKeyConflict
+	}
+	if existing.Completed() {
+		return existing, nil
+	}
+
+	err = s.repo.Reclaim(record, now.Add(-IdempotencyKeyLease))
+	if errors.Is(err, repositories.ErrIdempotencyKeyExists) {
+		return nil, ErrIdempotencyKeyInProgress
+	}
+	return nil, err
+}
+
+// Complete armazena a resposta da requisição que reservou a chave
+func (s *IdempotencyService) Complete(key string, statusCode int, body []byte) error {
+	return s.repo.SaveResponse(key, statusCode, body)
+}
+
+// Release libera a chave sem armazenar resposta, para que a requisição possa
+// ser repetida. É usado quando a requisição falha por um erro inesperado, que
+// não deve ser reproduzido nas repetições.
+func (s *IdempotencyService) Release(key string) error {
+	return s.repo.Release(key)
+}
@@ -0,0 +1,240 @@
+// src/services/interest_service.go
+package services
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrInvalidRate envolve os erros de validação das taxas de juros
+	ErrInvalidRate = errors.New("invalid interest rate")
+	// ErrPeriodNotEnded é retornado ao acumular juros de um dia, capitalizar um
+	// mês ou guardar os saldos de um dia que ainda não terminou
+	ErrPeriodNotEnded = errors.New("period has not ended yet")
+)
+
+// InterestServiceInterface define a administração e a consulta dos juros das contas
+type InterestServiceInterface interface {
+	GetRates() ([]models.InterestRate, error)
+	SetRate(accountType string, rateBps int) (*models.InterestRate, error)
+	GetInterest(accountNum string) (*models.InterestHistory, error)
+}
+
+// InterestService é a implementação concreta do InterestServiceInterface
+type InterestService struct {
+	clientRepo   repositories.ClientRepository
+	interestRepo repositories.InterestRepository
+	uow          repositories.UnitOfWork
+
+	mu      sync.Mutex
+	lastRun string // último dia processado por AccrueDue nesta instância
+}
+
+// Certifique-se de que InterestService implementa InterestServiceInterface
+var _ InterestServiceInterface = (*InterestService)(nil)
+
+// NewInterestService cria uma nova instância de InterestService
+func NewInterestService(clientRepo repositories.ClientRepository, interestRepo repositories.InterestRepository, uow repositories.UnitOfWork) *InterestService {
+	return &InterestService{clientRepo: clientRepo, interestRepo: interestRepo, uow: uow}
+}
+
+// GetRates lista as taxas de juros anuais de cada tipo de conta
+func (s *InterestService) GetRates() ([]models.InterestRate, error) {
+	return s.interestRepo.GetRates()
+}
+
+// SetRate define a taxa de juros anual, em pontos-base, das contas do tipo
+// accountType. A nova taxa vale a partir do próximo acúmulo; dias já
+// acumulados mantêm a taxa da época.
+func (s *InterestService) SetRate(accountType string, rateBps int) (*models.InterestRate, error) {
+	if !models.IsValidAccountType(accountType) {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRate, models.ErrInvalidAccountType)
+	}
+	if rateBps < 0 {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRate, models.ErrInvalidInterestRate)
+	}
+	rate := &models.InterestRate{AccountType: accountType, RateBps: rateBps}
+	if err := s.interestRepo.SaveRate(rate); err != nil {
+		return nil, err
+	}
+	return rate, nil
+}
+
+// GetInterest lista os juros acumulados e capitalizados da conta
+func (s *InterestService) GetInterest(accountNum string) (*models.InterestHistory, error) {
+	if _, err := s.clientRepo.GetClientByAccountNum(accountNum); err != nil {
+		return nil, err
+	}
+	accruals, err := s.interestRepo.GetAccrualsByAccountNum(accountNum)
+	if err != nil {
+		return nil, err
+	}
+	capitalizations, err := s.interestRepo.GetCapitalizationsByAccountNum(accountNum)
+	if err != nil {
+		return nil, err
+	}
+	return &models.InterestHistory{Accruals: accruals, Capitalizations: capitalizations}, nil
+}
+
+// AccrueDue acumula os juros que faltam até o dia anterior a now, a partir do
+// dia seguinte ao último já acumulado ou, se nenhum foi acumulado, só do dia
+// anterior, e depois capitaliza os meses terminados desde o primeiro desses
+// dias, ou ao menos o mês anterior. É chamado periodicamente pelo executor em
+// segundo plano e faz o trabalho no máximo uma vez por dia nesta instância; as
+// duas etapas são idempotentes, então várias instâncias podem executá-lo ao
+// mesmo tempo.
+func (s *InterestService) AccrueDue(now time.Time) error {
+	today := models.StartOfBusinessDay(now)
+	date := today.Format(time.DateOnly)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.lastRun == date {
+		return nil
+	}
+
+	latest, err := s.interestRepo.GetLatestAccrualDate()
+	if err != nil {
+		return err
+	}
+	day, err := firstDueDay(latest, today)
+	if err != nil {
+		return err
+	}
+	thisMonth := models.StartOfBusinessMonth(today)
+	month := thisMonth.AddDate(0, -1, 0)
+	if first := models.StartOfBusinessMonth(day); first.Before(month) {
+		month = first
+	}
+
+	for ; day.Before(today); day = day.AddDate(0, 0, 1) {
+		if _, err := s.AccrueInterest(day, now); err != nil {
+			return err
+		}
+	}
+	for ; month.Before(thisMonth); month = month.AddDate(0, 1, 0) {
+		if _, err := s.Capitalize(month, now); err != nil {
+			return err
+		}
+	}
+	s.lastRun = date
+	return nil
+}
+
+// AccrueInterest acumula os juros do dia day de cada conta que terminou o dia
+// com saldo positivo, pelo livro-razão, e cujo tipo tem taxa positiva. Nada é
+// creditado agora: o acúmulo guarda o saldo e a taxa do dia, e os juros são
+// pagos na capitalização do mês. O acúmulo é único por conta e dia, então
+// executar de novo o mesmo dia não acumula nada. Retorna o número de contas
+// acumuladas nesta execução.
+func (s *InterestService) AccrueInterest(day, now time.Time) (int, error) {
+	day = models.StartOfBusinessDay(day)
+	end := day.AddDate(0, 0, 1)
+	if end.After(now) {
+		return 0, ErrPeriodNotEnded
+	}
+
+	candidates, err := s.interestRepo.GetAccrualCandidates(end)
+	if err != nil {
+		return 0, err
+	}
+
+	accrued := 0
+	for _, accrual := range candidates {
+		accrual.AccrualDate = day.Format(time.DateOnly)
+		err := s.interestRepo.CreateAccrual(&accrual)
+		if errors.Is(err, repositories.ErrAccrualExists) {
+			continue
+		}
+		if err != nil {
+			return accrued, err
+		}
+		accrued++
+	}
+	return accrued, nil
+}
+
+// Capitalize paga os juros acumulados até o fim do mês de month e ainda não
+// capitalizados: para cada conta, os valores exatos dos dias são somados e
+// arredondados uma única vez, e o total é creditado na conta a partir da conta
+// interna de despesa models.InterestExpenseAccountNum. Dias acumulados depois
+// que o mês deles foi capitalizado entram na capitalização do mês seguinte. A
+// capitalização é única por conta e mês, então executar de novo o mesmo mês
+// não paga nada. Os dias do mês devem ser acumulados antes.
+func (s *InterestService) Capitalize(month, now time.Time) ([]models.InterestCapitalization, error) {
+	start := models.StartOfBusinessMonth(month)
+	end := start.AddDate(0, 1, 0)
+	if end.After(now) {
+		return nil, ErrPeriodNotEnded
+	}
+	to := end.AddDate(0, 0, -1).Format(time.DateOnly)
+
+	accrued, err := s.interestRepo.GetAccruedInterest(to)
+	if err != nil {
+		return nil, err
+	}
+
+	capitalizations := []models.InterestCapitalization{}
+	for accountNum, interest := range accrued {
+		capitalization := models.InterestCapitalization{
+			AccountNum: accountNum,
+			Period:     start.Format("2006-01"),
+			Interest:   interest,
+		}
+		err := s.capitalizeAccount(&capitalization, to)
+		if errors.Is(err, repositories.ErrPeriodCapitalized) {
+			continue
+		}
+		if err != nil {
+			return capitalizations, err
+		}
+		log.Printf("Interest of %s for %s capitalized on account %s", capitalization.Interest, capitalization.Period, accountNum)
+		capitalizations = append(capitalizations, capitalization)
+	}
+	return capitalizations, nil
+}
+
+func (s *InterestService) capitalizeAccount(capitalization *models.InterestCapitalization, to string) error {
+	accountNum := capitalization.AccountNum
+	return s.uow.Do(func(repos repositories.Repositories) error {
+		if capitalization.Interest.IsPositive() {
+			credit := models.Transfer{
+				FromAccountNum: models.InterestExpenseAccountNum,
+				ToAccountNum:   accountNum,
+				Amount:         capitalization.Interest,
+				Type:           models.TransferTypeInterest,
+				Status:         models.TransferStatusSuccess,
+			}
+			balance, err := repos.Clients.CreditClientBalance(accountNum, credit.Amount)
+			if err != nil {
+				return err
+			}
+			if err := notifyOverdraftChange(repos.Notifications, accountNum, balance, credit.Amount); err != nil {
+				return err
+			}
+			if err := repos.Transfers.CreateTransfer(&credit); err != nil {
+				return err
+			}
+			entry := models.NewTransferEntry(models.EntryInterest, models.InterestExpenseAccountNum, accountNum, credit.Amount)
+			entry.TransferID = &credit.ID
+			if err := repos.Ledger.CreateEntry(&entry); err != nil {
+				return err
+			}
+			if err := verifyLedgerBalances(repos.Ledger, &models.Client{AccountNum: accountNum, Balance: balance}); err != nil {
+				return err
+			}
+			capitalization.TransferID = &credit.ID
+		}
+
+		if err := repos.Interest.CreateCapitalization(capitalization); err != nil {
+			return err
+		}
+		return repos.Interest.MarkAccrualsCapitalized(accountNum, to, capitalization.ID)
+	})
+}
@@ -0,0 +1,114 @@
+// src/services/limit_service.go
+package services
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidLimits envolve os erros de validação dos limites
+	ErrInvalidLimits = errors.New("invalid transfer limits")
+	// ErrDefaultLimitsRequired é retornado ao tentar remover os limites padrão
+	ErrDefaultLimitsRequired = errors.New("the default limits cannot be removed")
+)
+
+// LimitServiceInterface define a administração dos limites de transferência
+type LimitServiceInterface interface {
+	GetLimits(accountNum string) (*models.LimitStatus, error)
+	SetLimits(limits models.TransferLimits) (*models.TransferLimits, error)
+	DeleteLimits(accountNum string) error
+}
+
+// LimitService é a implementação concreta do LimitServiceInterface
+type LimitService struct {
+	clientRepo repositories.ClientRepository
+	limitRepo  repositories.LimitRepository
+}
+
+// Certifique-se de que LimitService implementa LimitServiceInterface
+var _ LimitServiceInterface = (*LimitService)(nil)
+
+// NewLimitService cria uma nova instância de LimitService
+func NewLimitService(clientRepo repositories.ClientRepository, limitRepo repositories.LimitRepository) *LimitService {
+	return &LimitService{clientRepo: clientRepo, limitRepo: limitRepo}
+}
+
+// GetLimits retorna os limites em vigor para a conta e o uso no dia, no mês e
+// no período noturno atuais. Com accountNum "default", retorna os limites
+// padrão, sem uso.
+func (s *LimitService) GetLimits(accountNum string) (*models.LimitStatus, error) {
+	if accountNum == models.DefaultLimitsKey {
+		limits, err := s.limitRepo.GetLimits(models.DefaultLimitsKey)
+		if err != nil {
+			return nil, err
+		}
+		return &models.LimitStatus{Limits: *limits}, nil
+	}
+
+	if _, err := s.clientRepo.GetClientByAccountNum(accountNum); err != nil {
+		return nil, err
+	}
+	status := &models.LimitStatus{}
+	limits, err := s.limitRepo.GetLimits(accountNum)
+	if errors.Is(err, repositories.ErrLimitsNotFound) {
+		status.Inherited = true
+		limits, err = s.limitRepo.GetLimits(models.DefaultLimitsKey)
+	}
+	if err != nil {
+		return nil, err
+	}
+	status.Limits = *limits
+
+	usage, err := s.limitRepo.GetUsage(accountNum, limits.PerTransfer.Currency, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	status.Usage = &usage
+	return status, nil
+}
+
+// SetLimits cria ou substitui os limites da conta (ou os limites padrão).
+// Valores sem moeda assumem a moeda dos demais, ou DefaultCurrency.
+func (s *LimitService) SetLimits(limits models.TransferLimits) (*models.TransferLimits, error) {
+	if limits.AccountNum != models.DefaultLimitsKey {
+		if _, err := s.clientRepo.GetClientByAccountNum(limits.AccountNum); err != nil {
+			return nil, err
+		}
+	}
+
+	amounts := []*models.Money{&limits.PerTransfer, &limits.Daily, &limits.Monthly, &limits.NightPerTransfer, &limits.NightTotal}
+	currency := models.DefaultCurrency
+	for _, amount := range amounts {
+		if amount.Currency != "" {
+			currency = strings.ToUpper(amount.Currency)
+			break
+		}
+	}
+	for _, amount := range amounts {
+		if amount.Currency == "" {
+			amount.Currency = currency
+		}
+	}
+	if err := limits.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidLimits, err)
+	}
+
+	if err := s.limitRepo.SaveLimits(&limits); err != nil {
+		return nil, err
+	}
+	return &limits, nil
+}
+
+// DeleteLimits remove os limites próprios da conta, que volta a seguir os
+// limites padrão. Os limites padrão não podem ser removidos, apenas alterados.
+func (s *LimitService) DeleteLimits(accountNum string) error {
+	if accountNum == models.DefaultLimitsKey {
+		return ErrDefaultLimitsRequired
+	}
+	return s.limitRepo.DeleteLimits(accountNum)
+}
@@ -0,0 +1,34 @@
+// src/services/notification_service.go
+package services
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+)
+
+// NotificationServiceInterface define a consulta das notificações das contas
+type NotificationServiceInterface interface {
+	GetNotifications(accountNum string) ([]models.Notification, error)
+}
+
+// NotificationService é a implementação concreta do NotificationServiceInterface
+type NotificationService struct {
+	clientRepo       repositories.ClientRepository
+	notificationRepo repositories.NotificationRepository
+}
+
+// Certifique-se de que NotificationService implementa NotificationServiceInterface
+var _ NotificationServiceInterface = (*NotificationService)(nil)
+
+// NewNotificationService cria uma nova instância de NotificationService
+func NewNotificationService(clientRepo repositories.ClientRepository, notificationRepo repositories.NotificationRepository) *NotificationService {
+	return &NotificationService{clientRepo: clientRepo, notificationRepo: notificationRepo}
+}
+
+// GetNotifications lista as notificações da conta, das mais recentes para as mais antigas
+func (s *NotificationService) GetNotifications(accountNum string) ([]models.Notification, error) {
+	if _, err := s.clientRepo.GetClientByAccountNum(accountNum); err != nil {
+		return nil, err
+	}
+	return s.notificationRepo.GetNotificationsByAccountNum(accountNum)
+}
@@ -0,0 +1,188 @@
+// src/services/overdraft_service.go
+package services
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrInvalidOverdraft envolve os erros de validação do cheque especial
+var ErrInvalidOverdraft = errors.New("invalid overdraft")
+
+// OverdraftServiceInterface define a administração do cheque especial
+type OverdraftServiceInterface interface {
+	SetOverdraft(accountNum string, limit models.Money, rateBps int) (*models.Client, error)
+	GetOverdraftInterest(accountNum string) ([]models.OverdraftInterest, error)
+}
+
+// OverdraftService é a implementação concreta do OverdraftServiceInterface
+type OverdraftService struct {
+	clientRepo    repositories.ClientRepository
+	overdraftRepo repositories.OverdraftRepository
+	uow           repositories.UnitOfWork
+
+	mu      sync.Mutex
+	lastRun string // último dia processado por AccrueDue nesta instância
+}
+
+// Certifique-se de que OverdraftService implementa OverdraftServiceInterface
+var _ OverdraftServiceInterface = (*OverdraftService)(nil)
+
+// NewOverdraftService cria uma nova instância de OverdraftService
+func NewOverdraftService(clientRepo repositories.ClientRepository, overdraftRepo repositories.OverdraftRepository, uow repositories.UnitOfWork) *OverdraftService {
+	return &OverdraftService{clientRepo: clientRepo, overdraftRepo: overdraftRepo, uow: uow}
+}
+
+// SetOverdraft define o limite e a taxa anual, em pontos-base, do cheque
+// especial da conta. Limite zero desativa o cheque especial; o limite não
+// pode ficar abaixo do valor já em uso. Limite sem moeda assume a da conta.
+func (s *OverdraftService) SetOverdraft(accountNum string, limit models.Money, rateBps int) (*models.Client, error) {
+	if limit.IsNegative() {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidOverdraft, models.ErrInvalidOverdraftLimit)
+	}
+	if rateBps < 0 {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidOverdraft, models.ErrInvalidOverdraftRate)
+	}
+	if limit.Currency == "" {
+		client, err := s.clientRepo.GetClientByAccountNum(accountNum)
+		if err != nil {
+			return nil, err
+		}
+		limit.Currency = client.Balance.Currency
+	}
+	limit.Currency = strings.ToUpper(limit.Currency)
+	return s.clientRepo.UpdateOverdraft(accountNum, limit, rateBps)
+}
+
+// GetOverdraftInterest lista as cobranças de juros do cheque especial da conta
+func (s *OverdraftService) GetOverdraftInterest(accountNum string) ([]models.OverdraftInterest, error) {
+	if _, err := s.clientRepo.GetClientByAccountNum(accountNum); err != nil {
+		return nil, err
+	}
+	return s.overdraftRepo.GetInterestByAccountNum(accountNum)
+}
+
+// AccrueDue cobra os juros que faltam até o dia anterior a now: os dias
+// depois do último já cobrado ou, se nenhum foi cobrado, só o dia anterior. É
+// chamado periodicamente pelo executor em segundo plano e faz o trabalho no
+// máximo uma vez por dia nesta instância; a cobrança é idempotente, então
+// várias instâncias podem executá-lo ao mesmo tempo.
+func (s *OverdraftService) AccrueDue(now time.Time) error {
+	today := models.StartOfBusinessDay(now)
+	date := today.Format(time.DateOnly)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.lastRun == date {
+		return nil
+	}
+
+	latest, err := s.overdraftRepo.GetLatestInterestDate()
+	if err != nil {
+		return err
+	}
+	day, err := firstDueDay(latest, today)
+	if err != nil {
+		return err
+	}
+	for ; day.Before(today); day = day.AddDate(0, 0, 1) {
+		if err := s.AccrueInterest(day); err != nil {
+			return err
+		}
+	}
+	s.lastRun = date
+	return nil
+}
+
+// AccrueInterest cobra os juros do dia day de cada conta que terminou o dia
+// negativa, sobre o saldo do fim do dia pelo livro-razão. Os juros são
+// debitados da conta, mesmo além do limite, e creditados na conta interna
+// models.OverdraftInterestAccountNum, e aparecem no histórico da conta. A
+// cobrança é única por conta e dia, então executar de novo o mesmo dia não
+// cobra nada.
+func (s *OverdraftService) AccrueInterest(day time.Time) error {
+	day = models.StartOfBusinessDay(day)
+	balances, err := s.overdraftRepo.GetOverdrawnBalances(day.AddDate(0, 0, 1))
+	if err != nil {
+		return err
+	}
+
+	for accountNum, balance := range balances {
+		err := s.accrueAccount(accountNum, balance, day.Format(time.DateOnly))
+		if errors.Is(err, repositories.ErrInterestAlreadyAccrued) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *OverdraftService) accrueAccount(accountNum string, balance models.Money, date string) error {
+	return s.uow.Do(func(repos repositories.Repositories) error {
+		client, err := repos.Clients.GetClientByAccountNum(accountNum)
+		if err != nil {
+			return err
+		}
+		if client.OverdraftRateBps == 0 {
+			return nil
+		}
+
+		interest := models.OverdraftInterest{
+			AccountNum:  accountNum,
+			AccrualDate: date,
+			Balance:     balance,
+			RateBps:     client.OverdraftRateBps,
+			Interest:    models.DailyOverdraftInterest(balance, client.OverdraftRateBps),
+		}
+		if interest.Interest.IsPositive() {
+			charge := models.Transfer{
+				FromAccountNum: accountNum,
+				ToAccountNum:   models.OverdraftInterestAccountNum,
+				Amount:         interest.Interest,
+				Type:           models.TransferTypeOverdraftInterest,
+				Status:         models.TransferStatusSuccess,
+			}
+			client.Balance, err = repos.Clients.ChargeClientBalance(accountNum, charge.Amount)
+			if err != nil {
+				return err
+			}
+			if err := repos.Transfers.CreateTransfer(&charge); err != nil {
+				return err
+			}
+			entry := models.NewTransferEntry(models.EntryOverdraftInterest, accountNum, models.OverdraftInterestAccountNum, charge.Amount)
+			entry.TransferID = &charge.ID
+			if err := repos.Ledger.CreateEntry(&entry); err != nil {
+				return err
+			}
+			if err := verifyLedgerBalances(repos.Ledger, client); err != nil {
+				return err
+			}
+			interest.TransferID = &charge.ID
+		}
+
+		return repos.Overdraft.CreateInterest(&interest)
+	})
+}
+
+// notifyOverdraftChange registra uma notificação quando uma movimentação de
+// delta leva a conta, que ficou com o saldo after, para o cheque especial ou a
+// tira dele
+func notifyOverdraftChange(repo repositories.NotificationRepository, accountNum string, after, delta models.Money) error {
+	notification := models.OverdraftNotification(accountNum, after.Sub(delta), after)
+	if notification == nil {
+		return nil
+	}
+	if err := repo.CreateNotification(notification); err != nil {
+		return err
+	}
+	log.Print(notification.Message)
+	return nil
+}
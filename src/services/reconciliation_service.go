@@ -0,0 +1,60 @@
+// src/services/reconciliation_service.go
+package services
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"time"
+)
+
+// ReconciliationServiceInterface define a conciliação do banco
+type ReconciliationServiceInterface interface {
+	Reconcile() (*models.ReconciliationReport, error)
+}
+
+// ReconciliationService é a implementação concreta do ReconciliationServiceInterface
+type ReconciliationService struct {
+	reconciliationRepo repositories.ReconciliationRepository
+}
+
+// Certifique-se de que ReconciliationService implementa ReconciliationServiceInterface
+var _ ReconciliationServiceInterface = (*ReconciliationService)(nil)
+
+// NewReconciliationService cria uma nova instância de ReconciliationService
+func NewReconciliationService(reconciliationRepo repositories.ReconciliationRepository) *ReconciliationService {
+	return &ReconciliationService{reconciliationRepo: reconciliationRepo}
+}
+
+// Reconcile executa todas as verificações e retorna o relatório com as
+// divergências encontradas. O relatório só está OK se não houver nenhuma; um
+// erro indica que a conciliação não pôde ser concluída.
+func (s *ReconciliationService) Reconcile() (*models.ReconciliationReport, error) {
+	report := &models.ReconciliationReport{
+		GeneratedAt:   time.Now().UTC(),
+		Discrepancies: []models.Discrepancy{},
+	}
+
+	var err error
+	report.AccountsChecked, report.TransfersChecked, report.EntriesChecked, err = s.reconciliationRepo.CountRecords()
+	if err != nil {
+		return nil, err
+	}
+
+	checks := []func() ([]models.Discrepancy, error){
+		s.reconciliationRepo.CheckBalances,
+		s.reconciliationRepo.CheckLedger,
+		s.reconciliationRepo.CheckReferences,
+		s.reconciliationRepo.CheckDuplicateAccounts,
+		s.reconciliationRepo.CheckForeignKeys,
+	}
+	for _, check := range checks {
+		discrepancies, err := check()
+		if err != nil {
+			return nil, err
+		}
+		report.Discrepancies = append(report.Discrepancies, discrepancies...)
+	}
+
+	report.OK = len(report.Discrepancies) == 0
+	return report, nil
+}
@@ -0,0 +1,155 @@
+// src/services/scheduled_transfer_service.go
+package services
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"errors"
+	"log"
+	"time"
+)
+
+// ErrExecuteAtNotInFuture é retornado ao agendar uma transferência para agora ou para o passado
+var ErrExecuteAtNotInFuture = errors.New("execute_at must be in the future")
+
+// scheduledTransferBatchSize é o número máximo de agendamentos executados a cada ciclo
+const scheduledTransferBatchSize = 100
+
+// scheduledTransferClaimLease é por quanto tempo um agendamento reivindicado
+// fica com o executor que o reivindicou. Um agendamento que continua em
+// execução depois desse prazo (a aplicação parou no meio da execução, por
+// exemplo) pode ser reivindicado de novo.
+const scheduledTransferClaimLease = 5 * time.Minute
+
+// ScheduledTransferServiceInterface define os métodos do serviço de transferências agendadas
+type ScheduledTransferServiceInterface interface {
+	ScheduleTransfer(fromAccountNum, toAccountNum string, amount models.Money, executeAt time.Time) (*models.ScheduledTransfer, error)
+	GetScheduledTransfer(id int) (*models.ScheduledTransfer, error)
+	GetScheduledTransfers(accountNum, status string) ([]models.ScheduledTransfer, error)
+	CancelScheduledTransfer(id int) (*models.ScheduledTransfer, error)
+}
+
+// ScheduledTransferService é a implementação concreta do ScheduledTransferServiceInterface
+type ScheduledTransferService struct {
+	clientRepo      repositories.ClientRepository
+	scheduledRepo   repositories.ScheduledTransferRepository
+	transferService TransferServiceInterface
+}
+
+// Certifique-se de que ScheduledTransferService implementa ScheduledTransferServiceInterface
+var _ ScheduledTransferServiceInterface = (*ScheduledTransferService)(nil)
+
+// NewScheduledTransferService cria uma nova instância de ScheduledTransferService
+func NewScheduledTransferService(clientRepo repositories.ClientRepository, scheduledRepo repositories.ScheduledTransferRepository, transferService TransferServiceInterface) *ScheduledTransferService {
+	return &ScheduledTransferService{
+		clientRepo:      clientRepo,
+		scheduledRepo:   scheduledRepo,
+		transferService: transferService,
+	}
+}
+
+// ScheduleTransfer agenda uma transferência para executeAt. O valor e as contas
+// são validados agora; o saldo só é verificado na execução.
+func (s *ScheduledTransferService) ScheduleTransfer(fromAccountNum, toAccountNum string, amount models.Money, executeAt time.Time) (*models.ScheduledTransfer, error) {
+	if amount.Currency == "" {
+		amount.Currency = models.DefaultCurrency
+	}
+	if !executeAt.After(time.Now()) {
+		return nil, ErrExecuteAtNotInFuture
+	}
+	if err := checkTransferAmount(amount); err != nil {
+		return nil, err
+	}
+	if err := checkTransferAccounts(s.clientRepo, fromAccountNum, toAccountNum, amount); err != nil {
+		return nil, err
+	}
+
+	scheduled := &models.ScheduledTransfer{
+		FromAccountNum: fromAccountNum,
+		ToAccountNum:   toAccountNum,
+		Amount:         amount,
+		ExecuteAt:      executeAt,
+		Status:         models.ScheduledStatusPending,
+	}
+	if err := s.scheduledRepo.CreateScheduledTransfer(scheduled); err != nil {
+		return nil, err
+	}
+	return scheduled, nil
+}
+
+// GetScheduledTransfer retorna um agendamento pelo ID
+func (s *ScheduledTransferService) GetScheduledTransfer(id int) (*models.ScheduledTransfer, error) {
+	return s.scheduledRepo.GetScheduledTransferByID(id)
+}
+
+// GetScheduledTransfers lista os agendamentos de uma conta, opcionalmente
+// filtrados por status
+func (s *ScheduledTransferService) GetScheduledTransfers(accountNum, status string) ([]models.ScheduledTransfer, error) {
+	return s.scheduledRepo.GetScheduledTransfersByAccountNum(accountNum, status)
+}
+
+// CancelScheduledTransfer cancela um agendamento que ainda não foi executado
+func (s *ScheduledTransferService) CancelScheduledTransfer(id int) (*models.ScheduledTransfer, error) {
+	if err := s.scheduledRepo.CancelScheduledTransfer(id); err != nil {
+		return nil, err
+	}
+	return s.scheduledRepo.GetScheduledTransferByID(id)
+}
+
+// ExecuteDue executa, pelo TransferService, os agendamentos pendentes cuja
+// data já chegou e registra o resultado de cada um. Cada agendamento é
+// reivindicado antes de ser executado, então vários executores (ou
+// instâncias da aplicação) nunca executam o mesmo agendamento ao mesmo tempo.
+func (s *ScheduledTransferService) ExecuteDue(now time.Time) error {
+	staleBefore := now.Add(-scheduledTransferClaimLease)
+	due, err := s.scheduledRepo.GetDueScheduledTransfers(now, staleBefore, scheduledTransferBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, scheduled := range due {
+		err := s.scheduledRepo.ClaimScheduledTransfer(scheduled.ID, now, staleBefore)
+		if errors.Is(err, repositories.ErrScheduledTransferNotPending) {
+			continue // cancelado ou reivindicado por outro executor
+		}
+		if err != nil {
+			return err
+		}
+		if err := s.execute(scheduled, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// execute executa um agendamento reivindicado em claimedAt. O sucesso é
+// registrado na mesma transação da transferência, então uma transferência
+// concluída nunca deixa o agendamento em execução, e uma reivindicação
+// expirada desfaz a transferência. Uma recusa marca o agendamento como
+// "failed"; um erro inesperado o devolve para "pending", para que seja
+// executado de novo no próximo ciclo.
+func (s *ScheduledTransferService) execute(scheduled models.ScheduledTransfer, claimedAt time.Time) error {
+	_, err := s.transferService.TransferFundsWith(scheduled.FromAccountNum, scheduled.ToAccountNum, scheduled.Amount, TransferOptions{
+		Then: func(repos repositories.Repositories, transfer *models.Transfer) error {
+			return repos.ScheduledTransfers.CompleteScheduledTransfer(scheduled.ID, claimedAt, models.ScheduledStatusExecuted, "", time.Now())
+		},
+	})
+
+	var transferErr *TransferError
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, repositories.ErrScheduledTransferNotPending):
+		return nil // reivindicado por outro executor depois que a reivindicação expirou
+	case errors.As(err, &transferErr):
+		log.Printf("Scheduled transfer %d from %s to %s failed: %v", scheduled.ID, scheduled.FromAccountNum, scheduled.ToAccountNum, err)
+		err = s.scheduledRepo.CompleteScheduledTransfer(scheduled.ID, claimedAt, models.ScheduledStatusFailed, transferErr.Reason, time.Now())
+	default:
+		log.Printf("Scheduled transfer %d from %s to %s will be retried: %v", scheduled.ID, scheduled.FromAccountNum, scheduled.ToAccountNum, err)
+		err = s.scheduledRepo.ReleaseScheduledTransfer(scheduled.ID, claimedAt)
+	}
+	if errors.Is(err, repositories.ErrScheduledTransferNotPending) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,278 @@
+// src/services/standing_order_service.go
+package services
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+var (
+	// ErrInvalidStandingOrder envolve os erros de uma regra de recorrência inválida
+	ErrInvalidStandingOrder = errors.New("invalid standing order")
+	errNoOccurrences        = errors.New("recurrence has no occurrence before end_at")
+)
+
+const (
+	// standingOrderBatchSize é o número máximo de ordens executadas a cada ciclo
+	standingOrderBatchSize = 100
+	// standingOrderMaxRetries é quantas vezes uma ocorrência recusada por saldo
+	// insuficiente é tentada novamente antes de ser dada como falha
+	standingOrderMaxRetries = 3
+	// standingOrderRetryDelay é o intervalo entre as novas tentativas
+	standingOrderRetryDelay = time.Hour
+	// standingOrderClaimLease é por quanto tempo uma ordem reivindicada fica
+	// com o executor que a reivindicou; depois disso, outro executor pode
+	// reivindicá-la
+	standingOrderClaimLease = 5 * time.Minute
+)
+
+// StandingOrderServiceInterface define os métodos do serviço de ordens permanentes
+type StandingOrderServiceInterface interface {
+	CreateStandingOrder(order models.StandingOrder) (*models.StandingOrder, error)
+	GetStandingOrder(id int) (*models.StandingOrder, error)
+	GetStandingOrders(accountNum, status string) ([]models.StandingOrder, error)
+	GetStandingOrderRuns(id int) ([]models.StandingOrderRun, error)
+	PauseStandingOrder(id int) (*models.StandingOrder, error)
+	ResumeStandingOrder(id int) (*models.StandingOrder, error)
+	CancelStandingOrder(id int) (*models.StandingOrder, error)
+}
+
+// StandingOrderService é a implementação concreta do StandingOrderServiceInterface
+type StandingOrderService struct {
+	clientRepo        repositories.ClientRepository
+	standingOrderRepo repositories.StandingOrderRepository
+	uow               repositories.UnitOfWork
+	transferService   TransferServiceInterface
+}
+
+// Certifique-se de que StandingOrderService implementa StandingOrderServiceInterface
+var _ StandingOrderServiceInterface = (*StandingOrderService)(nil)
+
+// NewStandingOrderService cria uma nova instância de StandingOrderService
+func NewStandingOrderService(clientRepo repositories.ClientRepository, standingOrderRepo repositories.StandingOrderRepository, uow repositories.UnitOfWork,
+	transferService TransferServiceInterface) *StandingOrderService {
+	return &StandingOrderService{
+		clientRepo:        clientRepo,
+		standingOrderRepo: standingOrderRepo,
+		uow:               uow,
+		transferService:   transferService,
+	}
+}
+
+// CreateStandingOrder valida a regra de recorrência, o valor e as contas e
+// agenda a primeira ocorrência a partir de StartAt (ou de agora, se StartAt
+// estiver vazio ou no passado). Interval vazio equivale a 1.
+func (s *StandingOrderService) CreateStandingOrder(order models.StandingOrder) (*models.StandingOrder, error) {
+	if order.Amount.Currency == "" {
+		order.Amount.Currency = models.DefaultCurrency
+	}
+	if order.Interval == 0 && order.Frequency != models.FrequencyCron {
+		order.Interval = 1
+	}
+	now := time.Now()
+	if order.StartAt.IsZero() {
+		order.StartAt = now
+	}
+	if err := order.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidStandingOrder, err)
+	}
+	if err := checkTransferAmount(order.Amount); err != nil {
+		return nil, err
+	}
+	if err := checkTransferAccounts(s.clientRepo, order.FromAccountNum, order.ToAccountNum, order.Amount); err != nil {
+		return nil, err
+	}
+
+	first := order.FirstOccurrence()
+	if first.Before(now) {
+		first = order.NextOccurrence(now)
+	}
+	if order.Finished(first) {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidStandingOrder, errNoOccurrences)
+	}
+
+	order.ID = 0
+	order.Occurrences = 0
+	order.Retries = 0
+	order.NextOccurrenceAt = first
+	order.NextRunAt = &first
+	order.Status = models.StandingOrderActive
+	if err := s.standingOrderRepo.CreateStandingOrder(&order); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// GetStandingOrder retorna uma ordem permanente pelo ID
+func (s *StandingOrderService) GetStandingOrder(id int) (*models.StandingOrder, error) {
+	return s.standingOrderRepo.GetStandingOrderByID(id)
+}
+
+// GetStandingOrders lista as ordens de uma conta, opcionalmente filtradas por status
+func (s *StandingOrderService) GetStandingOrders(accountNum, status string) ([]models.StandingOrder, error) {
+	return s.standingOrderRepo.GetStandingOrdersByAccountNum(accountNum, status)
+}
+
+// GetStandingOrderRuns retorna o histórico de execuções de uma ordem
+func (s *StandingOrderService) GetStandingOrderRuns(id int) ([]models.StandingOrderRun, error) {
+	if _, err := s.standingOrderRepo.GetStandingOrderByID(id); err != nil {
+		return nil, err
+	}
+	return s.standingOrderRepo.GetStandingOrderRuns(id)
+}
+
+// PauseStandingOrder suspende uma ordem ativa
+func (s *StandingOrderService) PauseStandingOrder(id int) (*models.StandingOrder, error) {
+	if err := s.standingOrderRepo.SetStandingOrderStatus(id, models.StandingOrderPaused, models.StandingOrderActive); err != nil {
+		return nil, err
+	}
+	return s.standingOrderRepo.GetStandingOrderByID(id)
+}
+
+// ResumeStandingOrder reativa uma ordem pausada. Ocorrências que venceram
+// durante a pausa não são executadas: a ordem segue a partir da próxima
+// ocorrência futura, e é concluída se não houver mais nenhuma.
+func (s *StandingOrderService) ResumeStandingOrder(id int) (*models.StandingOrder, error) {
+	order, err := s.standingOrderRepo.GetStandingOrderByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != models.StandingOrderPaused {
+		return nil, repositories.ErrStandingOrderInvalidState
+	}
+
+	now := time.Now()
+	if order.NextOccurrenceAt.Before(now) {
+		next := order.NextOccurrence(now)
+		if order.Finished(next) {
+			err = s.standingOrderRepo.SetStandingOrderStatus(id, models.StandingOrderCompleted, models.StandingOrderPaused)
+			if err != nil {
+				return nil, err
+			}
+			return s.standingOrderRepo.GetStandingOrderByID(id)
+		}
+		order.NextOccurrenceAt = next
+		order.NextRunAt = &next
+		order.Retries = 0
+	}
+
+	if err := s.standingOrderRepo.ResumeStandingOrder(order); err != nil {
+		return nil, err
+	}
+	return s.standingOrderRepo.GetStandingOrderByID(id)
+}
+
+// CancelStandingOrder encerra definitivamente uma ordem ativa ou pausada
+func (s *StandingOrderService) CancelStandingOrder(id int) (*models.StandingOrder, error) {
+	err := s.standingOrderRepo.SetStandingOrderStatus(id, models.StandingOrderCancelled, models.StandingOrderActive, models.StandingOrderPaused)
+	if err != nil {
+		return nil, err
+	}
+	return s.standingOrderRepo.GetStandingOrderByID(id)
+}
+
+// ExecuteDue executa as ocorrências vencidas das ordens ativas pelo
+// TransferService e registra cada tentativa no histórico da ordem. Assim como
+// nas transferências agendadas, cada ordem é reivindicada antes da execução.
+func (s *StandingOrderService) ExecuteDue(now time.Time) error {
+	staleBefore := now.Add(-standingOrderClaimLease)
+	due, err := s.standingOrderRepo.GetDueStandingOrders(now, staleBefore, standingOrderBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for i := range due {
+		err := s.standingOrderRepo.ClaimStandingOrder(due[i].ID, now, staleBefore)
+		if errors.Is(err, repositories.ErrStandingOrderNotDue) {
+			continue // pausada, cancelada ou reivindicada por outro executor
+		}
+		if err != nil {
+			return err
+		}
+		if err := s.runOccurrence(&due[i], now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runOccurrence tenta executar a ocorrência pendente da ordem, reivindicada em
+// now. Recusas por saldo insuficiente são tentadas novamente a cada
+// standingOrderRetryDelay, até standingOrderMaxRetries vezes; as demais
+// recusas, e a última tentativa, encerram a ocorrência como falha. A execução
+// e o andamento da ordem são gravados na mesma transação da transferência,
+// para que uma ocorrência nunca seja paga duas vezes; se a reivindicação
+// expirou e foi substituída, a transferência é desfeita.
+func (s *StandingOrderService) runOccurrence(order *models.StandingOrder, now time.Time) error {
+	run := models.StandingOrderRun{
+		StandingOrderID: order.ID,
+		OccurrenceAt:    order.NextOccurrenceAt,
+		Attempt:         order.Retries + 1,
+		Status:          models.StandingOrderRunSuccess,
+		ExecutedAt:      now,
+	}
+
+	advanced := *order
+	advanceStandingOrder(&advanced, now)
+	_, err := s.transferService.TransferFundsWith(order.FromAccountNum, order.ToAccountNum, order.Amount, TransferOptions{
+		Then: func(repos repositories.Repositories, transfer *models.Transfer) error {
+			return saveStandingOrderRun(repos.StandingOrders, &run, &advanced, now)
+		},
+	})
+	if err == nil || errors.Is(err, repositories.ErrStandingOrderNotDue) {
+		return nil
+	}
+
+	run.ID = 0
+	run.Status = models.StandingOrderRunFailed
+	run.FailureReason = FailureReason(err)
+	log.Printf("Standing order %d from %s to %s failed (attempt %d): %v", order.ID, order.FromAccountNum, order.ToAccountNum, run.Attempt, err)
+
+	if run.FailureReason == models.FailureInsufficientBalance && order.Retries < standingOrderMaxRetries {
+		retryAt := now.Add(standingOrderRetryDelay)
+		run.RetryAt = &retryAt
+		order.Retries++
+		order.NextRunAt = &retryAt
+	} else {
+		advanceStandingOrder(order, now)
+	}
+
+	err = s.uow.Do(func(repos repositories.Repositories) error {
+		return saveStandingOrderRun(repos.StandingOrders, &run, order, now)
+	})
+	if errors.Is(err, repositories.ErrStandingOrderNotDue) {
+		return nil // reivindicada por outro executor depois que a reivindicação expirou
+	}
+	return err
+}
+
+// saveStandingOrderRun grava o andamento da ordem, se ela ainda estiver
+// reivindicada em claimedAt, e a execução
+func saveStandingOrderRun(repo repositories.StandingOrderRepository, run *models.StandingOrderRun, order *models.StandingOrder, claimedAt time.Time) error {
+	if err := repo.UpdateStandingOrderSchedule(order, claimedAt); err != nil {
+		return err
+	}
+	return repo.CreateStandingOrderRun(run)
+}
+
+// advanceStandingOrder encerra a ocorrência pendente e agenda a próxima
+// ocorrência posterior a now. Ocorrências perdidas enquanto o executor estava
+// parado são puladas, para que a volta do servidor não dispare várias
+// transferências de uma vez.
+func advanceStandingOrder(order *models.StandingOrder, now time.Time) {
+	order.Occurrences++
+	order.Retries = 0
+
+	next := order.NextOccurrence(now)
+	if order.Finished(next) {
+		order.Status = models.StandingOrderCompleted
+		order.NextRunAt = nil
+		return
+	}
+	order.NextOccurrenceAt = next
+	order.NextRunAt = &next
+}
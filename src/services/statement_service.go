@@ -0,0 +1,60 @@
+// src/services/statement_service.go
+package services
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"time"
+)
+
+// StatementServiceInterface define a consulta dos extratos das contas
+type StatementServiceInterface interface {
+	GetStatement(accountNum string, from, to time.Time) (*models.Statement, error)
+}
+
+// StatementService é a implementação concreta do StatementServiceInterface
+type StatementService struct {
+	clientRepo repositories.ClientRepository
+	ledgerRepo repositories.LedgerRepository
+}
+
+// Certifique-se de que StatementService implementa StatementServiceInterface
+var _ StatementServiceInterface = (*StatementService)(nil)
+
+// NewStatementService cria uma nova instância de StatementService
+func NewStatementService(clientRepo repositories.ClientRepository, ledgerRepo repositories.LedgerRepository) *StatementService {
+	return &StatementService{clientRepo: clientRepo, ledgerRepo: ledgerRepo}
+}
+
+// GetStatement monta o extrato da conta entre from (inclusive) e to
+// (exclusive), pelo livro-razão e na moeda da conta. Sem to, o período vai
+// até agora; sem from, começa no início (em models.BusinessLocation) do mês em
+// que o período termina.
+func (s *StatementService) GetStatement(accountNum string, from, to time.Time) (*models.Statement, error) {
+	client, err := s.clientRepo.GetClientByAccountNum(accountNum)
+	if err != nil {
+		return nil, err
+	}
+	if to.IsZero() {
+		// O livro-razão registra os instantes com precisão de segundos, então o
+		// fim padrão inclui o segundo corrente
+		to = time.Now().Truncate(time.Second).Add(time.Second)
+	}
+	if from.IsZero() {
+		from = models.StartOfBusinessMonth(to.Add(-time.Nanosecond))
+	}
+	if !from.Before(to) {
+		return nil, models.ErrInvalidPeriod
+	}
+
+	currency := client.Balance.Currency
+	opening, err := s.ledgerRepo.GetBalanceAt(accountNum, currency, from)
+	if err != nil {
+		return nil, err
+	}
+	movements, err := s.ledgerRepo.GetStatementLines(accountNum, currency, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return models.NewStatement(accountNum, from, to, opening, movements), nil
+}
@@ -5,79 +5,514 @@ import (
 	"banking/src/models"
 	"banking/src/repositories"
 	"errors"
-	"sync"
+	"fmt"
+	"log"
+	"time"
 )
 
+// ErrLedgerMismatch indica que o saldo armazenado diverge do livro-razão
+var ErrLedgerMismatch = errors.New("ledger balance mismatch")
+
+var (
+	errNotReversible    = errors.New("only successful transfers between accounts can be reversed")
+	errReversalExceeded = errors.New("reversal exceeds the amount not yet reversed")
+)
+
+// TransferError é uma recusa de transferência com um motivo legível por máquina
+// (um dos códigos models.Failure*)
+type TransferError struct {
+	Reason string
+	Err    error
+}
+
+func (e *TransferError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *TransferError) Unwrap() error {
+	return e.Err
+}
+
+func declineTransfer(reason string, err error) error {
+	return &TransferError{Reason: reason, Err: err}
+}
+
+// FailureReason retorna o código do motivo de falha de um erro de transferência;
+// erros inesperados (banco de dados, livro-razão) são "internal_error"
+func FailureReason(err error) string {
+	var transferErr *TransferError
+	if errors.As(err, &transferErr) {
+		return transferErr.Reason
+	}
+	return models.FailureInternalError
+}
+
 // TransferServiceInterface define os métodos do serviço de transferência
 type TransferServiceInterface interface {
-	TransferFunds(fromAccountNum, toAccountNum string, amount float64) error
+	TransferFunds(fromAccountNum, toAccountNum string, amount models.Money) (*models.Transfer, error)
+	TransferFundsWith(fromAccountNum, toAccountNum string, amount models.Money, options TransferOptions) (*models.Transfer, error)
+	ReverseTransfer(transferID int, amount models.Money) (*models.Transfer, error)
 	GetTransferHistory(accountNum string) ([]models.Transfer, error)
 }
 
+// TransferOptions ajusta uma transferência feita em nome de outro serviço
+type TransferOptions struct {
+	// FundsHeld indica que o valor já está reservado na conta de origem (a
+	// captura de uma reserva). A reserva é liberada dentro da transação, antes
+	// do débito, e os limites, verificados ao reservar, não são verificados de
+	// novo.
+	FundsHeld bool
+	// Exempt dispensa a transferência de tarifa e dos limites da conta de
+	// origem. É usado por movimentações do próprio banco, como a transferência
+	// do saldo de uma conta que está sendo encerrada.
+	Exempt bool
+	// Then é executado dentro da transação da transferência, depois do débito,
+	// do crédito e da tarifa. Se retornar erro, a transferência é desfeita; ela
+	// só é registrada como recusada se o erro for uma recusa (*TransferError).
+	Then func(repos repositories.Repositories, transfer *models.Transfer) error
+}
+
 // TransferService é a implementação concreta do TransferServiceInterface
 type TransferService struct {
-	clientRepo    repositories.ClientRepository
-	transferRepo  repositories.TransferRepository
-	transferMutex sync.Mutex
+	clientRepo   repositories.ClientRepository
+	transferRepo repositories.TransferRepository
+	ledgerRepo   repositories.LedgerRepository
+	uow          repositories.UnitOfWork
+	accountLocks *AccountLocks
 }
 
 // Certifique-se de que TransferService implementa TransferServiceInterface
 var _ TransferServiceInterface = (*TransferService)(nil)
 
-// NewTransferService cria uma nova instância de TransferService
-func NewTransferService(clientRepo repositories.ClientRepository, transferRepo repositories.TransferRepository) *TransferService {
+// NewTransferService cria uma nova instância de TransferService. accountLocks
+// é compartilhado com o AccountService.
+func NewTransferService(clientRepo repositories.ClientRepository, transferRepo repositories.TransferRepository, ledgerRepo repositories.LedgerRepository, uow repositories.UnitOfWork, accountLocks *AccountLocks) *TransferService {
 	return &TransferService{
 		clientRepo:   clientRepo,
 		transferRepo: transferRepo,
+		ledgerRepo:   ledgerRepo,
+		uow:          uow,
+		accountLocks: accountLocks,
 	}
 }
 
-// TransferFunds realiza uma transferência entre duas contas
-func (s *TransferService) TransferFunds(fromAccountNum, toAccountNum string, amount float64) error {
-	if amount <= 0 || amount > 10000 {
-		return errors.New("amount must be between 0 and 10,000")
+// TransferFunds realiza uma transferência entre duas contas e retorna a
+// transferência registrada, com a tarifa cobrada em Fee. O débito, o crédito,
+// a tarifa, o registro da transferência e os lançamentos no livro-razão são
+// confirmados ou desfeitos juntos. Tentativas que falham são registradas com
+// status "failed" e o motivo da recusa.
+func (s *TransferService) TransferFunds(fromAccountNum, toAccountNum string, amount models.Money) (*models.Transfer, error) {
+	return s.TransferFundsWith(fromAccountNum, toAccountNum, amount, TransferOptions{})
+}
+
+// TransferFundsWith realiza uma transferência como TransferFunds, ajustada
+// por options
+func (s *TransferService) TransferFundsWith(fromAccountNum, toAccountNum string, amount models.Money, options TransferOptions) (*models.Transfer, error) {
+	if amount.Currency == "" {
+		amount.Currency = models.DefaultCurrency
+	}
+
+	transfer := &models.Transfer{
+		FromAccountNum: fromAccountNum,
+		ToAccountNum:   toAccountNum,
+		Amount:         amount,
+		Type:           models.TransferTypeTransfer,
 	}
+	err := s.transferFunds(transfer, options)
+	var hookErr *hookError
+	if errors.As(err, &hookErr) {
+		err = hookErr.err
+		var transferErr *TransferError
+		if !errors.As(err, &transferErr) {
+			return nil, err
+		}
+	}
+	if err != nil {
+		recordFailedTransfer(s.transferRepo, models.Transfer{
+			FromAccountNum: fromAccountNum,
+			ToAccountNum:   toAccountNum,
+			Amount:         amount,
+			Type:           models.TransferTypeTransfer,
+		}, err)
+		return nil, err
+	}
+	return transfer, nil
+}
 
-	s.transferMutex.Lock()
-	defer s.transferMutex.Unlock()
+// checkTransferAmount recusa valores não positivos. Os limites de valor
+// dependem da conta e são verificados na execução, por checkTransferLimits.
+func checkTransferAmount(amount models.Money) error {
+	if !amount.IsPositive() {
+		return declineTransfer(models.FailureInvalidAmount, errNonPositiveAmount)
+	}
+	return nil
+}
 
-	fromClient, err := s.clientRepo.GetClientByAccountNum(fromAccountNum)
+// checkTransferLimits verifica se amount cabe nos limites da conta de origem
+// (ou nos limites padrão, se ela não tiver limites próprios). Executa dentro
+// da transação da transferência, para que transferências concorrentes da
+// mesma conta não somem mais que o limite.
+func checkTransferLimits(limitRepo repositories.LimitRepository, accountNum string, amount models.Money, now time.Time) error {
+	limits, err := limitRepo.GetLimits(accountNum)
+	if errors.Is(err, repositories.ErrLimitsNotFound) {
+		limits, err = limitRepo.GetLimits(models.DefaultLimitsKey)
+		if errors.Is(err, repositories.ErrLimitsNotFound) {
+			return nil
+		}
+	}
 	if err != nil {
 		return err
 	}
 
-	if fromClient.Balance < amount {
-		return errors.New("insufficient balance")
+	usage, err := limitRepo.GetUsage(accountNum, amount.Currency, now)
+	if err != nil {
+		return err
 	}
+	if exceeded := limits.Check(amount, usage, now); exceeded != nil {
+		return declineTransfer(models.FailureLimitExceeded, exceeded)
+	}
+	return nil
+}
 
-	toClient, err := s.clientRepo.GetClientByAccountNum(toAccountNum)
-	if err != nil {
+func (s *TransferService) transferFunds(transfer *models.Transfer, options TransferOptions) error {
+	fromAccountNum, toAccountNum, amount := transfer.FromAccountNum, transfer.ToAccountNum, transfer.Amount
+	if err := checkTransferAmount(amount); err != nil {
 		return err
 	}
 
-	fromClient.Balance -= amount
-	toClient.Balance += amount
+	unlock := s.accountLocks.Lock(fromAccountNum, toAccountNum)
+	defer unlock()
+
+	return s.uow.Do(func(repos repositories.Repositories) error {
+		fromClient, err := repos.Clients.GetClientByAccountNum(fromAccountNum)
+		if errors.Is(err, repositories.ErrClientNotFound) {
+			return declineTransfer(models.FailureSourceNotFound, err)
+		}
+		if err != nil {
+			return err
+		}
+
+		if !fromClient.Balance.SameCurrency(amount) {
+			return declineTransfer(models.FailureCurrencyMismatch, models.ErrCurrencyMismatch)
+		}
+		if err := checkDebitAllowed(fromClient); err != nil {
+			return err
+		}
+
+		toClient, err := repos.Clients.GetClientByAccountNum(toAccountNum)
+		if errors.Is(err, repositories.ErrClientNotFound) {
+			return declineTransfer(models.FailureDestinationNotFound, err)
+		}
+		if err != nil {
+			return err
+		}
+
+		if !toClient.Balance.SameCurrency(amount) {
+			return declineTransfer(models.FailureCurrencyMismatch, models.ErrCurrencyMismatch)
+		}
+		if err := checkCreditAllowed(toClient); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if !options.FundsHeld && !options.Exempt {
+			if err := checkTransferLimits(repos.Limits, fromAccountNum, amount, now); err != nil {
+				return err
+			}
+		}
+		fee := models.NewMoney(0, amount.Currency)
+		if !options.Exempt {
+			if fee, err = transferFee(repos.Fees, fromClient, amount, now); err != nil {
+				return err
+			}
+		}
+		if options.FundsHeld {
+			if _, err := repos.Clients.ReleaseClientHold(fromAccountNum, amount); err != nil {
+				return err
+			}
+		}
+
+		// O saldo é verificado pelo próprio UPDATE, e não pela leitura acima, para
+		// que instâncias diferentes da aplicação nunca deixem a conta negativa
+		fromClient.Balance, toClient.Balance, err = postTransfer(repos, transfer, models.EntryTransfer)
+		if err != nil {
+			return err
+		}
+		if fee.IsPositive() {
+			if fromClient.Balance, err = postFee(repos, transfer, fee); err != nil {
+				return err
+			}
+		}
+		transfer.Fee = &fee
+		if options.Then != nil {
+			if err := options.Then(repos, transfer); err != nil {
+				return &hookError{err: err}
+			}
+		}
+		return nil
+	})
+}
+
+// hookError marca um erro retornado por TransferOptions.Then, que desfaz a
+// transferência sem que ela tenha sido recusada
+type hookError struct {
+	err error
+}
+
+func (e *hookError) Error() string {
+	return e.err.Error()
+}
+
+func (e *hookError) Unwrap() error {
+	return e.err
+}
+
+// checkDebitAllowed recusa o débito de uma conta cujo status não permite
+// débitos (congelada, bloqueada ou encerrada)
+func checkDebitAllowed(client *models.Client) error {
+	if !models.AllowsDebits(client.Status) {
+		return declineTransfer(models.FailureDebitsNotAllowed,
+			fmt.Errorf("%w: account %s is %s", models.ErrDebitsNotAllowed, client.AccountNum, client.Status))
+	}
+	return nil
+}
+
+// checkCreditAllowed recusa o crédito em uma conta cujo status não permite
+// créditos (bloqueada ou encerrada)
+func checkCreditAllowed(client *models.Client) error {
+	if !models.AllowsCredits(client.Status) {
+		return declineTransfer(models.FailureCreditsNotAllowed,
+			fmt.Errorf("%w: account %s is %s", models.ErrCreditsNotAllowed, client.AccountNum, client.Status))
+	}
+	return nil
+}
 
-	err = s.clientRepo.UpdateClientBalance(fromClient)
+// checkTransferAccounts verifica se as contas existem, usam a moeda do valor e
+// têm status que permitem debitar a origem e creditar o destino. Verifica
+// antes de agendar e, dentro da transação, antes de estornos e capturas de
+// reservas.
+func checkTransferAccounts(clientRepo repositories.ClientRepository, fromAccountNum, toAccountNum string, amount models.Money) error {
+	accounts := []struct {
+		accountNum  string
+		reason      string
+		checkStatus func(*models.Client) error
+	}{
+		{fromAccountNum, models.FailureSourceNotFound, checkDebitAllowed},
+		{toAccountNum, models.FailureDestinationNotFound, checkCreditAllowed},
+	}
+	for _, account := range accounts {
+		client, err := clientRepo.GetClientByAccountNum(account.accountNum)
+		if errors.Is(err, repositories.ErrClientNotFound) {
+			return declineTransfer(account.reason, err)
+		}
+		if err != nil {
+			return err
+		}
+		if !client.Balance.SameCurrency(amount) {
+			return declineTransfer(models.FailureCurrencyMismatch, models.ErrCurrencyMismatch)
+		}
+		if err := account.checkStatus(client); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// transferFee calcula a tarifa de uma transferência de amount feita por
+// client, pela tabela de tarifas do tipo da conta. Executa dentro da
+// transação da transferência, para que a cota de transferências gratuitas do
+// mês conte as transferências concorrentes.
+func transferFee(feeRepo repositories.FeeRepository, client *models.Client, amount models.Money, now time.Time) (models.Money, error) {
+	noFee := models.NewMoney(0, amount.Currency)
+	schedule, err := feeRepo.GetSchedule(client.AccountType)
+	if errors.Is(err, repositories.ErrFeeScheduleNotFound) {
+		return noFee, nil
+	}
 	if err != nil {
-		return err
+		return noFee, err
 	}
 
-	err = s.clientRepo.UpdateClientBalance(toClient)
+	transfers := 0
+	if schedule.FreeTransfers > 0 {
+		transfers, err = feeRepo.CountTransfersSince(client.AccountNum, models.StartOfBusinessMonth(now))
+		if err != nil {
+			return noFee, err
+		}
+	}
+	return schedule.Fee(amount, transfers), nil
+}
+
+// postFee debita a tarifa da conta de origem da transferência e a credita na
+// conta interna de receita de tarifas, registrando-a como uma transferência do
+// tipo "fee" ligada à tarifada. Retorna o novo saldo da origem.
+func postFee(repos repositories.Repositories, transfer *models.Transfer, fee models.Money) (models.Money, error) {
+	balance, err := repos.Clients.DebitClientBalance(transfer.FromAccountNum, fee)
+	if errors.Is(err, repositories.ErrInsufficientBalance) {
+		return models.Money{}, declineTransfer(models.FailureInsufficientBalance, err)
+	}
 	if err != nil {
-		return err
+		return models.Money{}, err
+	}
+	if err := notifyOverdraftChange(repos.Notifications, transfer.FromAccountNum, balance, fee.Neg()); err != nil {
+		return models.Money{}, err
 	}
 
-	transfer := models.Transfer{
-		FromAccountNum: fromAccountNum,
-		ToAccountNum:   toAccountNum,
+	charge := models.Transfer{
+		FromAccountNum: transfer.FromAccountNum,
+		ToAccountNum:   models.FeeRevenueAccountNum,
+		Amount:         fee,
+		Type:           models.TransferTypeFee,
+		Status:         models.TransferStatusSuccess,
+		FeeOf:          &transfer.ID,
+	}
+	if err := repos.Transfers.CreateTransfer(&charge); err != nil {
+		return models.Money{}, err
+	}
+	entry := models.NewTransferEntry(models.EntryFee, transfer.FromAccountNum, models.FeeRevenueAccountNum, fee)
+	entry.TransferID = &charge.ID
+	if err := repos.Ledger.CreateEntry(&entry); err != nil {
+		return models.Money{}, err
+	}
+
+	err = verifyLedgerBalances(repos.Ledger, &models.Client{AccountNum: transfer.FromAccountNum, Balance: balance})
+	return balance, err
+}
+
+// postTransfer debita a origem, credita o destino, registra a transferência
+// bem-sucedida e o seu lançamento no livro-razão e confere os saldos com ele.
+// Executa dentro da unidade de trabalho de quem chama e retorna os novos saldos
+// da origem e do destino.
+func postTransfer(repos repositories.Repositories, transfer *models.Transfer, description string) (models.Money, models.Money, error) {
+	fromBalance, err := repos.Clients.DebitClientBalance(transfer.FromAccountNum, transfer.Amount)
+	if errors.Is(err, repositories.ErrInsufficientBalance) {
+		return models.Money{}, models.Money{}, declineTransfer(models.FailureInsufficientBalance, err)
+	}
+	if err != nil {
+		return models.Money{}, models.Money{}, err
+	}
+	toBalance, err := repos.Clients.CreditClientBalance(transfer.ToAccountNum, transfer.Amount)
+	if err != nil {
+		return models.Money{}, models.Money{}, err
+	}
+
+	if err := notifyOverdraftChange(repos.Notifications, transfer.FromAccountNum, fromBalance, transfer.Amount.Neg()); err != nil {
+		return models.Money{}, models.Money{}, err
+	}
+	if err := notifyOverdraftChange(repos.Notifications, transfer.ToAccountNum, toBalance, transfer.Amount); err != nil {
+		return models.Money{}, models.Money{}, err
+	}
+
+	transfer.Status = models.TransferStatusSuccess
+	if err := repos.Transfers.CreateTransfer(transfer); err != nil {
+		return models.Money{}, models.Money{}, err
+	}
+
+	entry := models.NewTransferEntry(description, transfer.FromAccountNum, transfer.ToAccountNum, transfer.Amount)
+	entry.TransferID = &transfer.ID
+	if err := repos.Ledger.CreateEntry(&entry); err != nil {
+		return models.Money{}, models.Money{}, err
+	}
+
+	err = verifyLedgerBalances(repos.Ledger,
+		&models.Client{AccountNum: transfer.FromAccountNum, Balance: fromBalance},
+		&models.Client{AccountNum: transfer.ToAccountNum, Balance: toBalance})
+	return fromBalance, toBalance, err
+}
+
+// ReverseTransfer estorna a transferência transferID, devolvendo amount da
+// conta de destino para a de origem. Com amount zero, estorna todo o valor
+// ainda não estornado. O estorno é uma nova transferência ligada à original
+// por ReversalOf, e a soma dos estornos nunca ultrapassa o valor original.
+func (s *TransferService) ReverseTransfer(transferID int, amount models.Money) (*models.Transfer, error) {
+	original, err := s.transferRepo.GetTransferByID(transferID)
+	if err != nil {
+		return nil, err
+	}
+
+	reversal := models.Transfer{
+		FromAccountNum: original.ToAccountNum,
+		ToAccountNum:   original.FromAccountNum,
 		Amount:         amount,
-		Status:         "success",
+		Type:           models.TransferTypeReversal,
+		ReversalOf:     &original.ID,
+	}
+	if reversal.Amount.Currency == "" {
+		reversal.Amount.Currency = original.Amount.Currency
+	}
+
+	err = s.reverseTransfer(original, &reversal)
+	if err != nil {
+		recordFailedTransfer(s.transferRepo, reversal, err)
+		return nil, err
+	}
+	return &reversal, nil
+}
+
+func (s *TransferService) reverseTransfer(original *models.Transfer, reversal *models.Transfer) error {
+	if original.Type != models.TransferTypeTransfer || original.Status != models.TransferStatusSuccess {
+		return declineTransfer(models.FailureNotReversible, errNotReversible)
+	}
+	if !reversal.Amount.SameCurrency(original.Amount) {
+		return declineTransfer(models.FailureCurrencyMismatch, models.ErrCurrencyMismatch)
+	}
+	if reversal.Amount.IsNegative() {
+		return declineTransfer(models.FailureInvalidAmount, errNonPositiveAmount)
+	}
+
+	unlock := s.accountLocks.Lock(reversal.FromAccountNum, reversal.ToAccountNum)
+	defer unlock()
+
+	return s.uow.Do(func(repos repositories.Repositories) error {
+		// Lido dentro da transação para que estornos concorrentes não somem
+		// mais que o valor original
+		reversed, err := repos.Transfers.GetReversedAmount(original.ID, original.Amount.Currency)
+		if err != nil {
+			return err
+		}
+		remaining := original.Amount.Sub(reversed)
+		if reversal.Amount.IsZero() {
+			reversal.Amount = remaining
+		}
+		if !remaining.IsPositive() || reversal.Amount.GreaterThan(remaining) {
+			return declineTransfer(models.FailureReversalExceeded, errReversalExceeded)
+		}
+		if err := checkTransferAccounts(repos.Clients, reversal.FromAccountNum, reversal.ToAccountNum, reversal.Amount); err != nil {
+			return err
+		}
+
+		_, _, err = postTransfer(repos, reversal, models.EntryReversal)
+		return err
+	})
+}
+
+// recordFailedTransfer registra a tentativa recusada fora da transação que foi
+// desfeita. Uma falha ao registrar não substitui o erro original.
+func recordFailedTransfer(repo repositories.TransferRepository, transfer models.Transfer, cause error) {
+	transfer.Status = models.TransferStatusFailed
+	transfer.FailureReason = FailureReason(cause)
+	if err := repo.CreateTransfer(&transfer); err != nil {
+		log.Printf("Error recording failed %s from %s to %s: %v", transfer.Type, transfer.FromAccountNum, transfer.ToAccountNum, err)
+	}
+}
+
+// verifyLedgerBalances garante que o saldo armazenado de cada cliente é igual
+// à soma das suas partidas no livro-razão
+func verifyLedgerBalances(ledger repositories.LedgerRepository, clients ...*models.Client) error {
+	for _, client := range clients {
+		ledgerBalance, err := ledger.GetAccountBalance(client.AccountNum, client.Balance.Currency)
+		if err != nil {
+			return err
+		}
+		if ledgerBalance != client.Balance {
+			return fmt.Errorf("%w: account %s has balance %s but ledger says %s",
+				ErrLedgerMismatch, client.AccountNum, client.Balance, ledgerBalance)
+		}
 	}
-	return s.transferRepo.CreateTransfer(&transfer)
+	return nil
 }
 
-// GetTransferHistory retorna o histórico de transferências de uma conta específica
+// GetTransferHistory retorna o histórico de transferências de uma conta
+// específica a partir das partidas lançadas no livro-razão
 func (s *TransferService) GetTransferHistory(accountNum string) ([]models.Transfer, error) {
-	return s.transferRepo.GetTransfersByAccountNum(accountNum)
+	return s.ledgerRepo.GetTransfersByAccountNum(accountNum)
 }
@@ -0,0 +1,70 @@
+// src/services/treasury_service.go
+package services
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"strings"
+)
+
+// TreasuryServiceInterface define as consultas sobre o dinheiro do sistema
+type TreasuryServiceInterface interface {
+	GetPosition(currency string) (*models.TreasuryPosition, error)
+}
+
+// TreasuryService é a implementação concreta do TreasuryServiceInterface
+type TreasuryService struct {
+	uow repositories.UnitOfWork
+}
+
+// Certifique-se de que TreasuryService implementa TreasuryServiceInterface
+var _ TreasuryServiceInterface = (*TreasuryService)(nil)
+
+// NewTreasuryService cria uma nova instância de TreasuryService
+func NewTreasuryService(uow repositories.UnitOfWork) *TreasuryService {
+	return &TreasuryService{uow: uow}
+}
+
+// GetPosition calcula a posição da tesouraria em uma moeda. Os saldos do
+// livro-razão e dos clientes são lidos na mesma transação.
+func (s *TreasuryService) GetPosition(currency string) (*models.TreasuryPosition, error) {
+	if currency == "" {
+		currency = models.DefaultCurrency
+	}
+	currency = strings.ToUpper(currency)
+
+	position := &models.TreasuryPosition{
+		Currency:         currency,
+		TreasuryBalance:  models.NewMoney(0, currency),
+		InternalBalances: make(map[string]models.Money),
+		LedgerTotal:      models.NewMoney(0, currency),
+	}
+	err := s.uow.Do(func(repos repositories.Repositories) error {
+		balances, err := repos.Ledger.GetAccountBalances(currency)
+		if err != nil {
+			return err
+		}
+		for accountNum, balance := range balances {
+			position.LedgerTotal = position.LedgerTotal.Add(balance)
+			switch {
+			case accountNum == models.TreasuryAccountNum:
+				position.TreasuryBalance = balance
+			case strings.HasPrefix(accountNum, models.InternalAccountPrefix):
+				position.InternalBalances[accountNum] = balance
+			}
+		}
+
+		position.ClientBalances, err = repos.Clients.GetTotalBalance(currency)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	total := position.TreasuryBalance.Add(position.ClientBalances)
+	for _, balance := range position.InternalBalances {
+		total = total.Add(balance)
+	}
+	position.Balanced = position.LedgerTotal.IsZero() && total.IsZero()
+	return position, nil
+}
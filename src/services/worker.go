@@ -0,0 +1,44 @@
+// src/services/worker.go
+package services
+
+import (
+	"banking/src/models"
+	"context"
+	"log"
+	"time"
+)
+
+// DefaultWorkerInterval é o intervalo padrão entre as execuções dos trabalhos em segundo plano
+const DefaultWorkerInterval = 10 * time.Second
+
+// RunEvery executa job imediatamente e depois a cada interval, até ctx ser
+// cancelado. Erros são registrados no log e não interrompem o laço.
+func RunEvery(ctx context.Context, interval time.Duration, name string, job func(now time.Time) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := job(time.Now()); err != nil {
+			log.Printf("Error running %s: %v", name, err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// firstDueDay retorna o primeiro dia que uma rotina diária ainda precisa
+// processar antes de today: o dia seguinte a latest, o último já processado,
+// ou, se nenhum foi processado, o dia anterior a today
+func firstDueDay(latest string, today time.Time) (time.Time, error) {
+	if latest == "" {
+		return today.AddDate(0, 0, -1), nil
+	}
+	last, err := time.ParseInLocation(time.DateOnly, latest, models.BusinessLocation)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return last.AddDate(0, 0, 1), nil
+}
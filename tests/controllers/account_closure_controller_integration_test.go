@@ -0,0 +1,90 @@
+// src/controllers/account_closure_controller_integration_test.go
+package controllers
+
+import (
+	"banking/src/controllers"
+	"banking/src/models"
+	"banking/src/repositories"
+	"banking/src/services"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAccountClosureService implementa a interface AccountClosureServiceInterface para testes
+type MockAccountClosureService struct {
+	mock.Mock
+}
+
+func (m *MockAccountClosureService) CloseAccount(accountNum, toAccountNum, reason string) (*models.AccountClosure, error) {
+	args := m.Called(accountNum, toAccountNum, reason)
+	if closure, ok := args.Get(0).(*models.AccountClosure); ok {
+		return closure, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func setupRouterAccountClosure(mockService *MockAccountClosureService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	controllers.InitAccountClosureRoutes(r, mockService)
+	return r
+}
+
+func TestCloseAccount_Success(t *testing.T) {
+	mockService := new(MockAccountClosureService)
+	router := setupRouterAccountClosure(mockService)
+
+	mockService.On("CloseAccount", "123456", "654321", "mudança de banco").Return(&models.AccountClosure{
+		Account: &models.Client{AccountNum: "123456", Status: models.AccountStatusClosed},
+		Sweep:   &models.Transfer{ID: 9, Amount: models.BRL(4200)},
+	}, nil)
+
+	w := postJSON(router, "/v1/accounts/123456/close", `{"to_account": "654321", "reason": "mudança de banco"}`)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"closed"`)
+	assert.Contains(t, w.Body.String(), `"sweep":{"id":9`)
+}
+
+func TestCloseAccount_BodyIsOptional(t *testing.T) {
+	mockService := new(MockAccountClosureService)
+	router := setupRouterAccountClosure(mockService)
+
+	mockService.On("CloseAccount", "123456", "", "").Return(&models.AccountClosure{
+		Account: &models.Client{AccountNum: "123456", Status: models.AccountStatusClosed},
+	}, nil)
+
+	w := postJSON(router, "/v1/accounts/123456/close", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "sweep")
+}
+
+func TestCloseAccount_Errors(t *testing.T) {
+	cases := []struct {
+		err    error
+		status int
+	}{
+		{repositories.ErrClientNotFound, http.StatusNotFound},
+		{services.ErrSweepAccountRequired, http.StatusBadRequest},
+		{&services.TransferError{Reason: models.FailureDestinationNotFound, Err: repositories.ErrClientNotFound}, http.StatusBadRequest},
+		{services.ErrAccountHasPendingItems, http.StatusConflict},
+		{services.ErrAccountNotEmpty, http.StatusConflict},
+		{models.ErrInvalidStatusTransition, http.StatusConflict},
+		{errors.New("database is locked"), http.StatusInternalServerError},
+	}
+	for _, tc := range cases {
+		mockService := new(MockAccountClosureService)
+		router := setupRouterAccountClosure(mockService)
+		mockService.On("CloseAccount", "123456", "654321", "").Return(nil, tc.err)
+
+		w := postJSON(router, "/v1/accounts/123456/close", `{"to_account": "654321"}`)
+
+		assert.Equal(t, tc.status, w.Code, tc.err.Error())
+	}
+}
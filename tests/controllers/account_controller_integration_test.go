@@ -0,0 +1,152 @@
+// src/controllers/account_controller_integration_test.go
+package controllers
+
+import (
+	"banking/src/controllers"
+	"banking/src/models"
+	"banking/src/repositories"
+	"banking/src/services"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAccountService implementa a interface AccountServiceInterface para testes
+type MockAccountService struct {
+	mock.Mock
+}
+
+func (m *MockAccountService) Deposit(accountNum string, amount models.Money) (*models.Transfer, error) {
+	args := m.Called(accountNum, amount)
+	if transfer, ok := args.Get(0).(*models.Transfer); ok {
+		return transfer, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAccountService) Withdraw(accountNum string, amount models.Money) (*models.Transfer, error) {
+	args := m.Called(accountNum, amount)
+	if transfer, ok := args.Get(0).(*models.Transfer); ok {
+		return transfer, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAccountService) Fund(accountNum string, amount models.Money) (*models.Transfer, error) {
+	args := m.Called(accountNum, amount)
+	if transfer, ok := args.Get(0).(*models.Transfer); ok {
+		return transfer, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func setupRouterAccountIntegration(mockService *MockAccountService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	controllers.InitAccountRoutes(r, mockService)
+	return r
+}
+
+func postJSON(router *gin.Engine, path, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("POST", path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestDeposit_Success(t *testing.T) {
+	mockService := new(MockAccountService)
+	router := setupRouterAccountIntegration(mockService)
+
+	deposit := &models.Transfer{
+		ID:             7,
+		FromAccountNum: models.CashAccountNum,
+		ToAccountNum:   "123456",
+		Amount:         models.BRL(5000),
+		Type:           models.TransferTypeDeposit,
+		Status:         models.TransferStatusSuccess,
+	}
+	mockService.On("Deposit", "123456", models.BRL(5000)).Return(deposit, nil)
+
+	w := postJSON(router, "/v1/accounts/123456/deposits", `{"amount": 50}`)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response models.Transfer
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, response.ID)
+	assert.Equal(t, models.TransferTypeDeposit, response.Type)
+	mockService.AssertExpectations(t)
+}
+
+func TestWithdraw_InsufficientBalance(t *testing.T) {
+	mockService := new(MockAccountService)
+	router := setupRouterAccountIntegration(mockService)
+
+	declined := &services.TransferError{Reason: models.FailureInsufficientBalance, Err: repositories.ErrInsufficientBalance}
+	mockService.On("Withdraw", "123456", models.BRL(5000)).Return(nil, declined)
+
+	w := postJSON(router, "/v1/accounts/123456/withdrawals", `{"amount": {"cents": 5000, "currency": "BRL"}}`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response map[string]string
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, models.FailureInsufficientBalance, response["code"])
+}
+
+func TestWithdraw_AccountNotFound(t *testing.T) {
+	mockService := new(MockAccountService)
+	router := setupRouterAccountIntegration(mockService)
+
+	declined := &services.TransferError{Reason: models.FailureSourceNotFound, Err: repositories.ErrClientNotFound}
+	mockService.On("Withdraw", "999999", models.BRL(100)).Return(nil, declined)
+
+	w := postJSON(router, "/v1/accounts/999999/withdrawals", `{"amount": 1}`)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestDeposit_BadRequest(t *testing.T) {
+	mockService := new(MockAccountService)
+	router := setupRouterAccountIntegration(mockService)
+
+	w := postJSON(router, "/v1/accounts/123456/deposits", `{invalid_json}`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "Deposit", mock.Anything, mock.Anything)
+}
+
+func TestValidateAccountNum(t *testing.T) {
+	mockService := new(MockAccountService)
+	mockClientService := new(MockClientService)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(controllers.ValidateAccountNum(mockClientService))
+	controllers.InitAccountRoutes(router, mockService)
+	mockService.On("Deposit", "0001-00000123-4", models.BRL(500)).Return(&models.Transfer{ID: 1}, nil)
+	mockService.On("Deposit", "0042-00000123-2", models.BRL(500)).Return(&models.Transfer{ID: 2}, nil)
+	mockService.On("Deposit", "123456", models.BRL(500)).Return(&models.Transfer{ID: 3}, nil)
+	mockService.On("Deposit", "conta-antiga", models.BRL(500)).Return(&models.Transfer{ID: 4}, nil)
+	mockClientService.On("GetClientByAccountNum", "conta-antiga").Return(&models.Client{AccountNum: "conta-antiga"}, nil)
+	mockClientService.On("GetClientByAccountNum", mock.Anything).Return(nil, repositories.ErrClientNotFound)
+
+	// DV de qualquer algoritmo, números legados e contas existentes passam
+	assert.Equal(t, http.StatusCreated, postJSON(router, "/v1/accounts/0001-00000123-4/deposits", `{"amount": 5}`).Code)
+	assert.Equal(t, http.StatusCreated, postJSON(router, "/v1/accounts/0042-00000123-2/deposits", `{"amount": 5}`).Code)
+	assert.Equal(t, http.StatusCreated, postJSON(router, "/v1/accounts/123456/deposits", `{"amount": 5}`).Code)
+	assert.Equal(t, http.StatusCreated, postJSON(router, "/v1/accounts/conta-antiga/deposits", `{"amount": 5}`).Code)
+
+	w := postJSON(router, "/v1/accounts/0001-00000123-5/deposits", `{"amount": 5}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "check digit")
+	assert.Equal(t, http.StatusBadRequest, postJSON(router, "/v1/accounts/0001-123-4/deposits", `{"amount": 5}`).Code)
+	mockService.AssertNumberOfCalls(t, "Deposit", 4)
+}
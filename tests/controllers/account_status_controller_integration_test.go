@@ -0,0 +1,114 @@
+// src/controllers/account_status_controller_integration_test.go
+package controllers
+
+import (
+	"banking/src/controllers"
+	"banking/src/models"
+	"banking/src/repositories"
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAccountStatusService implementa a interface AccountStatusServiceInterface para testes
+type MockAccountStatusService struct {
+	mock.Mock
+}
+
+func (m *MockAccountStatusService) ChangeStatus(accountNum, status, reason string) (*models.Client, error) {
+	args := m.Called(accountNum, status, reason)
+	if client, ok := args.Get(0).(*models.Client); ok {
+		return client, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAccountStatusService) GetStatusHistory(accountNum string) ([]models.AccountStatusChange, error) {
+	args := m.Called(accountNum)
+	if history, ok := args.Get(0).([]models.AccountStatusChange); ok {
+		return history, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func setupRouterAccountStatus(mockService *MockAccountStatusService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	controllers.InitAccountStatusRoutes(r, mockService)
+	return r
+}
+
+func putAccountStatus(router *gin.Engine, accountNum, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("PUT", "/v1/admin/accounts/"+accountNum+"/status", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestSetAccountStatus_Success(t *testing.T) {
+	mockService := new(MockAccountStatusService)
+	router := setupRouterAccountStatus(mockService)
+
+	mockService.On("ChangeStatus", "123456", models.AccountStatusFrozen, "ordem judicial").
+		Return(&models.Client{AccountNum: "123456", Status: models.AccountStatusFrozen}, nil)
+
+	w := putAccountStatus(router, "123456", `{"status": "frozen", "reason": "ordem judicial"}`)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"frozen"`)
+}
+
+func TestSetAccountStatus_ReasonIsMandatory(t *testing.T) {
+	mockService := new(MockAccountStatusService)
+	router := setupRouterAccountStatus(mockService)
+
+	w := putAccountStatus(router, "123456", `{"status": "blocked"}`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "ChangeStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSetAccountStatus_Errors(t *testing.T) {
+	cases := []struct {
+		err    error
+		status int
+	}{
+		{repositories.ErrClientNotFound, http.StatusNotFound},
+		{fmt.Errorf("%w: %q", models.ErrInvalidAccountStatus, "suspended"), http.StatusBadRequest},
+		{models.ErrStatusReasonRequired, http.StatusBadRequest},
+		{fmt.Errorf("%w: from closed to active", models.ErrInvalidStatusTransition), http.StatusConflict},
+	}
+	for _, tc := range cases {
+		mockService := new(MockAccountStatusService)
+		router := setupRouterAccountStatus(mockService)
+		mockService.On("ChangeStatus", "123456", "active", "reabertura").Return(nil, tc.err)
+
+		w := putAccountStatus(router, "123456", `{"status": "active", "reason": "reabertura"}`)
+
+		assert.Equal(t, tc.status, w.Code, tc.err.Error())
+	}
+}
+
+func TestGetStatusHistory(t *testing.T) {
+	mockService := new(MockAccountStatusService)
+	router := setupRouterAccountStatus(mockService)
+
+	mockService.On("GetStatusHistory", "123456").Return([]models.AccountStatusChange{
+		{ID: 1, AccountNum: "123456", FromStatus: models.AccountStatusActive, ToStatus: models.AccountStatusBlocked, Reason: "titular falecido"},
+	}, nil)
+	mockService.On("GetStatusHistory", "999999").Return(nil, repositories.ErrClientNotFound)
+
+	w := getJSON(router, "/v1/accounts/123456/status-history")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"reason":"titular falecido"`)
+
+	w = getJSON(router, "/v1/accounts/999999/status-history")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,95 @@
+// src/controllers/balance_controller_integration_test.go
+package controllers
+
+import (
+	"banking/src/controllers"
+	"banking/src/models"
+	"banking/src/repositories"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockBalanceService implementa a interface BalanceServiceInterface para testes
+type MockBalanceService struct {
+	mock.Mock
+}
+
+func (m *MockBalanceService) GetBalance(accountNum string, asOf time.Time) (*models.AccountBalance, error) {
+	args := m.Called(accountNum, asOf)
+	if balance, ok := args.Get(0).(*models.AccountBalance); ok {
+		return balance, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockBalanceService) GetDailyBalances(accountNum string, from, to time.Time) ([]models.BalanceSnapshot, error) {
+	args := m.Called(accountNum, from, to)
+	if snapshots, ok := args.Get(0).([]models.BalanceSnapshot); ok {
+		return snapshots, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func setupRouterBalance(mockService *MockBalanceService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	controllers.InitBalanceRoutes(r, mockService)
+	return r
+}
+
+func TestGetBalance_AsOfDateIsEndOfDay(t *testing.T) {
+	mockService := new(MockBalanceService)
+	router := setupRouterBalance(mockService)
+
+	endOfDay := time.Date(2030, 3, 2, 0, 0, 0, 0, models.BusinessLocation)
+	mockService.On("GetBalance", "123456", endOfDay).
+		Return(&models.AccountBalance{AccountNum: "123456", AsOf: endOfDay, Balance: models.BRL(4200)}, nil)
+
+	w := getJSON(router, "/v1/accounts/123456/balance?as_of=2030-03-01")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response models.AccountBalance
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, models.BRL(4200), response.Balance)
+}
+
+func TestGetBalance_Errors(t *testing.T) {
+	mockService := new(MockBalanceService)
+	router := setupRouterBalance(mockService)
+
+	mockService.On("GetBalance", "999999", time.Time{}).Return(nil, repositories.ErrClientNotFound)
+
+	w := getJSON(router, "/v1/accounts/123456/balance?as_of=yesterday")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = getJSON(router, "/v1/accounts/999999/balance")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGetDailyBalances(t *testing.T) {
+	mockService := new(MockBalanceService)
+	router := setupRouterBalance(mockService)
+
+	from := time.Date(2030, 3, 1, 0, 0, 0, 0, models.BusinessLocation)
+	to := time.Date(2030, 3, 2, 0, 0, 0, 0, models.BusinessLocation)
+	mockService.On("GetDailyBalances", "123456", from, to).Return([]models.BalanceSnapshot{
+		{AccountNum: "123456", Date: "2030-03-01", Balance: models.BRL(100)},
+		{AccountNum: "123456", Date: "2030-03-02", Balance: models.BRL(300)},
+	}, nil)
+	mockService.On("GetDailyBalances", "123456", to, from).Return(nil, models.ErrInvalidPeriod)
+
+	w := getJSON(router, "/v1/accounts/123456/daily-balances?from=2030-03-01&to=2030-03-02")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response []models.BalanceSnapshot
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response, 2)
+
+	w = getJSON(router, "/v1/accounts/123456/daily-balances?from=2030-03-02&to=2030-03-01")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
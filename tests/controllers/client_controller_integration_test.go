@@ -4,6 +4,7 @@ package controllers
 import (
 	"banking/src/controllers"
 	"banking/src/models"
+	"banking/src/repositories"
 	"bytes"
 	"encoding/json"
 	"errors"
@@ -34,6 +35,14 @@ func (m *MockClientService) CreateClient(client *models.Client) error {
 	return args.Error(0)
 }
 
+func (m *MockClientService) UpdateClient(accountNum, name string, expectedVersion int) (*models.Client, error) {
+	args := m.Called(accountNum, name, expectedVersion)
+	if client, ok := args.Get(0).(*models.Client); ok {
+		return client, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 func (m *MockClientService) GetClients() ([]models.Client, error) {
 	args := m.Called()
 	return args.Get(0).([]models.Client), args.Error(1)
@@ -50,8 +59,14 @@ func TestCreateClient_Success(t *testing.T) {
 	mockService := new(MockClientService)
 	router := setupRouterClientIntegration(mockService)
 
-	client := models.Client{Name: "John Doe", AccountNum: "123456", Balance: 1000.0}
-	mockService.On("CreateClient", &client).Return(nil)
+	client := models.Client{Name: "John Doe", AccountNum: "123456", Balance: models.BRL(100000),
+		Held: models.BRL(0), AvailableBalance: models.BRL(0), OverdraftLimit: models.BRL(0), OverdraftUsage: models.BRL(0)}
+	// O número enviado é ignorado: o serviço gera outro
+	mockService.On("CreateClient", mock.MatchedBy(func(c *models.Client) bool {
+		return c.Name == client.Name && c.AccountNum == ""
+	})).Run(func(args mock.Arguments) {
+		args.Get(0).(*models.Client).AccountNum = "0001-00000001-7"
+	}).Return(nil)
 
 	clientJSON, _ := json.Marshal(client)
 	req, _ := http.NewRequest("POST", "/v1/clients", bytes.NewBuffer(clientJSON))
@@ -65,7 +80,7 @@ func TestCreateClient_Success(t *testing.T) {
 	err := json.Unmarshal(w.Body.Bytes(), &responseClient)
 	assert.NoError(t, err)
 	assert.Equal(t, client.Name, responseClient.Name)
-	assert.Equal(t, client.AccountNum, responseClient.AccountNum)
+	assert.Equal(t, "0001-00000001-7", responseClient.AccountNum)
 	assert.Equal(t, client.Balance, responseClient.Balance)
 
 	mockService.AssertExpectations(t)
@@ -95,8 +110,8 @@ func TestGetClients_Success(t *testing.T) {
 	router := setupRouterClientIntegration(mockService)
 
 	clients := []models.Client{
-		{Name: "John Doe", AccountNum: "123456", Balance: 1000.0},
-		{Name: "Jane Doe", AccountNum: "654321", Balance: 2000.0},
+		{Name: "John Doe", AccountNum: "123456", Balance: models.BRL(100000)},
+		{Name: "Jane Doe", AccountNum: "654321", Balance: models.BRL(200000)},
 	}
 	mockService.On("GetClients").Return(clients, nil)
 
@@ -143,7 +158,7 @@ func TestGetClientByAccountNum_Success(t *testing.T) {
 		ID:         1,
 		Name:       "John Doe",
 		AccountNum: "123456",
-		Balance:    1000.0,
+		Balance:    models.BRL(100000),
 	}
 	mockService.On("GetClientByAccountNum", "123456").Return(client, nil)
 
@@ -182,3 +197,110 @@ func TestGetClientByAccountNum_NotFound(t *testing.T) {
 
 	mockService.AssertExpectations(t)
 }
+
+func TestGetClientByAccountNum_ETag(t *testing.T) {
+	mockService := new(MockClientService)
+	router := setupRouterClientIntegration(mockService)
+
+	client := &models.Client{ID: 1, Name: "John Doe", AccountNum: "123456", Balance: models.BRL(100000), Version: 3}
+	mockService.On("GetClientByAccountNum", "123456").Return(client, nil)
+
+	req, _ := http.NewRequest("GET", "/v1/clients/123456", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+
+	// Com a ETag atual, a resposta é 304 sem corpo
+	req, _ = http.NewRequest("GET", "/v1/clients/123456", nil)
+	req.Header.Set("If-None-Match", `"3"`)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+}
+
+func newUpdateClientRequest(accountNum, body, ifMatch string) *http.Request {
+	req, _ := http.NewRequest("PATCH", "/v1/clients/"+accountNum, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+	return req
+}
+
+func TestUpdateClient_WithIfMatch(t *testing.T) {
+	mockService := new(MockClientService)
+	router := setupRouterClientIntegration(mockService)
+
+	updated := &models.Client{ID: 1, Name: "John Smith", AccountNum: "123456", Balance: models.BRL(100000), Version: 4}
+	mockService.On("UpdateClient", "123456", "John Smith", 3).Return(updated, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newUpdateClientRequest("123456", `{"name": "John Smith"}`, `"3"`))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"4"`, w.Header().Get("ETag"))
+	var responseClient models.Client
+	err := json.Unmarshal(w.Body.Bytes(), &responseClient)
+	assert.NoError(t, err)
+	assert.Equal(t, "John Smith", responseClient.Name)
+	assert.Equal(t, 4, responseClient.Version)
+	mockService.AssertExpectations(t)
+}
+
+func TestUpdateClient_WithoutIfMatch(t *testing.T) {
+	mockService := new(MockClientService)
+	router := setupRouterClientIntegration(mockService)
+
+	updated := &models.Client{Name: "John Smith", AccountNum: "123456", Version: 2}
+	mockService.On("UpdateClient", "123456", "John Smith", 0).Return(updated, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newUpdateClientRequest("123456", `{"name": "John Smith"}`, ""))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestUpdateClient_VersionConflict(t *testing.T) {
+	mockService := new(MockClientService)
+	router := setupRouterClientIntegration(mockService)
+
+	mockService.On("UpdateClient", "123456", "John Smith", 3).
+		Return(nil, &repositories.VersionConflictError{AccountNum: "123456", ExpectedVersion: 3})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newUpdateClientRequest("123456", `{"name": "John Smith"}`, `"3"`))
+
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	var response map[string]string
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Contains(t, response["error"], "modified by another request")
+}
+
+func TestUpdateClient_InvalidIfMatch(t *testing.T) {
+	mockService := new(MockClientService)
+	router := setupRouterClientIntegration(mockService)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newUpdateClientRequest("123456", `{"name": "John Smith"}`, `"abc"`))
+
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	mockService.AssertNotCalled(t, "UpdateClient", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateClient_NotFound(t *testing.T) {
+	mockService := new(MockClientService)
+	router := setupRouterClientIntegration(mockService)
+
+	mockService.On("UpdateClient", "999999", "John Smith", 0).Return(nil, repositories.ErrClientNotFound)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newUpdateClientRequest("999999", `{"name": "John Smith"}`, ""))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,115 @@
+// src/controllers/customer_controller_integration_test.go
+package controllers
+
+import (
+	"banking/src/controllers"
+	"banking/src/models"
+	"banking/src/repositories"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockCustomerService implementa a interface CustomerServiceInterface para testes
+type MockCustomerService struct {
+	mock.Mock
+}
+
+func (m *MockCustomerService) CreateCustomer(name string) (*models.Customer, error) {
+	args := m.Called(name)
+	if customer, ok := args.Get(0).(*models.Customer); ok {
+		return customer, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockCustomerService) GetCustomer(id int) (*models.Customer, error) {
+	args := m.Called(id)
+	if customer, ok := args.Get(0).(*models.Customer); ok {
+		return customer, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockCustomerService) GetAccounts(customerID int) ([]models.Account, error) {
+	args := m.Called(customerID)
+	if accounts, ok := args.Get(0).([]models.Account); ok {
+		return accounts, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockCustomerService) OpenAccount(customerID int, account *models.Account) error {
+	args := m.Called(customerID, account)
+	return args.Error(0)
+}
+
+func setupRouterCustomer(mockService *MockCustomerService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	controllers.InitCustomerRoutes(r, mockService)
+	return r
+}
+
+func TestCreateCustomer_Success(t *testing.T) {
+	mockService := new(MockCustomerService)
+	router := setupRouterCustomer(mockService)
+	mockService.On("CreateCustomer", "Maria Silva").Return(&models.Customer{ID: 7, Name: "Maria Silva"}, nil)
+
+	w := postJSON(router, "/v1/customers", `{"name": "Maria Silva"}`)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"id":7`)
+
+	w = postJSON(router, "/v1/customers", `{}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetCustomerAccounts(t *testing.T) {
+	mockService := new(MockCustomerService)
+	router := setupRouterCustomer(mockService)
+	mockService.On("GetAccounts", 7).Return([]models.Account{
+		{ID: 1, CustomerID: 7, AccountNum: "111111", AccountType: models.AccountTypeChecking},
+		{ID: 2, CustomerID: 7, AccountNum: "222222", AccountType: models.AccountTypeSavings},
+	}, nil)
+	mockService.On("GetAccounts", 8).Return(nil, repositories.ErrCustomerNotFound)
+
+	w := getJSON(router, "/v1/customers/7/accounts")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var accounts []models.Account
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &accounts))
+	assert.Len(t, accounts, 2)
+	assert.Equal(t, "222222", accounts[1].AccountNum)
+
+	assert.Equal(t, http.StatusNotFound, getJSON(router, "/v1/customers/8/accounts").Code)
+	assert.Equal(t, http.StatusBadRequest, getJSON(router, "/v1/customers/abc/accounts").Code)
+}
+
+func TestOpenCustomerAccount(t *testing.T) {
+	mockService := new(MockCustomerService)
+	router := setupRouterCustomer(mockService)
+	mockService.On("OpenAccount", 7, mock.MatchedBy(func(a *models.Account) bool {
+		return a.AccountNum == "" && a.AccountType == models.AccountTypeSavings && a.Balance.Currency == "USD"
+	})).Run(func(args mock.Arguments) {
+		account := args.Get(1).(*models.Account)
+		account.CustomerID = 7
+		account.AccountNum = "0001-00000002-5"
+		account.Version = 1
+	}).Return(nil)
+	mockService.On("OpenAccount", 8, mock.Anything).Return(repositories.ErrCustomerNotFound)
+	mockService.On("OpenAccount", 7, mock.Anything).Return(models.ErrInvalidAccountType)
+
+	w := postJSON(router, "/v1/customers/7/accounts", `{"account_num": "123456", "account_type": "savings", "currency": "USD"}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, `"1"`, w.Header().Get("ETag"))
+	assert.Contains(t, w.Body.String(), `"customer_id":7`)
+	assert.Contains(t, w.Body.String(), `"account_num":"0001-00000002-5"`)
+
+	assert.Equal(t, http.StatusNotFound, postJSON(router, "/v1/customers/8/accounts", ``).Code)
+	assert.Equal(t, http.StatusBadRequest, postJSON(router, "/v1/customers/7/accounts", `{"account_type": "investment"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, postJSON(router, "/v1/customers/7/accounts", `{invalid`).Code)
+}
@@ -0,0 +1,95 @@
+// src/controllers/fee_controller_integration_test.go
+package controllers
+
+import (
+	"banking/src/controllers"
+	"banking/src/models"
+	"banking/src/services"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockFeeService implementa a interface FeeServiceInterface para testes
+type MockFeeService struct {
+	mock.Mock
+}
+
+func (m *MockFeeService) GetSchedules() ([]models.FeeSchedule, error) {
+	args := m.Called()
+	if schedules, ok := args.Get(0).([]models.FeeSchedule); ok {
+		return schedules, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockFeeService) SetSchedule(schedule models.FeeSchedule) (*models.FeeSchedule, error) {
+	args := m.Called(schedule)
+	if saved, ok := args.Get(0).(*models.FeeSchedule); ok {
+		return saved, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func setupRouterFee(mockService *MockFeeService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	controllers.InitFeeRoutes(r, mockService)
+	return r
+}
+
+func sendFeeScheduleRequest(router *gin.Engine, accountType, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("PUT", "/v1/admin/fees/"+accountType, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestGetFeeSchedules(t *testing.T) {
+	mockService := new(MockFeeService)
+	router := setupRouterFee(mockService)
+
+	schedules := []models.FeeSchedule{{AccountType: models.AccountTypeChecking, Currency: "BRL", Flat: models.BRL(150)}}
+	mockService.On("GetSchedules").Return(schedules, nil)
+
+	req, _ := http.NewRequest("GET", "/v1/admin/fees", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response []models.FeeSchedule
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, models.BRL(150), response[0].Flat)
+}
+
+func TestSetFeeSchedule(t *testing.T) {
+	mockService := new(MockFeeService)
+	router := setupRouterFee(mockService)
+
+	mockService.On("SetSchedule", mock.MatchedBy(func(schedule models.FeeSchedule) bool {
+		return schedule.AccountType == models.AccountTypeSavings && schedule.PercentBps == 50 && schedule.FreeTransfers == 5
+	})).Return(&models.FeeSchedule{AccountType: models.AccountTypeSavings, Currency: "BRL", PercentBps: 50, FreeTransfers: 5}, nil)
+	mockService.On("SetSchedule", mock.MatchedBy(func(schedule models.FeeSchedule) bool {
+		return schedule.AccountType == "investment"
+	})).Return(nil, fmt.Errorf("%w: %w", services.ErrInvalidFeeSchedule, models.ErrInvalidAccountType))
+
+	w := sendFeeScheduleRequest(router, models.AccountTypeSavings, `{"percent_bps": 50, "free_transfers": 5}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response models.FeeSchedule
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 5, response.FreeTransfers)
+
+	w = sendFeeScheduleRequest(router, "investment", `{}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = sendFeeScheduleRequest(router, models.AccountTypeSavings, `{"flat": "abc"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,155 @@
+// src/controllers/hold_controller_integration_test.go
+package controllers
+
+import (
+	"banking/src/controllers"
+	"banking/src/models"
+	"banking/src/repositories"
+	"banking/src/services"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockHoldService implementa a interface HoldServiceInterface para testes
+type MockHoldService struct {
+	mock.Mock
+}
+
+func (m *MockHoldService) PlaceHold(accountNum, toAccountNum string, amount models.Money, expiresAt time.Time) (*models.Hold, error) {
+	args := m.Called(accountNum, toAccountNum, amount, expiresAt)
+	if hold, ok := args.Get(0).(*models.Hold); ok {
+		return hold, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockHoldService) GetHold(id int) (*models.Hold, error) {
+	args := m.Called(id)
+	if hold, ok := args.Get(0).(*models.Hold); ok {
+		return hold, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockHoldService) GetHolds(accountNum, status string) ([]models.Hold, error) {
+	args := m.Called(accountNum, status)
+	if holds, ok := args.Get(0).([]models.Hold); ok {
+		return holds, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockHoldService) CaptureHold(id int, amount models.Money) (*models.Hold, *models.Transfer, error) {
+	args := m.Called(id, amount)
+	hold, _ := args.Get(0).(*models.Hold)
+	transfer, _ := args.Get(1).(*models.Transfer)
+	return hold, transfer, args.Error(2)
+}
+
+func (m *MockHoldService) VoidHold(id int) (*models.Hold, error) {
+	args := m.Called(id)
+	if hold, ok := args.Get(0).(*models.Hold); ok {
+		return hold, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func setupRouterHolds(mockService *MockHoldService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	controllers.InitHoldRoutes(r, mockService)
+	return r
+}
+
+func sendHoldRequest(router *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestPlaceHold_Success(t *testing.T) {
+	mockService := new(MockHoldService)
+	router := setupRouterHolds(mockService)
+
+	hold := &models.Hold{ID: 1, AccountNum: "123456", ToAccountNum: "654321", Amount: models.BRL(5000), Status: models.HoldStatusActive}
+	mockService.On("PlaceHold", "123456", "654321", models.BRL(5000), time.Time{}).Return(hold, nil)
+
+	w := sendHoldRequest(router, "POST", "/v1/accounts/123456/holds", `{"to_account": "654321", "amount": 50}`)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response models.Hold
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.ID)
+	mockService.AssertExpectations(t)
+}
+
+func TestPlaceHold_Declined(t *testing.T) {
+	mockService := new(MockHoldService)
+	router := setupRouterHolds(mockService)
+
+	mockService.On("PlaceHold", "123456", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, &services.TransferError{Reason: models.FailureInsufficientBalance, Err: repositories.ErrInsufficientBalance})
+	mockService.On("PlaceHold", "999999", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, &services.TransferError{Reason: models.FailureSourceNotFound, Err: repositories.ErrClientNotFound})
+
+	w := sendHoldRequest(router, "POST", "/v1/accounts/123456/holds", `{"to_account": "654321", "amount": 50}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), models.FailureInsufficientBalance)
+
+	w = sendHoldRequest(router, "POST", "/v1/accounts/999999/holds", `{"to_account": "654321", "amount": 50}`)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestCaptureHold_Partial(t *testing.T) {
+	mockService := new(MockHoldService)
+	router := setupRouterHolds(mockService)
+
+	hold := &models.Hold{ID: 1, Amount: models.BRL(5000), CapturedAmount: models.BRL(2000), Status: models.HoldStatusActive}
+	transfer := &models.Transfer{ID: 9, Amount: models.BRL(2000), Type: models.TransferTypeTransfer}
+	mockService.On("CaptureHold", 1, models.BRL(2000)).Return(hold, transfer, nil)
+	mockService.On("CaptureHold", 1, models.Money{}).Return(nil, nil, repositories.ErrHoldNotActive)
+	mockService.On("CaptureHold", 2, mock.Anything).Return(nil, nil, models.ErrCaptureExceedsHold)
+
+	w := sendHoldRequest(router, "POST", "/v1/holds/1/capture", `{"amount": 20}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response controllers.CaptureResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, models.BRL(2000), response.Hold.CapturedAmount)
+	assert.Equal(t, 9, response.Transfer.ID)
+
+	// Sem corpo, a captura é do restante
+	assert.Equal(t, http.StatusConflict, sendHoldRequest(router, "POST", "/v1/holds/1/capture", "").Code)
+	assert.Equal(t, http.StatusBadRequest, sendHoldRequest(router, "POST", "/v1/holds/2/capture", `{"amount": 999}`).Code)
+}
+
+func TestVoidAndGetHold(t *testing.T) {
+	mockService := new(MockHoldService)
+	router := setupRouterHolds(mockService)
+
+	voided := &models.Hold{ID: 1, Status: models.HoldStatusVoided}
+	mockService.On("VoidHold", 1).Return(voided, nil)
+	mockService.On("VoidHold", 2).Return(nil, repositories.ErrHoldNotFound)
+	mockService.On("GetHold", 1).Return(voided, nil)
+	mockService.On("GetHolds", "123456", "active").Return([]models.Hold{}, nil)
+	mockService.On("GetHolds", "999999", "").Return(nil, repositories.ErrClientNotFound)
+	mockService.On("GetHold", 3).Return(nil, errors.New("database is locked"))
+
+	assert.Equal(t, http.StatusOK, sendHoldRequest(router, "POST", "/v1/holds/1/void", "").Code)
+	assert.Equal(t, http.StatusNotFound, sendHoldRequest(router, "POST", "/v1/holds/2/void", "").Code)
+	assert.Equal(t, http.StatusOK, sendHoldRequest(router, "GET", "/v1/holds/1", "").Code)
+	assert.Equal(t, http.StatusInternalServerError, sendHoldRequest(router, "GET", "/v1/holds/3", "").Code)
+	assert.Equal(t, http.StatusBadRequest, sendHoldRequest(router, "GET", "/v1/holds/abc", "").Code)
+	assert.Equal(t, http.StatusOK, sendHoldRequest(router, "GET", "/v1/accounts/123456/holds?status=active", "").Code)
+	assert.Equal(t, http.StatusNotFound, sendHoldRequest(router, "GET", "/v1/accounts/999999/holds", "").Code)
+}
@@ -0,0 +1,124 @@
+// src/controllers/interest_controller_integration_test.go
+package controllers
+
+import (
+	"banking/src/controllers"
+	"banking/src/models"
+	"banking/src/repositories"
+	"banking/src/services"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockInterestService implementa a interface InterestServiceInterface para testes
+type MockInterestService struct {
+	mock.Mock
+}
+
+func (m *MockInterestService) GetRates() ([]models.InterestRate, error) {
+	args := m.Called()
+	if rates, ok := args.Get(0).([]models.InterestRate); ok {
+		return rates, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockInterestService) SetRate(accountType string, rateBps int) (*models.InterestRate, error) {
+	args := m.Called(accountType, rateBps)
+	if rate, ok := args.Get(0).(*models.InterestRate); ok {
+		return rate, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockInterestService) GetInterest(accountNum string) (*models.InterestHistory, error) {
+	args := m.Called(accountNum)
+	if history, ok := args.Get(0).(*models.InterestHistory); ok {
+		return history, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func setupRouterInterest(mockService *MockInterestService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	controllers.InitInterestRoutes(r, mockService)
+	return r
+}
+
+func sendInterestRateRequest(router *gin.Engine, accountType, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("PUT", "/v1/admin/interest-rates/"+accountType, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestGetInterestRates(t *testing.T) {
+	mockService := new(MockInterestService)
+	router := setupRouterInterest(mockService)
+
+	rates := []models.InterestRate{{AccountType: models.AccountTypeChecking}, {AccountType: models.AccountTypeSavings, RateBps: 650}}
+	mockService.On("GetRates").Return(rates, nil)
+
+	req, _ := http.NewRequest("GET", "/v1/admin/interest-rates", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response []models.InterestRate
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 650, response[1].RateBps)
+}
+
+func TestSetInterestRate(t *testing.T) {
+	mockService := new(MockInterestService)
+	router := setupRouterInterest(mockService)
+
+	mockService.On("SetRate", models.AccountTypeSavings, 650).Return(&models.InterestRate{AccountType: models.AccountTypeSavings, RateBps: 650}, nil)
+	mockService.On("SetRate", "investment", mock.Anything).
+		Return(nil, fmt.Errorf("%w: %w", services.ErrInvalidRate, models.ErrInvalidAccountType))
+
+	w := sendInterestRateRequest(router, models.AccountTypeSavings, `{"rate_bps": 650}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response models.InterestRate
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 650, response.RateBps)
+
+	assert.Equal(t, http.StatusBadRequest, sendInterestRateRequest(router, "investment", `{"rate_bps": 650}`).Code)
+	assert.Equal(t, http.StatusBadRequest, sendInterestRateRequest(router, models.AccountTypeSavings, `{invalid_json}`).Code)
+}
+
+func TestGetInterest(t *testing.T) {
+	mockService := new(MockInterestService)
+	router := setupRouterInterest(mockService)
+
+	history := &models.InterestHistory{
+		Accruals:        []models.InterestAccrual{{AccountNum: "123456", AccrualDate: "2030-03-01", Interest: models.BRL(3)}},
+		Capitalizations: []models.InterestCapitalization{},
+	}
+	mockService.On("GetInterest", "123456").Return(history, nil)
+	mockService.On("GetInterest", "999999").Return(nil, repositories.ErrClientNotFound)
+
+	req, _ := http.NewRequest("GET", "/v1/accounts/123456/interest", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response models.InterestHistory
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, models.BRL(3), response.Accruals[0].Interest)
+
+	req, _ = http.NewRequest("GET", "/v1/accounts/999999/interest", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,129 @@
+// src/controllers/limit_controller_integration_test.go
+package controllers
+
+import (
+	"banking/src/controllers"
+	"banking/src/models"
+	"banking/src/repositories"
+	"banking/src/services"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockLimitService implementa a interface LimitServiceInterface para testes
+type MockLimitService struct {
+	mock.Mock
+}
+
+func (m *MockLimitService) GetLimits(accountNum string) (*models.LimitStatus, error) {
+	args := m.Called(accountNum)
+	if status, ok := args.Get(0).(*models.LimitStatus); ok {
+		return status, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockLimitService) SetLimits(limits models.TransferLimits) (*models.TransferLimits, error) {
+	args := m.Called(limits)
+	if saved, ok := args.Get(0).(*models.TransferLimits); ok {
+		return saved, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockLimitService) DeleteLimits(accountNum string) error {
+	args := m.Called(accountNum)
+	return args.Error(0)
+}
+
+func setupRouterLimits(mockService *MockLimitService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	controllers.InitLimitRoutes(r, mockService)
+	return r
+}
+
+func sendLimitsRequest(router *gin.Engine, method, accountNum, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, "/v1/admin/limits/"+accountNum, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestGetLimits_Success(t *testing.T) {
+	mockService := new(MockLimitService)
+	router := setupRouterLimits(mockService)
+
+	status := &models.LimitStatus{
+		Limits:    models.TransferLimits{AccountNum: models.DefaultLimitsKey, PerTransfer: models.BRL(1000000)},
+		Inherited: true,
+		Usage:     &models.LimitUsage{Daily: models.BRL(5000), DailyCount: 1},
+	}
+	mockService.On("GetLimits", "123456").Return(status, nil)
+
+	w := sendLimitsRequest(router, "GET", "123456", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response models.LimitStatus
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.True(t, response.Inherited)
+	assert.Equal(t, models.BRL(1000000), response.Limits.PerTransfer)
+	assert.Equal(t, 1, response.Usage.DailyCount)
+}
+
+func TestGetLimits_UnknownAccount(t *testing.T) {
+	mockService := new(MockLimitService)
+	router := setupRouterLimits(mockService)
+
+	mockService.On("GetLimits", "999999").Return(nil, repositories.ErrClientNotFound)
+
+	w := sendLimitsRequest(router, "GET", "999999", "")
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestSetLimits_Success(t *testing.T) {
+	mockService := new(MockLimitService)
+	router := setupRouterLimits(mockService)
+
+	expected := models.TransferLimits{AccountNum: "123456", Daily: models.BRL(50000), DailyCount: 10, NightTotal: models.BRL(10000)}
+	mockService.On("SetLimits", expected).Return(&expected, nil)
+
+	w := sendLimitsRequest(router, "PUT", "123456", `{"daily": 500, "daily_count": 10, "night_total": "100.00"}`)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestSetLimits_Invalid(t *testing.T) {
+	mockService := new(MockLimitService)
+	router := setupRouterLimits(mockService)
+
+	mockService.On("SetLimits", mock.Anything).Return(nil, fmt.Errorf("%w: %w", services.ErrInvalidLimits, models.ErrNegativeLimit))
+
+	w := sendLimitsRequest(router, "PUT", "123456", `{"daily": -1}`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestDeleteLimits(t *testing.T) {
+	mockService := new(MockLimitService)
+	router := setupRouterLimits(mockService)
+
+	mockService.On("DeleteLimits", "123456").Return(nil)
+	mockService.On("DeleteLimits", "654321").Return(repositories.ErrLimitsNotFound)
+	mockService.On("DeleteLimits", models.DefaultLimitsKey).Return(services.ErrDefaultLimitsRequired)
+
+	assert.Equal(t, http.StatusNoContent, sendLimitsRequest(router, "DELETE", "123456", "").Code)
+	assert.Equal(t, http.StatusNotFound, sendLimitsRequest(router, "DELETE", "654321", "").Code)
+	assert.Equal(t, http.StatusConflict, sendLimitsRequest(router, "DELETE", models.DefaultLimitsKey, "").Code)
+}
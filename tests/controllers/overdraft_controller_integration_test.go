@@ -0,0 +1,140 @@
+// src/controllers/overdraft_controller_integration_test.go
+package controllers
+
+import (
+	"banking/src/controllers"
+	"banking/src/models"
+	"banking/src/repositories"
+	"banking/src/services"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockOverdraftService implementa a interface OverdraftServiceInterface para testes
+type MockOverdraftService struct {
+	mock.Mock
+}
+
+func (m *MockOverdraftService) SetOverdraft(accountNum string, limit models.Money, rateBps int) (*models.Client, error) {
+	args := m.Called(accountNum, limit, rateBps)
+	if client, ok := args.Get(0).(*models.Client); ok {
+		return client, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockOverdraftService) GetOverdraftInterest(accountNum string) ([]models.OverdraftInterest, error) {
+	args := m.Called(accountNum)
+	if charges, ok := args.Get(0).([]models.OverdraftInterest); ok {
+		return charges, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// MockNotificationService implementa a interface NotificationServiceInterface para testes
+type MockNotificationService struct {
+	mock.Mock
+}
+
+func (m *MockNotificationService) GetNotifications(accountNum string) ([]models.Notification, error) {
+	args := m.Called(accountNum)
+	if notifications, ok := args.Get(0).([]models.Notification); ok {
+		return notifications, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func setupRouterOverdraft(mockService *MockOverdraftService, mockNotifications *MockNotificationService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	controllers.InitOverdraftRoutes(r, mockService)
+	controllers.InitNotificationRoutes(r, mockNotifications)
+	return r
+}
+
+func sendOverdraftRequest(router *gin.Engine, accountNum, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("PUT", "/v1/admin/overdraft/"+accountNum, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestSetOverdraft_Success(t *testing.T) {
+	mockService := new(MockOverdraftService)
+	router := setupRouterOverdraft(mockService, new(MockNotificationService))
+
+	client := &models.Client{AccountNum: "123456", Balance: models.BRL(0), OverdraftLimit: models.BRL(50000), OverdraftRateBps: 1200}
+	mockService.On("SetOverdraft", "123456", models.BRL(50000), 1200).Return(client, nil)
+
+	w := sendOverdraftRequest(router, "123456", `{"limit": 500, "rate_bps": 1200}`)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response models.Client
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, models.BRL(50000), response.OverdraftLimit)
+	mockService.AssertExpectations(t)
+}
+
+func TestSetOverdraft_Errors(t *testing.T) {
+	mockService := new(MockOverdraftService)
+	router := setupRouterOverdraft(mockService, new(MockNotificationService))
+
+	mockService.On("SetOverdraft", "999999", mock.Anything, mock.Anything).Return(nil, repositories.ErrClientNotFound)
+	mockService.On("SetOverdraft", "123456", models.BRL(100), mock.Anything).Return(nil, repositories.ErrOverdraftInUse)
+	mockService.On("SetOverdraft", "123456", models.BRL(-100), mock.Anything).
+		Return(nil, fmt.Errorf("%w: %w", services.ErrInvalidOverdraft, models.ErrInvalidOverdraftLimit))
+
+	assert.Equal(t, http.StatusNotFound, sendOverdraftRequest(router, "999999", `{"limit": 1}`).Code)
+	assert.Equal(t, http.StatusConflict, sendOverdraftRequest(router, "123456", `{"limit": 1}`).Code)
+	assert.Equal(t, http.StatusBadRequest, sendOverdraftRequest(router, "123456", `{"limit": -1}`).Code)
+	assert.Equal(t, http.StatusBadRequest, sendOverdraftRequest(router, "123456", `{invalid_json}`).Code)
+}
+
+func TestGetOverdraftInterest(t *testing.T) {
+	mockService := new(MockOverdraftService)
+	router := setupRouterOverdraft(mockService, new(MockNotificationService))
+
+	charges := []models.OverdraftInterest{{AccountNum: "123456", AccrualDate: "2030-03-01", Interest: models.BRL(33)}}
+	mockService.On("GetOverdraftInterest", "123456").Return(charges, nil)
+	mockService.On("GetOverdraftInterest", "999999").Return(nil, repositories.ErrClientNotFound)
+
+	req, _ := http.NewRequest("GET", "/v1/accounts/123456/overdraft-interest", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response []models.OverdraftInterest
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, charges[0].Interest, response[0].Interest)
+
+	req, _ = http.NewRequest("GET", "/v1/accounts/999999/overdraft-interest", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGetNotifications(t *testing.T) {
+	mockNotifications := new(MockNotificationService)
+	router := setupRouterOverdraft(new(MockOverdraftService), mockNotifications)
+
+	notifications := []models.Notification{*models.OverdraftNotification("123456", models.BRL(0), models.BRL(-100))}
+	mockNotifications.On("GetNotifications", "123456").Return(notifications, nil)
+
+	req, _ := http.NewRequest("GET", "/v1/accounts/123456/notifications", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response []models.Notification
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, models.NotificationOverdraftEntered, response[0].Type)
+}
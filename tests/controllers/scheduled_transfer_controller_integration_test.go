@@ -0,0 +1,153 @@
+package controllers
+
+import (
+	"banking/src/controllers"
+	"banking/src/models"
+	"banking/src/repositories"
+	"banking/src/services"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockScheduledTransferService implementa a interface ScheduledTransferServiceInterface para testes
+type MockScheduledTransferService struct {
+	mock.Mock
+}
+
+func (m *MockScheduledTransferService) ScheduleTransfer(fromAccountNum, toAccountNum string, amount models.Money, executeAt time.Time) (*models.ScheduledTransfer, error) {
+	args := m.Called(fromAccountNum, toAccountNum, amount, executeAt)
+	if scheduled, ok := args.Get(0).(*models.ScheduledTransfer); ok {
+		return scheduled, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockScheduledTransferService) GetScheduledTransfer(id int) (*models.ScheduledTransfer, error) {
+	args := m.Called(id)
+	if scheduled, ok := args.Get(0).(*models.ScheduledTransfer); ok {
+		return scheduled, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockScheduledTransferService) GetScheduledTransfers(accountNum, status string) ([]models.ScheduledTransfer, error) {
+	args := m.Called(accountNum, status)
+	return args.Get(0).([]models.ScheduledTransfer), args.Error(1)
+}
+
+func (m *MockScheduledTransferService) CancelScheduledTransfer(id int) (*models.ScheduledTransfer, error) {
+	args := m.Called(id)
+	if scheduled, ok := args.Get(0).(*models.ScheduledTransfer); ok {
+		return scheduled, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func setupRouterScheduledTransfers(mockTransfers *MockTransferService, mockScheduled *MockScheduledTransferService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	controllers.InitTransferRoutes(r, mockTransfers, mockScheduled, new(MockIdempotencyService))
+	return r
+}
+
+func TestTransferFunds_WithExecuteAtSchedules(t *testing.T) {
+	mockTransfers := new(MockTransferService)
+	mockScheduled := new(MockScheduledTransferService)
+	router := setupRouterScheduledTransfers(mockTransfers, mockScheduled)
+
+	executeAt := time.Date(2030, 1, 5, 12, 0, 0, 0, time.UTC)
+	mockScheduled.On("ScheduleTransfer", "123456", "654321", models.BRL(10000), mock.MatchedBy(executeAt.Equal)).
+		Return(&models.ScheduledTransfer{ID: 7, Status: models.ScheduledStatusPending, ExecuteAt: executeAt}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newTransferRequest(`{"from_account": "123456", "to_account": "654321", "amount": 100, "execute_at": "2030-01-05T09:00:00-03:00"}`, ""))
+
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	var response struct {
+		Status    string                   `json:"status"`
+		Scheduled models.ScheduledTransfer `json:"scheduled_transfer"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "transfer scheduled", response.Status)
+	assert.Equal(t, 7, response.Scheduled.ID)
+	mockTransfers.AssertNotCalled(t, "TransferFunds", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTransferFunds_WithExecuteAtInThePast(t *testing.T) {
+	mockScheduled := new(MockScheduledTransferService)
+	router := setupRouterScheduledTransfers(new(MockTransferService), mockScheduled)
+
+	mockScheduled.On("ScheduleTransfer", "123456", "654321", models.BRL(10000), mock.Anything).Return(nil, services.ErrExecuteAtNotInFuture)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newTransferRequest(`{"from_account": "123456", "to_account": "654321", "amount": 100, "execute_at": "2001-01-01T00:00:00Z"}`, ""))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetScheduledTransfers_FiltersByStatus(t *testing.T) {
+	mockScheduled := new(MockScheduledTransferService)
+	router := setupRouterScheduledTransfers(new(MockTransferService), mockScheduled)
+
+	mockScheduled.On("GetScheduledTransfers", "123456", models.ScheduledStatusPending).
+		Return([]models.ScheduledTransfer{{ID: 1}, {ID: 2}}, nil)
+
+	req, _ := http.NewRequest("GET", "/v1/accounts/123456/scheduled-transfers?status=pending", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response []models.ScheduledTransfer
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 2, len(response))
+}
+
+func TestCancelScheduledTransfer(t *testing.T) {
+	cases := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"pending", nil, http.StatusOK},
+		{"already executed", repositories.ErrScheduledTransferNotPending, http.StatusConflict},
+		{"unknown", repositories.ErrScheduledTransferNotFound, http.StatusNotFound},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockScheduled := new(MockScheduledTransferService)
+			router := setupRouterScheduledTransfers(new(MockTransferService), mockScheduled)
+
+			if tc.err == nil {
+				mockScheduled.On("CancelScheduledTransfer", 3).Return(&models.ScheduledTransfer{ID: 3, Status: models.ScheduledStatusCancelled}, nil)
+			} else {
+				mockScheduled.On("CancelScheduledTransfer", 3).Return(nil, tc.err)
+			}
+
+			req, _ := http.NewRequest("POST", "/v1/scheduled-transfers/3/cancel", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.status, w.Code)
+		})
+	}
+}
+
+func TestGetScheduledTransfer_NotFound(t *testing.T) {
+	mockScheduled := new(MockScheduledTransferService)
+	router := setupRouterScheduledTransfers(new(MockTransferService), mockScheduled)
+
+	mockScheduled.On("GetScheduledTransfer", 42).Return(nil, repositories.ErrScheduledTransferNotFound)
+
+	req, _ := http.NewRequest("GET", "/v1/scheduled-transfers/42", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
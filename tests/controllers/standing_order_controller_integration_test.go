@@ -0,0 +1,180 @@
+package controllers
+
+import (
+	"banking/src/controllers"
+	"banking/src/models"
+	"banking/src/repositories"
+	"banking/src/services"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockStandingOrderService implementa a interface StandingOrderServiceInterface para testes
+type MockStandingOrderService struct {
+	mock.Mock
+}
+
+func (m *MockStandingOrderService) order(args mock.Arguments) (*models.StandingOrder, error) {
+	if order, ok := args.Get(0).(*models.StandingOrder); ok {
+		return order, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockStandingOrderService) CreateStandingOrder(order models.StandingOrder) (*models.StandingOrder, error) {
+	return m.order(m.Called(order))
+}
+
+func (m *MockStandingOrderService) GetStandingOrder(id int) (*models.StandingOrder, error) {
+	return m.order(m.Called(id))
+}
+
+func (m *MockStandingOrderService) GetStandingOrders(accountNum, status string) ([]models.StandingOrder, error) {
+	args := m.Called(accountNum, status)
+	return args.Get(0).([]models.StandingOrder), args.Error(1)
+}
+
+func (m *MockStandingOrderService) GetStandingOrderRuns(id int) ([]models.StandingOrderRun, error) {
+	args := m.Called(id)
+	if runs, ok := args.Get(0).([]models.StandingOrderRun); ok {
+		return runs, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockStandingOrderService) PauseStandingOrder(id int) (*models.StandingOrder, error) {
+	return m.order(m.Called(id))
+}
+
+func (m *MockStandingOrderService) ResumeStandingOrder(id int) (*models.StandingOrder, error) {
+	return m.order(m.Called(id))
+}
+
+func (m *MockStandingOrderService) CancelStandingOrder(id int) (*models.StandingOrder, error) {
+	return m.order(m.Called(id))
+}
+
+func setupRouterStandingOrders(mockService *MockStandingOrderService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	controllers.InitStandingOrderRoutes(r, mockService)
+	return r
+}
+
+func TestCreateStandingOrder_Created(t *testing.T) {
+	mockService := new(MockStandingOrderService)
+	router := setupRouterStandingOrders(mockService)
+
+	start := time.Date(2030, 1, 5, 12, 0, 0, 0, time.UTC)
+	mockService.On("CreateStandingOrder", mock.MatchedBy(func(order models.StandingOrder) bool {
+		return order.FromAccountNum == "123456" && order.Frequency == models.FrequencyMonthly &&
+			order.Amount == models.BRL(150000) && order.StartAt.Equal(start) && order.MaxOccurrences == 12
+	})).Return(&models.StandingOrder{ID: 1, Status: models.StandingOrderActive}, nil)
+
+	w := postJSON(router, "/v1/standing-orders", `{"from_account": "123456", "to_account": "654321", "amount": 1500,
+		"frequency": "monthly", "start_at": "2030-01-05T09:00:00-03:00", "max_occurrences": 12}`)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestCreateStandingOrder_InvalidRule(t *testing.T) {
+	mockService := new(MockStandingOrderService)
+	router := setupRouterStandingOrders(mockService)
+
+	mockService.On("CreateStandingOrder", mock.Anything).
+		Return(nil, fmt.Errorf("%w: %w", services.ErrInvalidStandingOrder, models.ErrInvalidCron))
+
+	w := postJSON(router, "/v1/standing-orders", `{"from_account": "123456", "to_account": "654321", "amount": 10, "frequency": "cron", "cron": "x"}`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestCreateStandingOrder_UnknownAccount(t *testing.T) {
+	mockService := new(MockStandingOrderService)
+	router := setupRouterStandingOrders(mockService)
+
+	mockService.On("CreateStandingOrder", mock.Anything).
+		Return(nil, &services.TransferError{Reason: models.FailureDestinationNotFound, Err: repositories.ErrClientNotFound})
+
+	w := postJSON(router, "/v1/standing-orders", `{"from_account": "123456", "to_account": "999999", "amount": 10, "frequency": "daily"}`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response map[string]string
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, models.FailureDestinationNotFound, response["code"])
+}
+
+func TestStandingOrderTransitions(t *testing.T) {
+	cases := []struct {
+		action string
+		method string
+		err    error
+		status int
+	}{
+		{"pause", "PauseStandingOrder", nil, http.StatusOK},
+		{"resume", "ResumeStandingOrder", repositories.ErrStandingOrderInvalidState, http.StatusConflict},
+		{"cancel", "CancelStandingOrder", repositories.ErrStandingOrderNotFound, http.StatusNotFound},
+	}
+	for _, tc := range cases {
+		t.Run(tc.action, func(t *testing.T) {
+			mockService := new(MockStandingOrderService)
+			router := setupRouterStandingOrders(mockService)
+
+			if tc.err == nil {
+				mockService.On(tc.method, 5).Return(&models.StandingOrder{ID: 5}, nil)
+			} else {
+				mockService.On(tc.method, 5).Return(nil, tc.err)
+			}
+
+			req, _ := http.NewRequest("POST", "/v1/standing-orders/5/"+tc.action, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.status, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetStandingOrderRuns(t *testing.T) {
+	mockService := new(MockStandingOrderService)
+	router := setupRouterStandingOrders(mockService)
+
+	mockService.On("GetStandingOrderRuns", 5).Return([]models.StandingOrderRun{
+		{ID: 2, Attempt: 2, Status: models.StandingOrderRunSuccess},
+		{ID: 1, Attempt: 1, Status: models.StandingOrderRunFailed, FailureReason: models.FailureInsufficientBalance},
+	}, nil)
+
+	req, _ := http.NewRequest("GET", "/v1/standing-orders/5/runs", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var runs []models.StandingOrderRun
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &runs))
+	assert.Equal(t, 2, len(runs))
+	assert.Equal(t, models.FailureInsufficientBalance, runs[1].FailureReason)
+}
+
+func TestGetStandingOrders_ByAccount(t *testing.T) {
+	mockService := new(MockStandingOrderService)
+	router := setupRouterStandingOrders(mockService)
+
+	mockService.On("GetStandingOrders", "123456", "").Return([]models.StandingOrder{{ID: 1}}, nil)
+
+	req, _ := http.NewRequest("GET", "/v1/accounts/123456/standing-orders", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,98 @@
+// src/controllers/statement_controller_integration_test.go
+package controllers
+
+import (
+	"banking/src/controllers"
+	"banking/src/models"
+	"banking/src/repositories"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockStatementService implementa a interface StatementServiceInterface para testes
+type MockStatementService struct {
+	mock.Mock
+}
+
+func (m *MockStatementService) GetStatement(accountNum string, from, to time.Time) (*models.Statement, error) {
+	args := m.Called(accountNum, from, to)
+	if statement, ok := args.Get(0).(*models.Statement); ok {
+		return statement, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func setupRouterStatement(mockService *MockStatementService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	controllers.InitStatementRoutes(r, mockService)
+	return r
+}
+
+func getJSON(router *gin.Engine, path string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("GET", path, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestGetStatement_DatesIncludeTheLastDay(t *testing.T) {
+	mockService := new(MockStatementService)
+	router := setupRouterStatement(mockService)
+
+	from := time.Date(2030, 3, 1, 0, 0, 0, 0, models.BusinessLocation)
+	to := time.Date(2030, 4, 1, 0, 0, 0, 0, models.BusinessLocation)
+	statement := models.NewStatement("123456", from, to, models.BRL(10000), []models.StatementLine{{EntryID: 1, Amount: models.BRL(-2500)}})
+	mockService.On("GetStatement", "123456", from, to).Return(statement, nil)
+
+	w := getJSON(router, "/v1/accounts/123456/statement?from=2030-03-01&to=2030-03-31")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response models.Statement
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, models.BRL(7500), response.ClosingBalance)
+	assert.Equal(t, models.BRL(7500), response.Movements[0].Balance)
+}
+
+func TestGetStatement_AcceptsInstantsAndDefaults(t *testing.T) {
+	mockService := new(MockStatementService)
+	router := setupRouterStatement(mockService)
+
+	from := time.Date(2030, 3, 1, 12, 0, 0, 0, time.UTC)
+	mockService.On("GetStatement", "123456", mock.MatchedBy(from.Equal), time.Time{}).Return(&models.Statement{}, nil)
+
+	w := getJSON(router, "/v1/accounts/123456/statement?from=2030-03-01T12:00:00Z")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetStatement_InvalidPeriod(t *testing.T) {
+	mockService := new(MockStatementService)
+	router := setupRouterStatement(mockService)
+
+	mockService.On("GetStatement", "123456", mock.Anything, mock.Anything).Return(nil, models.ErrInvalidPeriod)
+
+	w := getJSON(router, "/v1/accounts/123456/statement?from=01/03/2030")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = getJSON(router, "/v1/accounts/123456/statement?from=2030-03-31&to=2030-03-01")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetStatement_AccountNotFound(t *testing.T) {
+	mockService := new(MockStatementService)
+	router := setupRouterStatement(mockService)
+
+	mockService.On("GetStatement", "999999", mock.Anything, mock.Anything).Return(nil, repositories.ErrClientNotFound)
+
+	w := getJSON(router, "/v1/accounts/999999/statement")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -3,8 +3,11 @@ package controllers
 import (
 	"banking/src/controllers"
 	"banking/src/models"
+	"banking/src/repositories"
+	"banking/src/services"
 	"bytes"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -19,9 +22,24 @@ type MockTransferService struct {
 	mock.Mock
 }
 
-func (m *MockTransferService) TransferFunds(fromAccount, toAccount string, amount float64) error {
+func (m *MockTransferService) TransferFunds(fromAccount, toAccount string, amount models.Money) (*models.Transfer, error) {
 	args := m.Called(fromAccount, toAccount, amount)
-	return args.Error(0)
+	if transfer, ok := args.Get(0).(*models.Transfer); ok {
+		return transfer, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTransferService) TransferFundsWith(fromAccount, toAccount string, amount models.Money, options services.TransferOptions) (*models.Transfer, error) {
+	return m.TransferFunds(fromAccount, toAccount, amount)
+}
+
+func (m *MockTransferService) ReverseTransfer(transferID int, amount models.Money) (*models.Transfer, error) {
+	args := m.Called(transferID, amount)
+	if transfer, ok := args.Get(0).(*models.Transfer); ok {
+		return transfer, args.Error(1)
+	}
+	return nil, args.Error(1)
 }
 
 func (m *MockTransferService) GetTransferHistory(accountNum string) ([]models.Transfer, error) {
@@ -29,12 +47,48 @@ func (m *MockTransferService) GetTransferHistory(accountNum string) ([]models.Tr
 	return args.Get(0).([]models.Transfer), args.Error(1)
 }
 
+// MockIdempotencyService implementa a interface IdempotencyServiceInterface para testes
+type MockIdempotencyService struct {
+	mock.Mock
+}
+
+func (m *MockIdempotencyService) Reserve(key, requestHash string) (*models.IdempotencyRecord, error) {
+	args := m.Called(key, requestHash)
+	if record, ok := args.Get(0).(*models.IdempotencyRecord); ok {
+		return record, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockIdempotencyService) Complete(key string, statusCode int, body []byte) error {
+	args := m.Called(key, statusCode, body)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyService) Release(key string) error {
+	args := m.Called(key)
+	return args.Error(0)
+}
+
 func setupRouterTranferIntegration(mockService *MockTransferService) *gin.Engine {
+	return setupRouterTranferIdempotency(mockService, new(MockIdempotencyService))
+}
+
+func setupRouterTranferIdempotency(mockService *MockTransferService, mockIdempotency *MockIdempotencyService) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	r := gin.Default()
-	controllers.InitTransferRoutes(r, mockService) // Passando o mock que implementa TransferServiceInterface
+	controllers.InitTransferRoutes(r, mockService, new(MockScheduledTransferService), mockIdempotency) // Passando os mocks que implementam as interfaces de serviço
 	return r
 }
+
+func newTransferRequest(body, idempotencyKey string) *http.Request {
+	req, _ := http.NewRequest("POST", "/v1/transfer", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	if idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", idempotencyKey)
+	}
+	return req
+}
 func TestTransferFunds_Success(t *testing.T) {
 	mockService := new(MockTransferService)
 	router := setupRouterTranferIntegration(mockService)
@@ -44,7 +98,8 @@ func TestTransferFunds_Success(t *testing.T) {
 		"to_account":   "654321",
 		"amount":       100.0,
 	}
-	mockService.On("TransferFunds", "123456", "654321", 100.0).Return(nil)
+	fee := models.BRL(150)
+	mockService.On("TransferFunds", "123456", "654321", models.BRL(10000)).Return(&models.Transfer{ID: 1, FromAccountNum: "123456", ToAccountNum: "654321", Amount: models.BRL(10000), Fee: &fee}, nil)
 
 	body, _ := json.Marshal(transferRequest)
 	req, _ := http.NewRequest("POST", "/v1/transfer", bytes.NewBuffer(body))
@@ -54,10 +109,16 @@ func TestTransferFunds_Success(t *testing.T) {
 	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
-	var response map[string]string
+	var response struct {
+		Status   string          `json:"status"`
+		Transfer models.Transfer `json:"transfer"`
+		Fee      models.Money    `json:"fee"`
+	}
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
-	assert.Equal(t, "transfer successful", response["status"])
+	assert.Equal(t, "transfer successful", response.Status)
+	assert.Equal(t, 1, response.Transfer.ID)
+	assert.Equal(t, fee, response.Fee)
 
 	mockService.AssertExpectations(t)
 }
@@ -90,7 +151,7 @@ func TestTransferFunds_FailedTransfer(t *testing.T) {
 		"to_account":   "654321",
 		"amount":       10000.0,
 	}
-	mockService.On("TransferFunds", "123456", "654321", 10000.0).Return(assert.AnError)
+	mockService.On("TransferFunds", "123456", "654321", models.BRL(1000000)).Return(nil, assert.AnError)
 
 	body, _ := json.Marshal(transferRequest)
 	req, _ := http.NewRequest("POST", "/v1/transfer", bytes.NewBuffer(body))
@@ -99,7 +160,7 @@ func TestTransferFunds_FailedTransfer(t *testing.T) {
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
 	var response map[string]string
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
@@ -108,13 +169,61 @@ func TestTransferFunds_FailedTransfer(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestTransferFunds_DeclinedIncludesReasonCode(t *testing.T) {
+	mockService := new(MockTransferService)
+	router := setupRouterTranferIntegration(mockService)
+
+	declined := &services.TransferError{Reason: models.FailureInsufficientBalance, Err: errors.New("insufficient balance")}
+	mockService.On("TransferFunds", "123456", "654321", models.BRL(5000)).Return(nil, declined)
+
+	body := []byte(`{"from_account": "123456", "to_account": "654321", "amount": {"cents": 5000, "currency": "BRL"}}`)
+	req, _ := http.NewRequest("POST", "/v1/transfer", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response map[string]string
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "insufficient balance", response["error"])
+	assert.Equal(t, models.FailureInsufficientBalance, response["code"])
+
+	mockService.AssertExpectations(t)
+}
+
+func TestTransferFunds_LimitExceededIncludesRemainingAllowance(t *testing.T) {
+	mockService := new(MockTransferService)
+	router := setupRouterTranferIntegration(mockService)
+
+	exceeded := &models.LimitExceededError{Limit: models.LimitDaily, Remaining: models.BRL(2500)}
+	mockService.On("TransferFunds", "123456", "654321", models.BRL(5000)).Return(nil, &services.TransferError{Reason: models.FailureLimitExceeded, Err: exceeded})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newTransferRequest(`{"from_account": "123456", "to_account": "654321", "amount": 50}`, ""))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response struct {
+		Error     string       `json:"error"`
+		Code      string       `json:"code"`
+		Limit     string       `json:"limit"`
+		Remaining models.Money `json:"remaining"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "daily limit exceeded: remaining allowance is 25.00 BRL", response.Error)
+	assert.Equal(t, models.FailureLimitExceeded, response.Code)
+	assert.Equal(t, models.LimitDaily, response.Limit)
+	assert.Equal(t, models.BRL(2500), response.Remaining)
+}
+
 func TestGetTransferHistory_Success(t *testing.T) {
 	mockService := new(MockTransferService)
 	router := setupRouterTranferIntegration(mockService)
 
 	transfers := []models.Transfer{
-		{FromAccountNum: "123456", ToAccountNum: "654321", Amount: 50.0, Status: "success"},
-		{FromAccountNum: "654321", ToAccountNum: "123456", Amount: 75.0, Status: "failed"},
+		{FromAccountNum: "123456", ToAccountNum: "654321", Amount: models.BRL(5000), Status: "success"},
+		{FromAccountNum: "654321", ToAccountNum: "123456", Amount: models.BRL(7500), Status: "failed", FailureReason: models.FailureInsufficientBalance},
 	}
 	mockService.On("GetTransferHistory", "123456").Return(transfers, nil)
 
@@ -134,6 +243,7 @@ func TestGetTransferHistory_Success(t *testing.T) {
 		assert.Equal(t, transfer.ToAccountNum, responseTransfers[i].ToAccountNum)
 		assert.Equal(t, transfer.Amount, responseTransfers[i].Amount)
 		assert.Equal(t, transfer.Status, responseTransfers[i].Status)
+		assert.Equal(t, transfer.FailureReason, responseTransfers[i].FailureReason)
 	}
 
 	mockService.AssertExpectations(t)
@@ -152,3 +262,238 @@ func TestGetTransferHistory_InternalServerError(t *testing.T) {
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 }
+
+func TestTransferFunds_IdempotencyKeyStoresFirstResponse(t *testing.T) {
+	mockService := new(MockTransferService)
+	mockIdempotency := new(MockIdempotencyService)
+	router := setupRouterTranferIdempotency(mockService, mockIdempotency)
+
+	mockIdempotency.On("Reserve", "key-1", mock.AnythingOfType("string")).Return(nil, nil)
+	mockService.On("TransferFunds", "123456", "654321", models.BRL(10000)).Return(&models.Transfer{ID: 1, Amount: models.BRL(10000)}, nil)
+	var stored []byte
+	mockIdempotency.On("Complete", "key-1", http.StatusOK, mock.AnythingOfType("[]uint8")).Return(nil).Run(func(args mock.Arguments) {
+		stored = args.Get(2).([]byte)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newTransferRequest(`{"from_account": "123456", "to_account": "654321", "amount": 100}`, "key-1"))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, string(stored), w.Body.String())
+	assert.Contains(t, w.Body.String(), `"status":"transfer successful"`)
+	mockService.AssertExpectations(t)
+	mockIdempotency.AssertExpectations(t)
+}
+
+func TestTransferFunds_IdempotencyKeyReleasedOnInternalError(t *testing.T) {
+	mockService := new(MockTransferService)
+	mockIdempotency := new(MockIdempotencyService)
+	router := setupRouterTranferIdempotency(mockService, mockIdempotency)
+
+	mockIdempotency.On("Reserve", "key-1", mock.AnythingOfType("string")).Return(nil, nil)
+	mockService.On("TransferFunds", "123456", "654321", models.BRL(10000)).Return(nil, assert.AnError)
+	mockIdempotency.On("Release", "key-1").Return(nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newTransferRequest(`{"from_account": "123456", "to_account": "654321", "amount": 100}`, "key-1"))
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	mockIdempotency.AssertExpectations(t)
+	mockIdempotency.AssertNotCalled(t, "Complete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTransferFunds_IdempotencyKeyReleasedWhenResponseNotStored(t *testing.T) {
+	mockService := new(MockTransferService)
+	mockIdempotency := new(MockIdempotencyService)
+	router := setupRouterTranferIdempotency(mockService, mockIdempotency)
+
+	mockIdempotency.On("Reserve", "key-1", mock.AnythingOfType("string")).Return(nil, nil)
+	mockService.On("TransferFunds", "123456", "654321", models.BRL(10000)).Return(&models.Transfer{ID: 1, Amount: models.BRL(10000)}, nil)
+	mockIdempotency.On("Complete", "key-1", http.StatusOK, mock.AnythingOfType("[]uint8")).Return(assert.AnError)
+	mockIdempotency.On("Release", "key-1").Return(nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newTransferRequest(`{"from_account": "123456", "to_account": "654321", "amount": 100}`, "key-1"))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockIdempotency.AssertExpectations(t)
+}
+
+func TestTransferFunds_IdempotencyKeyReplaysStoredResponse(t *testing.T) {
+	mockService := new(MockTransferService)
+	mockIdempotency := new(MockIdempotencyService)
+	router := setupRouterTranferIdempotency(mockService, mockIdempotency)
+
+	stored := &models.IdempotencyRecord{
+		Key:          "key-1",
+		StatusCode:   http.StatusBadRequest,
+		ResponseBody: []byte(`{"code":"insufficient_balance","error":"insufficient balance"}`),
+	}
+	mockIdempotency.On("Reserve", "key-1", mock.AnythingOfType("string")).Return(stored, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newTransferRequest(`{"from_account": "123456", "to_account": "654321", "amount": 100}`, "key-1"))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "true", w.Header().Get("Idempotent-Replayed"))
+	assert.JSONEq(t, string(stored.ResponseBody), w.Body.String())
+	mockService.AssertNotCalled(t, "TransferFunds", mock.Anything, mock.Anything, mock.Anything)
+	mockIdempotency.AssertNotCalled(t, "Complete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTransferFunds_IdempotencyKeyHashesPayload(t *testing.T) {
+	mockService := new(MockTransferService)
+	mockIdempotency := new(MockIdempotencyService)
+	router := setupRouterTranferIdempotency(mockService, mockIdempotency)
+
+	var hashes []string
+	mockIdempotency.On("Reserve", "key-1", mock.AnythingOfType("string")).
+		Run(func(args mock.Arguments) { hashes = append(hashes, args.String(1)) }).
+		Return(nil, services.ErrIdempotencyKeyInProgress)
+
+	for _, body := range []string{
+		`{"from_account": "123456", "to_account": "654321", "amount": 100}`,
+		`{"from_account": "123456", "to_account": "654321", "amount": {"cents": 10000, "currency": "BRL"}}`,
+		`{"from_account": "123456", "to_account": "654321", "amount": 100.01}`,
+	} {
+		router.ServeHTTP(httptest.NewRecorder(), newTransferRequest(body, "key-1"))
+	}
+
+	// O mesmo valor em formatos diferentes gera o mesmo hash; outro valor não
+	assert.Equal(t, 3, len(hashes))
+	assert.Equal(t, hashes[0], hashes[1])
+	assert.NotEqual(t, hashes[0], hashes[2])
+}
+
+func TestTransferFunds_IdempotencyKeyConflict(t *testing.T) {
+	mockService := new(MockTransferService)
+	mockIdempotency := new(MockIdempotencyService)
+	router := setupRouterTranferIdempotency(mockService, mockIdempotency)
+
+	mockIdempotency.On("Reserve", "key-1", mock.AnythingOfType("string")).Return(nil, services.ErrIdempotencyKeyConflict)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newTransferRequest(`{"from_account": "123456", "to_account": "654321", "amount": 999}`, "key-1"))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	var response map[string]string
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, services.ErrIdempotencyKeyConflict.Error(), response["error"])
+	mockService.AssertNotCalled(t, "TransferFunds", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTransferFunds_IdempotencyKeyInProgress(t *testing.T) {
+	mockService := new(MockTransferService)
+	mockIdempotency := new(MockIdempotencyService)
+	router := setupRouterTranferIdempotency(mockService, mockIdempotency)
+
+	mockIdempotency.On("Reserve", "key-1", mock.AnythingOfType("string")).Return(nil, services.ErrIdempotencyKeyInProgress)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newTransferRequest(`{"from_account": "123456", "to_account": "654321", "amount": 100}`, "key-1"))
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	mockService.AssertNotCalled(t, "TransferFunds", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReverseTransfer_Full(t *testing.T) {
+	mockService := new(MockTransferService)
+	router := setupRouterTranferIntegration(mockService)
+
+	originalID := 10
+	reversal := &models.Transfer{
+		ID:             11,
+		FromAccountNum: "654321",
+		ToAccountNum:   "123456",
+		Amount:         models.BRL(10000),
+		Type:           models.TransferTypeReversal,
+		Status:         models.TransferStatusSuccess,
+		ReversalOf:     &originalID,
+	}
+	mockService.On("ReverseTransfer", 10, models.Money{}).Return(reversal, nil)
+
+	req, _ := http.NewRequest("POST", "/v1/transfers/10/reversal", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response models.Transfer
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, *response.ReversalOf)
+	mockService.AssertExpectations(t)
+}
+
+func TestReverseTransfer_Partial(t *testing.T) {
+	mockService := new(MockTransferService)
+	router := setupRouterTranferIntegration(mockService)
+
+	mockService.On("ReverseTransfer", 10, models.BRL(2500)).Return(&models.Transfer{ID: 11}, nil)
+
+	req, _ := http.NewRequest("POST", "/v1/transfers/10/reversal", bytes.NewBufferString(`{"amount": 25}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestReverseTransfer_NotFound(t *testing.T) {
+	mockService := new(MockTransferService)
+	router := setupRouterTranferIntegration(mockService)
+
+	mockService.On("ReverseTransfer", 99, models.Money{}).Return(nil, repositories.ErrTransferNotFound)
+
+	req, _ := http.NewRequest("POST", "/v1/transfers/99/reversal", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestReverseTransfer_Exceeded(t *testing.T) {
+	mockService := new(MockTransferService)
+	router := setupRouterTranferIntegration(mockService)
+
+	declined := &services.TransferError{Reason: models.FailureReversalExceeded, Err: errors.New("reversal exceeds the amount not yet reversed")}
+	mockService.On("ReverseTransfer", 10, models.BRL(999900)).Return(nil, declined)
+
+	req, _ := http.NewRequest("POST", "/v1/transfers/10/reversal", bytes.NewBufferString(`{"amount": 9999}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response map[string]string
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, models.FailureReversalExceeded, response["code"])
+}
+
+func TestReverseTransfer_InternalError(t *testing.T) {
+	mockService := new(MockTransferService)
+	router := setupRouterTranferIntegration(mockService)
+
+	mockService.On("ReverseTransfer", 10, models.Money{}).Return(nil, errors.New("database is locked"))
+
+	req, _ := http.NewRequest("POST", "/v1/transfers/10/reversal", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.NotContains(t, w.Body.String(), `"code"`)
+}
+
+func TestReverseTransfer_InvalidID(t *testing.T) {
+	mockService := new(MockTransferService)
+	router := setupRouterTranferIntegration(mockService)
+
+	req, _ := http.NewRequest("POST", "/v1/transfers/abc/reversal", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "ReverseTransfer", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,117 @@
+// src/controllers/treasury_controller_integration_test.go
+package controllers
+
+import (
+	"banking/src/controllers"
+	"banking/src/models"
+	"banking/src/services"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTreasuryService implementa a interface TreasuryServiceInterface para testes
+type MockTreasuryService struct {
+	mock.Mock
+}
+
+func (m *MockTreasuryService) GetPosition(currency string) (*models.TreasuryPosition, error) {
+	args := m.Called(currency)
+	if position, ok := args.Get(0).(*models.TreasuryPosition); ok {
+		return position, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func setupRouterTreasuryIntegration(mockAccounts *MockAccountService, mockTreasury *MockTreasuryService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	controllers.InitTreasuryRoutes(r, mockAccounts, mockTreasury)
+	return r
+}
+
+func TestFund_Success(t *testing.T) {
+	mockAccounts := new(MockAccountService)
+	router := setupRouterTreasuryIntegration(mockAccounts, new(MockTreasuryService))
+
+	funding := &models.Transfer{
+		ID:             1,
+		FromAccountNum: models.TreasuryAccountNum,
+		ToAccountNum:   "123456",
+		Amount:         models.BRL(100000),
+		Type:           models.TransferTypeFunding,
+		Status:         models.TransferStatusSuccess,
+	}
+	mockAccounts.On("Fund", "123456", models.BRL(100000)).Return(funding, nil)
+
+	w := postJSON(router, "/v1/admin/treasury/fundings", `{"account_num": "123456", "amount": 1000}`)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response models.Transfer
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, models.TreasuryAccountNum, response.FromAccountNum)
+	mockAccounts.AssertExpectations(t)
+}
+
+func TestFund_MissingAccount(t *testing.T) {
+	mockAccounts := new(MockAccountService)
+	router := setupRouterTreasuryIntegration(mockAccounts, new(MockTreasuryService))
+
+	w := postJSON(router, "/v1/admin/treasury/fundings", `{"amount": 1000}`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockAccounts.AssertNotCalled(t, "Fund", mock.Anything, mock.Anything)
+}
+
+func TestFund_AlreadyFunded(t *testing.T) {
+	mockAccounts := new(MockAccountService)
+	router := setupRouterTreasuryIntegration(mockAccounts, new(MockTreasuryService))
+
+	declined := &services.TransferError{Reason: models.FailureAlreadyFunded, Err: services.ErrAccountAlreadyFunded}
+	mockAccounts.On("Fund", "123456", models.BRL(100000)).Return(nil, declined)
+
+	w := postJSON(router, "/v1/admin/treasury/fundings", `{"account_num": "123456", "amount": 1000}`)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), models.FailureAlreadyFunded)
+}
+
+func TestFund_PublicRouteRemoved(t *testing.T) {
+	router := setupRouterTreasuryIntegration(new(MockAccountService), new(MockTreasuryService))
+
+	w := postJSON(router, "/v1/treasury/fundings", `{"account_num": "123456", "amount": 1000}`)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGetTreasuryPosition(t *testing.T) {
+	mockTreasury := new(MockTreasuryService)
+	router := setupRouterTreasuryIntegration(new(MockAccountService), mockTreasury)
+
+	position := &models.TreasuryPosition{
+		Currency:         "BRL",
+		TreasuryBalance:  models.BRL(-100000),
+		InternalBalances: map[string]models.Money{},
+		ClientBalances:   models.BRL(100000),
+		LedgerTotal:      models.BRL(0),
+		Balanced:         true,
+	}
+	mockTreasury.On("GetPosition", "BRL").Return(position, nil)
+
+	req, _ := http.NewRequest("GET", "/v1/treasury/position?currency=BRL", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response models.TreasuryPosition
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.True(t, response.Balanced)
+	assert.Equal(t, models.BRL(-100000), response.TreasuryBalance)
+}
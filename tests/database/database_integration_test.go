@@ -3,10 +3,15 @@ package database
 
 import (
 	"banking/src/database"
+	"banking/src/models"
+	"banking/src/repositories"
+	"database/sql"
 	"io/ioutil"
 	"log"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/assert"
@@ -54,3 +59,288 @@ func TestInitDB_Failure(t *testing.T) {
 
 	assert.Contains(t, logOutput, "")
 }
+
+func TestInitDB_MigratesRealMoneyColumnsToCents(t *testing.T) {
+	dbName := "./test_legacy_bank.db"
+	os.Remove(dbName)
+	defer os.Remove(dbName)
+
+	// Cria um banco no formato antigo, com valores REAL
+	legacy, err := sql.Open("sqlite3", dbName)
+	assert.NoError(t, err)
+	_, err = legacy.Exec(`
+	CREATE TABLE clients (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL,
+		account_num TEXT NOT NULL UNIQUE,
+		balance REAL NOT NULL
+	);
+	CREATE TABLE transfers (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		from_account_num TEXT NOT NULL,
+		to_account_num TEXT NOT NULL,
+		amount REAL NOT NULL,
+		status TEXT NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	);
+	INSERT INTO clients (name, account_num, balance) VALUES ('John Doe', '123456', 1000.1), ('Jane Doe', '654321', 0.295);
+	INSERT INTO transfers (from_account_num, to_account_num, amount, status) VALUES ('123456', '654321', 0.7, 'success');`)
+	assert.NoError(t, err)
+	legacy.Close()
+
+	db, err := database.InitDB(dbName)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	var balance int64
+	var currency string
+	err = db.QueryRow("SELECT balance, currency FROM clients WHERE account_num = '123456'").Scan(&balance, &currency)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100010), balance)
+	assert.Equal(t, "BRL", currency)
+
+	err = db.QueryRow("SELECT balance FROM clients WHERE account_num = '654321'").Scan(&balance)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(30), balance) // 29,5 centavos arredonda para longe do zero
+
+	var amount int64
+	err = db.QueryRow("SELECT amount FROM transfers").Scan(&amount)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(70), amount)
+
+	// O livro-razão é populado com a transferência antiga e os saldos de abertura
+	var ledgerBalance int64
+	err = db.QueryRow("SELECT SUM(amount) FROM postings WHERE account_num = '123456'").Scan(&ledgerBalance)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100010), ledgerBalance)
+	err = db.QueryRow("SELECT SUM(amount) FROM postings WHERE account_num = '654321'").Scan(&ledgerBalance)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(30), ledgerBalance)
+	err = db.QueryRow("SELECT SUM(amount) FROM postings").Scan(&ledgerBalance)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), ledgerBalance)
+
+	var transferEntries int
+	err = db.QueryRow("SELECT COUNT(*) FROM journal_entries WHERE transfer_id IS NOT NULL").Scan(&transferEntries)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, transferEntries)
+
+	// Reabrir o banco não deve migrar novamente
+	db2, err := database.InitDB(dbName)
+	assert.NoError(t, err)
+	defer db2.Close()
+	err = db2.QueryRow("SELECT balance FROM clients WHERE account_num = '123456'").Scan(&balance)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100010), balance)
+	var entries int
+	err = db2.QueryRow("SELECT COUNT(*) FROM journal_entries").Scan(&entries)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, entries)
+}
+
+func TestInitDB_DatesOpeningBalancesBeforeHistory(t *testing.T) {
+	dbName := "./test_legacy_history_bank.db"
+	os.Remove(dbName)
+	defer os.Remove(dbName)
+
+	// Banco antigo em que a primeira conta transferiu todo o saldo para a segunda
+	legacy, err := sql.Open("sqlite3", dbName)
+	assert.NoError(t, err)
+	_, err = legacy.Exec(`
+	CREATE TABLE clients (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL,
+		account_num TEXT NOT NULL UNIQUE,
+		balance REAL NOT NULL
+	);
+	CREATE TABLE transfers (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		from_account_num TEXT NOT NULL,
+		to_account_num TEXT NOT NULL,
+		amount REAL NOT NULL,
+		status TEXT NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	);
+	INSERT INTO clients (name, account_num, balance) VALUES ('João Silva', '123456', 0.0), ('Xuxa da Silva', '654321', 11000.0), ('Sem Movimento', '777777', 300.0);
+	INSERT INTO transfers (from_account_num, to_account_num, amount, status, created_at) VALUES ('123456', '654321', 5000.0, 'success', '2024-10-12 23:50:13');`)
+	assert.NoError(t, err)
+	legacy.Close()
+
+	db, err := database.InitDB(dbName)
+	assert.NoError(t, err)
+	defer db.Close()
+	ledger := repositories.NewLedgerRepository(db)
+
+	// O saldo de abertura é datado da primeira transferência da conta, então
+	// os saldos logo depois dela nunca ficam negativos
+	after := time.Date(2024, 10, 13, 0, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		accountNum string
+		at         time.Time
+		want       models.Money
+	}{
+		{"123456", after, models.BRL(0)},
+		{"654321", after, models.BRL(1100000)},
+		{"777777", after, models.BRL(30000)},
+	} {
+		balance, err := ledger.GetBalanceAt(tc.accountNum, "BRL", tc.at)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.want, balance, "%s at %s", tc.accountNum, tc.at)
+	}
+
+	// No extrato, a abertura vem antes da transferência do mesmo instante
+	lines, err := ledger.GetStatementLines("654321", "BRL", time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC), after)
+	assert.NoError(t, err)
+	if assert.Len(t, lines, 2) {
+		assert.Equal(t, models.BRL(600000), lines[0].Amount)
+		assert.Equal(t, models.BRL(500000), lines[1].Amount)
+	}
+}
+
+func TestInitDB_AddsMissingColumns(t *testing.T) {
+	dbName := "./test_columns_bank.db"
+	os.Remove(dbName)
+	defer os.Remove(dbName)
+
+	// Tabela transfers sem a coluna failure_reason e clients sem version
+	existing, err := sql.Open("sqlite3", dbName)
+	assert.NoError(t, err)
+	_, err = existing.Exec(`
+	CREATE TABLE clients (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL,
+		account_num TEXT NOT NULL UNIQUE,
+		balance INTEGER NOT NULL,
+		currency TEXT NOT NULL DEFAULT 'BRL'
+	);
+	INSERT INTO clients (name, account_num, balance) VALUES ('John Doe', '123456', 100);
+	CREATE TABLE transfers (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		from_account_num TEXT NOT NULL,
+		to_account_num TEXT NOT NULL,
+		amount INTEGER NOT NULL,
+		currency TEXT NOT NULL DEFAULT 'BRL',
+		status TEXT NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	);
+	INSERT INTO transfers (from_account_num, to_account_num, amount, status) VALUES ('123456', '654321', 100, 'success');`)
+	assert.NoError(t, err)
+	existing.Close()
+
+	db, err := database.InitDB(dbName)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	var failureReason string
+	err = db.QueryRow("SELECT failure_reason FROM transfers").Scan(&failureReason)
+	assert.NoError(t, err)
+	assert.Equal(t, "", failureReason)
+
+	var version int
+	err = db.QueryRow("SELECT version FROM clients WHERE account_num = '123456'").Scan(&version)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, version)
+}
+
+func TestInitDB_EnforcesForeignKeysExceptTransferAccounts(t *testing.T) {
+	dbName := "./test_foreign_keys_bank.db"
+	os.Remove(dbName)
+	defer os.Remove(dbName)
+
+	// Banco da versão em que transfers tinha chaves estrangeiras para clients
+	existing, err := sql.Open("sqlite3", dbName)
+	assert.NoError(t, err)
+	_, err = existing.Exec(`
+	CREATE TABLE clients (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL,
+		account_num TEXT NOT NULL UNIQUE,
+		balance INTEGER NOT NULL,
+		currency TEXT NOT NULL DEFAULT 'BRL'
+	);
+	INSERT INTO clients (name, account_num, balance) VALUES ('John Doe', '123456', 0), ('Jane Doe', '654321', 100);
+	CREATE TABLE transfers (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		from_account_num TEXT NOT NULL,
+		to_account_num TEXT NOT NULL,
+		amount INTEGER NOT NULL,
+		currency TEXT NOT NULL DEFAULT 'BRL',
+		status TEXT NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (from_account_num) REFERENCES clients(account_num),
+		FOREIGN KEY (to_account_num) REFERENCES clients(account_num)
+	);
+	INSERT INTO transfers (from_account_num, to_account_num, amount, status) VALUES ('123456', '654321', 100, 'success');`)
+	assert.NoError(t, err)
+	existing.Close()
+
+	db, err := database.InitDB(dbName)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	// A tabela é recriada sem as chaves para clients e mantém os dados e o
+	// lançamento que aponta para a transferência
+	var references, transfers, entries int
+	assert.NoError(t, db.QueryRow("SELECT COUNT(*) FROM pragma_foreign_key_list('transfers') WHERE \"table\" = 'clients'").Scan(&references))
+	assert.Equal(t, 0, references)
+	assert.NoError(t, db.QueryRow("SELECT COUNT(*) FROM transfers").Scan(&transfers))
+	assert.Equal(t, 1, transfers)
+	assert.NoError(t, db.QueryRow("SELECT COUNT(*) FROM journal_entries WHERE transfer_id = 1").Scan(&entries))
+	assert.Equal(t, 1, entries)
+
+	// Transferências das contas internas são aceitas
+	_, err = db.Exec("INSERT INTO transfers (from_account_num, to_account_num, amount, status) VALUES ('SYSTEM-TREASURY', '123456', 100, 'success')")
+	assert.NoError(t, err)
+
+	// As demais chaves estrangeiras são verificadas
+	_, err = db.Exec("INSERT INTO notifications (account_num, type, message, balance, currency) VALUES ('999999', 'overdraft_entered', 'x', 0, 'BRL')")
+	assert.ErrorContains(t, err, "FOREIGN KEY constraint failed")
+	_, err = db.Exec("INSERT INTO journal_entries (transfer_id, description) VALUES (999, 'transfer')")
+	assert.ErrorContains(t, err, "FOREIGN KEY constraint failed")
+}
+
+func TestInitDB_MigratesClientsToCustomers(t *testing.T) {
+	dbName := filepath.Join(t.TempDir(), "test_customers_bank.db")
+
+	// Contas de antes da tabela customers, duas delas com o mesmo nome
+	existing, err := sql.Open("sqlite3", dbName)
+	assert.NoError(t, err)
+	_, err = existing.Exec(`
+	CREATE TABLE clients (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL,
+		account_num TEXT NOT NULL UNIQUE,
+		balance INTEGER NOT NULL,
+		currency TEXT NOT NULL DEFAULT 'BRL'
+	);
+	INSERT INTO clients (name, account_num, balance) VALUES ('John Doe', '123456', 100), ('Jane Doe', '654321', 0), ('John Doe', '111111', 0);`)
+	assert.NoError(t, err)
+	existing.Close()
+
+	db, err := database.InitDB(dbName)
+	assert.NoError(t, err)
+
+	rows, err := db.Query("SELECT c.account_num, c.name, cu.name FROM clients c JOIN customers cu ON cu.id = c.customer_id ORDER BY c.id")
+	assert.NoError(t, err)
+	var migrated [][3]string
+	for rows.Next() {
+		var row [3]string
+		assert.NoError(t, rows.Scan(&row[0], &row[1], &row[2]))
+		migrated = append(migrated, row)
+	}
+	rows.Close()
+	assert.Equal(t, [][3]string{
+		{"123456", "John Doe", "John Doe"},
+		{"654321", "Jane Doe", "Jane Doe"},
+		{"111111", "John Doe", "John Doe"},
+	}, migrated)
+	db.Close()
+
+	// Inicializar de novo não cria outros clientes
+	db, err = database.InitDB(dbName)
+	assert.NoError(t, err)
+	defer db.Close()
+	var customers int
+	assert.NoError(t, db.QueryRow("SELECT COUNT(*) FROM customers").Scan(&customers))
+	assert.Equal(t, 3, customers)
+}
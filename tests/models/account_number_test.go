@@ -0,0 +1,59 @@
+// src/models/account_number_test.go
+package test
+
+import (
+	"banking/src/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckDigitAlgorithms(t *testing.T) {
+	// Exemplos clássicos: Luhn de 7992739871 e módulo 11 de 261533
+	assert.Equal(t, "3", models.CheckDigitMod10.Digit("7992739871"))
+	assert.Equal(t, "9", models.CheckDigitMod11.Digit("261533"))
+
+	// Módulo 11 com resto 0 ou 1 resulta em DV 0
+	assert.Equal(t, "0", models.CheckDigitMod11.Digit("0"))
+	assert.Equal(t, "0", models.CheckDigitMod11.Digit("6"))
+}
+
+func TestAccountNumberFormat_FormatAndValidate(t *testing.T) {
+	mod11 := models.DefaultAccountNumberFormat
+	assert.Equal(t, "0001-00000123-4", mod11.Format(123))
+
+	mod10, err := models.NewAccountNumberFormat("0042", "MOD10")
+	assert.NoError(t, err)
+	accountNum := mod10.Format(123)
+	assert.Equal(t, "0042-00000123-2", accountNum)
+	assert.NoError(t, mod10.Validate(accountNum))
+
+	// O DV de outra agência é verificado com o algoritmo configurado
+	assert.NoError(t, mod11.Validate("0002-00000123-2"))
+	assert.ErrorIs(t, mod11.Validate("0001-00000123-5"), models.ErrInvalidCheckDigit)
+	assert.ErrorIs(t, mod11.Validate("0001-123-4"), models.ErrInvalidAccountNumber)
+	assert.ErrorIs(t, mod11.Validate("0001-0000012a-4"), models.ErrInvalidAccountNumber)
+
+	// Números legados não estão no formato
+	assert.ErrorIs(t, mod11.Validate("123456"), models.ErrInvalidAccountNumber)
+	assert.ErrorIs(t, mod11.Validate("000100000123"), models.ErrInvalidAccountNumber)
+}
+
+func TestValidateAccountNumber(t *testing.T) {
+	// Números legados e DVs de qualquer algoritmo são aceitos
+	assert.NoError(t, models.ValidateAccountNumber("123456"))
+	assert.NoError(t, models.ValidateAccountNumber("0001-00000123-4"))
+	assert.NoError(t, models.ValidateAccountNumber("0042-00000123-2"))
+
+	assert.ErrorIs(t, models.ValidateAccountNumber("0001-00000123-5"), models.ErrInvalidCheckDigit)
+	assert.ErrorIs(t, models.ValidateAccountNumber("conta-1"), models.ErrInvalidAccountNumber)
+	assert.ErrorIs(t, models.ValidateAccountNumber(""), models.ErrInvalidAccountNumber)
+}
+
+func TestNewAccountNumberFormat_Invalid(t *testing.T) {
+	_, err := models.NewAccountNumberFormat("1", "mod11")
+	assert.ErrorIs(t, err, models.ErrInvalidBranch)
+
+	_, err = models.NewAccountNumberFormat("0001", "mod7")
+	assert.ErrorIs(t, err, models.ErrInvalidCheckDigitAlgorithm)
+}
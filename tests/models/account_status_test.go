@@ -0,0 +1,59 @@
+// src/models/account_status_test.go
+package test
+
+import (
+	"banking/src/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckStatusTransition(t *testing.T) {
+	allowed := [][2]string{
+		{models.AccountStatusActive, models.AccountStatusFrozen},
+		{models.AccountStatusActive, models.AccountStatusBlocked},
+		{models.AccountStatusActive, models.AccountStatusClosed},
+		{models.AccountStatusFrozen, models.AccountStatusActive},
+		{models.AccountStatusBlocked, models.AccountStatusActive},
+	}
+	for _, transition := range allowed {
+		assert.NoError(t, models.CheckStatusTransition(transition[0], transition[1]), "%s -> %s", transition[0], transition[1])
+	}
+
+	refused := [][2]string{
+		{models.AccountStatusActive, models.AccountStatusActive},
+		{models.AccountStatusFrozen, models.AccountStatusBlocked},
+		{models.AccountStatusBlocked, models.AccountStatusClosed},
+		{models.AccountStatusClosed, models.AccountStatusActive},
+	}
+	for _, transition := range refused {
+		assert.ErrorIs(t, models.CheckStatusTransition(transition[0], transition[1]), models.ErrInvalidStatusTransition, "%s -> %s", transition[0], transition[1])
+	}
+
+	assert.ErrorIs(t, models.CheckStatusTransition(models.AccountStatusActive, "suspended"), models.ErrInvalidAccountStatus)
+}
+
+func TestAccountStatus_DebitsAndCredits(t *testing.T) {
+	assert.True(t, models.AllowsDebits(models.AccountStatusActive))
+	assert.True(t, models.AllowsCredits(models.AccountStatusActive))
+
+	// Conta congelada recebe créditos, mas não pode ser debitada
+	assert.False(t, models.AllowsDebits(models.AccountStatusFrozen))
+	assert.True(t, models.AllowsCredits(models.AccountStatusFrozen))
+
+	for _, status := range []string{models.AccountStatusBlocked, models.AccountStatusClosed} {
+		assert.False(t, models.AllowsDebits(status))
+		assert.False(t, models.AllowsCredits(status))
+	}
+}
+
+func TestNewAccountStatusChange_RequiresReason(t *testing.T) {
+	_, err := models.NewAccountStatusChange("123456", models.AccountStatusActive, models.AccountStatusFrozen, "   ")
+	assert.ErrorIs(t, err, models.ErrStatusReasonRequired)
+
+	change, err := models.NewAccountStatusChange("123456", models.AccountStatusActive, models.AccountStatusFrozen, " suspeita de fraude ")
+	assert.NoError(t, err)
+	assert.Equal(t, "suspeita de fraude", change.Reason)
+	assert.Equal(t, models.AccountStatusActive, change.FromStatus)
+	assert.Equal(t, models.AccountStatusFrozen, change.ToStatus)
+}
@@ -12,13 +12,13 @@ func TestNewClient(t *testing.T) {
 		ID:         1,
 		Name:       "John Doe",
 		AccountNum: "123456",
-		Balance:    1000.0,
+		Balance:    models.BRL(100000),
 	}
 
 	assert.Equal(t, 1, client.ID)
 	assert.Equal(t, "John Doe", client.Name)
 	assert.Equal(t, "123456", client.AccountNum)
-	assert.Equal(t, 1000.0, client.Balance)
+	assert.Equal(t, models.BRL(100000), client.Balance)
 }
 
 func TestClient_UpdateBalance(t *testing.T) {
@@ -26,27 +26,27 @@ func TestClient_UpdateBalance(t *testing.T) {
 		ID:         2,
 		Name:       "Jane Doe",
 		AccountNum: "654321",
-		Balance:    500.0,
+		Balance:    models.BRL(50000),
 	}
 
 	// Atualizar o saldo
-	client.Balance += 250.0
-	assert.Equal(t, 750.0, client.Balance)
+	client.Balance = client.Balance.Add(models.BRL(25000))
+	assert.Equal(t, models.BRL(75000), client.Balance)
 
-	client.Balance -= 100.0
-	assert.Equal(t, 650.0, client.Balance)
+	client.Balance = client.Balance.Sub(models.BRL(10000))
+	assert.Equal(t, models.BRL(65000), client.Balance)
 }
 
 func TestClient_InvalidData(t *testing.T) {
 	client := models.Client{
-		ID:         0,      // ID inválido
-		Name:       "",     // Nome inválido
-		AccountNum: "",     // Número da conta inválido
-		Balance:    -500.0, // Saldo inválido
+		ID:         0,                  // ID inválido
+		Name:       "",                 // Nome inválido
+		AccountNum: "",                 // Número da conta inválido
+		Balance:    models.BRL(-50000), // Saldo inválido
 	}
 
 	assert.Equal(t, 0, client.ID)
 	assert.Equal(t, "", client.Name)
 	assert.Equal(t, "", client.AccountNum)
-	assert.Equal(t, -500.0, client.Balance)
+	assert.Equal(t, models.BRL(-50000), client.Balance)
 }
@@ -0,0 +1,18 @@
+// src/models/customer_test.go
+package test
+
+import (
+	"banking/src/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCustomer(t *testing.T) {
+	customer, err := models.NewCustomer("  Maria Silva ")
+	assert.NoError(t, err)
+	assert.Equal(t, "Maria Silva", customer.Name)
+
+	_, err = models.NewCustomer("   ")
+	assert.ErrorIs(t, err, models.ErrCustomerNameRequired)
+}
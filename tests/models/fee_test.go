@@ -0,0 +1,77 @@
+// src/models/fee_test.go
+package test
+
+import (
+	"banking/src/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeeSchedule_FlatAndPercent(t *testing.T) {
+	schedule := models.FeeSchedule{Currency: "BRL", Flat: models.BRL(100), PercentBps: 50}
+
+	// R$ 100,00: 0,5% = 50 centavos mais R$ 1,00 fixo
+	assert.Equal(t, models.BRL(150), schedule.Fee(models.BRL(10000), 0))
+	// R$ 1,00: 0,5% = exatamente meio centavo, arredonda para o par mais próximo
+	assert.Equal(t, models.BRL(100), schedule.Fee(models.BRL(100), 0))
+	assert.Equal(t, models.BRL(102), schedule.Fee(models.BRL(300), 0))
+}
+
+func TestFeeSchedule_TiersOverrideBaseFee(t *testing.T) {
+	schedule := models.FeeSchedule{
+		Currency: "BRL",
+		Flat:     models.BRL(200),
+		Tiers: []models.FeeTier{
+			{MinAmount: models.BRL(100000), Flat: models.BRL(0), PercentBps: 10},
+			{MinAmount: models.BRL(10000), Flat: models.BRL(100)},
+		},
+	}
+	schedule.Normalize()
+	assert.NoError(t, schedule.Validate())
+
+	assert.Equal(t, models.BRL(200), schedule.Fee(models.BRL(9999), 0))
+	assert.Equal(t, models.BRL(100), schedule.Fee(models.BRL(10000), 0))
+	assert.Equal(t, models.BRL(100), schedule.Fee(models.BRL(100000), 0))
+	assert.Equal(t, models.BRL(500), schedule.Fee(models.BRL(500000), 0))
+}
+
+func TestFeeSchedule_FreeTransfers(t *testing.T) {
+	schedule := models.FeeSchedule{Currency: "BRL", Flat: models.BRL(100), FreeTransfers: 3}
+
+	assert.True(t, schedule.Fee(models.BRL(10000), 2).IsZero())
+	assert.Equal(t, models.BRL(100), schedule.Fee(models.BRL(10000), 3))
+}
+
+func TestFeeSchedule_OtherCurrencyIsFree(t *testing.T) {
+	schedule := models.FeeSchedule{Currency: "BRL", Flat: models.BRL(100)}
+
+	assert.Equal(t, models.NewMoney(0, "USD"), schedule.Fee(models.NewMoney(10000, "USD"), 0))
+}
+
+func TestFeeSchedule_Validate(t *testing.T) {
+	valid := func() models.FeeSchedule {
+		return models.FeeSchedule{Flat: models.Money{Cents: 100}, Tiers: []models.FeeTier{{MinAmount: models.Money{Cents: 1000}}}}
+	}
+
+	schedule := valid()
+	schedule.Normalize()
+	assert.NoError(t, schedule.Validate())
+	assert.Equal(t, "BRL", schedule.Flat.Currency)
+	assert.Equal(t, "BRL", schedule.Tiers[0].MinAmount.Currency)
+
+	schedule = valid()
+	schedule.PercentBps = -1
+	schedule.Normalize()
+	assert.ErrorIs(t, schedule.Validate(), models.ErrInvalidFee)
+
+	schedule = valid()
+	schedule.Tiers = append(schedule.Tiers, models.FeeTier{MinAmount: models.Money{Cents: 1000}})
+	schedule.Normalize()
+	assert.ErrorIs(t, schedule.Validate(), models.ErrInvalidFeeTiers)
+
+	schedule = valid()
+	schedule.Flat.Currency = "USD"
+	schedule.Normalize()
+	assert.ErrorIs(t, schedule.Validate(), models.ErrFeeCurrencyMismatch)
+}
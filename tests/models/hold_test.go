@@ -0,0 +1,36 @@
+// src/models/hold_test.go
+package test
+
+import (
+	"banking/src/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHold_RemainingAndIsActive(t *testing.T) {
+	now := time.Now()
+	hold := models.Hold{
+		Amount:         models.BRL(10000),
+		CapturedAmount: models.BRL(2500),
+		Status:         models.HoldStatusActive,
+		ExpiresAt:      now.Add(time.Hour),
+	}
+
+	assert.Equal(t, models.BRL(7500), hold.Remaining())
+	assert.True(t, hold.IsActive(now))
+	// Reservas vencidas não podem ser capturadas antes de o executor expirá-las
+	assert.False(t, hold.IsActive(now.Add(time.Hour)))
+
+	hold.Status = models.HoldStatusVoided
+	assert.False(t, hold.IsActive(now))
+}
+
+func TestClient_SetAvailableBalance(t *testing.T) {
+	client := models.Client{Balance: models.BRL(10000), Held: models.Money{Cents: 2500}}
+	client.SetAvailableBalance()
+
+	assert.Equal(t, models.BRL(2500), client.Held)
+	assert.Equal(t, models.BRL(7500), client.AvailableBalance)
+}
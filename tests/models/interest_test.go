@@ -0,0 +1,41 @@
+// src/models/interest_test.go
+package test
+
+import (
+	"banking/src/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDailyInterest(t *testing.T) {
+	// R$ 1.000,00 a 1% ao ano: 100000 * 100 / 3650000 = 2,74 centavos
+	assert.Equal(t, models.BRL(3), models.DailyInterest(models.BRL(100000), 100))
+	// Exatamente meio centavo arredonda para o par mais próximo
+	assert.Equal(t, models.BRL(0), models.DailyInterest(models.BRL(1825), 1000))
+
+	assert.Equal(t, models.BRL(0), models.DailyInterest(models.BRL(-100000), 100))
+	assert.Equal(t, models.BRL(0), models.DailyInterest(models.BRL(100000), 0))
+}
+
+func TestCapitalizedInterest_RoundsOnlyTheTotal(t *testing.T) {
+	// 30 dias de R$ 1.000,00 a 1% ao ano: arredondar cada dia daria 30 * 3 = 90
+	// centavos; o valor exato do mês é 82,19 centavos
+	accrued := int64(30 * 100000 * 100)
+	assert.Equal(t, models.BRL(82), models.CapitalizedInterest(accrued, "BRL"))
+	assert.Equal(t, models.NewMoney(0, "USD"), models.CapitalizedInterest(0, "USD"))
+}
+
+func TestIsValidAccountType(t *testing.T) {
+	assert.True(t, models.IsValidAccountType(models.AccountTypeChecking))
+	assert.True(t, models.IsValidAccountType(models.AccountTypeSavings))
+	assert.False(t, models.IsValidAccountType(""))
+	assert.False(t, models.IsValidAccountType("investment"))
+}
+
+func TestStartOfBusinessMonth(t *testing.T) {
+	// 1º de abril às 01:00 UTC ainda é 31 de março em Brasília
+	month := models.StartOfBusinessMonth(time.Date(2030, 4, 1, 1, 0, 0, 0, time.UTC))
+	assert.True(t, month.Equal(time.Date(2030, 3, 1, 0, 0, 0, 0, models.BusinessLocation)))
+}
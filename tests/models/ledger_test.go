@@ -0,0 +1,30 @@
+package test
+
+import (
+	"banking/src/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTransferEntry_IsBalanced(t *testing.T) {
+	entry := models.NewTransferEntry(models.EntryTransfer, "123456", "654321", models.BRL(2500))
+
+	assert.NoError(t, entry.Validate())
+	assert.Equal(t, "123456", entry.Postings[0].AccountNum)
+	assert.Equal(t, models.BRL(-2500), entry.Postings[0].Amount)
+	assert.Equal(t, "654321", entry.Postings[1].AccountNum)
+	assert.Equal(t, models.BRL(2500), entry.Postings[1].Amount)
+}
+
+func TestJournalEntry_ValidateRejectsUnbalanced(t *testing.T) {
+	single := models.JournalEntry{Postings: []models.Posting{{AccountNum: "123456", Amount: models.BRL(0)}}}
+	assert.ErrorIs(t, single.Validate(), models.ErrUnbalancedEntry)
+
+	// Partidas em moedas diferentes não se compensam
+	mixed := models.JournalEntry{Postings: []models.Posting{
+		{AccountNum: "123456", Amount: models.BRL(-100)},
+		{AccountNum: "654321", Amount: models.NewMoney(100, "USD")},
+	}}
+	assert.ErrorIs(t, mixed.Validate(), models.ErrUnbalancedEntry)
+}
@@ -0,0 +1,87 @@
+// src/models/limits_test.go
+package test
+
+import (
+	"banking/src/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimitWindows_UseBrasiliaTime(t *testing.T) {
+	// 02:30 UTC do dia 1º de março é 23:30 de 28 de fevereiro em Brasília
+	windows := models.NewLimitWindows(date(2030, 3, 1, 2, 30))
+
+	assert.Equal(t, date(2030, 2, 28, 3, 0), windows.DayStart.UTC())
+	assert.Equal(t, date(2030, 2, 1, 3, 0), windows.MonthStart.UTC())
+	assert.True(t, windows.Night)
+	assert.Equal(t, date(2030, 2, 28, 23, 0), windows.NightStart.UTC())
+
+	// 08:00 UTC (05:00 em Brasília): o período noturno começou na véspera
+	windows = models.NewLimitWindows(date(2030, 3, 1, 8, 0))
+	assert.True(t, windows.Night)
+	assert.Equal(t, date(2030, 2, 28, 23, 0), windows.NightStart.UTC())
+
+	// 12:00 UTC (09:00 em Brasília) é período diurno
+	assert.False(t, models.NewLimitWindows(date(2030, 3, 1, 12, 0)).Night)
+}
+
+func TestTransferLimits_Check(t *testing.T) {
+	limits := models.TransferLimits{
+		PerTransfer:      models.BRL(500000),
+		Daily:            models.BRL(1000000),
+		Monthly:          models.BRL(3000000),
+		DailyCount:       3,
+		NightPerTransfer: models.BRL(100000),
+		NightTotal:       models.BRL(150000),
+	}
+	day := date(2030, 3, 1, 15, 0)   // 12:00 em Brasília
+	night := date(2030, 3, 1, 23, 0) // 20:00 em Brasília
+	usage := models.LimitUsage{Daily: models.BRL(800000), Monthly: models.BRL(800000), DailyCount: 2, NightTotal: models.BRL(0)}
+
+	assert.Nil(t, limits.Check(models.BRL(200000), usage, day))
+
+	exceeded := limits.Check(models.BRL(600000), usage, day)
+	assert.Equal(t, models.LimitPerTransfer, exceeded.Limit)
+	assert.Equal(t, models.BRL(500000), exceeded.Remaining)
+
+	exceeded = limits.Check(models.BRL(300000), usage, day)
+	assert.Equal(t, models.LimitDaily, exceeded.Limit)
+	assert.EqualError(t, exceeded, "daily limit exceeded: remaining allowance is 2000.00 BRL")
+
+	exceeded = limits.Check(models.BRL(100), models.LimitUsage{Daily: models.BRL(0), Monthly: models.BRL(0), DailyCount: 3}, day)
+	assert.EqualError(t, exceeded, "daily_count limit exceeded: all 3 transfers allowed today have been made")
+
+	// À noite valem também os limites noturnos
+	exceeded = limits.Check(models.BRL(120000), usage, night)
+	assert.Equal(t, models.LimitNightPerTransfer, exceeded.Limit)
+
+	usage.NightTotal = models.BRL(100000)
+	exceeded = limits.Check(models.BRL(60000), usage, night)
+	assert.Equal(t, models.LimitNightTotal, exceeded.Limit)
+	assert.Equal(t, models.BRL(50000), exceeded.Remaining)
+
+	usage.Monthly = models.BRL(3000000)
+	exceeded = limits.Check(models.BRL(100), usage, day)
+	assert.Equal(t, models.LimitMonthly, exceeded.Limit)
+	assert.Equal(t, models.BRL(0), exceeded.Remaining)
+}
+
+func TestTransferLimits_ZeroMeansUnlimited(t *testing.T) {
+	limits := models.TransferLimits{PerTransfer: models.BRL(0), Daily: models.BRL(0)}
+
+	assert.Nil(t, limits.Check(models.BRL(100000000), models.LimitUsage{Daily: models.BRL(0)}, date(2030, 3, 1, 15, 0)))
+}
+
+func TestTransferLimits_Validate(t *testing.T) {
+	valid := models.TransferLimits{PerTransfer: models.BRL(100), Daily: models.BRL(0), Monthly: models.BRL(0), NightPerTransfer: models.BRL(0), NightTotal: models.BRL(0)}
+	assert.NoError(t, valid.Validate())
+
+	negative := valid
+	negative.Daily = models.BRL(-1)
+	assert.ErrorIs(t, negative.Validate(), models.ErrNegativeLimit)
+
+	mixed := valid
+	mixed.Monthly = models.NewMoney(100, "USD")
+	assert.ErrorIs(t, mixed.Validate(), models.ErrLimitCurrency)
+}
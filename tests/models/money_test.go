@@ -0,0 +1,126 @@
+package test
+
+import (
+	"banking/src/models"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMoney(t *testing.T) {
+	cases := map[string]int64{
+		"100.50": 10050,
+		"100.5":  10050,
+		"100":    10000,
+		"0.01":   1,
+		".5":     50,
+		"-3.25":  -325,
+		"0.10":   10,
+		"1.500":  150,
+	}
+	for input, expected := range cases {
+		money, err := models.ParseMoney(input, "")
+		assert.NoError(t, err, input)
+		assert.Equal(t, models.BRL(expected), money, input)
+	}
+}
+
+func TestParseMoney_Invalid(t *testing.T) {
+	_, err := models.ParseMoney("10.005", "BRL")
+	assert.ErrorIs(t, err, models.ErrAmountPrecision)
+
+	for _, input := range []string{"", "abc", "1e3", "1.", "-", "1.2.3"} {
+		_, err := models.ParseMoney(input, "BRL")
+		assert.ErrorIs(t, err, models.ErrInvalidAmount, input)
+	}
+
+	_, err = models.ParseMoney("10.00", "R$")
+	assert.ErrorIs(t, err, models.ErrInvalidCurrency)
+}
+
+func TestParseMoney_RejectsAmountsThatOverflow(t *testing.T) {
+	// O maior valor representável em centavos int64
+	money, err := models.ParseMoney("92233720368547758.07", "BRL")
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(math.MaxInt64), money)
+
+	for _, input := range []string{"92233720368547758.08", "-92233720368547758.99", "92233720368547759", "99999999999999999999"} {
+		_, err := models.ParseMoney(input, "BRL")
+		assert.ErrorIs(t, err, models.ErrAmountOutOfRange, input)
+	}
+}
+
+func TestMoney_ArithmeticHasNoDrift(t *testing.T) {
+	balance := models.BRL(0)
+	for i := 0; i < 1000; i++ {
+		balance = balance.Add(models.BRL(10)) // 0,10 somado mil vezes
+	}
+	assert.Equal(t, models.BRL(10000), balance)
+	assert.Equal(t, "100.00", balance.Decimal())
+
+	balance = balance.Sub(models.BRL(10001))
+	assert.True(t, balance.IsNegative())
+	assert.Equal(t, "-0.01 BRL", balance.String())
+}
+
+func TestMoney_MulDivRoundsHalfEven(t *testing.T) {
+	assert.Equal(t, models.BRL(2), models.BRL(25).MulDiv(1, 10))   // 2,5 -> 2
+	assert.Equal(t, models.BRL(4), models.BRL(35).MulDiv(1, 10))   // 3,5 -> 4
+	assert.Equal(t, models.BRL(3), models.BRL(26).MulDiv(1, 10))   // 2,6 -> 3
+	assert.Equal(t, models.BRL(-2), models.BRL(-25).MulDiv(1, 10)) // -2,5 -> -2
+	assert.Equal(t, models.BRL(150), models.BRL(10000).MulDiv(15, 1000))
+}
+
+func TestMoney_MismatchedCurrencyPanics(t *testing.T) {
+	assert.Panics(t, func() {
+		models.BRL(100).Add(models.NewMoney(100, "USD"))
+	})
+}
+
+func TestMoney_JSON(t *testing.T) {
+	data, err := json.Marshal(models.BRL(10050))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"cents": 10050, "currency": "BRL"}`, string(data))
+
+	var fromObject, fromNumber, fromString models.Money
+	assert.NoError(t, json.Unmarshal([]byte(`{"cents": 10050, "currency": "usd"}`), &fromObject))
+	assert.Equal(t, models.NewMoney(10050, "USD"), fromObject)
+
+	assert.NoError(t, json.Unmarshal([]byte(`100.50`), &fromNumber))
+	assert.Equal(t, models.BRL(10050), fromNumber)
+
+	assert.NoError(t, json.Unmarshal([]byte(`"0.07"`), &fromString))
+	assert.Equal(t, models.BRL(7), fromString)
+
+	var invalid models.Money
+	assert.Error(t, json.Unmarshal([]byte(`100.505`), &invalid))
+	assert.Error(t, json.Unmarshal([]byte(`{"currency": "BRL"}`), &invalid))
+	assert.ErrorIs(t, json.Unmarshal([]byte(`{"cents": 1.5, "currency": "BRL"}`), &invalid), models.ErrInvalidAmount)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`{"cents": 100, "currency": "REAIS"}`), &invalid), models.ErrInvalidCurrency)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`{"cents": 100, "currency": "R$1"}`), &invalid), models.ErrInvalidCurrency)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`{"cents": 9223372036854775808, "currency": "BRL"}`), &invalid), models.ErrAmountOutOfRange)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`92233720368547758.08`), &invalid), models.ErrAmountOutOfRange)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`"92233720368547758.08"`), &invalid), models.ErrAmountOutOfRange)
+	assert.Equal(t, models.Money{}, invalid)
+
+	var withoutCurrency models.Money
+	assert.NoError(t, json.Unmarshal([]byte(`{"cents": 100}`), &withoutCurrency))
+	assert.Equal(t, models.BRL(100), withoutCurrency)
+}
+
+func TestNormalizeCurrency(t *testing.T) {
+	currency, err := models.NormalizeCurrency(" usd ")
+	assert.NoError(t, err)
+	assert.Equal(t, "USD", currency)
+
+	currency, err = models.NormalizeCurrency("")
+	assert.NoError(t, err)
+	assert.Equal(t, models.DefaultCurrency, currency)
+
+	for _, invalid := range []string{"US", "EURO", "U$D", "r$1"} {
+		_, err := models.NormalizeCurrency(invalid)
+		assert.ErrorIs(t, err, models.ErrInvalidCurrency, invalid)
+	}
+}
@@ -0,0 +1,37 @@
+// src/models/overdraft_test.go
+package test
+
+import (
+	"banking/src/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDailyOverdraftInterest(t *testing.T) {
+	// R$ 1.000,00 negativos a 12% ao ano: 100000 * 1200 / 3650000 = 32,88 centavos
+	assert.Equal(t, models.BRL(33), models.DailyOverdraftInterest(models.BRL(-100000), 1200))
+	// Exatamente meio centavo arredonda para o par mais próximo
+	assert.Equal(t, models.BRL(0), models.DailyOverdraftInterest(models.BRL(-1825), 1000))
+	assert.Equal(t, models.BRL(2), models.DailyOverdraftInterest(models.BRL(-5475), 1000))
+
+	assert.Equal(t, models.BRL(0), models.DailyOverdraftInterest(models.BRL(100000), 1200))
+	assert.Equal(t, models.BRL(0), models.DailyOverdraftInterest(models.BRL(-100000), 0))
+}
+
+func TestOverdraftNotification(t *testing.T) {
+	entered := models.OverdraftNotification("123456", models.BRL(100), models.BRL(-50))
+	if assert.NotNil(t, entered) {
+		assert.Equal(t, models.NotificationOverdraftEntered, entered.Type)
+		assert.Equal(t, "account 123456 entered overdraft: balance is -0.50 BRL", entered.Message)
+		assert.Equal(t, models.BRL(-50), entered.Balance)
+	}
+
+	left := models.OverdraftNotification("123456", models.BRL(-50), models.BRL(0))
+	if assert.NotNil(t, left) {
+		assert.Equal(t, models.NotificationOverdraftLeft, left.Type)
+	}
+
+	assert.Nil(t, models.OverdraftNotification("123456", models.BRL(-50), models.BRL(-100)))
+	assert.Nil(t, models.OverdraftNotification("123456", models.BRL(50), models.BRL(0)))
+}
@@ -0,0 +1,99 @@
+// src/models/standing_order_test.go
+package test
+
+import (
+	"banking/src/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func date(year int, month time.Month, day, hour, minute int) time.Time {
+	return time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+}
+
+func TestStandingOrder_DailyAndWeeklyOccurrences(t *testing.T) {
+	daily := models.StandingOrder{Frequency: models.FrequencyDaily, Interval: 2, StartAt: date(2030, 1, 1, 9, 0)}
+
+	assert.Equal(t, date(2030, 1, 1, 9, 0), daily.FirstOccurrence())
+	assert.Equal(t, date(2030, 1, 3, 9, 0), daily.NextOccurrence(date(2030, 1, 1, 9, 0)))
+	assert.Equal(t, date(2030, 1, 5, 9, 0), daily.NextOccurrence(date(2030, 1, 4, 23, 0)))
+
+	weekly := models.StandingOrder{Frequency: models.FrequencyWeekly, Interval: 1, StartAt: date(2030, 1, 1, 9, 0)}
+	assert.Equal(t, date(2030, 1, 8, 9, 0), weekly.NextOccurrence(date(2030, 1, 1, 9, 30)))
+}
+
+func TestStandingOrder_MonthlyClampsToEndOfMonth(t *testing.T) {
+	order := models.StandingOrder{Frequency: models.FrequencyMonthly, Interval: 1, StartAt: date(2030, 1, 31, 12, 0)}
+
+	assert.Equal(t, date(2030, 2, 28, 12, 0), order.NextOccurrence(date(2030, 1, 31, 12, 0)))
+	// O dia de referência continua sendo 31, e não o dia ajustado de fevereiro
+	assert.Equal(t, date(2030, 3, 31, 12, 0), order.NextOccurrence(date(2030, 2, 28, 12, 0)))
+	assert.Equal(t, date(2030, 4, 30, 12, 0), order.NextOccurrence(date(2030, 4, 1, 0, 0)))
+
+	quarterly := models.StandingOrder{Frequency: models.FrequencyMonthly, Interval: 3, StartAt: date(2030, 1, 15, 12, 0)}
+	assert.Equal(t, date(2030, 4, 15, 12, 0), quarterly.NextOccurrence(date(2030, 2, 20, 0, 0)))
+}
+
+func TestStandingOrder_CronOccurrences(t *testing.T) {
+	// Às 12:00 nos dias 1 e 15 de cada mês
+	order := models.StandingOrder{Frequency: models.FrequencyCron, Cron: "0 12 1,15 * *", StartAt: date(2030, 1, 10, 0, 0)}
+
+	assert.Equal(t, date(2030, 1, 15, 12, 0), order.FirstOccurrence())
+	assert.Equal(t, date(2030, 2, 1, 12, 0), order.NextOccurrence(date(2030, 1, 15, 12, 0)))
+}
+
+func TestCronSchedule_Next(t *testing.T) {
+	cases := []struct {
+		expr  string
+		after time.Time
+		want  time.Time
+	}{
+		{"*/15 * * * *", date(2030, 1, 1, 10, 7), date(2030, 1, 1, 10, 15)},
+		{"0 9 * * 1-5", date(2030, 1, 4, 9, 0), date(2030, 1, 7, 9, 0)}, // sexta -> segunda
+		{"30 8 * * 7", date(2030, 1, 1, 0, 0), date(2030, 1, 6, 8, 30)}, // 7 também é domingo
+		{"0 0 29 2 *", date(2030, 1, 1, 0, 0), date(2032, 2, 29, 0, 0)},
+		// Dia do mês e dia da semana restritos: basta um coincidir
+		{"0 0 10 * 1", date(2030, 1, 1, 0, 0), date(2030, 1, 7, 0, 0)},
+	}
+	for _, tc := range cases {
+		schedule, err := models.ParseCron(tc.expr)
+		assert.NoError(t, err, tc.expr)
+		assert.Equal(t, tc.want, schedule.Next(tc.after), tc.expr)
+	}
+
+	never, err := models.ParseCron("0 0 31 2 *")
+	assert.NoError(t, err)
+	assert.True(t, never.Next(date(2030, 1, 1, 0, 0)).IsZero())
+}
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* * 0 * *", "*/0 * * * *", "a * * * *", "5-1 * * * *"} {
+		_, err := models.ParseCron(expr)
+		assert.ErrorIs(t, err, models.ErrInvalidCron, expr)
+	}
+}
+
+func TestStandingOrder_Validate(t *testing.T) {
+	start := date(2030, 1, 1, 0, 0)
+	before := start.Add(-time.Hour)
+
+	assert.NoError(t, models.StandingOrder{Frequency: models.FrequencyDaily, Interval: 1, StartAt: start}.Validate())
+	assert.ErrorIs(t, models.StandingOrder{Frequency: "yearly", Interval: 1}.Validate(), models.ErrInvalidFrequency)
+	assert.ErrorIs(t, models.StandingOrder{Frequency: models.FrequencyWeekly}.Validate(), models.ErrInvalidInterval)
+	assert.ErrorIs(t, models.StandingOrder{Frequency: models.FrequencyCron, Cron: "bad"}.Validate(), models.ErrInvalidCron)
+	assert.ErrorIs(t, models.StandingOrder{Frequency: models.FrequencyDaily, Interval: 1, StartAt: start, EndAt: &before}.Validate(), models.ErrInvalidEndAt)
+}
+
+func TestStandingOrder_Finished(t *testing.T) {
+	end := date(2030, 3, 1, 0, 0)
+	order := models.StandingOrder{MaxOccurrences: 3, Occurrences: 2, EndAt: &end}
+
+	assert.False(t, order.Finished(date(2030, 2, 1, 0, 0)))
+	assert.True(t, order.Finished(date(2030, 3, 2, 0, 0)))
+	assert.True(t, order.Finished(time.Time{}))
+
+	order.Occurrences = 3
+	assert.True(t, order.Finished(date(2030, 2, 1, 0, 0)))
+}
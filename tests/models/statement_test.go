@@ -0,0 +1,44 @@
+// src/models/statement_test.go
+package test
+
+import (
+	"banking/src/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStatement_RunningBalanceAndTotals(t *testing.T) {
+	from := time.Date(2030, 3, 1, 0, 0, 0, 0, models.BusinessLocation)
+	to := from.AddDate(0, 1, 0)
+	movements := []models.StatementLine{
+		{EntryID: 1, Amount: models.BRL(5000)},
+		{EntryID: 2, Amount: models.BRL(-3000)},
+		{EntryID: 3, Amount: models.BRL(-150)},
+		{EntryID: 4, Amount: models.BRL(1000)},
+	}
+
+	statement := models.NewStatement("123456", from, to, models.BRL(10000), movements)
+
+	assert.Equal(t, models.BRL(10000), statement.OpeningBalance)
+	assert.Equal(t, models.BRL(15000), statement.Movements[0].Balance)
+	assert.Equal(t, models.BRL(12000), statement.Movements[1].Balance)
+	assert.Equal(t, models.BRL(11850), statement.Movements[2].Balance)
+	assert.Equal(t, models.BRL(12850), statement.Movements[3].Balance)
+	assert.Equal(t, models.BRL(12850), statement.ClosingBalance)
+	assert.Equal(t, models.BRL(6000), statement.TotalCredits)
+	assert.Equal(t, models.BRL(3150), statement.TotalDebits)
+}
+
+func TestNewStatement_EmptyPeriod(t *testing.T) {
+	from := time.Date(2030, 3, 1, 0, 0, 0, 0, models.BusinessLocation)
+
+	statement := models.NewStatement("123456", from, from.AddDate(0, 0, 1), models.NewMoney(-500, "USD"), nil)
+
+	assert.NotNil(t, statement.Movements)
+	assert.Empty(t, statement.Movements)
+	assert.Equal(t, models.NewMoney(-500, "USD"), statement.ClosingBalance)
+	assert.Equal(t, models.NewMoney(0, "USD"), statement.TotalCredits)
+	assert.Equal(t, models.NewMoney(0, "USD"), statement.TotalDebits)
+}
@@ -15,7 +15,7 @@ func TestNewTransfer(t *testing.T) {
 		ID:             1,
 		FromAccountNum: "123456",
 		ToAccountNum:   "654321",
-		Amount:         500.0,
+		Amount:         models.BRL(50000),
 		Status:         "success",
 		CreatedAt:      createdAt,
 	}
@@ -23,7 +23,7 @@ func TestNewTransfer(t *testing.T) {
 	assert.Equal(t, 1, transfer.ID)
 	assert.Equal(t, "123456", transfer.FromAccountNum)
 	assert.Equal(t, "654321", transfer.ToAccountNum)
-	assert.Equal(t, 500.0, transfer.Amount)
+	assert.Equal(t, models.BRL(50000), transfer.Amount)
 	assert.Equal(t, "success", transfer.Status)
 	assert.Equal(t, createdAt, transfer.CreatedAt)
 }
@@ -32,17 +32,17 @@ func TestTransfer_InvalidData(t *testing.T) {
 	invalidTime := time.Time{} // Tempo zero inválido
 	transfer := models.Transfer{
 		ID:             0,
-		FromAccountNum: "",     // Conta de origem inválida
-		ToAccountNum:   "",     // Conta de destino inválida
-		Amount:         -100.0, // Valor negativo
-		Status:         "",     // Status vazio
+		FromAccountNum: "",                 // Conta de origem inválida
+		ToAccountNum:   "",                 // Conta de destino inválida
+		Amount:         models.BRL(-10000), // Valor negativo
+		Status:         "",                 // Status vazio
 		CreatedAt:      invalidTime,
 	}
 
 	assert.Equal(t, 0, transfer.ID)
 	assert.Equal(t, "", transfer.FromAccountNum)
 	assert.Equal(t, "", transfer.ToAccountNum)
-	assert.Equal(t, -100.0, transfer.Amount)
+	assert.Equal(t, models.BRL(-10000), transfer.Amount)
 	assert.Equal(t, "", transfer.Status)
 	assert.Equal(t, invalidTime, transfer.CreatedAt)
 }
@@ -53,15 +53,15 @@ func TestTransfer_UpdateAmountAndStatus(t *testing.T) {
 		ID:             2,
 		FromAccountNum: "123456",
 		ToAccountNum:   "654321",
-		Amount:         300.0,
+		Amount:         models.BRL(30000),
 		Status:         "pending",
 		CreatedAt:      createdAt,
 	}
 
 	// Atualizando o valor e o status
-	transfer.Amount = 400.0
+	transfer.Amount = models.BRL(40000)
 	transfer.Status = "failed"
 
-	assert.Equal(t, 400.0, transfer.Amount)
+	assert.Equal(t, models.BRL(40000), transfer.Amount)
 	assert.Equal(t, "failed", transfer.Status)
 }
@@ -0,0 +1,75 @@
+// src/repositories/account_number_repository_integration_test.go
+package test
+
+import (
+	"banking/src/database"
+	"banking/src/models"
+	"banking/src/repositories"
+	"banking/src/services"
+	"database/sql"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountNumberRepository_SequencePerBranch(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := repositories.NewAccountNumberRepository(db)
+	for _, expected := range []int64{1, 2, 3} {
+		number, err := repo.NextAccountNumber("0001")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, number)
+	}
+	number, err := repo.NextAccountNumber("0002")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), number)
+}
+
+func TestCreateClient_ConcurrentInstancesGetDistinctAccountNumbers(t *testing.T) {
+	dbFile := filepath.Join(t.TempDir(), "test_bank.db")
+
+	const instances = 4
+	dbs := make([]*sql.DB, instances)
+	clientServices := make([]*services.ClientService, instances)
+	for i := range dbs {
+		db, err := database.InitDB(dbFile)
+		if err != nil {
+			t.Fatalf("Erro ao abrir o banco de dados: %v", err)
+		}
+		defer db.Close()
+		dbs[i] = db
+		clientServices[i] = services.NewClientService(repositories.NewClientRepository(db), repositories.NewUnitOfWork(db), models.DefaultAccountNumberFormat)
+	}
+
+	// Um número informado antes da geração pelo servidor é pulado
+	assert.NoError(t, clientServices[0].CreateClient(&models.Client{Name: "Legacy", AccountNum: models.DefaultAccountNumberFormat.Format(3)}))
+
+	const accounts = 40
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	allocated := map[string]bool{}
+	for i := 0; i < accounts; i++ {
+		wg.Add(1)
+		go func(service *services.ClientService) {
+			defer wg.Done()
+			client := &models.Client{Name: "Cliente"}
+			err := service.CreateClient(client)
+			mu.Lock()
+			defer mu.Unlock()
+			assert.NoError(t, err)
+			allocated[client.AccountNum] = true
+		}(clientServices[i%instances])
+	}
+	wg.Wait()
+
+	assert.Len(t, allocated, accounts)
+	assert.False(t, allocated[models.DefaultAccountNumberFormat.Format(3)])
+	for accountNum := range allocated {
+		assert.NoError(t, models.DefaultAccountNumberFormat.Validate(accountNum))
+	}
+}
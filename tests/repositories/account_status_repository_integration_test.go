@@ -0,0 +1,141 @@
+// src/repositories/account_status_repository_integration_test.go
+package test
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"banking/src/services"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountStatus_LifecycleAndHistory(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	uow := repositories.NewUnitOfWork(db)
+	clientRepo := repositories.NewClientRepository(db)
+	transferRepo := repositories.NewTransferRepository(db)
+	clientService := services.NewClientService(clientRepo, uow, models.DefaultAccountNumberFormat)
+	accountLocks := services.NewAccountLocks()
+	accountService := services.NewAccountService(transferRepo, uow, accountLocks)
+	transferService := services.NewTransferService(clientRepo, transferRepo, repositories.NewLedgerRepository(db), uow, accountLocks)
+	holdService := services.NewHoldService(clientRepo, repositories.NewHoldRepository(db), uow, transferService)
+	statusService := services.NewAccountStatusService(clientRepo, repositories.NewAccountStatusRepository(db), uow)
+
+	assert.NoError(t, clientService.CreateClient(&models.Client{Name: "John Doe", AccountNum: "111111"}))
+	assert.NoError(t, clientService.CreateClient(&models.Client{Name: "Jane Doe", AccountNum: "222222"}))
+	_, err := accountService.Deposit("111111", models.BRL(10000))
+	assert.NoError(t, err)
+
+	client, err := statusService.ChangeStatus("111111", models.AccountStatusFrozen, "suspeita de fraude")
+	assert.NoError(t, err)
+	assert.Equal(t, models.AccountStatusFrozen, client.Status)
+
+	// Congelada: recebe créditos, mas não pode ser debitada
+	_, err = accountService.Deposit("111111", models.BRL(500))
+	assert.NoError(t, err)
+	_, err = accountService.Withdraw("111111", models.BRL(500))
+	assert.Equal(t, models.FailureDebitsNotAllowed, services.FailureReason(err))
+	_, err = transferService.TransferFunds("111111", "222222", models.BRL(500))
+	assert.Equal(t, models.FailureDebitsNotAllowed, services.FailureReason(err))
+	_, err = holdService.PlaceHold("111111", "222222", models.BRL(500), time.Time{})
+	assert.Equal(t, models.FailureDebitsNotAllowed, services.FailureReason(err))
+
+	_, err = statusService.ChangeStatus("111111", models.AccountStatusActive, "fraude descartada")
+	assert.NoError(t, err)
+	_, err = statusService.ChangeStatus("222222", models.AccountStatusBlocked, "titular falecido")
+	assert.NoError(t, err)
+
+	// Bloqueada: não recebe créditos
+	_, err = transferService.TransferFunds("111111", "222222", models.BRL(500))
+	assert.Equal(t, models.FailureCreditsNotAllowed, services.FailureReason(err))
+	_, err = accountService.Deposit("222222", models.BRL(500))
+	assert.Equal(t, models.FailureCreditsNotAllowed, services.FailureReason(err))
+
+	// Contas com saldo não podem ser encerradas
+	_, err = statusService.ChangeStatus("111111", models.AccountStatusClosed, "pedido do cliente")
+	assert.ErrorIs(t, err, services.ErrAccountNotEmpty)
+
+	stored, err := clientRepo.GetClientByAccountNum("111111")
+	assert.NoError(t, err)
+	assert.Equal(t, models.AccountStatusActive, stored.Status)
+	assert.Equal(t, models.BRL(10500), stored.Balance)
+
+	history, err := statusService.GetStatusHistory("111111")
+	assert.NoError(t, err)
+	if assert.Len(t, history, 2) {
+		assert.Equal(t, models.AccountStatusActive, history[0].ToStatus)
+		assert.Equal(t, "fraude descartada", history[0].Reason)
+		assert.Equal(t, models.AccountStatusActive, history[1].FromStatus)
+		assert.Equal(t, models.AccountStatusFrozen, history[1].ToStatus)
+		assert.False(t, history[1].CreatedAt.IsZero())
+	}
+}
+
+func TestAccountClosure_SweepsBalanceAndKeepsHistory(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	uow := repositories.NewUnitOfWork(db)
+	clientRepo := repositories.NewClientRepository(db)
+	transferRepo := repositories.NewTransferRepository(db)
+	ledgerRepo := repositories.NewLedgerRepository(db)
+	holdRepo := repositories.NewHoldRepository(db)
+	clientService := services.NewClientService(clientRepo, uow, models.DefaultAccountNumberFormat)
+	accountLocks := services.NewAccountLocks()
+	accountService := services.NewAccountService(transferRepo, uow, accountLocks)
+	transferService := services.NewTransferService(clientRepo, transferRepo, ledgerRepo, uow, accountLocks)
+	holdService := services.NewHoldService(clientRepo, holdRepo, uow, transferService)
+	statusService := services.NewAccountStatusService(clientRepo, repositories.NewAccountStatusRepository(db), uow)
+	closureService := services.NewAccountClosureService(clientRepo, uow, transferService)
+
+	assert.NoError(t, clientService.CreateClient(&models.Client{Name: "John Doe", AccountNum: "111111"}))
+	assert.NoError(t, clientService.CreateClient(&models.Client{Name: "Jane Doe", AccountNum: "222222"}))
+	_, err := accountService.Deposit("111111", models.BRL(10000))
+	assert.NoError(t, err)
+
+	// Uma reserva ativa impede o encerramento
+	hold, err := holdService.PlaceHold("111111", "222222", models.BRL(1000), time.Time{})
+	assert.NoError(t, err)
+	_, err = closureService.CloseAccount("111111", "222222", "")
+	assert.ErrorIs(t, err, services.ErrAccountHasPendingItems)
+	_, err = holdService.VoidHold(hold.ID)
+	assert.NoError(t, err)
+
+	// A transferência do saldo não paga tarifa nem respeita os limites da conta
+	assert.NoError(t, repositories.NewFeeRepository(db).SaveSchedule(&models.FeeSchedule{AccountType: models.AccountTypeChecking, Currency: "BRL", Flat: models.BRL(100)}))
+	assert.NoError(t, repositories.NewLimitRepository(db).SaveLimits(&models.TransferLimits{AccountNum: "111111", PerTransfer: models.BRL(500)}))
+
+	closure, err := closureService.CloseAccount("111111", "222222", "mudança de banco")
+	assert.NoError(t, err)
+	assert.Equal(t, models.AccountStatusClosed, closure.Account.Status)
+	assert.Equal(t, models.BRL(0), closure.Account.Balance)
+	assert.Equal(t, models.BRL(10000), closure.Sweep.Amount)
+	assert.True(t, closure.Sweep.Fee.IsZero())
+
+	recipient, err := clientRepo.GetClientByAccountNum("222222")
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(10000), recipient.Balance)
+
+	// Conta encerrada não recebe créditos nem volta a ser ativa
+	_, err = transferService.TransferFunds("222222", "111111", models.BRL(100))
+	assert.Equal(t, models.FailureCreditsNotAllowed, services.FailureReason(err))
+	_, err = accountService.Deposit("111111", models.BRL(100))
+	assert.Equal(t, models.FailureCreditsNotAllowed, services.FailureReason(err))
+	_, err = statusService.ChangeStatus("111111", models.AccountStatusActive, "reabertura")
+	assert.ErrorIs(t, err, models.ErrInvalidStatusTransition)
+
+	// O histórico da conta encerrada continua disponível
+	history, err := transferService.GetTransferHistory("111111")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, history)
+	statuses, err := statusService.GetStatusHistory("111111")
+	assert.NoError(t, err)
+	if assert.Len(t, statuses, 1) {
+		assert.Equal(t, "mudança de banco", statuses[0].Reason)
+	}
+}
@@ -0,0 +1,102 @@
+// src/repositories/balance_snapshot_repository_integration_test.go
+package test
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"banking/src/services"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBalanceService_SnapshotsAndPointInTimeBalance(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	uow := repositories.NewUnitOfWork(db)
+	clientRepo := repositories.NewClientRepository(db)
+	ledgerRepo := repositories.NewLedgerRepository(db)
+	snapshotRepo := repositories.NewBalanceSnapshotRepository(db)
+	clientService := services.NewClientService(clientRepo, uow, models.DefaultAccountNumberFormat)
+	accountService := services.NewAccountService(repositories.NewTransferRepository(db), uow, services.NewAccountLocks())
+	balanceService := services.NewBalanceService(clientRepo, ledgerRepo, snapshotRepo)
+
+	assert.NoError(t, clientService.CreateClient(&models.Client{Name: "John Doe", AccountNum: "111111"}))
+	assert.NoError(t, clientService.CreateClient(&models.Client{Name: "Jane Doe", AccountNum: "222222"}))
+
+	// Movimentações em três dias seguidos, ao meio-dia de Brasília
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, models.BusinessLocation)
+	for i, cents := range []int64{10000, 2500, -4000} {
+		var movement *models.Transfer
+		var err error
+		if cents > 0 {
+			movement, err = accountService.Deposit("111111", models.BRL(cents))
+		} else {
+			movement, err = accountService.Withdraw("111111", models.BRL(-cents))
+		}
+		assert.NoError(t, err)
+		noon := day.AddDate(0, 0, i).Add(12 * time.Hour).UTC().Format("2006-01-02 15:04:05")
+		_, err = db.Exec("UPDATE journal_entries SET created_at = ? WHERE transfer_id = ?", noon, movement.ID)
+		assert.NoError(t, err)
+	}
+
+	now := day.AddDate(0, 0, 3).Add(time.Hour)
+	for i := 0; i < 2; i++ {
+		stored, err := balanceService.SnapshotBalances(day.AddDate(0, 0, i), now)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, stored)
+	}
+	// Executar de novo o mesmo dia não guarda nada
+	stored, err := balanceService.SnapshotBalances(day, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, stored)
+	_, err = balanceService.SnapshotBalances(day.AddDate(0, 0, 3), now)
+	assert.ErrorIs(t, err, services.ErrPeriodNotEnded)
+
+	snapshots, err := snapshotRepo.GetSnapshots("111111", "2024-03-01", "2024-03-31")
+	assert.NoError(t, err)
+	if assert.Len(t, snapshots, 2) {
+		assert.Equal(t, "2024-03-01", snapshots[0].Date)
+		assert.Equal(t, models.BRL(10000), snapshots[0].Balance)
+		assert.Equal(t, models.BRL(12500), snapshots[1].Balance)
+		assert.True(t, snapshots[1].AsOf.Equal(day.AddDate(0, 0, 2)))
+	}
+
+	// Saldos em instantes antes, entre e depois dos dias guardados
+	for _, tc := range []struct {
+		at      time.Time
+		balance int64
+	}{
+		{day, 0},
+		{day.Add(13 * time.Hour), 10000},
+		{day.AddDate(0, 0, 1), 10000},
+		{day.AddDate(0, 0, 2), 12500},
+		{day.AddDate(0, 0, 2).Add(11 * time.Hour), 12500},
+		{day.AddDate(0, 0, 2).Add(13 * time.Hour), 8500},
+	} {
+		balance, err := balanceService.GetBalance("111111", tc.at)
+		assert.NoError(t, err)
+		assert.Equal(t, models.BRL(tc.balance), balance.Balance, "saldo em %s", tc.at)
+	}
+
+	// O saldo atual bate com o saldo armazenado da conta
+	current, err := balanceService.GetBalance("111111", time.Time{})
+	assert.NoError(t, err)
+	client, err := clientRepo.GetClientByAccountNum("111111")
+	assert.NoError(t, err)
+	assert.Equal(t, client.Balance, current.Balance)
+
+	// A rotina diária preenche os dias que faltam depois do último guardado
+	assert.NoError(t, balanceService.SnapshotDue(now))
+	latest, err := snapshotRepo.GetLatestSnapshotDate()
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-03-03", latest)
+	snapshots, err = balanceService.GetDailyBalances("111111", day, day.AddDate(0, 0, 2))
+	assert.NoError(t, err)
+	if assert.Len(t, snapshots, 3) {
+		assert.Equal(t, models.BRL(8500), snapshots[2].Balance)
+	}
+}
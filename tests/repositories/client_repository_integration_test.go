@@ -18,7 +18,7 @@ func TestClientRepository_CreateClient(t *testing.T) {
 	client := &models.Client{
 		Name:       "John Doe",
 		AccountNum: "123456",
-		Balance:    1000.0,
+		Balance:    models.BRL(100000),
 	}
 
 	err := repo.CreateClient(client)
@@ -29,7 +29,7 @@ func TestClientRepository_CreateClient(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "John Doe", storedClient.Name)
 	assert.Equal(t, "123456", storedClient.AccountNum)
-	assert.Equal(t, 1000.0, storedClient.Balance)
+	assert.Equal(t, models.BRL(100000), storedClient.Balance)
 }
 
 func TestClientRepository_GetClientByAccountNum_NotFound(t *testing.T) {
@@ -54,20 +54,20 @@ func TestClientRepository_UpdateClientBalance(t *testing.T) {
 	client := &models.Client{
 		Name:       "Jane Doe",
 		AccountNum: "654321",
-		Balance:    200.0,
+		Balance:    models.BRL(20000),
 	}
 	err := repo.CreateClient(client)
 	assert.NoError(t, err)
 
 	// Atualiza o saldo
-	client.Balance = 500.0
+	client.Balance = models.BRL(50000)
 	err = repo.UpdateClientBalance(client)
 	assert.NoError(t, err)
 
 	// Verifica se o saldo foi atualizado corretamente
 	updatedClient, err := repo.GetClientByAccountNum("654321")
 	assert.NoError(t, err)
-	assert.Equal(t, 500.0, updatedClient.Balance)
+	assert.Equal(t, models.BRL(50000), updatedClient.Balance)
 }
 
 func TestClientRepository_GetClients(t *testing.T) {
@@ -78,8 +78,8 @@ func TestClientRepository_GetClients(t *testing.T) {
 
 	// Insere alguns clientes
 	clients := []models.Client{
-		{Name: "Alice", AccountNum: "111111", Balance: 1000.0},
-		{Name: "Bob", AccountNum: "222222", Balance: 1500.0},
+		{Name: "Alice", AccountNum: "111111", Balance: models.BRL(100000)},
+		{Name: "Bob", AccountNum: "222222", Balance: models.BRL(150000)},
 	}
 	for _, client := range clients {
 		err := repo.CreateClient(&client)
@@ -93,3 +93,93 @@ func TestClientRepository_GetClients(t *testing.T) {
 	assert.Equal(t, "Alice", storedClients[0].Name)
 	assert.Equal(t, "Bob", storedClients[1].Name)
 }
+
+func TestClientRepository_DebitAndCreditClientBalance(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := repositories.NewClientRepository(db)
+	client := &models.Client{Name: "Jane Doe", AccountNum: "654321", Balance: models.BRL(20000)}
+	assert.NoError(t, repo.CreateClient(client))
+
+	balance, err := repo.DebitClientBalance("654321", models.BRL(15000))
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(5000), balance)
+
+	// O débito que deixaria o saldo negativo não altera nada
+	_, err = repo.DebitClientBalance("654321", models.BRL(5001))
+	assert.ErrorIs(t, err, repositories.ErrInsufficientBalance)
+
+	balance, err = repo.CreditClientBalance("654321", models.BRL(1))
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(5001), balance)
+
+	_, err = repo.DebitClientBalance("654321", models.NewMoney(1, "USD"))
+	assert.ErrorIs(t, err, models.ErrCurrencyMismatch)
+
+	_, err = repo.CreditClientBalance("999999", models.BRL(1))
+	assert.ErrorIs(t, err, repositories.ErrClientNotFound)
+
+	storedClient, err := repo.GetClientByAccountNum("654321")
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(5001), storedClient.Balance)
+}
+
+func TestClientRepository_VersionedUpdates(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := repositories.NewClientRepository(db)
+	client := &models.Client{Name: "Jane Doe", AccountNum: "654321", Balance: models.BRL(20000)}
+	assert.NoError(t, repo.CreateClient(client))
+	assert.Equal(t, 1, client.Version)
+
+	// Duas leituras da mesma versão
+	first, err := repo.GetClientByAccountNum("654321")
+	assert.NoError(t, err)
+	second, err := repo.GetClientByAccountNum("654321")
+	assert.NoError(t, err)
+
+	first.Name = "Jane Smith"
+	assert.NoError(t, repo.UpdateClient(first))
+	assert.Equal(t, 2, first.Version)
+
+	// A segunda escrita partiria de uma versão antiga e é rejeitada
+	second.Balance = models.BRL(0)
+	err = repo.UpdateClientBalance(second)
+	var conflict *repositories.VersionConflictError
+	assert.ErrorAs(t, err, &conflict)
+	assert.ErrorIs(t, err, repositories.ErrVersionConflict)
+	assert.Equal(t, 1, conflict.ExpectedVersion)
+
+	// Débitos e créditos também geram uma nova versão
+	_, err = repo.DebitClientBalance("654321", models.BRL(100))
+	assert.NoError(t, err)
+
+	stored, err := repo.GetClientByAccountNum("654321")
+	assert.NoError(t, err)
+	assert.Equal(t, "Jane Smith", stored.Name)
+	assert.Equal(t, models.BRL(19900), stored.Balance)
+	assert.Equal(t, 3, stored.Version)
+
+	err = repo.UpdateClient(&models.Client{Name: "Nobody", AccountNum: "999999", Version: 1})
+	assert.ErrorIs(t, err, repositories.ErrClientNotFound)
+}
+
+func TestClientRepository_GetTotalBalance(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := repositories.NewClientRepository(db)
+	assert.NoError(t, repo.CreateClient(&models.Client{Name: "Alice", AccountNum: "111111", Balance: models.BRL(100000)}))
+	assert.NoError(t, repo.CreateClient(&models.Client{Name: "Bob", AccountNum: "222222", Balance: models.BRL(50000)}))
+	assert.NoError(t, repo.CreateClient(&models.Client{Name: "Carol", AccountNum: "333333", Balance: models.NewMoney(700, "USD")}))
+
+	total, err := repo.GetTotalBalance("BRL")
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(150000), total)
+
+	total, err = repo.GetTotalBalance("EUR")
+	assert.NoError(t, err)
+	assert.Equal(t, models.NewMoney(0, "EUR"), total)
+}
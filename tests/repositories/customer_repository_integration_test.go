@@ -0,0 +1,82 @@
+// src/repositories/customer_repository_integration_test.go
+package test
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"banking/src/services"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomerRepository_CreateAndGet(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := repositories.NewCustomerRepository(db)
+	customer := &models.Customer{Name: "Maria Silva"}
+	assert.NoError(t, repo.CreateCustomer(customer))
+	assert.NotZero(t, customer.ID)
+	assert.False(t, customer.CreatedAt.IsZero())
+
+	found, err := repo.GetCustomerByID(customer.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Maria Silva", found.Name)
+
+	_, err = repo.GetCustomerByID(customer.ID + 1)
+	assert.ErrorIs(t, err, repositories.ErrCustomerNotFound)
+	assert.ErrorIs(t, repo.UpdateCustomerName(customer.ID+1, "Outro"), repositories.ErrCustomerNotFound)
+}
+
+func TestCustomer_AccountsShareTheHolder(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	uow := repositories.NewUnitOfWork(db)
+	clientRepo := repositories.NewClientRepository(db)
+	clientService := services.NewClientService(clientRepo, uow, models.DefaultAccountNumberFormat)
+	customerService := services.NewCustomerService(repositories.NewCustomerRepository(db), clientRepo, uow, models.DefaultAccountNumberFormat)
+
+	// A rota de compatibilidade cria o cliente junto com a primeira conta
+	checking := &models.Client{Name: "Maria Silva", AccountNum: "111111"}
+	assert.NoError(t, clientService.CreateClient(checking))
+	assert.NotZero(t, checking.CustomerID)
+
+	savings := &models.Account{AccountNum: "222222", AccountType: models.AccountTypeSavings}
+	assert.NoError(t, customerService.OpenAccount(checking.CustomerID, savings))
+	assert.Equal(t, checking.CustomerID, savings.CustomerID)
+	assert.Equal(t, "Maria Silva", savings.Name)
+
+	other := &models.Client{Name: "João Souza", AccountNum: "333333"}
+	assert.NoError(t, clientService.CreateClient(other))
+
+	accounts, err := customerService.GetAccounts(checking.CustomerID)
+	assert.NoError(t, err)
+	assert.Len(t, accounts, 2)
+	assert.Equal(t, "111111", accounts[0].AccountNum)
+	assert.Equal(t, "222222", accounts[1].AccountNum)
+	assert.Equal(t, models.AccountTypeSavings, accounts[1].AccountType)
+
+	// Renomear pela rota de compatibilidade renomeia o titular de todas as contas
+	updated, err := clientService.UpdateClient("111111", "Maria Souza", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, updated.Version)
+
+	customer, err := customerService.GetCustomer(checking.CustomerID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Maria Souza", customer.Name)
+	savingsAfter, err := clientRepo.GetClientByAccountNum("222222")
+	assert.NoError(t, err)
+	assert.Equal(t, "Maria Souza", savingsAfter.Name)
+	assert.Equal(t, 2, savingsAfter.Version)
+	otherAfter, err := clientRepo.GetClientByAccountNum("333333")
+	assert.NoError(t, err)
+	assert.Equal(t, "João Souza", otherAfter.Name)
+
+	// Conta com número repetido não é aberta
+	assert.Error(t, customerService.OpenAccount(checking.CustomerID, &models.Account{AccountNum: "333333"}))
+	_, err = customerService.GetAccounts(checking.CustomerID + 100)
+	assert.ErrorIs(t, err, repositories.ErrCustomerNotFound)
+}
@@ -0,0 +1,121 @@
+// src/repositories/fee_repository_integration_test.go
+package test
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"banking/src/services"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeeRepository_SaveAndGetSchedule(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := repositories.NewFeeRepository(db)
+
+	// Todos os tipos de conta começam sem tarifas
+	schedules, err := repo.GetSchedules()
+	assert.NoError(t, err)
+	if assert.Len(t, schedules, 2) {
+		assert.Equal(t, models.AccountTypeChecking, schedules[0].AccountType)
+		assert.True(t, schedules[0].Flat.IsZero())
+		assert.Empty(t, schedules[0].Tiers)
+	}
+
+	schedule := &models.FeeSchedule{
+		AccountType: models.AccountTypeChecking, Flat: models.BRL(100), PercentBps: 50, FreeTransfers: 3,
+		Tiers: []models.FeeTier{{MinAmount: models.BRL(10000), Flat: models.BRL(50)}},
+	}
+	schedule.Normalize()
+	assert.NoError(t, repo.SaveSchedule(schedule))
+
+	// Gravar de novo substitui as faixas
+	schedule.Tiers = []models.FeeTier{{MinAmount: models.BRL(20000), Flat: models.BRL(0), PercentBps: 25}}
+	assert.NoError(t, repo.SaveSchedule(schedule))
+
+	saved, err := repo.GetSchedule(models.AccountTypeChecking)
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(100), saved.Flat)
+	assert.Equal(t, 50, saved.PercentBps)
+	assert.Equal(t, 3, saved.FreeTransfers)
+	assert.Equal(t, []models.FeeTier{{MinAmount: models.BRL(20000), Flat: models.BRL(0), PercentBps: 25}}, saved.Tiers)
+
+	_, err = repo.GetSchedule("investment")
+	assert.ErrorIs(t, err, repositories.ErrFeeScheduleNotFound)
+}
+
+func TestTransferService_ChargesFeeAtomically(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	uow := repositories.NewUnitOfWork(db)
+	clientRepo := repositories.NewClientRepository(db)
+	transferRepo := repositories.NewTransferRepository(db)
+	ledgerRepo := repositories.NewLedgerRepository(db)
+	feeRepo := repositories.NewFeeRepository(db)
+	clientService := services.NewClientService(clientRepo, uow, models.DefaultAccountNumberFormat)
+	accountLocks := services.NewAccountLocks()
+	accountService := services.NewAccountService(transferRepo, uow, accountLocks)
+	feeService := services.NewFeeService(feeRepo, uow)
+	transferService := services.NewTransferService(clientRepo, transferRepo, ledgerRepo, uow, accountLocks)
+
+	assert.NoError(t, clientService.CreateClient(&models.Client{Name: "John Doe", AccountNum: "111111"}))
+	assert.NoError(t, clientService.CreateClient(&models.Client{Name: "Jane Doe", AccountNum: "222222"}))
+	_, err := accountService.Fund("111111", models.BRL(100000))
+	assert.NoError(t, err)
+	_, err = feeService.SetSchedule(models.FeeSchedule{AccountType: models.AccountTypeChecking, Flat: models.BRL(150), FreeTransfers: 1})
+	assert.NoError(t, err)
+
+	// A primeira transferência do mês é gratuita
+	first, err := transferService.TransferFunds("111111", "222222", models.BRL(10000))
+	assert.NoError(t, err)
+	assert.True(t, first.Fee.IsZero())
+
+	second, err := transferService.TransferFunds("111111", "222222", models.BRL(10000))
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(150), *second.Fee)
+
+	count, err := feeRepo.CountTransfersSince("111111", models.StartOfBusinessMonth(time.Now()))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	client, err := clientRepo.GetClientByAccountNum("111111")
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(100000-20000-150), client.Balance)
+	revenue, err := ledgerRepo.GetAccountBalance(models.FeeRevenueAccountNum, "BRL")
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(150), revenue)
+
+	// O histórico traz a tarifa como linha própria, ligada à transferência tarifada
+	history, err := transferService.GetTransferHistory("111111")
+	assert.NoError(t, err)
+	var feeLine, charged *models.Transfer
+	for i := range history {
+		switch history[i].ID {
+		case second.ID:
+			charged = &history[i]
+		default:
+			if history[i].Type == models.TransferTypeFee {
+				feeLine = &history[i]
+			}
+		}
+	}
+	if assert.NotNil(t, feeLine) && assert.NotNil(t, charged) {
+		assert.Equal(t, second.ID, *feeLine.FeeOf)
+		assert.Equal(t, models.FeeRevenueAccountNum, feeLine.ToAccountNum)
+		assert.Equal(t, models.BRL(150), feeLine.Amount)
+		assert.Equal(t, models.BRL(150), *charged.Fee)
+	}
+
+	// Sem saldo para a tarifa, nem a transferência acontece
+	_, err = transferService.TransferFunds("111111", "222222", models.BRL(100000-20000-150))
+	assert.Equal(t, models.FailureInsufficientBalance, services.FailureReason(err))
+	client, err = clientRepo.GetClientByAccountNum("111111")
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(100000-20000-150), client.Balance)
+}
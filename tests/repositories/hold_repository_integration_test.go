@@ -0,0 +1,247 @@
+// src/repositories/hold_repository_integration_test.go
+package test
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"banking/src/services"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientRepository_HoldsReduceAvailableBalance(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := repositories.NewClientRepository(db)
+	assert.NoError(t, repo.CreateClient(&models.Client{Name: "Jane Doe", AccountNum: "654321", Balance: models.BRL(0)}))
+	_, err := repo.CreditClientBalance("654321", models.BRL(10000))
+	assert.NoError(t, err)
+
+	available, err := repo.HoldClientBalance("654321", models.BRL(6000))
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(4000), available)
+
+	// Débitos e novas reservas só podem usar o saldo disponível
+	_, err = repo.DebitClientBalance("654321", models.BRL(4001))
+	assert.ErrorIs(t, err, repositories.ErrInsufficientBalance)
+	_, err = repo.HoldClientBalance("654321", models.BRL(4001))
+	assert.ErrorIs(t, err, repositories.ErrInsufficientBalance)
+
+	client, err := repo.GetClientByAccountNum("654321")
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(10000), client.Balance)
+	assert.Equal(t, models.BRL(6000), client.Held)
+	assert.Equal(t, models.BRL(4000), client.AvailableBalance)
+
+	available, err = repo.ReleaseClientHold("654321", models.BRL(6000))
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(10000), available)
+
+	// Não é possível liberar mais do que está reservado
+	_, err = repo.ReleaseClientHold("654321", models.BRL(1))
+	assert.Error(t, err)
+}
+
+func TestHoldRepository_Lifecycle(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	createTestClients(t, db, "123456", "654321")
+	repo := repositories.NewHoldRepository(db)
+	now := time.Now()
+	hold := &models.Hold{AccountNum: "123456", ToAccountNum: "654321", Amount: models.BRL(10000), ExpiresAt: now.Add(time.Hour)}
+	assert.NoError(t, repo.CreateHold(hold))
+	assert.NotZero(t, hold.ID)
+	assert.Equal(t, models.HoldStatusActive, hold.Status)
+
+	expired := &models.Hold{AccountNum: "123456", ToAccountNum: "654321", Amount: models.BRL(500), ExpiresAt: now.Add(-time.Minute)}
+	assert.NoError(t, repo.CreateHold(expired))
+
+	due, err := repo.GetExpiredHolds(now, 10)
+	assert.NoError(t, err)
+	assert.Len(t, due, 1)
+	assert.Equal(t, expired.ID, due[0].ID)
+
+	hold.CapturedAmount = models.BRL(2500)
+	assert.NoError(t, repo.UpdateHold(hold))
+
+	stored, err := repo.GetHoldByID(hold.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(2500), stored.CapturedAmount)
+	assert.Equal(t, models.BRL(7500), stored.Remaining())
+
+	hold.Status = models.HoldStatusVoided
+	assert.NoError(t, repo.UpdateHold(hold))
+	// Reservas encerradas não mudam mais
+	assert.ErrorIs(t, repo.UpdateHold(hold), repositories.ErrHoldNotActive)
+
+	holds, err := repo.GetHoldsByAccountNum("123456", models.HoldStatusActive)
+	assert.NoError(t, err)
+	assert.Len(t, holds, 1)
+	holds, err = repo.GetHoldsByAccountNum("123456", "")
+	assert.NoError(t, err)
+	assert.Len(t, holds, 2)
+
+	_, err = repo.GetHoldByID(999)
+	assert.ErrorIs(t, err, repositories.ErrHoldNotFound)
+	assert.ErrorIs(t, repo.UpdateHold(&models.Hold{ID: 999}), repositories.ErrHoldNotFound)
+}
+
+func TestHoldService_CaptureVoidAndExpireWithDatabase(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	uow := repositories.NewUnitOfWork(db)
+	clientRepo := repositories.NewClientRepository(db)
+	transferRepo := repositories.NewTransferRepository(db)
+	accountLocks := services.NewAccountLocks()
+	transferService := services.NewTransferService(clientRepo, transferRepo, repositories.NewLedgerRepository(db), uow, accountLocks)
+	holdService := services.NewHoldService(clientRepo, repositories.NewHoldRepository(db), uow, transferService)
+	clientService := services.NewClientService(clientRepo, uow, models.DefaultAccountNumberFormat)
+	assert.NoError(t, clientService.CreateClient(&models.Client{Name: "Payer", AccountNum: "111111"}))
+	assert.NoError(t, clientService.CreateClient(&models.Client{Name: "Merchant", AccountNum: "222222"}))
+	_, err := services.NewAccountService(transferRepo, uow, accountLocks).Fund("111111", models.BRL(10000))
+	assert.NoError(t, err)
+
+	hold, err := holdService.PlaceHold("111111", "222222", models.BRL(6000), time.Time{})
+	assert.NoError(t, err)
+	_, err = holdService.PlaceHold("111111", "222222", models.BRL(4001), time.Time{})
+	assert.Equal(t, models.FailureInsufficientBalance, services.FailureReason(err))
+
+	// Captura parcial: a reserva continua ativa com o restante
+	hold, transfer, err := holdService.CaptureHold(hold.ID, models.BRL(2500))
+	assert.NoError(t, err)
+	assert.Equal(t, models.HoldStatusActive, hold.Status)
+	assert.Equal(t, models.BRL(2500), transfer.Amount)
+
+	payer, err := clientRepo.GetClientByAccountNum("111111")
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(7500), payer.Balance)
+	assert.Equal(t, models.BRL(3500), payer.Held)
+	assert.Equal(t, models.BRL(4000), payer.AvailableBalance)
+
+	_, _, err = holdService.CaptureHold(hold.ID, models.BRL(3501))
+	assert.ErrorIs(t, err, models.ErrCaptureExceedsHold)
+
+	hold, err = holdService.VoidHold(hold.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.HoldStatusVoided, hold.Status)
+	_, _, err = holdService.CaptureHold(hold.ID, models.Money{})
+	assert.ErrorIs(t, err, repositories.ErrHoldNotActive)
+
+	// Reservas vencidas são expiradas pelo executor e liberam o saldo
+	expiring, err := holdService.PlaceHold("111111", "222222", models.BRL(1000), time.Now().Add(time.Second))
+	assert.NoError(t, err)
+	assert.NoError(t, holdService.ExpireDue(time.Now().Add(time.Minute)))
+	expiring, err = holdService.GetHold(expiring.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.HoldStatusExpired, expiring.Status)
+
+	payer, err = clientRepo.GetClientByAccountNum("111111")
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(7500), payer.AvailableBalance)
+	merchant, err := clientRepo.GetClientByAccountNum("222222")
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(2500), merchant.Balance)
+}
+
+func TestHoldService_CaptureChargesFeeAndRecordsDeclines(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	uow := repositories.NewUnitOfWork(db)
+	clientRepo := repositories.NewClientRepository(db)
+	transferRepo := repositories.NewTransferRepository(db)
+	accountLocks := services.NewAccountLocks()
+	transferService := services.NewTransferService(clientRepo, transferRepo, repositories.NewLedgerRepository(db), uow, accountLocks)
+	holdService := services.NewHoldService(clientRepo, repositories.NewHoldRepository(db), uow, transferService)
+	clientService := services.NewClientService(clientRepo, uow, models.DefaultAccountNumberFormat)
+	assert.NoError(t, clientService.CreateClient(&models.Client{Name: "Payer", AccountNum: "111111"}))
+	assert.NoError(t, clientService.CreateClient(&models.Client{Name: "Merchant", AccountNum: "222222"}))
+	_, err := services.NewAccountService(transferRepo, uow, accountLocks).Fund("111111", models.BRL(10000))
+	assert.NoError(t, err)
+	assert.NoError(t, repositories.NewFeeRepository(db).SaveSchedule(&models.FeeSchedule{
+		AccountType: models.AccountTypeChecking, Currency: "BRL", Flat: models.BRL(100),
+	}))
+
+	// A captura é uma transferência comum e paga a tarifa do tipo da conta
+	hold, err := holdService.PlaceHold("111111", "222222", models.BRL(6000), time.Time{})
+	assert.NoError(t, err)
+	_, transfer, err := holdService.CaptureHold(hold.ID, models.Money{})
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(100), *transfer.Fee)
+
+	payer, err := clientRepo.GetClientByAccountNum("111111")
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(3900), payer.Balance)
+	assert.Equal(t, models.BRL(0), payer.Held)
+
+	// Sem saldo disponível para a tarifa, a captura é recusada, registrada e
+	// a reserva continua ativa
+	hold, err = holdService.PlaceHold("111111", "222222", models.BRL(3900), time.Time{})
+	assert.NoError(t, err)
+	_, _, err = holdService.CaptureHold(hold.ID, models.Money{})
+	assert.Equal(t, models.FailureInsufficientBalance, services.FailureReason(err))
+
+	hold, err = holdService.GetHold(hold.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.HoldStatusActive, hold.Status)
+	payer, err = clientRepo.GetClientByAccountNum("111111")
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(3900), payer.Held)
+
+	var failed int
+	assert.NoError(t, db.QueryRow("SELECT COUNT(*) FROM transfers WHERE from_account_num = ? AND status = ? AND failure_reason = ?",
+		"111111", models.TransferStatusFailed, models.FailureInsufficientBalance).Scan(&failed))
+	assert.Equal(t, 1, failed)
+}
+
+func TestHoldService_HoldsCountTowardTransferLimits(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	uow := repositories.NewUnitOfWork(db)
+	clientRepo := repositories.NewClientRepository(db)
+	transferRepo := repositories.NewTransferRepository(db)
+	accountLocks := services.NewAccountLocks()
+	transferService := services.NewTransferService(clientRepo, transferRepo, repositories.NewLedgerRepository(db), uow, accountLocks)
+	holdService := services.NewHoldService(clientRepo, repositories.NewHoldRepository(db), uow, transferService)
+	clientService := services.NewClientService(clientRepo, uow, models.DefaultAccountNumberFormat)
+	assert.NoError(t, clientService.CreateClient(&models.Client{Name: "Payer", AccountNum: "111111"}))
+	assert.NoError(t, clientService.CreateClient(&models.Client{Name: "Merchant", AccountNum: "222222"}))
+	_, err := services.NewAccountService(transferRepo, uow, accountLocks).Fund("111111", models.BRL(100000))
+	assert.NoError(t, err)
+	assert.NoError(t, repositories.NewLimitRepository(db).SaveLimits(&models.TransferLimits{
+		AccountNum:       "111111",
+		PerTransfer:      models.BRL(0),
+		Daily:            models.BRL(10000),
+		Monthly:          models.BRL(0),
+		NightPerTransfer: models.BRL(0),
+		NightTotal:       models.BRL(0),
+	}))
+
+	hold, err := holdService.PlaceHold("111111", "222222", models.BRL(6000), time.Time{})
+	assert.NoError(t, err)
+
+	// O valor reservado já conta no limite diário, para novas reservas e transferências
+	_, err = holdService.PlaceHold("111111", "222222", models.BRL(5000), time.Time{})
+	assert.Equal(t, models.FailureLimitExceeded, services.FailureReason(err))
+	_, err = transferService.TransferFunds("111111", "222222", models.BRL(5000))
+	assert.Equal(t, models.FailureLimitExceeded, services.FailureReason(err))
+
+	// A captura não conta de novo o que já estava reservado
+	_, _, err = holdService.CaptureHold(hold.ID, models.BRL(2000))
+	assert.NoError(t, err)
+	_, err = transferService.TransferFunds("111111", "222222", models.BRL(4000))
+	assert.NoError(t, err)
+
+	// Cancelar a reserva devolve o restante ao limite
+	_, err = holdService.VoidHold(hold.ID)
+	assert.NoError(t, err)
+	_, err = transferService.TransferFunds("111111", "222222", models.BRL(4000))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,117 @@
+// src/repositories/idempotency_repository_integration_test.go
+package test
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+func newIdempotencyRecord(key string, createdAt time.Time, ttl time.Duration) *models.IdempotencyRecord {
+	return &models.IdempotencyRecord{
+		Key:         key,
+		RequestHash: "hash-" + key,
+		CreatedAt:   createdAt,
+		ExpiresAt:   createdAt.Add(ttl),
+	}
+}
+
+func TestIdempotencyRepository_CreateAndSaveResponse(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := repositories.NewIdempotencyRepository(db)
+	now := time.Now().UTC()
+
+	assert.NoError(t, repo.Create(newIdempotencyRecord("key-1", now, time.Hour)))
+
+	record, err := repo.GetByKey("key-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "hash-key-1", record.RequestHash)
+	assert.False(t, record.Completed())
+
+	assert.NoError(t, repo.SaveResponse("key-1", 200, []byte(`{"status":"transfer successful"}`)))
+
+	record, err = repo.GetByKey("key-1")
+	assert.NoError(t, err)
+	assert.True(t, record.Completed())
+	assert.Equal(t, 200, record.StatusCode)
+	assert.Equal(t, `{"status":"transfer successful"}`, string(record.ResponseBody))
+}
+
+func TestIdempotencyRepository_DuplicateKey(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := repositories.NewIdempotencyRepository(db)
+	now := time.Now().UTC()
+
+	assert.NoError(t, repo.Create(newIdempotencyRecord("key-1", now, time.Hour)))
+	err := repo.Create(newIdempotencyRecord("key-1", now, time.Hour))
+	assert.ErrorIs(t, err, repositories.ErrIdempotencyKeyExists)
+
+	_, err = repo.GetByKey("missing")
+	assert.ErrorIs(t, err, repositories.ErrIdempotencyKeyNotFound)
+}
+
+func TestIdempotencyRepository_DeleteExpired(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := repositories.NewIdempotencyRepository(db)
+	now := time.Now().UTC()
+
+	assert.NoError(t, repo.Create(newIdempotencyRecord("old", now.Add(-2*time.Hour), time.Hour)))
+	assert.NoError(t, repo.Create(newIdempotencyRecord("fresh", now, time.Hour)))
+
+	assert.NoError(t, repo.DeleteExpired(now))
+
+	_, err := repo.GetByKey("old")
+	assert.ErrorIs(t, err, repositories.ErrIdempotencyKeyNotFound)
+	_, err = repo.GetByKey("fresh")
+	assert.NoError(t, err)
+}
+
+func TestIdempotencyRepository_ReclaimStaleReservation(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := repositories.NewIdempotencyRepository(db)
+	now := time.Now().UTC()
+
+	assert.NoError(t, repo.Create(newIdempotencyRecord("key-1", now.Add(-time.Hour), 24*time.Hour)))
+
+	// Uma reserva recente não pode ser retomada
+	err := repo.Reclaim(newIdempotencyRecord("key-1", now, 24*time.Hour), now.Add(-2*time.Hour))
+	assert.ErrorIs(t, err, repositories.ErrIdempotencyKeyExists)
+
+	assert.NoError(t, repo.Reclaim(newIdempotencyRecord("key-1", now, 24*time.Hour), now.Add(-time.Minute)))
+
+	// A segunda tentativa já encontra a reserva renovada
+	err = repo.Reclaim(newIdempotencyRecord("key-1", now, 24*time.Hour), now.Add(-time.Minute))
+	assert.ErrorIs(t, err, repositories.ErrIdempotencyKeyExists)
+}
+
+func TestIdempotencyRepository_ReleaseKeepsCompletedKeys(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := repositories.NewIdempotencyRepository(db)
+	now := time.Now().UTC()
+
+	assert.NoError(t, repo.Create(newIdempotencyRecord("pending", now, time.Hour)))
+	assert.NoError(t, repo.Create(newIdempotencyRecord("done", now, time.Hour)))
+	assert.NoError(t, repo.SaveResponse("done", 200, []byte(`{}`)))
+
+	assert.NoError(t, repo.Release("pending"))
+	assert.NoError(t, repo.Release("done"))
+
+	_, err := repo.GetByKey("pending")
+	assert.ErrorIs(t, err, repositories.ErrIdempotencyKeyNotFound)
+	_, err = repo.GetByKey("done")
+	assert.NoError(t, err)
+}
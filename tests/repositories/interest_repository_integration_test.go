@@ -0,0 +1,163 @@
+// src/repositories/interest_repository_integration_test.go
+package test
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"banking/src/services"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterestRepository_Rates(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := repositories.NewInterestRepository(db)
+
+	// Todos os tipos de conta começam sem juros
+	rates, err := repo.GetRates()
+	assert.NoError(t, err)
+	if assert.Len(t, rates, 2) {
+		assert.Equal(t, models.AccountTypeChecking, rates[0].AccountType)
+		assert.Equal(t, 0, rates[0].RateBps)
+	}
+
+	assert.NoError(t, repo.SaveRate(&models.InterestRate{AccountType: models.AccountTypeSavings, RateBps: 650}))
+	rates, err = repo.GetRates()
+	assert.NoError(t, err)
+	assert.Equal(t, 650, rates[1].RateBps)
+}
+
+func TestInterestRepository_AccrualIsUniquePerDay(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	clientRepo := repositories.NewClientRepository(db)
+	assert.NoError(t, clientRepo.CreateClient(&models.Client{Name: "Jane Doe", AccountNum: "654321", Balance: models.BRL(0), AccountType: models.AccountTypeSavings}))
+	repo := repositories.NewInterestRepository(db)
+
+	accrual := &models.InterestAccrual{AccountNum: "654321", AccrualDate: "2030-03-01", Balance: models.BRL(100000), RateBps: 100}
+	assert.NoError(t, repo.CreateAccrual(accrual))
+	assert.Equal(t, models.BRL(3), accrual.Interest)
+
+	duplicate := &models.InterestAccrual{AccountNum: "654321", AccrualDate: "2030-03-01", Balance: models.BRL(200000), RateBps: 100}
+	assert.ErrorIs(t, repo.CreateAccrual(duplicate), repositories.ErrAccrualExists)
+}
+
+func TestInterestService_AccrueAndCapitalize(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	uow := repositories.NewUnitOfWork(db)
+	clientRepo := repositories.NewClientRepository(db)
+	clientService := services.NewClientService(clientRepo, uow, models.DefaultAccountNumberFormat)
+	interestService := services.NewInterestService(clientRepo, repositories.NewInterestRepository(db), uow)
+	accountService := services.NewAccountService(repositories.NewTransferRepository(db), uow, services.NewAccountLocks())
+
+	assert.NoError(t, clientService.CreateClient(&models.Client{Name: "John Doe", AccountNum: "111111"}))
+	assert.NoError(t, clientService.CreateClient(&models.Client{Name: "Jane Doe", AccountNum: "222222", AccountType: models.AccountTypeSavings}))
+	_, err := accountService.Fund("111111", models.BRL(10000000))
+	assert.NoError(t, err)
+	_, err = accountService.Fund("222222", models.BRL(10000000))
+	assert.NoError(t, err)
+	_, err = interestService.SetRate(models.AccountTypeSavings, 650)
+	assert.NoError(t, err)
+
+	// Três dias de R$ 100.000,00 a 6,5% ao ano: 1780,82 centavos por dia
+	now := time.Date(2099, 2, 1, 9, 0, 0, 0, models.BusinessLocation)
+	for day := 1; day <= 3; day++ {
+		accrued, err := interestService.AccrueInterest(time.Date(2099, 1, day, 0, 0, 0, 0, models.BusinessLocation), now)
+		assert.NoError(t, err)
+		// Contas correntes estão sem taxa
+		assert.Equal(t, 1, accrued)
+	}
+	// Acumular o mesmo dia de novo não acumula nada
+	accrued, err := interestService.AccrueInterest(time.Date(2099, 1, 1, 0, 0, 0, 0, models.BusinessLocation), now)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, accrued)
+
+	capitalizations, err := interestService.Capitalize(time.Date(2099, 1, 1, 0, 0, 0, 0, models.BusinessLocation), now)
+	assert.NoError(t, err)
+	if assert.Len(t, capitalizations, 1) {
+		// O total exato (5342,47) é arredondado uma vez, não dia a dia (3 × 1781)
+		assert.Equal(t, models.BRL(5342), capitalizations[0].Interest)
+		assert.Equal(t, "2099-01", capitalizations[0].Period)
+		assert.NotNil(t, capitalizations[0].TransferID)
+	}
+
+	capitalizations, err = interestService.Capitalize(time.Date(2099, 1, 1, 0, 0, 0, 0, models.BusinessLocation), now)
+	assert.NoError(t, err)
+	assert.Empty(t, capitalizations)
+
+	client, err := clientRepo.GetClientByAccountNum("222222")
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(10005342), client.Balance)
+
+	ledgerBalance, err := repositories.NewLedgerRepository(db).GetAccountBalance(models.InterestExpenseAccountNum, "BRL")
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(-5342), ledgerBalance)
+
+	history, err := interestService.GetInterest("222222")
+	assert.NoError(t, err)
+	if assert.Len(t, history.Accruals, 3) {
+		assert.Equal(t, "2099-01-03", history.Accruals[0].AccrualDate)
+		assert.Equal(t, models.BRL(1781), history.Accruals[0].Interest)
+		assert.NotNil(t, history.Accruals[0].CapitalizationID)
+	}
+	assert.Len(t, history.Capitalizations, 1)
+}
+
+func TestInterestService_AccrueDueCatchesUpAndPaysLateAccruals(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	uow := repositories.NewUnitOfWork(db)
+	clientRepo := repositories.NewClientRepository(db)
+	clientService := services.NewClientService(clientRepo, uow, models.DefaultAccountNumberFormat)
+	interestService := services.NewInterestService(clientRepo, repositories.NewInterestRepository(db), uow)
+	accountService := services.NewAccountService(repositories.NewTransferRepository(db), uow, services.NewAccountLocks())
+
+	assert.NoError(t, clientService.CreateClient(&models.Client{Name: "Jane Doe", AccountNum: "222222", AccountType: models.AccountTypeSavings}))
+	_, err := accountService.Fund("222222", models.BRL(10000000))
+	assert.NoError(t, err)
+	_, err = interestService.SetRate(models.AccountTypeSavings, 650)
+	assert.NoError(t, err)
+
+	// Janeiro é acumulado e capitalizado só até o dia 2; o dia 3 chega atrasado
+	now := time.Date(2099, 2, 1, 9, 0, 0, 0, models.BusinessLocation)
+	for day := 1; day <= 2; day++ {
+		_, err := interestService.AccrueInterest(time.Date(2099, 1, day, 0, 0, 0, 0, models.BusinessLocation), now)
+		assert.NoError(t, err)
+	}
+	_, err = interestService.Capitalize(time.Date(2099, 1, 1, 0, 0, 0, 0, models.BusinessLocation), now)
+	assert.NoError(t, err)
+	_, err = interestService.AccrueInterest(time.Date(2099, 1, 3, 0, 0, 0, 0, models.BusinessLocation), now)
+	assert.NoError(t, err)
+
+	// O executor ficou parado de 04/01 a 02/03: a rotina acumula todos os dias
+	// que faltam e capitaliza janeiro e fevereiro
+	assert.NoError(t, interestService.AccrueDue(time.Date(2099, 3, 2, 9, 0, 0, 0, models.BusinessLocation)))
+
+	history, err := interestService.GetInterest("222222")
+	assert.NoError(t, err)
+	// 31 dias de janeiro e 28 de fevereiro, mais 01/03
+	assert.Len(t, history.Accruals, 60)
+	assert.Equal(t, "2099-03-01", history.Accruals[0].AccrualDate)
+	assert.Nil(t, history.Accruals[0].CapitalizationID)
+	for _, accrual := range history.Accruals[1:] {
+		assert.NotNil(t, accrual.CapitalizationID, accrual.AccrualDate)
+	}
+	if assert.Len(t, history.Capitalizations, 2) {
+		// Fevereiro paga os 28 dias dele e os 29 dias de janeiro acumulados depois
+		// da capitalização de janeiro, todos sobre o saldo já com os juros de
+		// janeiro: 57 × 1781,46 centavos
+		assert.Equal(t, "2099-02", history.Capitalizations[0].Period)
+		assert.Equal(t, models.BRL(101543), history.Capitalizations[0].Interest)
+		assert.Equal(t, "2099-01", history.Capitalizations[1].Period)
+		assert.Equal(t, models.BRL(3562), history.Capitalizations[1].Interest)
+	}
+}
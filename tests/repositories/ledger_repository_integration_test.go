@@ -0,0 +1,233 @@
+// src/repositories/ledger_repository_integration_test.go
+package test
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLedgerRepository_CreateEntryAndBalance(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := repositories.NewLedgerRepository(db)
+
+	opening := models.NewTransferEntry(models.EntryOpeningBalance, models.OpeningBalanceAccountNum, "123456", models.BRL(100000))
+	assert.NoError(t, repo.CreateEntry(&opening))
+	assert.NotZero(t, opening.ID)
+	assert.Equal(t, opening.ID, opening.Postings[0].EntryID)
+
+	transfer := models.NewTransferEntry(models.EntryTransfer, "123456", "654321", models.BRL(2550))
+	assert.NoError(t, repo.CreateEntry(&transfer))
+
+	balance, err := repo.GetAccountBalance("123456", "BRL")
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(97450), balance)
+
+	balance, err = repo.GetAccountBalance("654321", "BRL")
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(2550), balance)
+
+	balance, err = repo.GetAccountBalance(models.OpeningBalanceAccountNum, "BRL")
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(-100000), balance)
+}
+
+func TestLedgerRepository_RejectsUnbalancedEntry(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := repositories.NewLedgerRepository(db)
+
+	entry := models.JournalEntry{
+		Description: models.EntryTransfer,
+		Postings: []models.Posting{
+			{AccountNum: "123456", Amount: models.BRL(-100)},
+			{AccountNum: "654321", Amount: models.BRL(99)},
+		},
+	}
+	assert.ErrorIs(t, repo.CreateEntry(&entry), models.ErrUnbalancedEntry)
+
+	var count int
+	assert.NoError(t, db.QueryRow("SELECT COUNT(*) FROM journal_entries").Scan(&count))
+	assert.Equal(t, 0, count)
+}
+
+func TestLedgerRepository_GetTransfersByAccountNum(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	transferRepo := repositories.NewTransferRepository(db)
+	ledgerRepo := repositories.NewLedgerRepository(db)
+
+	posted := &models.Transfer{FromAccountNum: "123456", ToAccountNum: "654321", Amount: models.BRL(5000), Status: "success"}
+	assert.NoError(t, transferRepo.CreateTransfer(posted))
+	entry := models.NewTransferEntry(models.EntryTransfer, "123456", "654321", posted.Amount)
+	entry.TransferID = &posted.ID
+	assert.NoError(t, ledgerRepo.CreateEntry(&entry))
+
+	// Transferência sem lançamento no livro-razão não aparece no histórico
+	unposted := &models.Transfer{FromAccountNum: "123456", ToAccountNum: "777777", Amount: models.BRL(100), Status: "success"}
+	assert.NoError(t, transferRepo.CreateTransfer(unposted))
+
+	history, err := ledgerRepo.GetTransfersByAccountNum("654321")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(history))
+	assert.Equal(t, posted.ID, history[0].ID)
+	assert.Equal(t, models.BRL(5000), history[0].Amount)
+	assert.False(t, history[0].CreatedAt.IsZero())
+
+	history, err = ledgerRepo.GetTransfersByAccountNum("123456")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(history))
+}
+
+func TestLedgerRepository_HistoryShowsFailedAttemptsToSourceOnly(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	transferRepo := repositories.NewTransferRepository(db)
+	ledgerRepo := repositories.NewLedgerRepository(db)
+
+	failed := &models.Transfer{
+		FromAccountNum: "123456",
+		ToAccountNum:   "654321",
+		Amount:         models.BRL(900000),
+		Status:         models.TransferStatusFailed,
+		FailureReason:  models.FailureInsufficientBalance,
+	}
+	assert.NoError(t, transferRepo.CreateTransfer(failed))
+
+	history, err := ledgerRepo.GetTransfersByAccountNum("123456")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(history))
+	assert.Equal(t, models.TransferStatusFailed, history[0].Status)
+	assert.Equal(t, models.FailureInsufficientBalance, history[0].FailureReason)
+	assert.False(t, history[0].CreatedAt.IsZero())
+
+	// A conta de destino não vê tentativas recusadas de terceiros
+	history, err = ledgerRepo.GetTransfersByAccountNum("654321")
+	assert.NoError(t, err)
+	assert.Empty(t, history)
+}
+
+func TestLedgerRepository_HistoryShowsDepositsAndWithdrawals(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	transferRepo := repositories.NewTransferRepository(db)
+	ledgerRepo := repositories.NewLedgerRepository(db)
+
+	deposit := &models.Transfer{
+		FromAccountNum: models.CashAccountNum,
+		ToAccountNum:   "123456",
+		Amount:         models.BRL(10000),
+		Type:           models.TransferTypeDeposit,
+		Status:         models.TransferStatusSuccess,
+	}
+	assert.NoError(t, transferRepo.CreateTransfer(deposit))
+	entry := models.NewTransferEntry(models.EntryDeposit, models.CashAccountNum, "123456", models.BRL(10000))
+	entry.TransferID = &deposit.ID
+	assert.NoError(t, ledgerRepo.CreateEntry(&entry))
+
+	// Depósito recusado: a conta que receberia o dinheiro vê a tentativa
+	failedDeposit := &models.Transfer{
+		FromAccountNum: models.CashAccountNum,
+		ToAccountNum:   "123456",
+		Amount:         models.NewMoney(500, "USD"),
+		Type:           models.TransferTypeDeposit,
+		Status:         models.TransferStatusFailed,
+		FailureReason:  models.FailureCurrencyMismatch,
+	}
+	assert.NoError(t, transferRepo.CreateTransfer(failedDeposit))
+
+	history, err := ledgerRepo.GetTransfersByAccountNum("123456")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(history))
+	assert.Equal(t, failedDeposit.ID, history[0].ID)
+	assert.Equal(t, models.TransferTypeDeposit, history[0].Type)
+	assert.Equal(t, deposit.ID, history[1].ID)
+	assert.Equal(t, models.TransferTypeDeposit, history[1].Type)
+
+	balance, err := ledgerRepo.GetAccountBalance(models.CashAccountNum, "BRL")
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(-10000), balance)
+}
+
+func TestLedgerRepository_GetAccountBalances(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := repositories.NewLedgerRepository(db)
+
+	funding := models.NewTransferEntry(models.EntryFunding, models.TreasuryAccountNum, "123456", models.BRL(10000))
+	assert.NoError(t, repo.CreateEntry(&funding))
+	transfer := models.NewTransferEntry(models.EntryTransfer, "123456", "654321", models.BRL(2500))
+	assert.NoError(t, repo.CreateEntry(&transfer))
+	other := models.NewTransferEntry(models.EntryFunding, models.TreasuryAccountNum, "777777", models.NewMoney(100, "USD"))
+	assert.NoError(t, repo.CreateEntry(&other))
+
+	balances, err := repo.GetAccountBalances("BRL")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]models.Money{
+		models.TreasuryAccountNum: models.BRL(-10000),
+		"123456":                  models.BRL(7500),
+		"654321":                  models.BRL(2500),
+	}, balances)
+}
+
+func TestLedgerRepository_HistoryLinksReversals(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	transferRepo := repositories.NewTransferRepository(db)
+	ledgerRepo := repositories.NewLedgerRepository(db)
+
+	post := func(transfer *models.Transfer) {
+		assert.NoError(t, transferRepo.CreateTransfer(transfer))
+		entry := models.NewTransferEntry(transfer.Type, transfer.FromAccountNum, transfer.ToAccountNum, transfer.Amount)
+		entry.TransferID = &transfer.ID
+		assert.NoError(t, ledgerRepo.CreateEntry(&entry))
+	}
+
+	original := &models.Transfer{FromAccountNum: "123456", ToAccountNum: "654321", Amount: models.BRL(10000), Status: models.TransferStatusSuccess}
+	post(original)
+	for _, cents := range []int64{2500, 1500} {
+		post(&models.Transfer{
+			FromAccountNum: "654321",
+			ToAccountNum:   "123456",
+			Amount:         models.BRL(cents),
+			Type:           models.TransferTypeReversal,
+			Status:         models.TransferStatusSuccess,
+			ReversalOf:     &original.ID,
+		})
+	}
+
+	reversed, err := transferRepo.GetReversedAmount(original.ID, "BRL")
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(4000), reversed)
+
+	stored, err := transferRepo.GetTransferByID(original.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(10000), stored.Amount)
+	assert.Nil(t, stored.ReversalOf)
+
+	history, err := ledgerRepo.GetTransfersByAccountNum("123456")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(history))
+	for _, transfer := range history {
+		if transfer.ID == original.ID {
+			assert.Equal(t, models.BRL(4000), *transfer.ReversedAmount)
+		} else {
+			assert.Equal(t, original.ID, *transfer.ReversalOf)
+			assert.Nil(t, transfer.ReversedAmount)
+		}
+	}
+
+	_, err = transferRepo.GetTransferByID(999)
+	assert.ErrorIs(t, err, repositories.ErrTransferNotFound)
+}
@@ -0,0 +1,129 @@
+package test
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimitRepository_DefaultLimitsAreSeeded(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := repositories.NewLimitRepository(db)
+
+	limits, err := repo.GetLimits(models.DefaultLimitsKey)
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(1000000), limits.PerTransfer)
+	assert.Equal(t, models.BRL(0), limits.Daily)
+
+	_, err = repo.GetLimits("123456")
+	assert.ErrorIs(t, err, repositories.ErrLimitsNotFound)
+}
+
+func TestLimitRepository_SaveAndDelete(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := repositories.NewLimitRepository(db)
+	limits := &models.TransferLimits{
+		AccountNum:       "123456",
+		PerTransfer:      models.BRL(50000),
+		Daily:            models.BRL(100000),
+		Monthly:          models.BRL(0),
+		DailyCount:       5,
+		NightPerTransfer: models.BRL(10000),
+		NightTotal:       models.BRL(20000),
+	}
+	assert.NoError(t, repo.SaveLimits(limits))
+	assert.False(t, limits.UpdatedAt.IsZero())
+
+	limits.Daily = models.BRL(200000)
+	assert.NoError(t, repo.SaveLimits(limits))
+
+	stored, err := repo.GetLimits("123456")
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(200000), stored.Daily)
+	assert.Equal(t, 5, stored.DailyCount)
+	assert.Equal(t, models.BRL(20000), stored.NightTotal)
+
+	assert.NoError(t, repo.DeleteLimits("123456"))
+	assert.ErrorIs(t, repo.DeleteLimits("123456"), repositories.ErrLimitsNotFound)
+}
+
+func TestLimitRepository_GetUsageCountsOnlySuccessfulOutgoingTransfers(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	transferRepo := repositories.NewTransferRepository(db)
+	repo := repositories.NewLimitRepository(db)
+
+	transfers := []models.Transfer{
+		{FromAccountNum: "123456", ToAccountNum: "654321", Amount: models.BRL(1000), Type: models.TransferTypeTransfer, Status: models.TransferStatusSuccess},
+		{FromAccountNum: "123456", ToAccountNum: "654321", Amount: models.BRL(2000), Type: models.TransferTypeTransfer, Status: models.TransferStatusSuccess},
+		{FromAccountNum: "123456", ToAccountNum: "654321", Amount: models.BRL(4000), Type: models.TransferTypeTransfer, Status: models.TransferStatusFailed},
+		{FromAccountNum: "123456", ToAccountNum: models.CashAccountNum, Amount: models.BRL(8000), Type: models.TransferTypeWithdrawal, Status: models.TransferStatusSuccess},
+		{FromAccountNum: "654321", ToAccountNum: "123456", Amount: models.BRL(16000), Type: models.TransferTypeTransfer, Status: models.TransferStatusSuccess},
+	}
+	for i := range transfers {
+		assert.NoError(t, transferRepo.CreateTransfer(&transfers[i]))
+	}
+	// Transferência de dois meses atrás não conta em nenhuma janela
+	_, err := db.Exec("INSERT INTO transfers (from_account_num, to_account_num, amount, currency, type, status, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		"123456", "654321", 32000, "BRL", models.TransferTypeTransfer, models.TransferStatusSuccess,
+		time.Now().AddDate(0, -2, 0).UTC().Format("2006-01-02 15:04:05"))
+	assert.NoError(t, err)
+
+	usage, err := repo.GetUsage("123456", "BRL", time.Now())
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(3000), usage.Daily)
+	assert.Equal(t, models.BRL(3000), usage.Monthly)
+	assert.Equal(t, 2, usage.DailyCount)
+	if models.NewLimitWindows(time.Now()).Night {
+		assert.Equal(t, models.BRL(3000), usage.NightTotal)
+	} else {
+		assert.Equal(t, models.BRL(0), usage.NightTotal)
+	}
+}
+
+func TestLimitRepository_GetUsageCountsUncapturedActiveHolds(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	createTestClients(t, db, "123456", "654321")
+	holdRepo := repositories.NewHoldRepository(db)
+	repo := repositories.NewLimitRepository(db)
+
+	expiresAt := time.Now().Add(time.Hour)
+	holds := []models.Hold{
+		{AccountNum: "123456", ToAccountNum: "654321", Amount: models.BRL(1000), ExpiresAt: expiresAt},
+		{AccountNum: "123456", ToAccountNum: "654321", Amount: models.BRL(2000), ExpiresAt: expiresAt},
+		{AccountNum: "123456", ToAccountNum: "654321", Amount: models.BRL(4000), ExpiresAt: expiresAt},
+		// Reserva vencida, ainda não expirada pelo executor, não conta
+		{AccountNum: "123456", ToAccountNum: "654321", Amount: models.BRL(8000), ExpiresAt: time.Now().Add(-time.Minute)},
+		{AccountNum: "654321", ToAccountNum: "123456", Amount: models.BRL(16000), ExpiresAt: expiresAt},
+	}
+	for i := range holds {
+		assert.NoError(t, holdRepo.CreateHold(&holds[i]))
+	}
+
+	// A parte capturada já conta como transferência
+	holds[1].CapturedAmount = models.BRL(500)
+	assert.NoError(t, holdRepo.UpdateHold(&holds[1]))
+	capture := &models.Transfer{FromAccountNum: "123456", ToAccountNum: "654321", Amount: models.BRL(500), Type: models.TransferTypeTransfer, Status: models.TransferStatusSuccess}
+	assert.NoError(t, repositories.NewTransferRepository(db).CreateTransfer(capture))
+	holds[2].Status = models.HoldStatusVoided
+	assert.NoError(t, holdRepo.UpdateHold(&holds[2]))
+
+	usage, err := repo.GetUsage("123456", "BRL", time.Now())
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(3000), usage.Daily)
+	assert.Equal(t, models.BRL(3000), usage.Monthly)
+	// A captura e a reserva ainda sem captura
+	assert.Equal(t, 2, usage.DailyCount)
+}
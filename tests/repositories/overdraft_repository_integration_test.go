@@ -0,0 +1,141 @@
+// src/repositories/overdraft_repository_integration_test.go
+package test
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientRepository_DebitUsesOverdraftLimit(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := repositories.NewClientRepository(db)
+	client := &models.Client{Name: "Jane Doe", AccountNum: "654321", Balance: models.BRL(0)}
+	assert.NoError(t, repo.CreateClient(client))
+	assert.Equal(t, models.BRL(0), client.OverdraftLimit)
+
+	updated, err := repo.UpdateOverdraft("654321", models.BRL(10000), 1200)
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(10000), updated.OverdraftLimit)
+	assert.Equal(t, 1200, updated.OverdraftRateBps)
+
+	balance, err := repo.DebitClientBalance("654321", models.BRL(10000))
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(-10000), balance)
+
+	// O débito além do limite não altera nada
+	_, err = repo.DebitClientBalance("654321", models.BRL(1))
+	assert.ErrorIs(t, err, repositories.ErrInsufficientBalance)
+
+	// Encargos podem passar do limite
+	balance, err = repo.ChargeClientBalance("654321", models.BRL(50))
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(-10050), balance)
+
+	// O limite não pode ficar abaixo do valor em uso
+	_, err = repo.UpdateOverdraft("654321", models.BRL(10000), 1200)
+	assert.ErrorIs(t, err, repositories.ErrOverdraftInUse)
+	_, err = repo.UpdateOverdraft("654321", models.NewMoney(20000, "USD"), 1200)
+	assert.ErrorIs(t, err, models.ErrCurrencyMismatch)
+	_, err = repo.UpdateOverdraft("999999", models.BRL(20000), 1200)
+	assert.ErrorIs(t, err, repositories.ErrClientNotFound)
+
+	stored, err := repo.GetClientByAccountNum("654321")
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(10050), stored.OverdraftUsage)
+}
+
+func TestOverdraftRepository_GetOverdrawnBalances(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	ledgerRepo := repositories.NewLedgerRepository(db)
+	repo := repositories.NewOverdraftRepository(db)
+
+	funding := models.NewTransferEntry(models.EntryFunding, models.TreasuryAccountNum, "123456", models.BRL(1000))
+	assert.NoError(t, ledgerRepo.CreateEntry(&funding))
+	transfer := models.NewTransferEntry(models.EntryTransfer, "654321", "123456", models.BRL(2500))
+	assert.NoError(t, ledgerRepo.CreateEntry(&transfer))
+
+	// Contas internas não pagam juros
+	balances, err := repo.GetOverdrawnBalances(time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]models.Money{"654321": models.BRL(-2500)}, balances)
+
+	// Lançamentos posteriores ao instante não contam
+	balances, err = repo.GetOverdrawnBalances(time.Now().Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Empty(t, balances)
+}
+
+func TestOverdraftRepository_InterestIsUniquePerDay(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	createTestClients(t, db, "654321")
+	charge := &models.Transfer{FromAccountNum: "654321", ToAccountNum: models.OverdraftInterestAccountNum, Amount: models.BRL(33),
+		Type: models.TransferTypeOverdraftInterest, Status: models.TransferStatusSuccess}
+	assert.NoError(t, repositories.NewTransferRepository(db).CreateTransfer(charge))
+	repo := repositories.NewOverdraftRepository(db)
+	latest, err := repo.GetLatestInterestDate()
+	assert.NoError(t, err)
+	assert.Empty(t, latest)
+	transferID := charge.ID
+	interest := &models.OverdraftInterest{
+		AccountNum:  "654321",
+		AccrualDate: "2030-03-01",
+		Balance:     models.BRL(-100000),
+		RateBps:     1200,
+		Interest:    models.BRL(33),
+		TransferID:  &transferID,
+	}
+	assert.NoError(t, repo.CreateInterest(interest))
+	assert.NotZero(t, interest.ID)
+
+	duplicate := *interest
+	assert.ErrorIs(t, repo.CreateInterest(&duplicate), repositories.ErrInterestAlreadyAccrued)
+
+	next := &models.OverdraftInterest{AccountNum: "654321", AccrualDate: "2030-03-02", Balance: models.BRL(-10), RateBps: 1200, Interest: models.BRL(0)}
+	assert.NoError(t, repo.CreateInterest(next))
+
+	latest, err = repo.GetLatestInterestDate()
+	assert.NoError(t, err)
+	assert.Equal(t, "2030-03-02", latest)
+
+	charges, err := repo.GetInterestByAccountNum("654321")
+	assert.NoError(t, err)
+	assert.Len(t, charges, 2)
+	assert.Equal(t, "2030-03-02", charges[0].AccrualDate)
+	assert.Nil(t, charges[0].TransferID)
+	assert.Equal(t, models.BRL(33), charges[1].Interest)
+	assert.Equal(t, &transferID, charges[1].TransferID)
+}
+
+func TestNotificationRepository_CreateAndList(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	createTestClients(t, db, "654321")
+	repo := repositories.NewNotificationRepository(db)
+	entered := models.OverdraftNotification("654321", models.BRL(100), models.BRL(-100))
+	assert.NoError(t, repo.CreateNotification(entered))
+	left := models.OverdraftNotification("654321", models.BRL(-100), models.BRL(0))
+	assert.NoError(t, repo.CreateNotification(left))
+
+	notifications, err := repo.GetNotificationsByAccountNum("654321")
+	assert.NoError(t, err)
+	assert.Len(t, notifications, 2)
+	assert.Equal(t, models.NotificationOverdraftLeft, notifications[0].Type)
+	assert.Equal(t, models.NotificationOverdraftEntered, notifications[1].Type)
+	assert.Equal(t, models.BRL(-100), notifications[1].Balance)
+
+	notifications, err = repo.GetNotificationsByAccountNum("123456")
+	assert.NoError(t, err)
+	assert.Empty(t, notifications)
+}
@@ -0,0 +1,143 @@
+// src/repositories/reconciliation_repository_integration_test.go
+package test
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"banking/src/services"
+	"database/sql"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupReconciliationDB cria duas contas com depósito, uma transferência
+// entre elas e uma reserva ativa, deixando o banco consistente
+func setupReconciliationDB(t *testing.T) (*sql.DB, *services.ReconciliationService) {
+	db := setupTestDB(t)
+
+	uow := repositories.NewUnitOfWork(db)
+	clientRepo := repositories.NewClientRepository(db)
+	transferRepo := repositories.NewTransferRepository(db)
+	clientService := services.NewClientService(clientRepo, uow, models.DefaultAccountNumberFormat)
+	accountLocks := services.NewAccountLocks()
+	accountService := services.NewAccountService(transferRepo, uow, accountLocks)
+	transferService := services.NewTransferService(clientRepo, transferRepo, repositories.NewLedgerRepository(db), uow, accountLocks)
+
+	assert.NoError(t, clientService.CreateClient(&models.Client{Name: "John Doe", AccountNum: "111111"}))
+	assert.NoError(t, clientService.CreateClient(&models.Client{Name: "Jane Doe", AccountNum: "222222"}))
+	_, err := accountService.Deposit("111111", models.BRL(10000))
+	assert.NoError(t, err)
+	_, err = transferService.TransferFunds("111111", "222222", models.BRL(2500))
+	assert.NoError(t, err)
+	// Tentativa recusada para uma conta inexistente não é divergência
+	_, err = transferService.TransferFunds("111111", "999999", models.BRL(100))
+	assert.Error(t, err)
+	holdService := services.NewHoldService(clientRepo, repositories.NewHoldRepository(db), uow, transferService)
+	_, err = holdService.PlaceHold("222222", "111111", models.BRL(700), time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+
+	return db, services.NewReconciliationService(repositories.NewReconciliationRepository(db))
+}
+
+func discrepancyTypes(report *models.ReconciliationReport) []string {
+	types := []string{}
+	for _, discrepancy := range report.Discrepancies {
+		types = append(types, discrepancy.Type)
+	}
+	return types
+}
+
+func TestReconcile_ConsistentDatabase(t *testing.T) {
+	db, reconciliationService := setupReconciliationDB(t)
+	defer db.Close()
+
+	report, err := reconciliationService.Reconcile()
+
+	assert.NoError(t, err)
+	assert.True(t, report.OK, "%+v", report.Discrepancies)
+	assert.Empty(t, report.Discrepancies)
+	assert.Equal(t, 2, report.AccountsChecked)
+	assert.Equal(t, 3, report.TransfersChecked)
+	assert.Equal(t, 2, report.EntriesChecked)
+}
+
+func TestReconcile_DetectsBalanceDiscrepancies(t *testing.T) {
+	db, reconciliationService := setupReconciliationDB(t)
+	defer db.Close()
+
+	_, err := db.Exec("UPDATE clients SET balance = balance + 100 WHERE account_num = '111111'")
+	assert.NoError(t, err)
+	_, err = db.Exec("UPDATE clients SET held = 0 WHERE account_num = '222222'")
+	assert.NoError(t, err)
+
+	report, err := reconciliationService.Reconcile()
+
+	assert.NoError(t, err)
+	assert.False(t, report.OK)
+	assert.Equal(t, []string{models.DiscrepancyLedgerBalance, models.DiscrepancyTransferBalance, models.DiscrepancyHeldBalance}, discrepancyTypes(report))
+	ledger := report.Discrepancies[0]
+	assert.Equal(t, "111111", ledger.AccountNum)
+	assert.Equal(t, models.BRL(7500), *ledger.Expected)
+	assert.Equal(t, models.BRL(7600), *ledger.Actual)
+	held := report.Discrepancies[2]
+	assert.Equal(t, "222222", held.AccountNum)
+	assert.Equal(t, models.BRL(700), *held.Expected)
+	assert.Equal(t, models.BRL(0), *held.Actual)
+}
+
+func TestReconcile_DetectsLedgerDiscrepancies(t *testing.T) {
+	db, reconciliationService := setupReconciliationDB(t)
+	defer db.Close()
+
+	// Uma partida solta na conta interna quebra o lançamento e a conservação
+	_, err := db.Exec("INSERT INTO postings (entry_id, account_num, amount, currency) VALUES (1, 'SYSTEM-CASH', 50, 'BRL')")
+	assert.NoError(t, err)
+	// O lançamento da transferência passa a movimentar outro valor
+	_, err = db.Exec("UPDATE transfers SET amount = 3000 WHERE id = 2")
+	assert.NoError(t, err)
+
+	report, err := reconciliationService.Reconcile()
+
+	assert.NoError(t, err)
+	assert.False(t, report.OK)
+	assert.Contains(t, discrepancyTypes(report), models.DiscrepancyUnbalancedEntry)
+	assert.Contains(t, discrepancyTypes(report), models.DiscrepancyMoneyNotConserved)
+	assert.Contains(t, discrepancyTypes(report), models.DiscrepancyEntryMismatch)
+	for _, discrepancy := range report.Discrepancies {
+		switch discrepancy.Type {
+		case models.DiscrepancyUnbalancedEntry:
+			assert.Equal(t, 1, *discrepancy.EntryID)
+		case models.DiscrepancyMoneyNotConserved:
+			assert.Equal(t, models.BRL(50), *discrepancy.Actual)
+		case models.DiscrepancyEntryMismatch:
+			assert.Equal(t, 2, *discrepancy.TransferID)
+		}
+	}
+}
+
+func TestReconcile_DetectsOrphansAndDuplicates(t *testing.T) {
+	db, reconciliationService := setupReconciliationDB(t)
+	defer db.Close()
+
+	_, err := db.Exec(`INSERT INTO transfers (from_account_num, to_account_num, amount, currency, type, status)
+		VALUES ('111111', '888888', 100, 'BRL', ?, ?)`, models.TransferTypeTransfer, models.TransferStatusSuccess)
+	assert.NoError(t, err)
+	_, err = db.Exec("INSERT INTO clients (name, account_num, balance) VALUES ('John Doe', ' 111111 ', 0)")
+	assert.NoError(t, err)
+
+	report, err := reconciliationService.Reconcile()
+
+	assert.NoError(t, err)
+	assert.False(t, report.OK)
+	// A transferência sem lançamento também desvia o saldo recalculado da origem
+	assert.Equal(t, []string{models.DiscrepancyTransferBalance, models.DiscrepancyMissingEntry,
+		models.DiscrepancyOrphanTransfer, models.DiscrepancyDuplicateAccount}, discrepancyTypes(report))
+	assert.Equal(t, "111111", report.Discrepancies[0].AccountNum)
+	orphan := report.Discrepancies[2]
+	assert.Equal(t, "888888", orphan.AccountNum)
+	assert.Equal(t, 4, *orphan.TransferID)
+	assert.Equal(t, "111111", report.Discrepancies[3].AccountNum)
+}
@@ -0,0 +1,105 @@
+package test
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduledTransferRepository_Lifecycle(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	createTestClients(t, db, "123456", "654321")
+	repo := repositories.NewScheduledTransferRepository(db)
+	now := time.Now()
+
+	// Datas em outro fuso são normalizadas para UTC
+	saoPaulo := time.FixedZone("BRT", -3*60*60)
+	due := &models.ScheduledTransfer{FromAccountNum: "123456", ToAccountNum: "654321", Amount: models.BRL(1000), ExecuteAt: now.Add(-time.Minute).In(saoPaulo)}
+	later := &models.ScheduledTransfer{FromAccountNum: "654321", ToAccountNum: "123456", Amount: models.BRL(2000), ExecuteAt: now.Add(time.Hour)}
+	for _, scheduled := range []*models.ScheduledTransfer{due, later} {
+		assert.NoError(t, repo.CreateScheduledTransfer(scheduled))
+		assert.NotZero(t, scheduled.ID)
+		assert.Equal(t, models.ScheduledStatusPending, scheduled.Status)
+	}
+
+	staleBefore := now.Add(-5 * time.Minute)
+	dueTransfers, err := repo.GetDueScheduledTransfers(now, staleBefore, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(dueTransfers))
+	assert.Equal(t, due.ID, dueTransfers[0].ID)
+	assert.Equal(t, models.BRL(1000), dueTransfers[0].Amount)
+
+	// Apenas um executor consegue reivindicar o agendamento
+	assert.NoError(t, repo.ClaimScheduledTransfer(due.ID, now, staleBefore))
+	assert.ErrorIs(t, repo.ClaimScheduledTransfer(due.ID, now, staleBefore), repositories.ErrScheduledTransferNotPending)
+	assert.ErrorIs(t, repo.CancelScheduledTransfer(due.ID), repositories.ErrScheduledTransferNotPending)
+
+	assert.NoError(t, repo.CompleteScheduledTransfer(due.ID, now, models.ScheduledStatusFailed, models.FailureInsufficientBalance, now))
+	stored, err := repo.GetScheduledTransferByID(due.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.ScheduledStatusFailed, stored.Status)
+	assert.Equal(t, models.FailureInsufficientBalance, stored.FailureReason)
+	assert.WithinDuration(t, now, *stored.ExecutedAt, time.Second)
+
+	assert.NoError(t, repo.CancelScheduledTransfer(later.ID))
+	assert.ErrorIs(t, repo.CancelScheduledTransfer(999), repositories.ErrScheduledTransferNotFound)
+
+	all, err := repo.GetScheduledTransfersByAccountNum("123456", "")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(all))
+	assert.Equal(t, due.ID, all[0].ID)
+	assert.Nil(t, all[1].ExecutedAt)
+
+	cancelled, err := repo.GetScheduledTransfersByAccountNum("123456", models.ScheduledStatusCancelled)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(cancelled))
+	assert.Equal(t, later.ID, cancelled[0].ID)
+
+	dueTransfers, err = repo.GetDueScheduledTransfers(now.Add(2*time.Hour), staleBefore, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, dueTransfers)
+}
+
+func TestScheduledTransferRepository_StaleClaim(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	createTestClients(t, db, "123456", "654321")
+	repo := repositories.NewScheduledTransferRepository(db)
+	now := time.Now()
+
+	scheduled := &models.ScheduledTransfer{FromAccountNum: "123456", ToAccountNum: "654321", Amount: models.BRL(1000), ExecuteAt: now.Add(-time.Hour)}
+	assert.NoError(t, repo.CreateScheduledTransfer(scheduled))
+
+	// O primeiro executor reivindica o agendamento e para antes de concluí-lo
+	firstClaim := now.Add(-10 * time.Minute)
+	assert.NoError(t, repo.ClaimScheduledTransfer(scheduled.ID, firstClaim, firstClaim.Add(-5*time.Minute)))
+
+	// Enquanto a reivindicação vale, o agendamento não é listado
+	dueTransfers, err := repo.GetDueScheduledTransfers(now, now.Add(-15*time.Minute), 10)
+	assert.NoError(t, err)
+	assert.Empty(t, dueTransfers)
+
+	// Depois do prazo, outro executor o reivindica de novo
+	staleBefore := now.Add(-5 * time.Minute)
+	dueTransfers, err = repo.GetDueScheduledTransfers(now, staleBefore, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(dueTransfers))
+	assert.NoError(t, repo.ClaimScheduledTransfer(scheduled.ID, now, staleBefore))
+
+	// O primeiro executor não consegue mais concluir nem liberar o agendamento
+	err = repo.CompleteScheduledTransfer(scheduled.ID, firstClaim, models.ScheduledStatusExecuted, "", now)
+	assert.ErrorIs(t, err, repositories.ErrScheduledTransferNotPending)
+	assert.ErrorIs(t, repo.ReleaseScheduledTransfer(scheduled.ID, firstClaim), repositories.ErrScheduledTransferNotPending)
+
+	// O novo executor libera o agendamento para a próxima tentativa
+	assert.NoError(t, repo.ReleaseScheduledTransfer(scheduled.ID, now))
+	stored, err := repo.GetScheduledTransferByID(scheduled.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.ScheduledStatusPending, stored.Status)
+}
@@ -0,0 +1,143 @@
+package test
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newStandingOrder(start time.Time) *models.StandingOrder {
+	return &models.StandingOrder{
+		FromAccountNum:   "123456",
+		ToAccountNum:     "654321",
+		Amount:           models.BRL(1000),
+		Frequency:        models.FrequencyDaily,
+		Interval:         1,
+		StartAt:          start,
+		NextOccurrenceAt: start,
+		NextRunAt:        &start,
+	}
+}
+
+func TestStandingOrderRepository_ClaimAndSchedule(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	createTestClients(t, db, "123456", "654321")
+	repo := repositories.NewStandingOrderRepository(db)
+	now := time.Now()
+
+	due := newStandingOrder(now.Add(-time.Minute))
+	later := newStandingOrder(now.Add(time.Hour))
+	for _, order := range []*models.StandingOrder{due, later} {
+		assert.NoError(t, repo.CreateStandingOrder(order))
+		assert.Equal(t, models.StandingOrderActive, order.Status)
+	}
+
+	staleBefore := now.Add(-5 * time.Minute)
+	dueOrders, err := repo.GetDueStandingOrders(now, staleBefore, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(dueOrders))
+	assert.Equal(t, due.ID, dueOrders[0].ID)
+	assert.Nil(t, dueOrders[0].EndAt)
+
+	// Apenas um executor reivindica a ocorrência, e ela some da lista de vencidas
+	assert.NoError(t, repo.ClaimStandingOrder(due.ID, now, staleBefore))
+	assert.ErrorIs(t, repo.ClaimStandingOrder(due.ID, now, staleBefore), repositories.ErrStandingOrderNotDue)
+	assert.ErrorIs(t, repo.ClaimStandingOrder(later.ID, now, staleBefore), repositories.ErrStandingOrderNotDue)
+	dueOrders, err = repo.GetDueStandingOrders(now, staleBefore, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, dueOrders)
+
+	retryAt := now.Add(time.Hour)
+	run := &models.StandingOrderRun{StandingOrderID: due.ID, OccurrenceAt: due.NextOccurrenceAt, Attempt: 1,
+		Status: models.StandingOrderRunFailed, FailureReason: models.FailureInsufficientBalance, RetryAt: &retryAt, ExecutedAt: now}
+	assert.NoError(t, repo.CreateStandingOrderRun(run))
+	assert.NotZero(t, run.ID)
+
+	// Uma pausa feita durante a execução não é desfeita ao gravar o andamento
+	assert.NoError(t, repo.SetStandingOrderStatus(due.ID, models.StandingOrderPaused, models.StandingOrderActive))
+	due.Retries = 1
+	due.NextRunAt = &retryAt
+	due.Status = models.StandingOrderCompleted
+	assert.NoError(t, repo.UpdateStandingOrderSchedule(due, now))
+
+	stored, err := repo.GetStandingOrderByID(due.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.StandingOrderPaused, stored.Status)
+	assert.Equal(t, 1, stored.Retries)
+	assert.WithinDuration(t, retryAt, *stored.NextRunAt, time.Microsecond)
+
+	runs, err := repo.GetStandingOrderRuns(due.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(runs))
+	assert.Equal(t, models.FailureInsufficientBalance, runs[0].FailureReason)
+	assert.WithinDuration(t, retryAt, *runs[0].RetryAt, time.Microsecond)
+}
+
+func TestStandingOrderRepository_StatusTransitions(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	createTestClients(t, db, "123456", "654321")
+	repo := repositories.NewStandingOrderRepository(db)
+	order := newStandingOrder(time.Now().Add(time.Hour))
+	end := order.StartAt.Add(30 * 24 * time.Hour)
+	order.EndAt = &end
+	assert.NoError(t, repo.CreateStandingOrder(order))
+
+	// Só ordens pausadas podem ser retomadas
+	assert.ErrorIs(t, repo.ResumeStandingOrder(order), repositories.ErrStandingOrderInvalidState)
+	assert.NoError(t, repo.SetStandingOrderStatus(order.ID, models.StandingOrderPaused, models.StandingOrderActive))
+	assert.NoError(t, repo.ResumeStandingOrder(order))
+
+	assert.NoError(t, repo.SetStandingOrderStatus(order.ID, models.StandingOrderCancelled, models.StandingOrderActive, models.StandingOrderPaused))
+	assert.ErrorIs(t, repo.SetStandingOrderStatus(order.ID, models.StandingOrderPaused, models.StandingOrderActive), repositories.ErrStandingOrderInvalidState)
+	assert.ErrorIs(t, repo.SetStandingOrderStatus(999, models.StandingOrderPaused, models.StandingOrderActive), repositories.ErrStandingOrderNotFound)
+
+	orders, err := repo.GetStandingOrdersByAccountNum("654321", models.StandingOrderCancelled)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(orders))
+	assert.WithinDuration(t, end, *orders[0].EndAt, time.Microsecond)
+
+	orders, err = repo.GetStandingOrdersByAccountNum("654321", models.StandingOrderActive)
+	assert.NoError(t, err)
+	assert.Empty(t, orders)
+}
+
+func TestStandingOrderRepository_StaleClaimExpires(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	createTestClients(t, db, "123456", "654321")
+	repo := repositories.NewStandingOrderRepository(db)
+	now := time.Now()
+
+	order := newStandingOrder(now.Add(-time.Hour))
+	assert.NoError(t, repo.CreateStandingOrder(order))
+
+	// O executor que reivindicou a ordem parou antes de gravar o andamento
+	claimedAt := now.Add(-10 * time.Minute)
+	assert.NoError(t, repo.ClaimStandingOrder(order.ID, claimedAt, claimedAt.Add(-5*time.Minute)))
+
+	// Enquanto a reivindicação vale, a ordem continua reivindicada
+	dueOrders, err := repo.GetDueStandingOrders(now, now.Add(-15*time.Minute), 10)
+	assert.NoError(t, err)
+	assert.Empty(t, dueOrders)
+	assert.ErrorIs(t, repo.ClaimStandingOrder(order.ID, now, now.Add(-15*time.Minute)), repositories.ErrStandingOrderNotDue)
+
+	// Depois do prazo, ela volta a ser vencida e pode ser reivindicada de novo
+	staleBefore := now.Add(-5 * time.Minute)
+	dueOrders, err = repo.GetDueStandingOrders(now, staleBefore, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(dueOrders))
+	assert.NoError(t, repo.ClaimStandingOrder(order.ID, now, staleBefore))
+
+	// O executor antigo não sobrescreve mais o andamento do novo
+	order.Occurrences = 1
+	assert.ErrorIs(t, repo.UpdateStandingOrderSchedule(order, claimedAt), repositories.ErrStandingOrderNotDue)
+	assert.NoError(t, repo.UpdateStandingOrderSchedule(order, now))
+}
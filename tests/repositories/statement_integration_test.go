@@ -0,0 +1,77 @@
+// src/repositories/statement_integration_test.go
+package test
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"banking/src/services"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatementService_FromLedger(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	uow := repositories.NewUnitOfWork(db)
+	clientRepo := repositories.NewClientRepository(db)
+	ledgerRepo := repositories.NewLedgerRepository(db)
+	clientService := services.NewClientService(clientRepo, uow, models.DefaultAccountNumberFormat)
+	accountLocks := services.NewAccountLocks()
+	accountService := services.NewAccountService(repositories.NewTransferRepository(db), uow, accountLocks)
+	transferService := services.NewTransferService(clientRepo, repositories.NewTransferRepository(db), ledgerRepo, uow, accountLocks)
+	statementService := services.NewStatementService(clientRepo, ledgerRepo)
+
+	assert.NoError(t, clientService.CreateClient(&models.Client{Name: "John Doe", AccountNum: "111111"}))
+	assert.NoError(t, clientService.CreateClient(&models.Client{Name: "Jane Doe", AccountNum: "222222"}))
+	funding, err := accountService.Fund("111111", models.BRL(100000))
+	assert.NoError(t, err)
+	// O financiamento fica antes do período e entra só no saldo de abertura
+	_, err = db.Exec("UPDATE journal_entries SET created_at = datetime('now', '-2 days') WHERE transfer_id = ?", funding.ID)
+	assert.NoError(t, err)
+
+	transfer, err := transferService.TransferFunds("111111", "222222", models.BRL(30000))
+	assert.NoError(t, err)
+	_, err = accountService.Deposit("111111", models.BRL(5000))
+	assert.NoError(t, err)
+	// Recusas não movimentam o livro-razão e não aparecem no extrato
+	_, err = transferService.TransferFunds("111111", "222222", models.BRL(1000000))
+	assert.Error(t, err)
+
+	now := time.Now()
+	statement, err := statementService.GetStatement("111111", now.Add(-24*time.Hour), now.Add(time.Hour))
+	assert.NoError(t, err)
+
+	assert.Equal(t, models.BRL(100000), statement.OpeningBalance)
+	if assert.Len(t, statement.Movements, 2) {
+		first := statement.Movements[0]
+		assert.Equal(t, transfer.ID, *first.TransferID)
+		assert.Equal(t, models.TransferTypeTransfer, first.Type)
+		assert.Equal(t, "222222", first.Counterparty)
+		assert.Equal(t, models.BRL(-30000), first.Amount)
+		assert.Equal(t, models.BRL(70000), first.Balance)
+		assert.False(t, first.CreatedAt.IsZero())
+
+		second := statement.Movements[1]
+		assert.Equal(t, models.TransferTypeDeposit, second.Type)
+		assert.Equal(t, models.CashAccountNum, second.Counterparty)
+		assert.Equal(t, models.BRL(75000), second.Balance)
+	}
+	assert.Equal(t, models.BRL(75000), statement.ClosingBalance)
+	assert.Equal(t, models.BRL(5000), statement.TotalCredits)
+	assert.Equal(t, models.BRL(30000), statement.TotalDebits)
+
+	// O saldo de fechamento bate com o saldo atual da conta
+	client, err := clientRepo.GetClientByAccountNum("111111")
+	assert.NoError(t, err)
+	assert.Equal(t, client.Balance, statement.ClosingBalance)
+
+	// Um período anterior a tudo não tem movimentações
+	statement, err = statementService.GetStatement("111111", now.AddDate(0, 0, -10), now.AddDate(0, 0, -5))
+	assert.NoError(t, err)
+	assert.Empty(t, statement.Movements)
+	assert.True(t, statement.ClosingBalance.IsZero())
+}
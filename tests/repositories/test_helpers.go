@@ -2,45 +2,32 @@
 package test
 
 import (
+	"banking/src/database"
+	"banking/src/models"
+	"banking/src/repositories"
 	"database/sql"
+	"path/filepath"
 	"testing"
 
 	_ "github.com/mattn/go-sqlite3"
 )
 
-// setupTestDB configura um banco de dados SQLite em memória para testes
+// setupTestDB cria um banco SQLite temporário com o mesmo esquema da aplicação
 func setupTestDB(t *testing.T) *sql.DB {
-	db, err := sql.Open("sqlite3", ":memory:") // Banco de dados em memória
+	db, err := database.InitDB(filepath.Join(t.TempDir(), "test_bank.db"))
 	if err != nil {
 		t.Fatalf("Erro ao abrir o banco de dados: %v", err)
 	}
+	return db
+}
 
-	// Cria as tabelas `clients` e `transfers`
-	createClientsTable := `
-    CREATE TABLE clients (
-        id INTEGER PRIMARY KEY AUTOINCREMENT,
-        name TEXT,
-        account_num TEXT UNIQUE,
-        balance REAL
-    );`
-	_, err = db.Exec(createClientsTable)
-	if err != nil {
-		t.Fatalf("Erro ao criar a tabela clients: %v", err)
-	}
-
-	createTransfersTable := `
-    CREATE TABLE transfers (
-        id INTEGER PRIMARY KEY AUTOINCREMENT,
-        from_account_num TEXT,
-        to_account_num TEXT,
-        amount REAL,
-        status TEXT,
-        created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-    );`
-	_, err = db.Exec(createTransfersTable)
-	if err != nil {
-		t.Fatalf("Erro ao criar a tabela transfers: %v", err)
+// createTestClients cria clientes sem saldo com os números de conta
+// informados, para os testes de tabelas com chave estrangeira para clients
+func createTestClients(t *testing.T, db *sql.DB, accountNums ...string) {
+	repo := repositories.NewClientRepository(db)
+	for _, accountNum := range accountNums {
+		if err := repo.CreateClient(&models.Client{Name: "Cliente " + accountNum, AccountNum: accountNum, Balance: models.BRL(0), Status: models.AccountStatusActive}); err != nil {
+			t.Fatalf("Erro ao criar o cliente %s: %v", accountNum, err)
+		}
 	}
-
-	return db
 }
@@ -0,0 +1,97 @@
+// src/repositories/transfer_concurrency_integration_test.go
+package test
+
+import (
+	"banking/src/database"
+	"banking/src/models"
+	"banking/src/repositories"
+	"banking/src/services"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTransferInstance monta um TransferService com a sua própria conexão ao
+// banco, como uma instância separada de `bankingapp run` usando o mesmo arquivo
+func newTransferInstance(t *testing.T, dbFile string) (*sql.DB, *services.TransferService) {
+	db, err := database.InitDB(dbFile)
+	if err != nil {
+		t.Fatalf("Erro ao abrir o banco de dados: %v", err)
+	}
+	clientRepo := repositories.NewClientRepository(db)
+	service := services.NewTransferService(clientRepo, repositories.NewTransferRepository(db),
+		repositories.NewLedgerRepository(db), repositories.NewUnitOfWork(db), services.NewAccountLocks())
+	return db, service
+}
+
+func TestTransferFunds_ConcurrentInstancesNeverOverdraw(t *testing.T) {
+	dbFile := filepath.Join(t.TempDir(), "test_bank.db")
+
+	const instances = 4
+	dbs := make([]*sql.DB, instances)
+	transferServices := make([]*services.TransferService, instances)
+	for i := range dbs {
+		dbs[i], transferServices[i] = newTransferInstance(t, dbFile)
+		defer dbs[i].Close()
+	}
+
+	uow := repositories.NewUnitOfWork(dbs[0])
+	clientService := services.NewClientService(repositories.NewClientRepository(dbs[0]), uow, models.DefaultAccountNumberFormat)
+	assert.NoError(t, clientService.CreateClient(&models.Client{Name: "Payer", AccountNum: "111111"}))
+	assert.NoError(t, clientService.CreateClient(&models.Client{Name: "Payee", AccountNum: "222222"}))
+	_, err := services.NewAccountService(repositories.NewTransferRepository(dbs[0]), uow, services.NewAccountLocks()).Fund("111111", models.BRL(10000))
+	assert.NoError(t, err)
+
+	// 40 transferências de R$ 10,00 disputam um saldo de R$ 100,00
+	const attempts = 40
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	succeeded, declined := 0, 0
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func(service *services.TransferService) {
+			defer wg.Done()
+			_, err := service.TransferFunds("111111", "222222", models.BRL(1000))
+
+			mu.Lock()
+			defer mu.Unlock()
+			var transferErr *services.TransferError
+			switch {
+			case err == nil:
+				succeeded++
+			case errors.As(err, &transferErr) && transferErr.Reason == models.FailureInsufficientBalance:
+				declined++
+			default:
+				t.Errorf("unexpected error: %v", err)
+			}
+		}(transferServices[i%instances])
+	}
+	wg.Wait()
+
+	assert.Equal(t, 10, succeeded)
+	assert.Equal(t, attempts-10, declined)
+
+	clientRepo := repositories.NewClientRepository(dbs[0])
+	payer, err := clientRepo.GetClientByAccountNum("111111")
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(0), payer.Balance)
+	payee, err := clientRepo.GetClientByAccountNum("222222")
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(10000), payee.Balance)
+
+	ledgerBalance, err := repositories.NewLedgerRepository(dbs[0]).GetAccountBalance("111111", "BRL")
+	assert.NoError(t, err)
+	assert.Equal(t, payer.Balance, ledgerBalance)
+
+	// Todo o dinheiro veio da tesouraria e continua contabilizado
+	position, err := services.NewTreasuryService(uow).GetPosition("BRL")
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(-10000), position.TreasuryBalance)
+	assert.Equal(t, models.BRL(10000), position.ClientBalances)
+	assert.True(t, position.Balanced)
+}
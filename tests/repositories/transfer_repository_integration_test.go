@@ -19,54 +19,54 @@ func TestTransferRepository_CreateTransfer(t *testing.T) {
 	transfer := &models.Transfer{
 		FromAccountNum: "123456",
 		ToAccountNum:   "654321",
-		Amount:         100.0,
+		Amount:         models.BRL(10000),
 		Status:         "success",
 		CreatedAt:      time.Now(),
 	}
 
 	err := repo.CreateTransfer(transfer)
 	assert.NoError(t, err)
+	assert.NotZero(t, transfer.ID)
+	assert.Equal(t, models.TransferTypeTransfer, transfer.Type)
+	assert.WithinDuration(t, time.Now(), transfer.CreatedAt, time.Minute)
 
 	// Verifica se a transferência foi realmente criada
-	rows, err := db.Query("SELECT from_account_num, to_account_num, amount, status FROM transfers WHERE from_account_num = ?", "123456")
+	rows, err := db.Query("SELECT from_account_num, to_account_num, amount, currency, status FROM transfers WHERE from_account_num = ?", "123456")
 	assert.NoError(t, err)
 	defer rows.Close()
 
 	assert.True(t, rows.Next())
-	var fromAccountNum, toAccountNum, status string
-	var amount float64
-	err = rows.Scan(&fromAccountNum, &toAccountNum, &amount, &status)
+	var fromAccountNum, toAccountNum, currency, status string
+	var amount int64
+	err = rows.Scan(&fromAccountNum, &toAccountNum, &amount, &currency, &status)
 	assert.NoError(t, err)
 	assert.Equal(t, "123456", fromAccountNum)
 	assert.Equal(t, "654321", toAccountNum)
-	assert.Equal(t, 100.0, amount)
+	assert.Equal(t, int64(10000), amount)
+	assert.Equal(t, "BRL", currency)
 	assert.Equal(t, "success", status)
 }
 
-func TestTransferRepository_GetTransfersByAccountNum(t *testing.T) {
+func TestTransferRepository_HasFunding(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close()
 
 	repo := repositories.NewTransferRepository(db)
 
-	// Insere transferências para teste
-	transfers := []models.Transfer{
-		{FromAccountNum: "123456", ToAccountNum: "654321", Amount: 50.0, Status: "success", CreatedAt: time.Now()},
-		{FromAccountNum: "654321", ToAccountNum: "123456", Amount: 75.0, Status: "failed", CreatedAt: time.Now()},
-	}
-	for _, transfer := range transfers {
-		err := repo.CreateTransfer(&transfer)
-		assert.NoError(t, err)
-	}
+	// Um financiamento recusado não conta como saldo inicial
+	failed := models.Transfer{FromAccountNum: models.TreasuryAccountNum, ToAccountNum: "123456", Amount: models.BRL(5000),
+		Type: models.TransferTypeFunding, Status: models.TransferStatusFailed}
+	assert.NoError(t, repo.CreateTransfer(&failed))
 
-	// Testa GetTransfersByAccountNum
-	storedTransfers, err := repo.GetTransfersByAccountNum("123456")
+	funded, err := repo.HasFunding("123456")
 	assert.NoError(t, err)
-	assert.Equal(t, 2, len(storedTransfers))
+	assert.False(t, funded)
 
-	// Verifica o conteúdo do resultado
-	assert.Equal(t, "123456", storedTransfers[0].FromAccountNum)
-	assert.Equal(t, "654321", storedTransfers[1].FromAccountNum)
-	assert.Equal(t, 75.0, storedTransfers[1].Amount)
-	assert.Equal(t, "failed", storedTransfers[1].Status)
+	funding := models.Transfer{FromAccountNum: models.TreasuryAccountNum, ToAccountNum: "123456", Amount: models.BRL(5000),
+		Type: models.TransferTypeFunding, Status: models.TransferStatusSuccess}
+	assert.NoError(t, repo.CreateTransfer(&funding))
+
+	funded, err = repo.HasFunding("123456")
+	assert.NoError(t, err)
+	assert.True(t, funded)
 }
@@ -0,0 +1,103 @@
+// src/repositories/unit_of_work_integration_test.go
+package test
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"errors"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitOfWork_Commit(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	clientRepo := repositories.NewClientRepository(db)
+	uow := repositories.NewUnitOfWork(db)
+
+	err := clientRepo.CreateClient(&models.Client{Name: "Alice", AccountNum: "111111", Balance: models.BRL(100000)})
+	assert.NoError(t, err)
+
+	err = uow.Do(func(repos repositories.Repositories) error {
+		client, err := repos.Clients.GetClientByAccountNum("111111")
+		if err != nil {
+			return err
+		}
+		client.Balance = models.BRL(50000)
+		if err := repos.Clients.UpdateClientBalance(client); err != nil {
+			return err
+		}
+		return repos.Transfers.CreateTransfer(&models.Transfer{
+			FromAccountNum: "111111", ToAccountNum: "222222", Amount: models.BRL(50000), Status: "success",
+		})
+	})
+	assert.NoError(t, err)
+
+	client, err := clientRepo.GetClientByAccountNum("111111")
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(50000), client.Balance)
+
+	var transfers int
+	assert.NoError(t, db.QueryRow("SELECT COUNT(*) FROM transfers WHERE from_account_num = '111111'").Scan(&transfers))
+	assert.Equal(t, 1, transfers)
+}
+
+func TestUnitOfWork_RollbackOnError(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	clientRepo := repositories.NewClientRepository(db)
+	uow := repositories.NewUnitOfWork(db)
+
+	err := clientRepo.CreateClient(&models.Client{Name: "Alice", AccountNum: "111111", Balance: models.BRL(100000)})
+	assert.NoError(t, err)
+
+	err = uow.Do(func(repos repositories.Repositories) error {
+		client, err := repos.Clients.GetClientByAccountNum("111111")
+		if err != nil {
+			return err
+		}
+		client.Balance = models.BRL(0)
+		if err := repos.Clients.UpdateClientBalance(client); err != nil {
+			return err
+		}
+		return errors.New("credit failed")
+	})
+	assert.EqualError(t, err, "credit failed")
+
+	// O débito não deve ter sido persistido
+	client, err := clientRepo.GetClientByAccountNum("111111")
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(100000), client.Balance)
+
+	var transfers int
+	assert.NoError(t, db.QueryRow("SELECT COUNT(*) FROM transfers WHERE from_account_num = '111111'").Scan(&transfers))
+	assert.Zero(t, transfers)
+}
+
+func TestUnitOfWork_RollbackOnPanic(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	clientRepo := repositories.NewClientRepository(db)
+	uow := repositories.NewUnitOfWork(db)
+
+	err := clientRepo.CreateClient(&models.Client{Name: "Alice", AccountNum: "111111", Balance: models.BRL(100000)})
+	assert.NoError(t, err)
+
+	assert.Panics(t, func() {
+		uow.Do(func(repos repositories.Repositories) error {
+			client, _ := repos.Clients.GetClientByAccountNum("111111")
+			client.Balance = models.BRL(0)
+			repos.Clients.UpdateClientBalance(client)
+			panic("unexpected")
+		})
+	})
+
+	client, err := clientRepo.GetClientByAccountNum("111111")
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(100000), client.Balance)
+}
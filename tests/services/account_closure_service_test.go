@@ -0,0 +1,163 @@
+// src/services/account_closure_service_test.go
+package test
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"banking/src/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type accountClosureMocks struct {
+	clients        *MockClientRepository
+	holds          *MockHoldRepository
+	scheduled      *MockScheduledTransferRepository
+	standingOrders *MockStandingOrderRepository
+	transfers      *MockTransferService
+	statuses       *MockAccountStatusRepository
+	uow            *MockUnitOfWork
+}
+
+func newAccountClosureTestService() (*services.AccountClosureService, accountClosureMocks) {
+	m := accountClosureMocks{
+		clients:        new(MockClientRepository),
+		holds:          new(MockHoldRepository),
+		scheduled:      new(MockScheduledTransferRepository),
+		standingOrders: new(MockStandingOrderRepository),
+		statuses:       new(MockAccountStatusRepository),
+	}
+	m.uow = NewMockUnitOfWork(m.clients, new(MockTransferRepository), new(MockLedgerRepository))
+	m.uow.Holds = m.holds
+	m.uow.Scheduled = m.scheduled
+	m.uow.StandingOrders = m.standingOrders
+	m.uow.Statuses = m.statuses
+	m.transfers = &MockTransferService{Repos: repositories.Repositories{
+		Clients:            m.clients,
+		Holds:              m.holds,
+		ScheduledTransfers: m.scheduled,
+		StandingOrders:     m.standingOrders,
+		Statuses:           m.statuses,
+	}}
+	closureService := services.NewAccountClosureService(m.clients, m.uow, m.transfers)
+	return closureService, m
+}
+
+// noPendingItems faz a conta não ter reservas, agendamentos nem ordens permanentes
+func (m accountClosureMocks) noPendingItems(accountNum string) {
+	m.holds.On("GetHoldsByAccountNum", accountNum, mock.Anything).Return([]models.Hold{}, nil)
+	m.scheduled.On("GetScheduledTransfersByAccountNum", accountNum, mock.Anything).Return([]models.ScheduledTransfer{}, nil)
+	m.standingOrders.On("GetStandingOrdersByAccountNum", accountNum, mock.Anything).Return([]models.StandingOrder{}, nil)
+}
+
+func TestCloseAccount_SweepsBalanceAndCloses(t *testing.T) {
+	closureService, m := newAccountClosureTestService()
+
+	m.clients.On("GetClientByAccountNum", "123456").Return(&models.Client{AccountNum: "123456", Balance: models.BRL(4200), Status: models.AccountStatusActive}, nil).Once()
+	m.noPendingItems("123456")
+	sweep := &models.Transfer{ID: 9, FromAccountNum: "123456", ToAccountNum: "654321", Amount: models.BRL(4200)}
+	m.transfers.On("TransferFunds", "123456", "654321", models.BRL(4200)).Return(sweep, nil)
+	m.clients.On("GetClientByAccountNum", "123456").Return(&models.Client{AccountNum: "123456", Balance: models.BRL(0), Status: models.AccountStatusActive}, nil).Once()
+	m.clients.On("UpdateClientStatus", "123456", models.AccountStatusClosed).Return(&models.Client{AccountNum: "123456", Status: models.AccountStatusClosed}, nil)
+	m.statuses.On("CreateStatusChange", mock.MatchedBy(func(change *models.AccountStatusChange) bool {
+		return change.ToStatus == models.AccountStatusClosed && change.Reason == models.DefaultClosureReason
+	})).Return(nil)
+
+	closure, err := closureService.CloseAccount("123456", "654321", "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.AccountStatusClosed, closure.Account.Status)
+	assert.Equal(t, sweep, closure.Sweep)
+	m.transfers.AssertExpectations(t)
+	m.statuses.AssertExpectations(t)
+}
+
+func TestCloseAccount_EmptyAccountNeedsNoSweep(t *testing.T) {
+	closureService, m := newAccountClosureTestService()
+
+	m.clients.On("GetClientByAccountNum", "123456").Return(&models.Client{AccountNum: "123456", Balance: models.BRL(0), Status: models.AccountStatusActive}, nil)
+	m.noPendingItems("123456")
+	m.clients.On("UpdateClientStatus", "123456", models.AccountStatusClosed).Return(&models.Client{AccountNum: "123456", Status: models.AccountStatusClosed}, nil)
+	m.statuses.On("CreateStatusChange", mock.Anything).Return(nil)
+
+	closure, err := closureService.CloseAccount("123456", "", "mudança de banco")
+
+	assert.NoError(t, err)
+	assert.Nil(t, closure.Sweep)
+	m.transfers.AssertNotCalled(t, "TransferFunds", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCloseAccount_RejectsPendingItems(t *testing.T) {
+	closureService, m := newAccountClosureTestService()
+
+	m.clients.On("GetClientByAccountNum", "123456").Return(&models.Client{AccountNum: "123456", Balance: models.BRL(4200), Status: models.AccountStatusActive}, nil)
+	m.holds.On("GetHoldsByAccountNum", "123456", models.HoldStatusActive).Return([]models.Hold{}, nil)
+	m.scheduled.On("GetScheduledTransfersByAccountNum", "123456", models.ScheduledStatusPending).Return([]models.ScheduledTransfer{{ID: 3}}, nil)
+	m.scheduled.On("GetScheduledTransfersByAccountNum", "123456", models.ScheduledStatusProcessing).Return([]models.ScheduledTransfer{}, nil)
+	m.standingOrders.On("GetStandingOrdersByAccountNum", "123456", mock.Anything).Return([]models.StandingOrder{}, nil)
+
+	_, err := closureService.CloseAccount("123456", "654321", "")
+
+	assert.ErrorIs(t, err, services.ErrAccountHasPendingItems)
+	m.transfers.AssertNotCalled(t, "TransferFunds", mock.Anything, mock.Anything, mock.Anything)
+	m.clients.AssertNotCalled(t, "UpdateClientStatus", mock.Anything, mock.Anything)
+}
+
+func TestCloseAccount_RequiresSweepAccount(t *testing.T) {
+	closureService, m := newAccountClosureTestService()
+
+	m.clients.On("GetClientByAccountNum", "123456").Return(&models.Client{AccountNum: "123456", Balance: models.BRL(4200), Status: models.AccountStatusActive}, nil)
+	m.noPendingItems("123456")
+
+	_, err := closureService.CloseAccount("123456", "", "")
+
+	assert.ErrorIs(t, err, services.ErrSweepAccountRequired)
+	m.clients.AssertNotCalled(t, "UpdateClientStatus", mock.Anything, mock.Anything)
+}
+
+func TestCloseAccount_RejectsInactiveOrOverdrawnAccount(t *testing.T) {
+	closureService, m := newAccountClosureTestService()
+
+	m.clients.On("GetClientByAccountNum", "123456").Return(&models.Client{AccountNum: "123456", Balance: models.BRL(0), Status: models.AccountStatusFrozen}, nil)
+	m.clients.On("GetClientByAccountNum", "654321").Return(&models.Client{AccountNum: "654321", Balance: models.BRL(-500), Status: models.AccountStatusActive}, nil)
+
+	_, err := closureService.CloseAccount("123456", "", "")
+	assert.ErrorIs(t, err, models.ErrInvalidStatusTransition)
+
+	_, err = closureService.CloseAccount("654321", "", "")
+	assert.ErrorIs(t, err, services.ErrAccountNotEmpty)
+
+	m.holds.AssertNotCalled(t, "GetHoldsByAccountNum", mock.Anything, mock.Anything)
+}
+
+func TestCloseAccount_SweepDeclined(t *testing.T) {
+	closureService, m := newAccountClosureTestService()
+
+	m.clients.On("GetClientByAccountNum", "123456").Return(&models.Client{AccountNum: "123456", Balance: models.BRL(4200), Status: models.AccountStatusActive}, nil)
+	m.noPendingItems("123456")
+	m.transfers.On("TransferFunds", "123456", "999999", models.BRL(4200)).
+		Return(nil, &services.TransferError{Reason: models.FailureDestinationNotFound, Err: assert.AnError})
+
+	_, err := closureService.CloseAccount("123456", "999999", "")
+
+	assert.Equal(t, models.FailureDestinationNotFound, services.FailureReason(err))
+	m.clients.AssertNotCalled(t, "UpdateClientStatus", mock.Anything, mock.Anything)
+}
+
+func TestCloseAccount_RechecksPendingItemsWithTheSweep(t *testing.T) {
+	closureService, m := newAccountClosureTestService()
+
+	m.clients.On("GetClientByAccountNum", "123456").Return(&models.Client{AccountNum: "123456", Balance: models.BRL(4200), Status: models.AccountStatusActive}, nil)
+	m.holds.On("GetHoldsByAccountNum", "123456", models.HoldStatusActive).Return([]models.Hold{}, nil).Once()
+	m.holds.On("GetHoldsByAccountNum", "123456", models.HoldStatusActive).Return([]models.Hold{{ID: 5}}, nil).Once()
+	m.scheduled.On("GetScheduledTransfersByAccountNum", "123456", mock.Anything).Return([]models.ScheduledTransfer{}, nil)
+	m.standingOrders.On("GetStandingOrdersByAccountNum", "123456", mock.Anything).Return([]models.StandingOrder{}, nil)
+	m.transfers.On("TransferFunds", "123456", "654321", models.BRL(4200)).Return(&models.Transfer{ID: 9}, nil)
+
+	_, err := closureService.CloseAccount("123456", "654321", "")
+
+	assert.ErrorIs(t, err, services.ErrAccountHasPendingItems)
+	m.clients.AssertNotCalled(t, "UpdateClientStatus", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,106 @@
+// test/account_locks_test.go
+package test
+
+import (
+	"banking/src/services"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountLocks_SerializesSameAccount(t *testing.T) {
+	locks := services.NewAccountLocks()
+
+	var mu sync.Mutex
+	active, maxActive := 0, 0
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// Todas as operações envolvem a conta "A", em pares diferentes
+			unlock := locks.Lock("A", string(rune('B'+i%3)))
+			defer unlock()
+
+			mu.Lock()
+			active++
+			if active > maxActive {
+				maxActive = active
+			}
+			mu.Unlock()
+			time.Sleep(time.Millisecond)
+			mu.Lock()
+			active--
+			mu.Unlock()
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, maxActive)
+}
+
+func TestAccountLocks_DisjointAccountsDoNotWait(t *testing.T) {
+	locks := services.NewAccountLocks()
+
+	unlockAB := locks.Lock("A", "B")
+	defer unlockAB()
+
+	done := make(chan struct{})
+	go func() {
+		unlock := locks.Lock("C", "D")
+		unlock()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("lock on disjoint accounts waited for an unrelated transfer")
+	}
+}
+
+func TestAccountLocks_OppositeDirectionsDoNotDeadlock(t *testing.T) {
+	locks := services.NewAccountLocks()
+
+	done := make(chan struct{})
+	go func() {
+		var wg sync.WaitGroup
+		for i := 0; i < 1000; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				locks.Lock("A", "B")()
+			}()
+			go func() {
+				defer wg.Done()
+				locks.Lock("B", "A")()
+			}()
+		}
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("transfers in opposite directions deadlocked")
+	}
+}
+
+func TestAccountLocks_SameAccountTwice(t *testing.T) {
+	locks := services.NewAccountLocks()
+
+	done := make(chan struct{})
+	go func() {
+		locks.Lock("A", "A")()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("locking the same account twice deadlocked")
+	}
+}
@@ -0,0 +1,193 @@
+// src/services/account_service_test.go
+package test
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"banking/src/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDeposit_Success(t *testing.T) {
+	mockClientRepo := new(MockClientRepository)
+	mockTransferRepo := new(MockTransferRepository)
+	mockLedgerRepo := new(MockLedgerRepository)
+	mockUow := NewMockUnitOfWork(mockClientRepo, mockTransferRepo, mockLedgerRepo)
+	accountService := services.NewAccountService(mockTransferRepo, mockUow, services.NewAccountLocks())
+
+	client := &models.Client{AccountNum: "123456", Balance: models.BRL(10000), Status: models.AccountStatusActive}
+	amount := models.BRL(2500)
+
+	mockClientRepo.On("GetClientByAccountNum", "123456").Return(client, nil)
+	mockClientRepo.On("CreditClientBalance", "123456", amount).Return(models.BRL(12500), nil)
+	mockTransferRepo.On("CreateTransfer", mock.MatchedBy(func(transfer *models.Transfer) bool {
+		return transfer.Type == models.TransferTypeDeposit &&
+			transfer.Status == models.TransferStatusSuccess &&
+			transfer.FromAccountNum == models.CashAccountNum &&
+			transfer.ToAccountNum == "123456"
+	})).Return(nil)
+	mockLedgerRepo.On("CreateEntry", mock.MatchedBy(func(entry *models.JournalEntry) bool {
+		return entry.Description == models.EntryDeposit &&
+			entry.Postings[0] == models.Posting{AccountNum: models.CashAccountNum, Amount: models.BRL(-2500)} &&
+			entry.Postings[1] == models.Posting{AccountNum: "123456", Amount: models.BRL(2500)}
+	})).Return(nil)
+	mockLedgerRepo.On("GetAccountBalance", "123456", "BRL").Return(models.BRL(12500), nil)
+
+	deposit, err := accountService.Deposit("123456", amount)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.TransferTypeDeposit, deposit.Type)
+	assert.Equal(t, amount, deposit.Amount)
+	assert.True(t, mockUow.Committed)
+	mockClientRepo.AssertExpectations(t)
+	mockTransferRepo.AssertExpectations(t)
+	mockLedgerRepo.AssertExpectations(t)
+}
+
+func TestWithdraw_Success(t *testing.T) {
+	mockClientRepo := new(MockClientRepository)
+	mockTransferRepo := new(MockTransferRepository)
+	mockLedgerRepo := new(MockLedgerRepository)
+	mockUow := NewMockUnitOfWork(mockClientRepo, mockTransferRepo, mockLedgerRepo)
+	accountService := services.NewAccountService(mockTransferRepo, mockUow, services.NewAccountLocks())
+
+	client := &models.Client{AccountNum: "123456", Balance: models.BRL(10000), Status: models.AccountStatusActive}
+	amount := models.BRL(2500)
+
+	mockClientRepo.On("GetClientByAccountNum", "123456").Return(client, nil)
+	mockClientRepo.On("DebitClientBalance", "123456", amount).Return(models.BRL(7500), nil)
+	mockTransferRepo.On("CreateTransfer", mock.MatchedBy(func(transfer *models.Transfer) bool {
+		return transfer.Type == models.TransferTypeWithdrawal &&
+			transfer.FromAccountNum == "123456" &&
+			transfer.ToAccountNum == models.CashAccountNum
+	})).Return(nil)
+	mockLedgerRepo.On("CreateEntry", mock.MatchedBy(func(entry *models.JournalEntry) bool {
+		return entry.Description == models.EntryWithdrawal &&
+			entry.Postings[0] == models.Posting{AccountNum: "123456", Amount: models.BRL(-2500)}
+	})).Return(nil)
+	mockLedgerRepo.On("GetAccountBalance", "123456", "BRL").Return(models.BRL(7500), nil)
+
+	withdrawal, err := accountService.Withdraw("123456", amount)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.TransferTypeWithdrawal, withdrawal.Type)
+	assert.True(t, mockUow.Committed)
+	mockLedgerRepo.AssertExpectations(t)
+}
+
+func TestWithdraw_InsufficientBalance(t *testing.T) {
+	mockClientRepo := new(MockClientRepository)
+	mockTransferRepo := new(MockTransferRepository)
+	mockLedgerRepo := new(MockLedgerRepository)
+	mockUow := NewMockUnitOfWork(mockClientRepo, mockTransferRepo, mockLedgerRepo)
+	accountService := services.NewAccountService(mockTransferRepo, mockUow, services.NewAccountLocks())
+
+	client := &models.Client{AccountNum: "123456", Balance: models.BRL(1000), Status: models.AccountStatusActive}
+
+	mockClientRepo.On("GetClientByAccountNum", "123456").Return(client, nil)
+	mockClientRepo.On("DebitClientBalance", "123456", models.BRL(2500)).Return(models.Money{}, repositories.ErrInsufficientBalance)
+	mockTransferRepo.On("CreateTransfer", mock.MatchedBy(func(transfer *models.Transfer) bool {
+		return transfer.Type == models.TransferTypeWithdrawal &&
+			transfer.Status == models.TransferStatusFailed &&
+			transfer.FailureReason == models.FailureInsufficientBalance
+	})).Return(nil)
+
+	withdrawal, err := accountService.Withdraw("123456", models.BRL(2500))
+
+	assert.Nil(t, withdrawal)
+	assert.Equal(t, models.FailureInsufficientBalance, services.FailureReason(err))
+	assert.True(t, mockUow.RolledBack)
+	mockTransferRepo.AssertExpectations(t)
+	mockLedgerRepo.AssertNotCalled(t, "CreateEntry", mock.Anything)
+}
+
+func TestDeposit_InvalidAmount(t *testing.T) {
+	mockClientRepo := new(MockClientRepository)
+	mockTransferRepo := new(MockTransferRepository)
+	mockUow := NewMockUnitOfWork(mockClientRepo, mockTransferRepo, new(MockLedgerRepository))
+	accountService := services.NewAccountService(mockTransferRepo, mockUow, services.NewAccountLocks())
+
+	mockTransferRepo.On("CreateTransfer", failedTransfer(models.FailureInvalidAmount)).Return(nil)
+
+	deposit, err := accountService.Deposit("123456", models.BRL(-100))
+
+	assert.Nil(t, deposit)
+	assert.EqualError(t, err, "amount must be greater than zero")
+	mockClientRepo.AssertNotCalled(t, "GetClientByAccountNum", mock.Anything)
+	mockTransferRepo.AssertExpectations(t)
+}
+
+func TestDeposit_UnknownAccount(t *testing.T) {
+	mockClientRepo := new(MockClientRepository)
+	mockTransferRepo := new(MockTransferRepository)
+	mockUow := NewMockUnitOfWork(mockClientRepo, mockTransferRepo, new(MockLedgerRepository))
+	accountService := services.NewAccountService(mockTransferRepo, mockUow, services.NewAccountLocks())
+
+	mockClientRepo.On("GetClientByAccountNum", "999999").Return((*models.Client)(nil), repositories.ErrClientNotFound)
+	mockTransferRepo.On("CreateTransfer", failedTransfer(models.FailureDestinationNotFound)).Return(nil)
+
+	_, err := accountService.Deposit("999999", models.BRL(100))
+
+	assert.ErrorIs(t, err, repositories.ErrClientNotFound)
+	assert.Equal(t, models.FailureDestinationNotFound, services.FailureReason(err))
+	mockTransferRepo.AssertExpectations(t)
+}
+
+func TestFund_Success(t *testing.T) {
+	mockClientRepo := new(MockClientRepository)
+	mockTransferRepo := new(MockTransferRepository)
+	mockLedgerRepo := new(MockLedgerRepository)
+	mockUow := NewMockUnitOfWork(mockClientRepo, mockTransferRepo, mockLedgerRepo)
+	accountService := services.NewAccountService(mockTransferRepo, mockUow, services.NewAccountLocks())
+
+	client := &models.Client{AccountNum: "123456", Balance: models.BRL(0), Status: models.AccountStatusActive}
+	amount := models.BRL(100000)
+
+	mockClientRepo.On("GetClientByAccountNum", "123456").Return(client, nil)
+	mockTransferRepo.On("HasFunding", "123456").Return(false, nil)
+	mockClientRepo.On("CreditClientBalance", "123456", amount).Return(amount, nil)
+	mockTransferRepo.On("CreateTransfer", mock.MatchedBy(func(transfer *models.Transfer) bool {
+		return transfer.Type == models.TransferTypeFunding &&
+			transfer.FromAccountNum == models.TreasuryAccountNum &&
+			transfer.ToAccountNum == "123456"
+	})).Return(nil)
+	mockLedgerRepo.On("CreateEntry", mock.MatchedBy(func(entry *models.JournalEntry) bool {
+		return entry.Description == models.EntryFunding &&
+			entry.Postings[0] == models.Posting{AccountNum: models.TreasuryAccountNum, Amount: models.BRL(-100000)} &&
+			entry.Postings[1] == models.Posting{AccountNum: "123456", Amount: models.BRL(100000)}
+	})).Return(nil)
+	mockLedgerRepo.On("GetAccountBalance", "123456", "BRL").Return(amount, nil)
+
+	funding, err := accountService.Fund("123456", amount)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.TransferTypeFunding, funding.Type)
+	assert.True(t, mockUow.Committed)
+	mockLedgerRepo.AssertExpectations(t)
+}
+
+func TestFund_AlreadyFunded(t *testing.T) {
+	mockClientRepo := new(MockClientRepository)
+	mockTransferRepo := new(MockTransferRepository)
+	mockLedgerRepo := new(MockLedgerRepository)
+	mockUow := NewMockUnitOfWork(mockClientRepo, mockTransferRepo, mockLedgerRepo)
+	accountService := services.NewAccountService(mockTransferRepo, mockUow, services.NewAccountLocks())
+
+	client := &models.Client{AccountNum: "123456", Balance: models.BRL(100000), Status: models.AccountStatusActive}
+	mockClientRepo.On("GetClientByAccountNum", "123456").Return(client, nil)
+	mockTransferRepo.On("HasFunding", "123456").Return(true, nil)
+	mockTransferRepo.On("CreateTransfer", mock.MatchedBy(func(transfer *models.Transfer) bool {
+		return transfer.Status == models.TransferStatusFailed && transfer.FailureReason == models.FailureAlreadyFunded
+	})).Return(nil)
+
+	funding, err := accountService.Fund("123456", models.BRL(100000))
+
+	assert.ErrorIs(t, err, services.ErrAccountAlreadyFunded)
+	assert.Nil(t, funding)
+	assert.False(t, mockUow.Committed)
+	mockClientRepo.AssertNotCalled(t, "CreditClientBalance", mock.Anything, mock.Anything)
+	mockTransferRepo.AssertExpectations(t)
+}
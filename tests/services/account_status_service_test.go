@@ -0,0 +1,74 @@
+// src/services/account_status_service_test.go
+package test
+
+import (
+	"banking/src/models"
+	"banking/src/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newAccountStatusTestService() (*services.AccountStatusService, *MockClientRepository, *MockAccountStatusRepository, *MockUnitOfWork) {
+	mockClientRepo := new(MockClientRepository)
+	mockStatusRepo := new(MockAccountStatusRepository)
+	mockUow := NewMockUnitOfWork(mockClientRepo, new(MockTransferRepository), new(MockLedgerRepository))
+	mockUow.Statuses = mockStatusRepo
+	return services.NewAccountStatusService(mockClientRepo, mockStatusRepo, mockUow), mockClientRepo, mockStatusRepo, mockUow
+}
+
+func TestChangeStatus_FreezesAndRecordsHistory(t *testing.T) {
+	statusService, mockClientRepo, mockStatusRepo, mockUow := newAccountStatusTestService()
+
+	mockClientRepo.On("GetClientByAccountNum", "123456").Return(&models.Client{AccountNum: "123456", Balance: models.BRL(5000), Status: models.AccountStatusActive}, nil)
+	mockClientRepo.On("UpdateClientStatus", "123456", models.AccountStatusFrozen).Return(&models.Client{AccountNum: "123456", Status: models.AccountStatusFrozen}, nil)
+	mockStatusRepo.On("CreateStatusChange", mock.MatchedBy(func(change *models.AccountStatusChange) bool {
+		return change.FromStatus == models.AccountStatusActive && change.ToStatus == models.AccountStatusFrozen &&
+			change.Reason == "ordem judicial"
+	})).Return(nil)
+
+	client, err := statusService.ChangeStatus("123456", models.AccountStatusFrozen, "ordem judicial")
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.AccountStatusFrozen, client.Status)
+	assert.True(t, mockUow.Committed)
+	mockStatusRepo.AssertExpectations(t)
+}
+
+func TestChangeStatus_RejectsInvalidTransition(t *testing.T) {
+	statusService, mockClientRepo, mockStatusRepo, _ := newAccountStatusTestService()
+
+	mockClientRepo.On("GetClientByAccountNum", "123456").Return(&models.Client{AccountNum: "123456", Status: models.AccountStatusFrozen}, nil)
+
+	_, err := statusService.ChangeStatus("123456", models.AccountStatusBlocked, "fraude confirmada")
+
+	assert.ErrorIs(t, err, models.ErrInvalidStatusTransition)
+	mockClientRepo.AssertNotCalled(t, "UpdateClientStatus", mock.Anything, mock.Anything)
+	mockStatusRepo.AssertNotCalled(t, "CreateStatusChange", mock.Anything)
+}
+
+func TestChangeStatus_RequiresReason(t *testing.T) {
+	statusService, mockClientRepo, _, _ := newAccountStatusTestService()
+
+	mockClientRepo.On("GetClientByAccountNum", "123456").Return(&models.Client{AccountNum: "123456", Status: models.AccountStatusActive}, nil)
+
+	_, err := statusService.ChangeStatus("123456", models.AccountStatusBlocked, "")
+
+	assert.ErrorIs(t, err, models.ErrStatusReasonRequired)
+	mockClientRepo.AssertNotCalled(t, "UpdateClientStatus", mock.Anything, mock.Anything)
+}
+
+func TestChangeStatus_ClosingRequiresEmptyAccount(t *testing.T) {
+	statusService, mockClientRepo, _, mockUow := newAccountStatusTestService()
+
+	mockClientRepo.On("GetClientByAccountNum", "123456").Return(&models.Client{
+		AccountNum: "123456", Balance: models.BRL(0), Held: models.BRL(700), Status: models.AccountStatusActive,
+	}, nil)
+
+	_, err := statusService.ChangeStatus("123456", models.AccountStatusClosed, "pedido do cliente")
+
+	assert.ErrorIs(t, err, services.ErrAccountNotEmpty)
+	assert.True(t, mockUow.RolledBack)
+	mockClientRepo.AssertNotCalled(t, "UpdateClientStatus", mock.Anything, mock.Anything)
+}
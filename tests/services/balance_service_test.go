@@ -0,0 +1,74 @@
+// src/services/balance_service_test.go
+package test
+
+import (
+	"banking/src/models"
+	"banking/src/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetBalance_AsOf(t *testing.T) {
+	mockClientRepo := new(MockClientRepository)
+	mockLedgerRepo := new(MockLedgerRepository)
+	balanceService := services.NewBalanceService(mockClientRepo, mockLedgerRepo, new(MockBalanceSnapshotRepository))
+
+	asOf := time.Date(2030, 3, 2, 0, 0, 0, 0, models.BusinessLocation)
+	mockClientRepo.On("GetClientByAccountNum", "123456").Return(&models.Client{AccountNum: "123456", Balance: models.NewMoney(0, "USD")}, nil)
+	mockLedgerRepo.On("GetBalanceAt", "123456", "USD", asOf).Return(models.NewMoney(4200, "USD"), nil)
+
+	balance, err := balanceService.GetBalance("123456", asOf)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.NewMoney(4200, "USD"), balance.Balance)
+	assert.Equal(t, asOf, balance.AsOf)
+}
+
+func TestGetDailyBalances_DefaultsToLastThirtyDays(t *testing.T) {
+	mockClientRepo := new(MockClientRepository)
+	mockSnapshotRepo := new(MockBalanceSnapshotRepository)
+	balanceService := services.NewBalanceService(mockClientRepo, new(MockLedgerRepository), mockSnapshotRepo)
+
+	to := time.Date(2030, 3, 31, 0, 0, 0, 0, models.BusinessLocation)
+	mockClientRepo.On("GetClientByAccountNum", "123456").Return(&models.Client{AccountNum: "123456"}, nil)
+	mockSnapshotRepo.On("GetSnapshots", "123456", "2030-03-02", "2030-03-31").Return([]models.BalanceSnapshot{}, nil)
+
+	_, err := balanceService.GetDailyBalances("123456", time.Time{}, to)
+	assert.NoError(t, err)
+
+	_, err = balanceService.GetDailyBalances("123456", to, to.AddDate(0, 0, -1))
+	assert.ErrorIs(t, err, models.ErrInvalidPeriod)
+	mockSnapshotRepo.AssertExpectations(t)
+}
+
+func TestSnapshotDue_FillsMissingDaysOnce(t *testing.T) {
+	mockSnapshotRepo := new(MockBalanceSnapshotRepository)
+	balanceService := services.NewBalanceService(new(MockClientRepository), new(MockLedgerRepository), mockSnapshotRepo)
+
+	now := time.Date(2030, 3, 4, 9, 0, 0, 0, models.BusinessLocation)
+	mockSnapshotRepo.On("GetLatestSnapshotDate").Return("2030-03-01", nil)
+	mockSnapshotRepo.On("CreateSnapshots", "2030-03-02", time.Date(2030, 3, 3, 0, 0, 0, 0, models.BusinessLocation)).Return(2, nil).Once()
+	mockSnapshotRepo.On("CreateSnapshots", "2030-03-03", time.Date(2030, 3, 4, 0, 0, 0, 0, models.BusinessLocation)).Return(2, nil).Once()
+
+	assert.NoError(t, balanceService.SnapshotDue(now))
+	// No mesmo dia, a rotina não faz nada de novo
+	assert.NoError(t, balanceService.SnapshotDue(now.Add(time.Hour)))
+
+	mockSnapshotRepo.AssertExpectations(t)
+	mockSnapshotRepo.AssertNumberOfCalls(t, "GetLatestSnapshotDate", 1)
+}
+
+func TestSnapshotDue_StartsWithYesterday(t *testing.T) {
+	mockSnapshotRepo := new(MockBalanceSnapshotRepository)
+	balanceService := services.NewBalanceService(new(MockClientRepository), new(MockLedgerRepository), mockSnapshotRepo)
+
+	now := time.Date(2030, 3, 4, 9, 0, 0, 0, models.BusinessLocation)
+	mockSnapshotRepo.On("GetLatestSnapshotDate").Return("", nil)
+	mockSnapshotRepo.On("CreateSnapshots", "2030-03-03", mock.Anything).Return(2, nil).Once()
+
+	assert.NoError(t, balanceService.SnapshotDue(now))
+	mockSnapshotRepo.AssertExpectations(t)
+}
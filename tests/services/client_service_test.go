@@ -3,23 +3,27 @@ package test
 
 import (
 	"banking/src/models"
+	"banking/src/repositories"
 	"banking/src/services"
 	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestNewClientService(t *testing.T) {
 	mockRepo := new(MockClientRepository)
-	clientService := services.NewClientService(mockRepo)
+	mockUow := NewMockUnitOfWork(mockRepo, nil, new(MockLedgerRepository))
+	clientService := services.NewClientService(mockRepo, mockUow, models.DefaultAccountNumberFormat)
 
 	assert.NotNil(t, clientService, "Expected NewClientService to return a non-nil ClientService instance")
 }
 
 func TestCreateClient_Success(t *testing.T) {
 	mockRepo := new(MockClientRepository)
-	clientService := services.NewClientService(mockRepo)
+	mockUow := NewMockUnitOfWork(mockRepo, nil, new(MockLedgerRepository))
+	clientService := services.NewClientService(mockRepo, mockUow, models.DefaultAccountNumberFormat)
 
 	client := &models.Client{Name: "John Doe", AccountNum: "123456"}
 
@@ -33,7 +37,8 @@ func TestCreateClient_Success(t *testing.T) {
 
 func TestCreateClient_MissingFields(t *testing.T) {
 	mockRepo := new(MockClientRepository)
-	clientService := services.NewClientService(mockRepo)
+	mockUow := NewMockUnitOfWork(mockRepo, nil, new(MockLedgerRepository))
+	clientService := services.NewClientService(mockRepo, mockUow, models.DefaultAccountNumberFormat)
 
 	client := &models.Client{Name: "", AccountNum: "123456"}
 
@@ -46,11 +51,12 @@ func TestCreateClient_MissingFields(t *testing.T) {
 
 func TestGetClients_Success(t *testing.T) {
 	mockRepo := new(MockClientRepository)
-	clientService := services.NewClientService(mockRepo)
+	mockUow := NewMockUnitOfWork(mockRepo, nil, new(MockLedgerRepository))
+	clientService := services.NewClientService(mockRepo, mockUow, models.DefaultAccountNumberFormat)
 
 	clients := []models.Client{
-		{Name: "John Doe", AccountNum: "123456", Balance: 100.0},
-		{Name: "Jane Doe", AccountNum: "654321", Balance: 200.0},
+		{Name: "John Doe", AccountNum: "123456", Balance: models.BRL(10000)},
+		{Name: "Jane Doe", AccountNum: "654321", Balance: models.BRL(20000)},
 	}
 
 	mockRepo.On("GetClients").Return(clients, nil)
@@ -64,9 +70,10 @@ func TestGetClients_Success(t *testing.T) {
 
 func TestGetClientByAccountNum_Success(t *testing.T) {
 	mockRepo := new(MockClientRepository)
-	clientService := services.NewClientService(mockRepo)
+	mockUow := NewMockUnitOfWork(mockRepo, nil, new(MockLedgerRepository))
+	clientService := services.NewClientService(mockRepo, mockUow, models.DefaultAccountNumberFormat)
 
-	client := &models.Client{Name: "John Doe", AccountNum: "123456", Balance: 100.0}
+	client := &models.Client{Name: "John Doe", AccountNum: "123456", Balance: models.BRL(10000)}
 
 	mockRepo.On("GetClientByAccountNum", "123456").Return(client, nil)
 
@@ -79,7 +86,8 @@ func TestGetClientByAccountNum_Success(t *testing.T) {
 
 func TestGetClientByAccountNum_NotFound(t *testing.T) {
 	mockRepo := new(MockClientRepository)
-	clientService := services.NewClientService(mockRepo)
+	mockUow := NewMockUnitOfWork(mockRepo, nil, new(MockLedgerRepository))
+	clientService := services.NewClientService(mockRepo, mockUow, models.DefaultAccountNumberFormat)
 
 	mockRepo.On("GetClientByAccountNum", "999999").Return((*models.Client)(nil), errors.New("client not found"))
 
@@ -90,3 +98,152 @@ func TestGetClientByAccountNum_NotFound(t *testing.T) {
 	assert.EqualError(t, err, "client not found")
 	mockRepo.AssertExpectations(t)
 }
+
+func TestCreateClient_RejectsInitialBalance(t *testing.T) {
+	mockRepo := new(MockClientRepository)
+	mockUow := NewMockUnitOfWork(mockRepo, nil, new(MockLedgerRepository))
+	clientService := services.NewClientService(mockRepo, mockUow, models.DefaultAccountNumberFormat)
+
+	client := &models.Client{Name: "John Doe", AccountNum: "123456", Balance: models.BRL(100000)}
+
+	err := clientService.CreateClient(client)
+
+	assert.ErrorIs(t, err, services.ErrInitialBalanceNotAllowed)
+	mockRepo.AssertNotCalled(t, "CreateClient", mock.Anything)
+}
+
+func TestCreateClient_KeepsCurrencyOfZeroBalance(t *testing.T) {
+	mockRepo := new(MockClientRepository)
+	mockUow := NewMockUnitOfWork(mockRepo, nil, new(MockLedgerRepository))
+	clientService := services.NewClientService(mockRepo, mockUow, models.DefaultAccountNumberFormat)
+
+	client := &models.Client{Name: "John Doe", AccountNum: "123456", Balance: models.NewMoney(0, "USD")}
+	mockRepo.On("CreateClient", client).Return(nil)
+
+	err := clientService.CreateClient(client)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.NewMoney(0, "USD"), client.Balance)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateClient_AccountType(t *testing.T) {
+	mockRepo := new(MockClientRepository)
+	mockUow := NewMockUnitOfWork(mockRepo, nil, new(MockLedgerRepository))
+	clientService := services.NewClientService(mockRepo, mockUow, models.DefaultAccountNumberFormat)
+
+	client := &models.Client{Name: "John Doe", AccountNum: "123456"}
+	mockRepo.On("CreateClient", client).Return(nil)
+
+	err := clientService.CreateClient(client)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.AccountTypeChecking, client.AccountType)
+
+	invalid := &models.Client{Name: "Jane Doe", AccountNum: "654321", AccountType: "investment"}
+	err = clientService.CreateClient(invalid)
+
+	assert.ErrorIs(t, err, models.ErrInvalidAccountType)
+	mockRepo.AssertNotCalled(t, "CreateClient", invalid)
+}
+
+func TestUpdateClient_Success(t *testing.T) {
+	mockRepo := new(MockClientRepository)
+	clientService := services.NewClientService(mockRepo, NewMockUnitOfWork(mockRepo, nil, new(MockLedgerRepository)), models.DefaultAccountNumberFormat)
+
+	client := &models.Client{Name: "John Doe", AccountNum: "123456", Version: 3}
+	mockRepo.On("GetClientByAccountNum", "123456").Return(client, nil)
+	mockRepo.On("UpdateClient", mock.MatchedBy(func(c *models.Client) bool {
+		return c.Name == "John Smith" && c.Version == 3
+	})).Return(nil)
+
+	result, err := clientService.UpdateClient("123456", "John Smith", 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "John Smith", result.Name)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateClient_StaleVersion(t *testing.T) {
+	mockRepo := new(MockClientRepository)
+	clientService := services.NewClientService(mockRepo, NewMockUnitOfWork(mockRepo, nil, new(MockLedgerRepository)), models.DefaultAccountNumberFormat)
+
+	client := &models.Client{Name: "John Doe", AccountNum: "123456", Version: 4}
+	mockRepo.On("GetClientByAccountNum", "123456").Return(client, nil)
+
+	result, err := clientService.UpdateClient("123456", "John Smith", 3)
+
+	assert.ErrorIs(t, err, repositories.ErrVersionConflict)
+	assert.Nil(t, result)
+	mockRepo.AssertNotCalled(t, "UpdateClient", mock.Anything)
+}
+
+func TestCreateClient_CreatesCustomerWithTheAccount(t *testing.T) {
+	mockRepo := new(MockClientRepository)
+	mockCustomers := new(MockCustomerRepository)
+	mockUow := NewMockUnitOfWork(mockRepo, nil, new(MockLedgerRepository))
+	mockUow.Customers = mockCustomers
+	clientService := services.NewClientService(mockRepo, mockUow, models.DefaultAccountNumberFormat)
+
+	mockCustomers.On("CreateCustomer", mock.MatchedBy(func(c *models.Customer) bool { return c.Name == "John Doe" })).
+		Run(func(args mock.Arguments) { args.Get(0).(*models.Customer).ID = 7 }).Return(nil)
+	mockRepo.On("CreateClient", mock.MatchedBy(func(c *models.Client) bool { return c.CustomerID == 7 })).Return(nil)
+
+	client := &models.Client{Name: " John Doe", AccountNum: "123456", CustomerID: 99}
+	err := clientService.CreateClient(client)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 7, client.CustomerID)
+	assert.Equal(t, "John Doe", client.Name)
+	mockCustomers.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateClient_RenamesCustomer(t *testing.T) {
+	mockRepo := new(MockClientRepository)
+	mockCustomers := new(MockCustomerRepository)
+	mockUow := NewMockUnitOfWork(mockRepo, nil, new(MockLedgerRepository))
+	mockUow.Customers = mockCustomers
+	clientService := services.NewClientService(mockRepo, mockUow, models.DefaultAccountNumberFormat)
+
+	client := &models.Client{CustomerID: 7, Name: "John Doe", AccountNum: "123456", Version: 3}
+	mockRepo.On("GetClientByAccountNum", "123456").Return(client, nil)
+	mockRepo.On("UpdateClient", client).Return(nil)
+	mockCustomers.On("UpdateCustomerName", 7, "John Smith").Return(errors.New("database is locked"))
+
+	result, err := clientService.UpdateClient("123456", "John Smith", 0)
+
+	assert.EqualError(t, err, "database is locked")
+	assert.Nil(t, result)
+	assert.True(t, mockUow.RolledBack)
+	mockCustomers.AssertExpectations(t)
+}
+
+func TestCreateClient_AllocatesAccountNumber(t *testing.T) {
+	mockRepo := new(MockClientRepository)
+	mockUow := NewMockUnitOfWork(mockRepo, nil, new(MockLedgerRepository))
+	clientService := services.NewClientService(mockRepo, mockUow, models.DefaultAccountNumberFormat)
+
+	// O primeiro número já existe (informado antes da geração) e é pulado
+	mockRepo.On("GetClientByAccountNum", "0001-00000001-7").Return(&models.Client{AccountNum: "0001-00000001-7"}, nil)
+	mockRepo.On("GetClientByAccountNum", "0001-00000002-5").Return((*models.Client)(nil), repositories.ErrClientNotFound)
+	mockRepo.On("CreateClient", mock.Anything).Return(nil)
+
+	client := &models.Client{Name: "John Doe"}
+	err := clientService.CreateClient(client)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "0001-00000002-5", client.AccountNum)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateClient_RejectsWrongCheckDigit(t *testing.T) {
+	mockRepo := new(MockClientRepository)
+	mockUow := NewMockUnitOfWork(mockRepo, nil, new(MockLedgerRepository))
+	clientService := services.NewClientService(mockRepo, mockUow, models.DefaultAccountNumberFormat)
+
+	err := clientService.CreateClient(&models.Client{Name: "John Doe", AccountNum: "0001-00000002-6"})
+
+	assert.ErrorIs(t, err, models.ErrInvalidCheckDigit)
+	mockRepo.AssertNotCalled(t, "CreateClient", mock.Anything)
+}
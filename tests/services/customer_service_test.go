@@ -0,0 +1,84 @@
+// src/services/customer_service_test.go
+package test
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"banking/src/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newCustomerTestService() (*services.CustomerService, *MockCustomerRepository, *MockClientRepository) {
+	mockCustomers := new(MockCustomerRepository)
+	mockClients := new(MockClientRepository)
+	mockUow := NewMockUnitOfWork(mockClients, nil, new(MockLedgerRepository))
+	mockUow.Customers = mockCustomers
+	return services.NewCustomerService(mockCustomers, mockClients, mockUow, models.DefaultAccountNumberFormat), mockCustomers, mockClients
+}
+
+func TestCreateCustomer_RequiresName(t *testing.T) {
+	service, mockCustomers, _ := newCustomerTestService()
+
+	_, err := service.CreateCustomer("  ")
+
+	assert.ErrorIs(t, err, models.ErrCustomerNameRequired)
+	mockCustomers.AssertNotCalled(t, "CreateCustomer", mock.Anything)
+}
+
+func TestGetAccounts_CustomerNotFound(t *testing.T) {
+	service, mockCustomers, mockClients := newCustomerTestService()
+	mockCustomers.On("GetCustomerByID", 7).Return(nil, repositories.ErrCustomerNotFound)
+
+	accounts, err := service.GetAccounts(7)
+
+	assert.ErrorIs(t, err, repositories.ErrCustomerNotFound)
+	assert.Nil(t, accounts)
+	mockClients.AssertNotCalled(t, "GetClientsByCustomer", mock.Anything)
+}
+
+func TestOpenAccount_UsesCustomerName(t *testing.T) {
+	service, mockCustomers, mockClients := newCustomerTestService()
+	mockCustomers.On("GetCustomerByID", 7).Return(&models.Customer{ID: 7, Name: "Maria Silva"}, nil)
+	mockClients.On("CreateClient", mock.Anything).Return(nil)
+
+	account := &models.Account{AccountNum: "222222", AccountType: models.AccountTypeSavings, Name: "Outro Nome"}
+	err := service.OpenAccount(7, account)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 7, account.CustomerID)
+	assert.Equal(t, "Maria Silva", account.Name)
+	assert.Equal(t, models.AccountStatusActive, account.Status)
+	assert.Equal(t, models.DefaultCurrency, account.Balance.Currency)
+	mockClients.AssertExpectations(t)
+}
+
+func TestOpenAccount_NormalizesCurrency(t *testing.T) {
+	service, mockCustomers, mockClients := newCustomerTestService()
+	mockCustomers.On("GetCustomerByID", 7).Return(&models.Customer{ID: 7, Name: "Maria Silva"}, nil)
+	mockClients.On("CreateClient", mock.Anything).Return(nil)
+
+	account := &models.Account{AccountNum: "222222", Balance: models.Money{Currency: " usd "}}
+	assert.NoError(t, service.OpenAccount(7, account))
+	assert.Equal(t, "USD", account.Balance.Currency)
+}
+
+func TestOpenAccount_ValidatesAccount(t *testing.T) {
+	service, mockCustomers, mockClients := newCustomerTestService()
+
+	err := service.OpenAccount(7, &models.Account{AccountNum: "222222", Balance: models.BRL(100)})
+	assert.ErrorIs(t, err, services.ErrInitialBalanceNotAllowed)
+
+	err = service.OpenAccount(7, &models.Account{AccountNum: "222222", AccountType: "investment"})
+	assert.ErrorIs(t, err, models.ErrInvalidAccountType)
+
+	err = service.OpenAccount(7, &models.Account{AccountNum: "222222", Balance: models.Money{Currency: "real"}})
+	assert.ErrorIs(t, err, models.ErrInvalidCurrency)
+
+	mockCustomers.On("GetCustomerByID", 8).Return(nil, repositories.ErrCustomerNotFound)
+	err = service.OpenAccount(8, &models.Account{AccountNum: "222222"})
+	assert.ErrorIs(t, err, repositories.ErrCustomerNotFound)
+	mockClients.AssertNotCalled(t, "CreateClient", mock.Anything)
+}
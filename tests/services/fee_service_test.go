@@ -0,0 +1,168 @@
+// src/services/fee_service_test.go
+package test
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"banking/src/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTransferFunds_ChargesFeeToRevenueAccount(t *testing.T) {
+	mockClientRepo := new(MockClientRepository)
+	mockTransferRepo := new(MockTransferRepository)
+	mockLedgerRepo := new(MockLedgerRepository)
+	mockFeeRepo := new(MockFeeRepository)
+	mockUow := NewMockUnitOfWork(mockClientRepo, mockTransferRepo, mockLedgerRepo)
+	mockUow.Fees = mockFeeRepo
+	transferService := services.NewTransferService(mockClientRepo, mockTransferRepo, mockLedgerRepo, mockUow, services.NewAccountLocks())
+
+	fromClient := &models.Client{AccountNum: "123456", AccountType: models.AccountTypeChecking, Balance: models.BRL(500000), Status: models.AccountStatusActive}
+	toClient := &models.Client{AccountNum: "654321", Balance: models.BRL(100000), Status: models.AccountStatusActive}
+	amount := models.BRL(100000)
+	fee := models.BRL(600) // R$ 1,00 fixo mais 0,5% de R$ 1.000,00
+
+	mockFeeRepo.On("GetSchedule", models.AccountTypeChecking).Return(&models.FeeSchedule{
+		Currency: "BRL", Flat: models.BRL(100), PercentBps: 50, FreeTransfers: 2,
+	}, nil)
+	mockFeeRepo.On("CountTransfersSince", "123456", mock.Anything).Return(2, nil)
+	mockClientRepo.On("GetClientByAccountNum", "123456").Return(fromClient, nil)
+	mockClientRepo.On("GetClientByAccountNum", "654321").Return(toClient, nil)
+	mockClientRepo.On("DebitClientBalance", "123456", amount).Return(models.BRL(400000), nil)
+	mockClientRepo.On("CreditClientBalance", "654321", amount).Return(models.BRL(200000), nil)
+	mockClientRepo.On("DebitClientBalance", "123456", fee).Return(models.BRL(399400), nil)
+	mockTransferRepo.On("CreateTransfer", mock.MatchedBy(func(transfer *models.Transfer) bool {
+		return transfer.Type == models.TransferTypeTransfer
+	})).Run(func(args mock.Arguments) {
+		args.Get(0).(*models.Transfer).ID = 7
+	}).Return(nil)
+	mockTransferRepo.On("CreateTransfer", mock.MatchedBy(func(transfer *models.Transfer) bool {
+		return transfer.Type == models.TransferTypeFee && transfer.ToAccountNum == models.FeeRevenueAccountNum &&
+			transfer.Amount == fee && transfer.FeeOf != nil && *transfer.FeeOf == 7
+	})).Return(nil)
+	mockLedgerRepo.On("CreateEntry", mock.MatchedBy(func(entry *models.JournalEntry) bool {
+		return entry.Description == models.EntryTransfer
+	})).Return(nil)
+	mockLedgerRepo.On("CreateEntry", mock.MatchedBy(func(entry *models.JournalEntry) bool {
+		return entry.Description == models.EntryFee && entry.Validate() == nil &&
+			entry.Postings[0] == models.Posting{AccountNum: "123456", Amount: fee.Neg()} &&
+			entry.Postings[1] == models.Posting{AccountNum: models.FeeRevenueAccountNum, Amount: fee}
+	})).Return(nil)
+	mockLedgerRepo.On("GetAccountBalance", "123456", "BRL").Return(models.BRL(400000), nil).Once()
+	mockLedgerRepo.On("GetAccountBalance", "654321", "BRL").Return(models.BRL(200000), nil)
+	mockLedgerRepo.On("GetAccountBalance", "123456", "BRL").Return(models.BRL(399400), nil).Once()
+
+	transfer, err := transferService.TransferFunds("123456", "654321", amount)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 7, transfer.ID)
+	assert.Equal(t, &fee, transfer.Fee)
+	assert.Equal(t, models.BRL(399400), fromClient.Balance)
+	assert.True(t, mockUow.Committed)
+	mockTransferRepo.AssertExpectations(t)
+	mockLedgerRepo.AssertExpectations(t)
+}
+
+func TestTransferFunds_FreeTransferHasNoFee(t *testing.T) {
+	mockClientRepo := new(MockClientRepository)
+	mockTransferRepo := new(MockTransferRepository)
+	mockLedgerRepo := new(MockLedgerRepository)
+	mockFeeRepo := new(MockFeeRepository)
+	mockUow := NewMockUnitOfWork(mockClientRepo, mockTransferRepo, mockLedgerRepo)
+	mockUow.Fees = mockFeeRepo
+	transferService := services.NewTransferService(mockClientRepo, mockTransferRepo, mockLedgerRepo, mockUow, services.NewAccountLocks())
+
+	amount := models.BRL(100000)
+	mockFeeRepo.On("GetSchedule", models.AccountTypeChecking).Return(&models.FeeSchedule{
+		Currency: "BRL", Flat: models.BRL(100), FreeTransfers: 2,
+	}, nil)
+	mockFeeRepo.On("CountTransfersSince", "123456", mock.Anything).Return(1, nil)
+	mockClientRepo.On("GetClientByAccountNum", "123456").Return(&models.Client{AccountNum: "123456", AccountType: models.AccountTypeChecking, Balance: models.BRL(500000), Status: models.AccountStatusActive}, nil)
+	mockClientRepo.On("GetClientByAccountNum", "654321").Return(&models.Client{AccountNum: "654321", Balance: models.BRL(0), Status: models.AccountStatusActive}, nil)
+	mockClientRepo.On("DebitClientBalance", "123456", amount).Return(models.BRL(400000), nil)
+	mockClientRepo.On("CreditClientBalance", "654321", amount).Return(models.BRL(100000), nil)
+	mockTransferRepo.On("CreateTransfer", mock.AnythingOfType("*models.Transfer")).Return(nil)
+	mockLedgerRepo.On("CreateEntry", mock.AnythingOfType("*models.JournalEntry")).Return(nil)
+	mockLedgerRepo.On("GetAccountBalance", "123456", "BRL").Return(models.BRL(400000), nil)
+	mockLedgerRepo.On("GetAccountBalance", "654321", "BRL").Return(models.BRL(100000), nil)
+
+	transfer, err := transferService.TransferFunds("123456", "654321", amount)
+
+	assert.NoError(t, err)
+	assert.True(t, transfer.Fee.IsZero())
+	mockTransferRepo.AssertNumberOfCalls(t, "CreateTransfer", 1)
+	mockClientRepo.AssertNumberOfCalls(t, "DebitClientBalance", 1)
+}
+
+func TestTransferFunds_RollsBackWhenFeeIsNotCovered(t *testing.T) {
+	mockClientRepo := new(MockClientRepository)
+	mockTransferRepo := new(MockTransferRepository)
+	mockLedgerRepo := new(MockLedgerRepository)
+	mockFeeRepo := new(MockFeeRepository)
+	mockUow := NewMockUnitOfWork(mockClientRepo, mockTransferRepo, mockLedgerRepo)
+	mockUow.Fees = mockFeeRepo
+	transferService := services.NewTransferService(mockClientRepo, mockTransferRepo, mockLedgerRepo, mockUow, services.NewAccountLocks())
+
+	amount := models.BRL(100000)
+	fee := models.BRL(100)
+	mockFeeRepo.On("GetSchedule", models.AccountTypeChecking).Return(&models.FeeSchedule{Currency: "BRL", Flat: fee}, nil)
+	mockClientRepo.On("GetClientByAccountNum", "123456").Return(&models.Client{AccountNum: "123456", AccountType: models.AccountTypeChecking, Balance: amount, Status: models.AccountStatusActive}, nil)
+	mockClientRepo.On("GetClientByAccountNum", "654321").Return(&models.Client{AccountNum: "654321", Balance: models.BRL(0), Status: models.AccountStatusActive}, nil)
+	mockClientRepo.On("DebitClientBalance", "123456", amount).Return(models.BRL(0), nil)
+	mockClientRepo.On("CreditClientBalance", "654321", amount).Return(amount, nil)
+	mockClientRepo.On("DebitClientBalance", "123456", fee).Return(models.Money{}, repositories.ErrInsufficientBalance)
+	mockTransferRepo.On("CreateTransfer", mock.AnythingOfType("*models.Transfer")).Return(nil)
+	mockLedgerRepo.On("CreateEntry", mock.AnythingOfType("*models.JournalEntry")).Return(nil)
+	mockLedgerRepo.On("GetAccountBalance", "123456", "BRL").Return(models.BRL(0), nil)
+	mockLedgerRepo.On("GetAccountBalance", "654321", "BRL").Return(amount, nil)
+
+	transfer, err := transferService.TransferFunds("123456", "654321", amount)
+
+	assert.Nil(t, transfer)
+	assert.Equal(t, models.FailureInsufficientBalance, services.FailureReason(err))
+	assert.False(t, mockUow.Committed)
+}
+
+func TestSetSchedule_NormalizesAndSaves(t *testing.T) {
+	mockFeeRepo := new(MockFeeRepository)
+	mockUow := NewMockUnitOfWork(new(MockClientRepository), new(MockTransferRepository), new(MockLedgerRepository))
+	mockUow.Fees = mockFeeRepo
+	feeService := services.NewFeeService(mockFeeRepo, mockUow)
+
+	mockFeeRepo.On("SaveSchedule", mock.AnythingOfType("*models.FeeSchedule")).Return(nil)
+
+	schedule, err := feeService.SetSchedule(models.FeeSchedule{
+		AccountType: models.AccountTypeSavings,
+		Flat:        models.Money{Cents: 100},
+		Tiers: []models.FeeTier{
+			{MinAmount: models.Money{Cents: 50000}},
+			{MinAmount: models.Money{Cents: 10000}, Flat: models.Money{Cents: 50}},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "BRL", schedule.Currency)
+	assert.Equal(t, models.BRL(10000), schedule.Tiers[0].MinAmount)
+	assert.Equal(t, models.BRL(50000), schedule.Tiers[1].MinAmount)
+	assert.True(t, mockUow.Committed)
+	mockFeeRepo.AssertExpectations(t)
+}
+
+func TestSetSchedule_RejectsInvalidSchedules(t *testing.T) {
+	mockFeeRepo := new(MockFeeRepository)
+	mockUow := NewMockUnitOfWork(new(MockClientRepository), new(MockTransferRepository), new(MockLedgerRepository))
+	feeService := services.NewFeeService(mockFeeRepo, mockUow)
+
+	_, err := feeService.SetSchedule(models.FeeSchedule{AccountType: "investment"})
+	assert.ErrorIs(t, err, services.ErrInvalidFeeSchedule)
+	assert.ErrorIs(t, err, models.ErrInvalidAccountType)
+
+	_, err = feeService.SetSchedule(models.FeeSchedule{AccountType: models.AccountTypeChecking, PercentBps: -10})
+	assert.ErrorIs(t, err, services.ErrInvalidFeeSchedule)
+	assert.ErrorIs(t, err, models.ErrInvalidFee)
+
+	mockFeeRepo.AssertNotCalled(t, "SaveSchedule", mock.Anything)
+}
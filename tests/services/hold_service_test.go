@@ -0,0 +1,146 @@
+// src/services/hold_service_test.go
+package test
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"banking/src/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newHoldTestService() (*services.HoldService, *MockClientRepository, *MockTransferRepository, *MockLedgerRepository, *MockUnitOfWork) {
+	mockClientRepo := new(MockClientRepository)
+	mockTransferRepo := new(MockTransferRepository)
+	mockLedgerRepo := new(MockLedgerRepository)
+	mockUow := NewMockUnitOfWork(mockClientRepo, mockTransferRepo, mockLedgerRepo)
+	mockUow.Holds = new(MockHoldRepository)
+	transferService := services.NewTransferService(mockClientRepo, mockTransferRepo, mockLedgerRepo, mockUow, services.NewAccountLocks())
+	return services.NewHoldService(mockClientRepo, mockUow.Holds, mockUow, transferService), mockClientRepo, mockTransferRepo, mockLedgerRepo, mockUow
+}
+
+func TestPlaceHold_Success(t *testing.T) {
+	holdService, mockClientRepo, _, _, mockUow := newHoldTestService()
+
+	mockClientRepo.On("GetClientByAccountNum", "123456").Return(&models.Client{AccountNum: "123456", Balance: models.BRL(10000), Status: models.AccountStatusActive}, nil)
+	mockClientRepo.On("GetClientByAccountNum", "654321").Return(&models.Client{AccountNum: "654321", Balance: models.BRL(0), Status: models.AccountStatusActive}, nil)
+	mockClientRepo.On("HoldClientBalance", "123456", models.BRL(6000)).Return(models.BRL(4000), nil)
+	mockUow.Holds.On("CreateHold", mock.MatchedBy(func(hold *models.Hold) bool {
+		return hold.AccountNum == "123456" && hold.ToAccountNum == "654321" && hold.ExpiresAt.After(time.Now().Add(models.DefaultHoldTTL-time.Minute))
+	})).Return(nil)
+
+	hold, err := holdService.PlaceHold("123456", "654321", models.BRL(6000), time.Time{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(6000), hold.Amount)
+	assert.True(t, mockUow.Committed)
+	mockUow.Holds.AssertExpectations(t)
+}
+
+func TestPlaceHold_InsufficientAvailableBalance(t *testing.T) {
+	holdService, mockClientRepo, _, _, mockUow := newHoldTestService()
+
+	mockClientRepo.On("GetClientByAccountNum", mock.Anything).Return(&models.Client{Balance: models.BRL(0), Status: models.AccountStatusActive}, nil)
+	mockClientRepo.On("HoldClientBalance", "123456", models.BRL(6000)).Return(models.Money{}, repositories.ErrInsufficientBalance)
+
+	_, err := holdService.PlaceHold("123456", "654321", models.BRL(6000), time.Time{})
+
+	assert.Equal(t, models.FailureInsufficientBalance, services.FailureReason(err))
+	assert.True(t, mockUow.RolledBack)
+	mockUow.Holds.AssertNotCalled(t, "CreateHold", mock.Anything)
+}
+
+func TestPlaceHold_ExpiresAtInThePast(t *testing.T) {
+	holdService, mockClientRepo, _, _, _ := newHoldTestService()
+
+	_, err := holdService.PlaceHold("123456", "654321", models.BRL(6000), time.Now().Add(-time.Minute))
+
+	assert.ErrorIs(t, err, services.ErrExpiresAtNotInFuture)
+	mockClientRepo.AssertNotCalled(t, "HoldClientBalance", mock.Anything, mock.Anything)
+}
+
+func activeTestHold() *models.Hold {
+	return &models.Hold{
+		ID:             7,
+		AccountNum:     "123456",
+		ToAccountNum:   "654321",
+		Amount:         models.BRL(6000),
+		CapturedAmount: models.BRL(0),
+		Status:         models.HoldStatusActive,
+		ExpiresAt:      time.Now().Add(time.Hour),
+	}
+}
+
+func TestCaptureHold_CapturesRemainingAmount(t *testing.T) {
+	holdService, mockClientRepo, mockTransferRepo, mockLedgerRepo, mockUow := newHoldTestService()
+
+	mockUow.Holds.On("GetHoldByID", 7).Return(activeTestHold(), nil)
+	mockClientRepo.On("GetClientByAccountNum", "123456").Return(&models.Client{AccountNum: "123456", Balance: models.BRL(10000), Status: models.AccountStatusActive}, nil)
+	mockClientRepo.On("GetClientByAccountNum", "654321").Return(&models.Client{AccountNum: "654321", Balance: models.BRL(0), Status: models.AccountStatusActive}, nil)
+	mockClientRepo.On("ReleaseClientHold", "123456", models.BRL(6000)).Return(models.BRL(10000), nil)
+	mockClientRepo.On("DebitClientBalance", "123456", models.BRL(6000)).Return(models.BRL(4000), nil)
+	mockClientRepo.On("CreditClientBalance", "654321", models.BRL(6000)).Return(models.BRL(6000), nil)
+	mockTransferRepo.On("CreateTransfer", mock.MatchedBy(func(transfer *models.Transfer) bool {
+		return transfer.Type == models.TransferTypeTransfer && transfer.Status == models.TransferStatusSuccess
+	})).Return(nil)
+	mockLedgerRepo.On("CreateEntry", mock.AnythingOfType("*models.JournalEntry")).Return(nil)
+	mockLedgerRepo.On("GetAccountBalance", "123456", "BRL").Return(models.BRL(4000), nil)
+	mockLedgerRepo.On("GetAccountBalance", "654321", "BRL").Return(models.BRL(6000), nil)
+	mockUow.Holds.On("UpdateHold", mock.MatchedBy(func(hold *models.Hold) bool {
+		return hold.Status == models.HoldStatusCaptured && hold.CapturedAmount == models.BRL(6000)
+	})).Return(nil)
+
+	hold, transfer, err := holdService.CaptureHold(7, models.Money{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.HoldStatusCaptured, hold.Status)
+	assert.Equal(t, models.BRL(6000), transfer.Amount)
+	assert.True(t, mockUow.Committed)
+	mockClientRepo.AssertExpectations(t)
+}
+
+func TestCaptureHold_ExceedsRemainingAmount(t *testing.T) {
+	holdService, mockClientRepo, _, _, mockUow := newHoldTestService()
+
+	mockUow.Holds.On("GetHoldByID", 7).Return(activeTestHold(), nil)
+
+	_, _, err := holdService.CaptureHold(7, models.BRL(6001))
+
+	assert.ErrorIs(t, err, models.ErrCaptureExceedsHold)
+	mockClientRepo.AssertNotCalled(t, "ReleaseClientHold", mock.Anything, mock.Anything)
+}
+
+func TestVoidHold_RejectsExpiredHold(t *testing.T) {
+	holdService, mockClientRepo, _, _, mockUow := newHoldTestService()
+
+	expired := activeTestHold()
+	expired.ExpiresAt = time.Now().Add(-time.Minute)
+	mockUow.Holds.On("GetHoldByID", 7).Return(expired, nil)
+
+	_, err := holdService.VoidHold(7)
+
+	assert.ErrorIs(t, err, repositories.ErrHoldNotActive)
+	mockClientRepo.AssertNotCalled(t, "ReleaseClientHold", mock.Anything, mock.Anything)
+}
+
+func TestExpireDue_ReleasesUncapturedAmount(t *testing.T) {
+	holdService, mockClientRepo, _, _, mockUow := newHoldTestService()
+
+	hold := activeTestHold()
+	hold.CapturedAmount = models.BRL(1000)
+	mockUow.Holds.On("GetExpiredHolds", mock.Anything, mock.Anything).Return([]models.Hold{*hold}, nil)
+	mockUow.Holds.On("GetHoldByID", 7).Return(hold, nil)
+	mockClientRepo.On("ReleaseClientHold", "123456", models.BRL(5000)).Return(models.BRL(9000), nil)
+	mockUow.Holds.On("UpdateHold", mock.MatchedBy(func(hold *models.Hold) bool {
+		return hold.Status == models.HoldStatusExpired
+	})).Return(nil)
+
+	err := holdService.ExpireDue(time.Now())
+
+	assert.NoError(t, err)
+	mockClientRepo.AssertExpectations(t)
+	mockUow.Holds.AssertExpectations(t)
+}
@@ -0,0 +1,107 @@
+// test/idempotency_service_test.go
+package test
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"banking/src/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReserve_NewKey(t *testing.T) {
+	mockRepo := new(MockIdempotencyRepository)
+	service := services.NewIdempotencyService(mockRepo, time.Hour)
+
+	mockRepo.On("DeleteExpired", mock.AnythingOfType("time.Time")).Return(nil)
+	mockRepo.On("Create", mock.MatchedBy(func(record *models.IdempotencyRecord) bool {
+		return record.Key == "key-1" && record.RequestHash == "hash" &&
+			record.ExpiresAt.Sub(record.CreatedAt) == time.Hour
+	})).Return(nil)
+
+	record, err := service.Reserve("key-1", "hash")
+
+	assert.NoError(t, err)
+	assert.Nil(t, record)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestReserve_ReplaysCompletedRequest(t *testing.T) {
+	mockRepo := new(MockIdempotencyRepository)
+	service := services.NewIdempotencyService(mockRepo, time.Hour)
+
+	stored := &models.IdempotencyRecord{Key: "key-1", RequestHash: "hash", StatusCode: 200, ResponseBody: []byte(`{}`)}
+	mockRepo.On("DeleteExpired", mock.AnythingOfType("time.Time")).Return(nil)
+	mockRepo.On("Create", mock.Anything).Return(repositories.ErrIdempotencyKeyExists)
+	mockRepo.On("GetByKey", "key-1").Return(stored, nil)
+
+	record, err := service.Reserve("key-1", "hash")
+
+	assert.NoError(t, err)
+	assert.Equal(t, stored, record)
+}
+
+func TestReserve_DifferentRequestConflicts(t *testing.T) {
+	mockRepo := new(MockIdempotencyRepository)
+	service := services.NewIdempotencyService(mockRepo, time.Hour)
+
+	stored := &models.IdempotencyRecord{Key: "key-1", RequestHash: "other", StatusCode: 200}
+	mockRepo.On("DeleteExpired", mock.AnythingOfType("time.Time")).Return(nil)
+	mockRepo.On("Create", mock.Anything).Return(repositories.ErrIdempotencyKeyExists)
+	mockRepo.On("GetByKey", "key-1").Return(stored, nil)
+
+	record, err := service.Reserve("key-1", "hash")
+
+	assert.ErrorIs(t, err, services.ErrIdempotencyKeyConflict)
+	assert.Nil(t, record)
+}
+
+func TestReserve_RequestInProgress(t *testing.T) {
+	mockRepo := new(MockIdempotencyRepository)
+	service := services.NewIdempotencyService(mockRepo, time.Hour)
+
+	stored := &models.IdempotencyRecord{Key: "key-1", RequestHash: "hash", CreatedAt: time.Now().UTC()}
+	mockRepo.On("DeleteExpired", mock.AnythingOfType("time.Time")).Return(nil)
+	mockRepo.On("Create", mock.Anything).Return(repositories.ErrIdempotencyKeyExists)
+	mockRepo.On("GetByKey", "key-1").Return(stored, nil)
+	mockRepo.On("Reclaim", mock.Anything, mock.AnythingOfType("time.Time")).Return(repositories.ErrIdempotencyKeyExists)
+
+	record, err := service.Reserve("key-1", "hash")
+
+	assert.ErrorIs(t, err, services.ErrIdempotencyKeyInProgress)
+	assert.Nil(t, record)
+}
+
+func TestReserve_ReclaimsStaleReservation(t *testing.T) {
+	mockRepo := new(MockIdempotencyRepository)
+	service := services.NewIdempotencyService(mockRepo, time.Hour)
+
+	stored := &models.IdempotencyRecord{Key: "key-1", RequestHash: "hash", CreatedAt: time.Now().UTC().Add(-time.Hour)}
+	mockRepo.On("DeleteExpired", mock.AnythingOfType("time.Time")).Return(nil)
+	mockRepo.On("Create", mock.Anything).Return(repositories.ErrIdempotencyKeyExists)
+	mockRepo.On("GetByKey", "key-1").Return(stored, nil)
+	mockRepo.On("Reclaim", mock.MatchedBy(func(record *models.IdempotencyRecord) bool {
+		return record.Key == "key-1" && record.RequestHash == "hash"
+	}), mock.MatchedBy(func(reservedBefore time.Time) bool {
+		return time.Since(reservedBefore) >= services.IdempotencyKeyLease
+	})).Return(nil)
+
+	record, err := service.Reserve("key-1", "hash")
+
+	assert.NoError(t, err)
+	assert.Nil(t, record)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRelease_DeletesReservation(t *testing.T) {
+	mockRepo := new(MockIdempotencyRepository)
+	service := services.NewIdempotencyService(mockRepo, time.Hour)
+
+	mockRepo.On("Release", "key-1").Return(nil)
+
+	assert.NoError(t, service.Release("key-1"))
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,178 @@
+// src/services/interest_service_test.go
+package test
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"banking/src/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newInterestTestService() (*services.InterestService, *MockClientRepository, *MockTransferRepository, *MockLedgerRepository, *MockUnitOfWork) {
+	mockClientRepo := new(MockClientRepository)
+	mockTransferRepo := new(MockTransferRepository)
+	mockLedgerRepo := new(MockLedgerRepository)
+	mockUow := NewMockUnitOfWork(mockClientRepo, mockTransferRepo, mockLedgerRepo)
+	mockUow.Interest = new(MockInterestRepository)
+	return services.NewInterestService(mockClientRepo, mockUow.Interest, mockUow), mockClientRepo, mockTransferRepo, mockLedgerRepo, mockUow
+}
+
+func TestSetRate_Validation(t *testing.T) {
+	interestService, _, _, _, mockUow := newInterestTestService()
+
+	_, err := interestService.SetRate("investment", 500)
+	assert.ErrorIs(t, err, services.ErrInvalidRate)
+	assert.ErrorIs(t, err, models.ErrInvalidAccountType)
+
+	_, err = interestService.SetRate(models.AccountTypeSavings, -1)
+	assert.ErrorIs(t, err, models.ErrInvalidInterestRate)
+
+	mockUow.Interest.AssertNotCalled(t, "SaveRate", mock.Anything)
+}
+
+func TestSetRate_Success(t *testing.T) {
+	interestService, _, _, _, mockUow := newInterestTestService()
+
+	mockUow.Interest.On("SaveRate", &models.InterestRate{AccountType: models.AccountTypeSavings, RateBps: 650}).Return(nil)
+
+	rate, err := interestService.SetRate(models.AccountTypeSavings, 650)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 650, rate.RateBps)
+	mockUow.Interest.AssertExpectations(t)
+}
+
+func TestAccrueInterest_RecordsEndOfDayBalances(t *testing.T) {
+	interestService, _, _, _, mockUow := newInterestTestService()
+
+	day := time.Date(2030, 3, 1, 15, 0, 0, 0, models.BusinessLocation)
+	now := time.Date(2030, 3, 2, 9, 0, 0, 0, models.BusinessLocation)
+	candidates := []models.InterestAccrual{
+		{AccountNum: "123456", Balance: models.BRL(100000), RateBps: 650},
+		{AccountNum: "654321", Balance: models.BRL(5000), RateBps: 650},
+	}
+	mockUow.Interest.On("GetAccrualCandidates", time.Date(2030, 3, 2, 0, 0, 0, 0, models.BusinessLocation)).Return(candidates, nil)
+	mockUow.Interest.On("CreateAccrual", mock.MatchedBy(func(accrual *models.InterestAccrual) bool {
+		return accrual.AccountNum == "123456" && accrual.AccrualDate == "2030-03-01"
+	})).Return(nil)
+	// O dia já foi acumulado para esta conta por outra execução
+	mockUow.Interest.On("CreateAccrual", mock.MatchedBy(func(accrual *models.InterestAccrual) bool {
+		return accrual.AccountNum == "654321"
+	})).Return(repositories.ErrAccrualExists)
+
+	accrued, err := interestService.AccrueInterest(day, now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, accrued)
+	mockUow.Interest.AssertExpectations(t)
+}
+
+func TestAccrueInterest_RejectsDayNotEnded(t *testing.T) {
+	interestService, _, _, _, mockUow := newInterestTestService()
+
+	day := time.Date(2030, 3, 1, 15, 0, 0, 0, models.BusinessLocation)
+	_, err := interestService.AccrueInterest(day, day.Add(time.Hour))
+
+	assert.ErrorIs(t, err, services.ErrPeriodNotEnded)
+	mockUow.Interest.AssertNotCalled(t, "GetAccrualCandidates", mock.Anything)
+}
+
+func TestAccrueDue_CatchesUpMissedDays(t *testing.T) {
+	interestService, _, _, _, mockUow := newInterestTestService()
+
+	// O último dia acumulado foi 30/01 e o executor só voltou em 02/03
+	now := time.Date(2030, 3, 2, 9, 0, 0, 0, models.BusinessLocation)
+	mockUow.Interest.On("GetLatestAccrualDate").Return("2030-01-30", nil)
+	mockUow.Interest.On("GetAccrualCandidates", mock.Anything).Return([]models.InterestAccrual{}, nil)
+	mockUow.Interest.On("GetAccruedInterest", "2030-01-31").Return(map[string]models.Money{}, nil).Once()
+	mockUow.Interest.On("GetAccruedInterest", "2030-02-28").Return(map[string]models.Money{}, nil).Once()
+
+	assert.NoError(t, interestService.AccrueDue(now))
+	// No mesmo dia, a rotina não faz nada de novo
+	assert.NoError(t, interestService.AccrueDue(now.Add(time.Hour)))
+
+	// De 31/01 a 01/03, e os dois meses terminados no período capitalizados
+	mockUow.Interest.AssertNumberOfCalls(t, "GetAccrualCandidates", 30)
+	mockUow.Interest.AssertCalled(t, "GetAccrualCandidates", time.Date(2030, 2, 1, 0, 0, 0, 0, models.BusinessLocation))
+	mockUow.Interest.AssertCalled(t, "GetAccrualCandidates", time.Date(2030, 3, 2, 0, 0, 0, 0, models.BusinessLocation))
+	mockUow.Interest.AssertExpectations(t)
+	mockUow.Interest.AssertNumberOfCalls(t, "GetLatestAccrualDate", 1)
+}
+
+func TestAccrueDue_StartsWithYesterday(t *testing.T) {
+	interestService, _, _, _, mockUow := newInterestTestService()
+
+	now := time.Date(2030, 3, 15, 9, 0, 0, 0, models.BusinessLocation)
+	mockUow.Interest.On("GetLatestAccrualDate").Return("", nil)
+	mockUow.Interest.On("GetAccrualCandidates", time.Date(2030, 3, 15, 0, 0, 0, 0, models.BusinessLocation)).Return([]models.InterestAccrual{}, nil).Once()
+	mockUow.Interest.On("GetAccruedInterest", "2030-02-28").Return(map[string]models.Money{}, nil).Once()
+
+	assert.NoError(t, interestService.AccrueDue(now))
+	mockUow.Interest.AssertExpectations(t)
+}
+
+func TestCapitalize_CreditsAccruedInterest(t *testing.T) {
+	interestService, mockClientRepo, mockTransferRepo, mockLedgerRepo, mockUow := newInterestTestService()
+
+	month := time.Date(2030, 3, 15, 0, 0, 0, 0, models.BusinessLocation)
+	now := time.Date(2030, 4, 1, 9, 0, 0, 0, models.BusinessLocation)
+	mockUow.Interest.On("GetAccruedInterest", "2030-03-31").Return(map[string]models.Money{"123456": models.BRL(552)}, nil)
+	mockClientRepo.On("CreditClientBalance", "123456", models.BRL(552)).Return(models.BRL(100552), nil)
+	mockTransferRepo.On("CreateTransfer", mock.MatchedBy(func(transfer *models.Transfer) bool {
+		return transfer.Type == models.TransferTypeInterest && transfer.FromAccountNum == models.InterestExpenseAccountNum &&
+			transfer.ToAccountNum == "123456" && transfer.Status == models.TransferStatusSuccess
+	})).Return(nil).Run(func(args mock.Arguments) {
+		args.Get(0).(*models.Transfer).ID = 7
+	})
+	mockLedgerRepo.On("CreateEntry", mock.MatchedBy(func(entry *models.JournalEntry) bool {
+		return entry.Description == models.EntryInterest
+	})).Return(nil)
+	mockLedgerRepo.On("GetAccountBalance", "123456", "BRL").Return(models.BRL(100552), nil)
+	mockUow.Interest.On("CreateCapitalization", mock.MatchedBy(func(capitalization *models.InterestCapitalization) bool {
+		return capitalization.Period == "2030-03" && capitalization.TransferID != nil && *capitalization.TransferID == 7
+	})).Return(nil).Run(func(args mock.Arguments) {
+		args.Get(0).(*models.InterestCapitalization).ID = 3
+	})
+	mockUow.Interest.On("MarkAccrualsCapitalized", "123456", "2030-03-31", 3).Return(nil)
+
+	capitalizations, err := interestService.Capitalize(month, now)
+
+	assert.NoError(t, err)
+	assert.Len(t, capitalizations, 1)
+	assert.True(t, mockUow.Committed)
+	mockClientRepo.AssertExpectations(t)
+	mockUow.Interest.AssertExpectations(t)
+}
+
+func TestCapitalize_SkipsCapitalizedPeriod(t *testing.T) {
+	interestService, mockClientRepo, mockTransferRepo, mockLedgerRepo, mockUow := newInterestTestService()
+
+	now := time.Date(2030, 4, 1, 9, 0, 0, 0, models.BusinessLocation)
+	mockUow.Interest.On("GetAccruedInterest", "2030-03-31").Return(map[string]models.Money{"123456": models.BRL(552)}, nil)
+	mockClientRepo.On("CreditClientBalance", "123456", models.BRL(552)).Return(models.BRL(100552), nil)
+	mockTransferRepo.On("CreateTransfer", mock.Anything).Return(nil)
+	mockLedgerRepo.On("CreateEntry", mock.Anything).Return(nil)
+	mockLedgerRepo.On("GetAccountBalance", "123456", "BRL").Return(models.BRL(100552), nil)
+	mockUow.Interest.On("CreateCapitalization", mock.Anything).Return(repositories.ErrPeriodCapitalized)
+
+	capitalizations, err := interestService.Capitalize(time.Date(2030, 3, 1, 0, 0, 0, 0, models.BusinessLocation), now)
+
+	// O crédito duplicado é desfeito e não interrompe as demais contas
+	assert.NoError(t, err)
+	assert.Empty(t, capitalizations)
+	assert.True(t, mockUow.RolledBack)
+	mockUow.Interest.AssertNotCalled(t, "MarkAccrualsCapitalized", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCapitalize_RejectsMonthNotEnded(t *testing.T) {
+	interestService, _, _, _, _ := newInterestTestService()
+
+	now := time.Date(2030, 3, 31, 23, 0, 0, 0, models.BusinessLocation)
+	_, err := interestService.Capitalize(now, now)
+
+	assert.ErrorIs(t, err, services.ErrPeriodNotEnded)
+}
@@ -0,0 +1,81 @@
+// src/services/limit_service_test.go
+package test
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"banking/src/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetLimits_InheritsDefaultLimits(t *testing.T) {
+	mockClientRepo := new(MockClientRepository)
+	mockLimitRepo := new(MockLimitRepository)
+	limitService := services.NewLimitService(mockClientRepo, mockLimitRepo)
+
+	defaults := &models.TransferLimits{AccountNum: models.DefaultLimitsKey, PerTransfer: models.BRL(1000000)}
+	usage := models.LimitUsage{Daily: models.BRL(5000), Monthly: models.BRL(5000), DailyCount: 1, NightTotal: models.BRL(0)}
+	mockClientRepo.On("GetClientByAccountNum", "123456").Return(&models.Client{AccountNum: "123456"}, nil)
+	mockLimitRepo.On("GetLimits", "123456").Return(nil, repositories.ErrLimitsNotFound)
+	mockLimitRepo.On("GetLimits", models.DefaultLimitsKey).Return(defaults, nil)
+	mockLimitRepo.On("GetUsage", "123456", "BRL", mock.Anything).Return(usage, nil)
+
+	status, err := limitService.GetLimits("123456")
+
+	assert.NoError(t, err)
+	assert.True(t, status.Inherited)
+	assert.Equal(t, *defaults, status.Limits)
+	assert.Equal(t, &usage, status.Usage)
+}
+
+func TestGetLimits_UnknownAccount(t *testing.T) {
+	mockClientRepo := new(MockClientRepository)
+	mockLimitRepo := new(MockLimitRepository)
+	limitService := services.NewLimitService(mockClientRepo, mockLimitRepo)
+
+	mockClientRepo.On("GetClientByAccountNum", "999999").Return((*models.Client)(nil), repositories.ErrClientNotFound)
+
+	_, err := limitService.GetLimits("999999")
+
+	assert.ErrorIs(t, err, repositories.ErrClientNotFound)
+	mockLimitRepo.AssertNotCalled(t, "GetLimits", mock.Anything)
+}
+
+func TestSetLimits_FillsMissingCurrencies(t *testing.T) {
+	mockClientRepo := new(MockClientRepository)
+	mockLimitRepo := new(MockLimitRepository)
+	limitService := services.NewLimitService(mockClientRepo, mockLimitRepo)
+
+	mockClientRepo.On("GetClientByAccountNum", "123456").Return(&models.Client{AccountNum: "123456"}, nil)
+	mockLimitRepo.On("SaveLimits", mock.Anything).Return(nil)
+
+	limits, err := limitService.SetLimits(models.TransferLimits{AccountNum: "123456", Daily: models.NewMoney(50000, "USD")})
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.NewMoney(0, "USD"), limits.PerTransfer)
+	assert.Equal(t, models.NewMoney(0, "USD"), limits.NightTotal)
+	mockLimitRepo.AssertExpectations(t)
+}
+
+func TestSetLimits_RejectsNegativeLimits(t *testing.T) {
+	mockClientRepo := new(MockClientRepository)
+	mockLimitRepo := new(MockLimitRepository)
+	limitService := services.NewLimitService(mockClientRepo, mockLimitRepo)
+
+	_, err := limitService.SetLimits(models.TransferLimits{AccountNum: models.DefaultLimitsKey, PerTransfer: models.BRL(-1)})
+
+	assert.ErrorIs(t, err, services.ErrInvalidLimits)
+	assert.ErrorIs(t, err, models.ErrNegativeLimit)
+	mockLimitRepo.AssertNotCalled(t, "SaveLimits", mock.Anything)
+}
+
+func TestDeleteLimits_DefaultLimitsCannotBeRemoved(t *testing.T) {
+	limitService := services.NewLimitService(new(MockClientRepository), new(MockLimitRepository))
+
+	err := limitService.DeleteLimits(models.DefaultLimitsKey)
+
+	assert.ErrorIs(t, err, services.ErrDefaultLimitsRequired)
+}
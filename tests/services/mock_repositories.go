@@ -3,6 +3,8 @@ package test
 
 import (
 	"banking/src/models"
+	"banking/src/repositories"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -17,11 +19,57 @@ func (m *MockClientRepository) GetClientByAccountNum(accountNum string) (*models
 	return args.Get(0).(*models.Client), args.Error(1)
 }
 
+func (m *MockClientRepository) UpdateClient(client *models.Client) error {
+	args := m.Called(client)
+	return args.Error(0)
+}
+
 func (m *MockClientRepository) UpdateClientBalance(client *models.Client) error {
 	args := m.Called(client)
 	return args.Error(0)
 }
 
+func (m *MockClientRepository) DebitClientBalance(accountNum string, amount models.Money) (models.Money, error) {
+	args := m.Called(accountNum, amount)
+	return args.Get(0).(models.Money), args.Error(1)
+}
+
+func (m *MockClientRepository) CreditClientBalance(accountNum string, amount models.Money) (models.Money, error) {
+	args := m.Called(accountNum, amount)
+	return args.Get(0).(models.Money), args.Error(1)
+}
+
+func (m *MockClientRepository) ChargeClientBalance(accountNum string, amount models.Money) (models.Money, error) {
+	args := m.Called(accountNum, amount)
+	return args.Get(0).(models.Money), args.Error(1)
+}
+
+func (m *MockClientRepository) UpdateOverdraft(accountNum string, limit models.Money, rateBps int) (*models.Client, error) {
+	args := m.Called(accountNum, limit, rateBps)
+	if client, ok := args.Get(0).(*models.Client); ok {
+		return client, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockClientRepository) HoldClientBalance(accountNum string, amount models.Money) (models.Money, error) {
+	args := m.Called(accountNum, amount)
+	return args.Get(0).(models.Money), args.Error(1)
+}
+
+func (m *MockClientRepository) UpdateClientStatus(accountNum, status string) (*models.Client, error) {
+	args := m.Called(accountNum, status)
+	if client, ok := args.Get(0).(*models.Client); ok {
+		return client, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockClientRepository) ReleaseClientHold(accountNum string, amount models.Money) (models.Money, error) {
+	args := m.Called(accountNum, amount)
+	return args.Get(0).(models.Money), args.Error(1)
+}
+
 func (m *MockClientRepository) CreateClient(client *models.Client) error {
 	args := m.Called(client)
 	return args.Error(0)
@@ -32,6 +80,19 @@ func (m *MockClientRepository) GetClients() ([]models.Client, error) {
 	return args.Get(0).([]models.Client), args.Error(1)
 }
 
+func (m *MockClientRepository) GetClientsByCustomer(customerID int) ([]models.Client, error) {
+	args := m.Called(customerID)
+	if clients, ok := args.Get(0).([]models.Client); ok {
+		return clients, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockClientRepository) GetTotalBalance(currency string) (models.Money, error) {
+	args := m.Called(currency)
+	return args.Get(0).(models.Money), args.Error(1)
+}
+
 // Definindo MockTransferRepository uma vez neste arquivo
 type MockTransferRepository struct {
 	mock.Mock
@@ -42,7 +103,582 @@ func (m *MockTransferRepository) CreateTransfer(transfer *models.Transfer) error
 	return args.Error(0)
 }
 
-func (m *MockTransferRepository) GetTransfersByAccountNum(accountNum string) ([]models.Transfer, error) {
+func (m *MockTransferRepository) GetTransferByID(id int) (*models.Transfer, error) {
+	args := m.Called(id)
+	if transfer, ok := args.Get(0).(*models.Transfer); ok {
+		return transfer, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTransferRepository) GetReversedAmount(transferID int, currency string) (models.Money, error) {
+	args := m.Called(transferID, currency)
+	return args.Get(0).(models.Money), args.Error(1)
+}
+
+func (m *MockTransferRepository) HasFunding(accountNum string) (bool, error) {
+	args := m.Called(accountNum)
+	return args.Bool(0), args.Error(1)
+}
+
+// Definindo MockLedgerRepository uma vez neste arquivo
+type MockLedgerRepository struct {
+	mock.Mock
+}
+
+func (m *MockLedgerRepository) CreateEntry(entry *models.JournalEntry) error {
+	args := m.Called(entry)
+	return args.Error(0)
+}
+
+func (m *MockLedgerRepository) GetAccountBalance(accountNum, currency string) (models.Money, error) {
+	args := m.Called(accountNum, currency)
+	return args.Get(0).(models.Money), args.Error(1)
+}
+
+func (m *MockLedgerRepository) GetAccountBalances(currency string) (map[string]models.Money, error) {
+	args := m.Called(currency)
+	return args.Get(0).(map[string]models.Money), args.Error(1)
+}
+
+func (m *MockLedgerRepository) GetTransfersByAccountNum(accountNum string) ([]models.Transfer, error) {
 	args := m.Called(accountNum)
 	return args.Get(0).([]models.Transfer), args.Error(1)
 }
+
+func (m *MockLedgerRepository) GetBalanceAt(accountNum, currency string, at time.Time) (models.Money, error) {
+	args := m.Called(accountNum, currency, at)
+	return args.Get(0).(models.Money), args.Error(1)
+}
+
+func (m *MockLedgerRepository) GetStatementLines(accountNum, currency string, from, to time.Time) ([]models.StatementLine, error) {
+	args := m.Called(accountNum, currency, from, to)
+	return args.Get(0).([]models.StatementLine), args.Error(1)
+}
+
+// MockUnitOfWork executa a função recebida com os repositórios mockados e
+// registra se a unidade de trabalho foi confirmada ou desfeita. Limits começa
+// sem nenhum limite configurado e Fees sem nenhuma tarifa; testes de limites e
+// de tarifas os substituem por um MockLimitRepository e um MockFeeRepository. Overdraft, Notifications, Holds e Interest são
+// preenchidos pelos testes que os usam. Customers começa aceitando qualquer
+// cliente novo; testes de clientes o substituem por um MockCustomerRepository.
+// AccountNumbers gera os números de conta em sequência a partir de 1.
+type MockUnitOfWork struct {
+	Clients        *MockClientRepository
+	Transfers      *MockTransferRepository
+	Ledger         *MockLedgerRepository
+	Limits         repositories.LimitRepository
+	Overdraft      *MockOverdraftRepository
+	Notifications  *MockNotificationRepository
+	Holds          *MockHoldRepository
+	Interest       *MockInterestRepository
+	Fees           repositories.FeeRepository
+	Statuses       *MockAccountStatusRepository
+	Customers      repositories.CustomerRepository
+	AccountNumbers repositories.AccountNumberRepository
+	Scheduled      *MockScheduledTransferRepository
+	StandingOrders *MockStandingOrderRepository
+	Committed      bool
+	RolledBack     bool
+}
+
+func NewMockUnitOfWork(clients *MockClientRepository, transfers *MockTransferRepository, ledger *MockLedgerRepository) *MockUnitOfWork {
+	return &MockUnitOfWork{Clients: clients, Transfers: transfers, Ledger: ledger, Limits: noLimits{}, Fees: noFees{}, Customers: &anyCustomers{}, AccountNumbers: &sequentialAccountNumbers{}}
+}
+
+func (m *MockUnitOfWork) Do(fn func(repos repositories.Repositories) error) error {
+	err := fn(repositories.Repositories{
+		Clients:            m.Clients,
+		Transfers:          m.Transfers,
+		Ledger:             m.Ledger,
+		Limits:             m.Limits,
+		Overdraft:          m.Overdraft,
+		Notifications:      m.Notifications,
+		Holds:              m.Holds,
+		Interest:           m.Interest,
+		Fees:               m.Fees,
+		Statuses:           m.Statuses,
+		Customers:          m.Customers,
+		AccountNumbers:     m.AccountNumbers,
+		ScheduledTransfers: m.Scheduled,
+		StandingOrders:     m.StandingOrders,
+	})
+	if err != nil {
+		m.RolledBack = true
+		return err
+	}
+	m.Committed = true
+	return nil
+}
+
+// MockCustomerRepository é um mock do repositório de clientes (titulares)
+type MockCustomerRepository struct {
+	mock.Mock
+}
+
+func (m *MockCustomerRepository) CreateCustomer(customer *models.Customer) error {
+	args := m.Called(customer)
+	return args.Error(0)
+}
+
+func (m *MockCustomerRepository) GetCustomerByID(id int) (*models.Customer, error) {
+	args := m.Called(id)
+	if customer, ok := args.Get(0).(*models.Customer); ok {
+		return customer, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockCustomerRepository) UpdateCustomerName(id int, name string) error {
+	args := m.Called(id, name)
+	return args.Error(0)
+}
+
+// anyCustomers é um repositório de clientes que aceita qualquer cliente novo
+// ou renomeado, numerando os novos a partir de 1
+type anyCustomers struct {
+	lastID int
+}
+
+func (r *anyCustomers) CreateCustomer(customer *models.Customer) error {
+	r.lastID++
+	customer.ID = r.lastID
+	return nil
+}
+func (r *anyCustomers) GetCustomerByID(int) (*models.Customer, error) {
+	return nil, repositories.ErrCustomerNotFound
+}
+func (r *anyCustomers) UpdateCustomerName(int, string) error { return nil }
+
+// sequentialAccountNumbers é uma sequência de números de conta em memória, sem
+// separar as agências
+type sequentialAccountNumbers struct {
+	last int64
+}
+
+func (r *sequentialAccountNumbers) NextAccountNumber(string) (int64, error) {
+	r.last++
+	return r.last, nil
+}
+
+// MockLimitRepository é um mock do repositório de limites de transferência
+type MockLimitRepository struct {
+	mock.Mock
+}
+
+func (m *MockLimitRepository) GetLimits(accountNum string) (*models.TransferLimits, error) {
+	args := m.Called(accountNum)
+	if limits, ok := args.Get(0).(*models.TransferLimits); ok {
+		return limits, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockLimitRepository) SaveLimits(limits *models.TransferLimits) error {
+	args := m.Called(limits)
+	return args.Error(0)
+}
+
+func (m *MockLimitRepository) DeleteLimits(accountNum string) error {
+	args := m.Called(accountNum)
+	return args.Error(0)
+}
+
+func (m *MockLimitRepository) GetUsage(accountNum, currency string, now time.Time) (models.LimitUsage, error) {
+	args := m.Called(accountNum, currency, now)
+	return args.Get(0).(models.LimitUsage), args.Error(1)
+}
+
+// noLimits é um repositório de limites sem nenhum limite configurado
+type noLimits struct{}
+
+func (noLimits) GetLimits(string) (*models.TransferLimits, error) {
+	return nil, repositories.ErrLimitsNotFound
+}
+func (noLimits) SaveLimits(*models.TransferLimits) error { return nil }
+func (noLimits) DeleteLimits(string) error               { return repositories.ErrLimitsNotFound }
+func (noLimits) GetUsage(accountNum, currency string, now time.Time) (models.LimitUsage, error) {
+	return models.LimitUsage{}, nil
+}
+
+// MockFeeRepository é um mock do repositório de tarifas
+type MockFeeRepository struct {
+	mock.Mock
+}
+
+func (m *MockFeeRepository) GetSchedules() ([]models.FeeSchedule, error) {
+	args := m.Called()
+	return args.Get(0).([]models.FeeSchedule), args.Error(1)
+}
+
+func (m *MockFeeRepository) GetSchedule(accountType string) (*models.FeeSchedule, error) {
+	args := m.Called(accountType)
+	if schedule, ok := args.Get(0).(*models.FeeSchedule); ok {
+		return schedule, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockFeeRepository) SaveSchedule(schedule *models.FeeSchedule) error {
+	args := m.Called(schedule)
+	return args.Error(0)
+}
+
+func (m *MockFeeRepository) CountTransfersSince(accountNum string, since time.Time) (int, error) {
+	args := m.Called(accountNum, since)
+	return args.Int(0), args.Error(1)
+}
+
+// noFees é um repositório de tarifas sem nenhuma tabela de tarifas
+type noFees struct{}
+
+func (noFees) GetSchedules() ([]models.FeeSchedule, error) { return []models.FeeSchedule{}, nil }
+func (noFees) GetSchedule(string) (*models.FeeSchedule, error) {
+	return nil, repositories.ErrFeeScheduleNotFound
+}
+func (noFees) SaveSchedule(*models.FeeSchedule) error { return nil }
+func (noFees) CountTransfersSince(string, time.Time) (int, error) {
+	return 0, nil
+}
+
+// MockOverdraftRepository é um mock do repositório de juros do cheque especial
+type MockOverdraftRepository struct {
+	mock.Mock
+}
+
+func (m *MockOverdraftRepository) GetOverdrawnBalances(before time.Time) (map[string]models.Money, error) {
+	args := m.Called(before)
+	return args.Get(0).(map[string]models.Money), args.Error(1)
+}
+
+func (m *MockOverdraftRepository) CreateInterest(interest *models.OverdraftInterest) error {
+	args := m.Called(interest)
+	return args.Error(0)
+}
+
+func (m *MockOverdraftRepository) GetLatestInterestDate() (string, error) {
+	args := m.Called()
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockOverdraftRepository) GetInterestByAccountNum(accountNum string) ([]models.OverdraftInterest, error) {
+	args := m.Called(accountNum)
+	return args.Get(0).([]models.OverdraftInterest), args.Error(1)
+}
+
+// MockNotificationRepository é um mock do repositório de notificações
+type MockNotificationRepository struct {
+	mock.Mock
+}
+
+func (m *MockNotificationRepository) CreateNotification(notification *models.Notification) error {
+	args := m.Called(notification)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) GetNotificationsByAccountNum(accountNum string) ([]models.Notification, error) {
+	args := m.Called(accountNum)
+	return args.Get(0).([]models.Notification), args.Error(1)
+}
+
+// MockHoldRepository é um mock do repositório de reservas de saldo
+type MockHoldRepository struct {
+	mock.Mock
+}
+
+func (m *MockHoldRepository) CreateHold(hold *models.Hold) error {
+	args := m.Called(hold)
+	return args.Error(0)
+}
+
+func (m *MockHoldRepository) GetHoldByID(id int) (*models.Hold, error) {
+	args := m.Called(id)
+	if hold, ok := args.Get(0).(*models.Hold); ok {
+		return hold, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockHoldRepository) GetHoldsByAccountNum(accountNum, status string) ([]models.Hold, error) {
+	args := m.Called(accountNum, status)
+	return args.Get(0).([]models.Hold), args.Error(1)
+}
+
+func (m *MockHoldRepository) GetExpiredHolds(now time.Time, limit int) ([]models.Hold, error) {
+	args := m.Called(now, limit)
+	return args.Get(0).([]models.Hold), args.Error(1)
+}
+
+func (m *MockHoldRepository) UpdateHold(hold *models.Hold) error {
+	args := m.Called(hold)
+	return args.Error(0)
+}
+
+// MockInterestRepository é um mock do repositório de juros das contas
+type MockInterestRepository struct {
+	mock.Mock
+}
+
+func (m *MockInterestRepository) GetRates() ([]models.InterestRate, error) {
+	args := m.Called()
+	return args.Get(0).([]models.InterestRate), args.Error(1)
+}
+
+func (m *MockInterestRepository) SaveRate(rate *models.InterestRate) error {
+	args := m.Called(rate)
+	return args.Error(0)
+}
+
+func (m *MockInterestRepository) GetAccrualCandidates(before time.Time) ([]models.InterestAccrual, error) {
+	args := m.Called(before)
+	return args.Get(0).([]models.InterestAccrual), args.Error(1)
+}
+
+func (m *MockInterestRepository) CreateAccrual(accrual *models.InterestAccrual) error {
+	args := m.Called(accrual)
+	return args.Error(0)
+}
+
+func (m *MockInterestRepository) GetLatestAccrualDate() (string, error) {
+	args := m.Called()
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockInterestRepository) GetAccruedInterest(to string) (map[string]models.Money, error) {
+	args := m.Called(to)
+	return args.Get(0).(map[string]models.Money), args.Error(1)
+}
+
+func (m *MockInterestRepository) CreateCapitalization(capitalization *models.InterestCapitalization) error {
+	args := m.Called(capitalization)
+	return args.Error(0)
+}
+
+func (m *MockInterestRepository) MarkAccrualsCapitalized(accountNum, to string, capitalizationID int) error {
+	args := m.Called(accountNum, to, capitalizationID)
+	return args.Error(0)
+}
+
+func (m *MockInterestRepository) GetAccrualsByAccountNum(accountNum string) ([]models.InterestAccrual, error) {
+	args := m.Called(accountNum)
+	return args.Get(0).([]models.InterestAccrual), args.Error(1)
+}
+
+func (m *MockInterestRepository) GetCapitalizationsByAccountNum(accountNum string) ([]models.InterestCapitalization, error) {
+	args := m.Called(accountNum)
+	return args.Get(0).([]models.InterestCapitalization), args.Error(1)
+}
+
+// MockIdempotencyRepository é um mock do repositório de chaves de idempotência
+type MockIdempotencyRepository struct {
+	mock.Mock
+}
+
+func (m *MockIdempotencyRepository) GetByKey(key string) (*models.IdempotencyRecord, error) {
+	args := m.Called(key)
+	if record, ok := args.Get(0).(*models.IdempotencyRecord); ok {
+		return record, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockIdempotencyRepository) Create(record *models.IdempotencyRecord) error {
+	args := m.Called(record)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyRepository) SaveResponse(key string, statusCode int, body []byte) error {
+	args := m.Called(key, statusCode, body)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyRepository) Reclaim(record *models.IdempotencyRecord, reservedBefore time.Time) error {
+	args := m.Called(record, reservedBefore)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyRepository) Release(key string) error {
+	args := m.Called(key)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyRepository) DeleteExpired(now time.Time) error {
+	args := m.Called(now)
+	return args.Error(0)
+}
+
+// MockScheduledTransferRepository é um mock do repositório de transferências agendadas
+type MockScheduledTransferRepository struct {
+	mock.Mock
+}
+
+func (m *MockScheduledTransferRepository) CreateScheduledTransfer(scheduled *models.ScheduledTransfer) error {
+	args := m.Called(scheduled)
+	return args.Error(0)
+}
+
+func (m *MockScheduledTransferRepository) GetScheduledTransferByID(id int) (*models.ScheduledTransfer, error) {
+	args := m.Called(id)
+	if scheduled, ok := args.Get(0).(*models.ScheduledTransfer); ok {
+		return scheduled, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockScheduledTransferRepository) GetScheduledTransfersByAccountNum(accountNum, status string) ([]models.ScheduledTransfer, error) {
+	args := m.Called(accountNum, status)
+	return args.Get(0).([]models.ScheduledTransfer), args.Error(1)
+}
+
+func (m *MockScheduledTransferRepository) GetDueScheduledTransfers(now, staleBefore time.Time, limit int) ([]models.ScheduledTransfer, error) {
+	args := m.Called(now, staleBefore, limit)
+	return args.Get(0).([]models.ScheduledTransfer), args.Error(1)
+}
+
+func (m *MockScheduledTransferRepository) ClaimScheduledTransfer(id int, now, staleBefore time.Time) error {
+	args := m.Called(id, now, staleBefore)
+	return args.Error(0)
+}
+
+func (m *MockScheduledTransferRepository) ReleaseScheduledTransfer(id int, claimedAt time.Time) error {
+	args := m.Called(id, claimedAt)
+	return args.Error(0)
+}
+
+func (m *MockScheduledTransferRepository) CompleteScheduledTransfer(id int, claimedAt time.Time, status, failureReason string, executedAt time.Time) error {
+	args := m.Called(id, claimedAt, status, failureReason, executedAt)
+	return args.Error(0)
+}
+
+func (m *MockScheduledTransferRepository) CancelScheduledTransfer(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// MockStandingOrderRepository é um mock do repositório de ordens permanentes
+type MockStandingOrderRepository struct {
+	mock.Mock
+}
+
+func (m *MockStandingOrderRepository) CreateStandingOrder(order *models.StandingOrder) error {
+	args := m.Called(order)
+	return args.Error(0)
+}
+
+func (m *MockStandingOrderRepository) GetStandingOrderByID(id int) (*models.StandingOrder, error) {
+	args := m.Called(id)
+	if order, ok := args.Get(0).(*models.StandingOrder); ok {
+		return order, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockStandingOrderRepository) GetStandingOrdersByAccountNum(accountNum, status string) ([]models.StandingOrder, error) {
+	args := m.Called(accountNum, status)
+	return args.Get(0).([]models.StandingOrder), args.Error(1)
+}
+
+func (m *MockStandingOrderRepository) GetDueStandingOrders(now, staleBefore time.Time, limit int) ([]models.StandingOrder, error) {
+	args := m.Called(now, staleBefore, limit)
+	return args.Get(0).([]models.StandingOrder), args.Error(1)
+}
+
+func (m *MockStandingOrderRepository) ClaimStandingOrder(id int, now, staleBefore time.Time) error {
+	args := m.Called(id, now, staleBefore)
+	return args.Error(0)
+}
+
+func (m *MockStandingOrderRepository) UpdateStandingOrderSchedule(order *models.StandingOrder, claimedAt time.Time) error {
+	args := m.Called(order, claimedAt)
+	return args.Error(0)
+}
+
+func (m *MockStandingOrderRepository) SetStandingOrderStatus(id int, status string, fromStatuses ...string) error {
+	args := m.Called(id, status, fromStatuses)
+	return args.Error(0)
+}
+
+func (m *MockStandingOrderRepository) ResumeStandingOrder(order *models.StandingOrder) error {
+	args := m.Called(order)
+	return args.Error(0)
+}
+
+func (m *MockStandingOrderRepository) CreateStandingOrderRun(run *models.StandingOrderRun) error {
+	args := m.Called(run)
+	return args.Error(0)
+}
+
+func (m *MockStandingOrderRepository) GetStandingOrderRuns(standingOrderID int) ([]models.StandingOrderRun, error) {
+	args := m.Called(standingOrderID)
+	return args.Get(0).([]models.StandingOrderRun), args.Error(1)
+}
+
+// MockBalanceSnapshotRepository é um mock do repositório de saldos de fim de dia
+type MockBalanceSnapshotRepository struct {
+	mock.Mock
+}
+
+func (m *MockBalanceSnapshotRepository) CreateSnapshots(date string, asOf time.Time) (int, error) {
+	args := m.Called(date, asOf)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockBalanceSnapshotRepository) GetLatestSnapshotDate() (string, error) {
+	args := m.Called()
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockBalanceSnapshotRepository) GetSnapshots(accountNum, from, to string) ([]models.BalanceSnapshot, error) {
+	args := m.Called(accountNum, from, to)
+	return args.Get(0).([]models.BalanceSnapshot), args.Error(1)
+}
+
+// MockReconciliationRepository é um mock das verificações da conciliação
+type MockReconciliationRepository struct {
+	mock.Mock
+}
+
+func (m *MockReconciliationRepository) CountRecords() (int, int, int, error) {
+	args := m.Called()
+	return args.Int(0), args.Int(1), args.Int(2), args.Error(3)
+}
+
+func (m *MockReconciliationRepository) discrepancies(method string) ([]models.Discrepancy, error) {
+	args := m.MethodCalled(method)
+	return args.Get(0).([]models.Discrepancy), args.Error(1)
+}
+
+func (m *MockReconciliationRepository) CheckBalances() ([]models.Discrepancy, error) {
+	return m.discrepancies("CheckBalances")
+}
+
+func (m *MockReconciliationRepository) CheckLedger() ([]models.Discrepancy, error) {
+	return m.discrepancies("CheckLedger")
+}
+
+func (m *MockReconciliationRepository) CheckReferences() ([]models.Discrepancy, error) {
+	return m.discrepancies("CheckReferences")
+}
+
+func (m *MockReconciliationRepository) CheckDuplicateAccounts() ([]models.Discrepancy, error) {
+	return m.discrepancies("CheckDuplicateAccounts")
+}
+
+func (m *MockReconciliationRepository) CheckForeignKeys() ([]models.Discrepancy, error) {
+	return m.discrepancies("CheckForeignKeys")
+}
+
+// MockAccountStatusRepository é um mock do histórico de status das contas
+type MockAccountStatusRepository struct {
+	mock.Mock
+}
+
+func (m *MockAccountStatusRepository) CreateStatusChange(change *models.AccountStatusChange) error {
+	args := m.Called(change)
+	return args.Error(0)
+}
+
+func (m *MockAccountStatusRepository) GetStatusHistory(accountNum string) ([]models.AccountStatusChange, error) {
+	args := m.Called(accountNum)
+	return args.Get(0).([]models.AccountStatusChange), args.Error(1)
+}
@@ -0,0 +1,147 @@
+// src/services/overdraft_service_test.go
+package test
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"banking/src/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newOverdraftTestService() (*services.OverdraftService, *MockClientRepository, *MockTransferRepository, *MockLedgerRepository, *MockUnitOfWork) {
+	mockClientRepo := new(MockClientRepository)
+	mockTransferRepo := new(MockTransferRepository)
+	mockLedgerRepo := new(MockLedgerRepository)
+	mockUow := NewMockUnitOfWork(mockClientRepo, mockTransferRepo, mockLedgerRepo)
+	mockUow.Overdraft = new(MockOverdraftRepository)
+	return services.NewOverdraftService(mockClientRepo, mockUow.Overdraft, mockUow), mockClientRepo, mockTransferRepo, mockLedgerRepo, mockUow
+}
+
+func TestSetOverdraft_Validation(t *testing.T) {
+	overdraftService, mockClientRepo, _, _, _ := newOverdraftTestService()
+
+	_, err := overdraftService.SetOverdraft("123456", models.BRL(-1), 1200)
+	assert.ErrorIs(t, err, services.ErrInvalidOverdraft)
+	assert.ErrorIs(t, err, models.ErrInvalidOverdraftLimit)
+
+	_, err = overdraftService.SetOverdraft("123456", models.BRL(10000), -1)
+	assert.ErrorIs(t, err, models.ErrInvalidOverdraftRate)
+
+	mockClientRepo.AssertNotCalled(t, "UpdateOverdraft", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSetOverdraft_UsesAccountCurrency(t *testing.T) {
+	overdraftService, mockClientRepo, _, _, _ := newOverdraftTestService()
+
+	client := &models.Client{AccountNum: "123456", Balance: models.NewMoney(0, "USD"), Status: models.AccountStatusActive}
+	mockClientRepo.On("GetClientByAccountNum", "123456").Return(client, nil)
+	mockClientRepo.On("UpdateOverdraft", "123456", models.NewMoney(10000, "USD"), 1200).Return(client, nil)
+
+	_, err := overdraftService.SetOverdraft("123456", models.Money{Cents: 10000}, 1200)
+
+	assert.NoError(t, err)
+	mockClientRepo.AssertExpectations(t)
+}
+
+func TestAccrueInterest_ChargesOverdrawnAccounts(t *testing.T) {
+	overdraftService, mockClientRepo, mockTransferRepo, mockLedgerRepo, mockUow := newOverdraftTestService()
+
+	day := time.Date(2030, 3, 1, 15, 0, 0, 0, models.BusinessLocation)
+	balances := map[string]models.Money{"123456": models.BRL(-100000), "654321": models.BRL(-5000)}
+	mockUow.Overdraft.On("GetOverdrawnBalances", time.Date(2030, 3, 2, 0, 0, 0, 0, models.BusinessLocation)).Return(balances, nil)
+	mockClientRepo.On("GetClientByAccountNum", "123456").Return(&models.Client{AccountNum: "123456", Balance: models.BRL(-100000), OverdraftRateBps: 1200, Status: models.AccountStatusActive}, nil)
+	mockClientRepo.On("GetClientByAccountNum", "654321").Return(&models.Client{AccountNum: "654321", Balance: models.BRL(-5000), Status: models.AccountStatusActive}, nil)
+	mockClientRepo.On("ChargeClientBalance", "123456", models.BRL(33)).Return(models.BRL(-100033), nil)
+	mockTransferRepo.On("CreateTransfer", mock.MatchedBy(func(transfer *models.Transfer) bool {
+		return transfer.Type == models.TransferTypeOverdraftInterest && transfer.ToAccountNum == models.OverdraftInterestAccountNum
+	})).Return(nil)
+	mockLedgerRepo.On("CreateEntry", mock.AnythingOfType("*models.JournalEntry")).Return(nil)
+	mockLedgerRepo.On("GetAccountBalance", "123456", "BRL").Return(models.BRL(-100033), nil)
+	mockUow.Overdraft.On("CreateInterest", mock.MatchedBy(func(interest *models.OverdraftInterest) bool {
+		return interest.AccountNum == "123456" && interest.AccrualDate == "2030-03-01" && interest.Interest == models.BRL(33)
+	})).Return(nil)
+
+	err := overdraftService.AccrueInterest(day)
+
+	assert.NoError(t, err)
+	// Contas sem taxa não pagam juros
+	mockClientRepo.AssertNotCalled(t, "ChargeClientBalance", "654321", mock.Anything)
+	mockClientRepo.AssertExpectations(t)
+	mockUow.Overdraft.AssertExpectations(t)
+}
+
+func TestAccrueInterest_SkipsAlreadyAccruedDay(t *testing.T) {
+	overdraftService, mockClientRepo, mockTransferRepo, mockLedgerRepo, mockUow := newOverdraftTestService()
+
+	balances := map[string]models.Money{"123456": models.BRL(-100000)}
+	mockUow.Overdraft.On("GetOverdrawnBalances", mock.Anything).Return(balances, nil)
+	mockClientRepo.On("GetClientByAccountNum", "123456").Return(&models.Client{AccountNum: "123456", Balance: models.BRL(-100000), OverdraftRateBps: 1200, Status: models.AccountStatusActive}, nil)
+	mockClientRepo.On("ChargeClientBalance", "123456", models.BRL(33)).Return(models.BRL(-100033), nil)
+	mockTransferRepo.On("CreateTransfer", mock.Anything).Return(nil)
+	mockLedgerRepo.On("CreateEntry", mock.Anything).Return(nil)
+	mockLedgerRepo.On("GetAccountBalance", "123456", "BRL").Return(models.BRL(-100033), nil)
+	mockUow.Overdraft.On("CreateInterest", mock.Anything).Return(repositories.ErrInterestAlreadyAccrued)
+
+	err := overdraftService.AccrueInterest(time.Date(2030, 3, 1, 15, 0, 0, 0, models.BusinessLocation))
+
+	// A cobrança duplicada é desfeita e não interrompe as demais contas
+	assert.NoError(t, err)
+	assert.True(t, mockUow.RolledBack)
+}
+
+func TestOverdraftAccrueDue_CatchesUpMissedDays(t *testing.T) {
+	overdraftService, _, _, _, mockUow := newOverdraftTestService()
+
+	now := time.Date(2030, 3, 2, 9, 0, 0, 0, models.BusinessLocation)
+	mockUow.Overdraft.On("GetLatestInterestDate").Return("2030-02-26", nil)
+	for _, before := range []time.Time{
+		time.Date(2030, 2, 28, 0, 0, 0, 0, models.BusinessLocation),
+		time.Date(2030, 3, 1, 0, 0, 0, 0, models.BusinessLocation),
+		time.Date(2030, 3, 2, 0, 0, 0, 0, models.BusinessLocation),
+	} {
+		mockUow.Overdraft.On("GetOverdrawnBalances", before).Return(map[string]models.Money{}, nil).Once()
+	}
+
+	assert.NoError(t, overdraftService.AccrueDue(now))
+	// No mesmo dia, a rotina não faz nada de novo
+	assert.NoError(t, overdraftService.AccrueDue(now.Add(time.Hour)))
+
+	mockUow.Overdraft.AssertExpectations(t)
+	mockUow.Overdraft.AssertNumberOfCalls(t, "GetLatestInterestDate", 1)
+}
+
+func TestTransferFunds_NotifiesOverdraftEntered(t *testing.T) {
+	mockClientRepo := new(MockClientRepository)
+	mockTransferRepo := new(MockTransferRepository)
+	mockLedgerRepo := new(MockLedgerRepository)
+	mockUow := NewMockUnitOfWork(mockClientRepo, mockTransferRepo, mockLedgerRepo)
+	mockUow.Notifications = new(MockNotificationRepository)
+	transferService := services.NewTransferService(mockClientRepo, mockTransferRepo, mockLedgerRepo, mockUow, services.NewAccountLocks())
+
+	fromClient := &models.Client{AccountNum: "123456", Balance: models.BRL(5000), OverdraftLimit: models.BRL(10000), Status: models.AccountStatusActive}
+	toClient := &models.Client{AccountNum: "654321", Balance: models.BRL(0), Status: models.AccountStatusActive}
+	amount := models.BRL(8000)
+
+	mockClientRepo.On("GetClientByAccountNum", "123456").Return(fromClient, nil)
+	mockClientRepo.On("GetClientByAccountNum", "654321").Return(toClient, nil)
+	mockClientRepo.On("DebitClientBalance", "123456", amount).Return(models.BRL(-3000), nil)
+	mockClientRepo.On("CreditClientBalance", "654321", amount).Return(models.BRL(8000), nil)
+	mockTransferRepo.On("CreateTransfer", mock.AnythingOfType("*models.Transfer")).Return(nil)
+	mockLedgerRepo.On("CreateEntry", mock.AnythingOfType("*models.JournalEntry")).Return(nil)
+	mockLedgerRepo.On("GetAccountBalance", "123456", "BRL").Return(models.BRL(-3000), nil)
+	mockLedgerRepo.On("GetAccountBalance", "654321", "BRL").Return(models.BRL(8000), nil)
+	mockUow.Notifications.On("CreateNotification", mock.MatchedBy(func(notification *models.Notification) bool {
+		return notification.AccountNum == "123456" && notification.Type == models.NotificationOverdraftEntered &&
+			notification.Balance == models.BRL(-3000)
+	})).Return(nil).Once()
+
+	_, err := transferService.TransferFunds("123456", "654321", amount)
+
+	assert.NoError(t, err)
+	assert.True(t, mockUow.Committed)
+	mockUow.Notifications.AssertExpectations(t)
+}
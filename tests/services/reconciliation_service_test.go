@@ -0,0 +1,63 @@
+// src/services/reconciliation_service_test.go
+package test
+
+import (
+	"banking/src/models"
+	"banking/src/services"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var reconciliationChecks = []string{"CheckBalances", "CheckLedger", "CheckReferences", "CheckDuplicateAccounts", "CheckForeignKeys"}
+
+func TestReconcile_NoDiscrepancies(t *testing.T) {
+	mockRepo := new(MockReconciliationRepository)
+	mockRepo.On("CountRecords").Return(2, 5, 4, nil)
+	for _, check := range reconciliationChecks {
+		mockRepo.On(check).Return([]models.Discrepancy{}, nil)
+	}
+
+	report, err := services.NewReconciliationService(mockRepo).Reconcile()
+
+	assert.NoError(t, err)
+	assert.True(t, report.OK)
+	assert.NotNil(t, report.Discrepancies)
+	assert.Empty(t, report.Discrepancies)
+	assert.Equal(t, 2, report.AccountsChecked)
+	assert.Equal(t, 5, report.TransfersChecked)
+	assert.Equal(t, 4, report.EntriesChecked)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestReconcile_CollectsDiscrepanciesOfAllChecks(t *testing.T) {
+	mockRepo := new(MockReconciliationRepository)
+	mockRepo.On("CountRecords").Return(2, 5, 4, nil)
+	mockRepo.On("CheckBalances").Return([]models.Discrepancy{{Type: models.DiscrepancyLedgerBalance, AccountNum: "123456"}}, nil)
+	mockRepo.On("CheckLedger").Return([]models.Discrepancy{}, nil)
+	mockRepo.On("CheckReferences").Return([]models.Discrepancy{}, nil)
+	mockRepo.On("CheckDuplicateAccounts").Return([]models.Discrepancy{{Type: models.DiscrepancyDuplicateAccount, AccountNum: "654321"}}, nil)
+	mockRepo.On("CheckForeignKeys").Return([]models.Discrepancy{}, nil)
+
+	report, err := services.NewReconciliationService(mockRepo).Reconcile()
+
+	assert.NoError(t, err)
+	assert.False(t, report.OK)
+	assert.Len(t, report.Discrepancies, 2)
+	assert.Equal(t, models.DiscrepancyLedgerBalance, report.Discrepancies[0].Type)
+	assert.Equal(t, models.DiscrepancyDuplicateAccount, report.Discrepancies[1].Type)
+}
+
+func TestReconcile_CheckFails(t *testing.T) {
+	mockRepo := new(MockReconciliationRepository)
+	mockRepo.On("CountRecords").Return(2, 5, 4, nil)
+	mockRepo.On("CheckBalances").Return([]models.Discrepancy{}, nil)
+	mockRepo.On("CheckLedger").Return([]models.Discrepancy(nil), errors.New("database is locked"))
+
+	report, err := services.NewReconciliationService(mockRepo).Reconcile()
+
+	assert.Error(t, err)
+	assert.Nil(t, report)
+	mockRepo.AssertNotCalled(t, "CheckReferences")
+}
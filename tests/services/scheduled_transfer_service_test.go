@@ -0,0 +1,213 @@
+// test/scheduled_transfer_service_test.go
+package test
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"banking/src/services"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTransferService é um mock do serviço de transferência usado pelo executor
+// de agendamentos. Repos são os repositórios passados para TransferOptions.Then
+// quando a transferência é bem-sucedida.
+type MockTransferService struct {
+	mock.Mock
+	Repos repositories.Repositories
+}
+
+func (m *MockTransferService) TransferFunds(fromAccountNum, toAccountNum string, amount models.Money) (*models.Transfer, error) {
+	args := m.Called(fromAccountNum, toAccountNum, amount)
+	if transfer, ok := args.Get(0).(*models.Transfer); ok {
+		return transfer, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTransferService) TransferFundsWith(fromAccountNum, toAccountNum string, amount models.Money, options services.TransferOptions) (*models.Transfer, error) {
+	transfer, err := m.TransferFunds(fromAccountNum, toAccountNum, amount)
+	if err == nil && options.Then != nil {
+		if err := options.Then(m.Repos, transfer); err != nil {
+			return nil, err
+		}
+	}
+	return transfer, err
+}
+
+func (m *MockTransferService) ReverseTransfer(transferID int, amount models.Money) (*models.Transfer, error) {
+	args := m.Called(transferID, amount)
+	if transfer, ok := args.Get(0).(*models.Transfer); ok {
+		return transfer, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTransferService) GetTransferHistory(accountNum string) ([]models.Transfer, error) {
+	args := m.Called(accountNum)
+	return args.Get(0).([]models.Transfer), args.Error(1)
+}
+
+func newScheduledTransferTestService() (*services.ScheduledTransferService, *MockClientRepository, *MockScheduledTransferRepository, *MockTransferService) {
+	mockClientRepo := new(MockClientRepository)
+	mockScheduledRepo := new(MockScheduledTransferRepository)
+	mockTransferService := &MockTransferService{Repos: repositories.Repositories{ScheduledTransfers: mockScheduledRepo}}
+	service := services.NewScheduledTransferService(mockClientRepo, mockScheduledRepo, mockTransferService)
+	return service, mockClientRepo, mockScheduledRepo, mockTransferService
+}
+
+func TestScheduleTransfer_Success(t *testing.T) {
+	service, mockClientRepo, mockScheduledRepo, _ := newScheduledTransferTestService()
+
+	executeAt := time.Now().Add(24 * time.Hour)
+	mockClientRepo.On("GetClientByAccountNum", "123456").Return(&models.Client{AccountNum: "123456", Balance: models.BRL(0), Status: models.AccountStatusActive}, nil)
+	mockClientRepo.On("GetClientByAccountNum", "654321").Return(&models.Client{AccountNum: "654321", Balance: models.BRL(0), Status: models.AccountStatusActive}, nil)
+	mockScheduledRepo.On("CreateScheduledTransfer", mock.MatchedBy(func(scheduled *models.ScheduledTransfer) bool {
+		return scheduled.Status == models.ScheduledStatusPending &&
+			scheduled.Amount == models.BRL(5000) && scheduled.ExecuteAt.Equal(executeAt)
+	})).Return(nil)
+
+	// O saldo zero não impede o agendamento: ele só é verificado na execução
+	scheduled, err := service.ScheduleTransfer("123456", "654321", models.NewMoney(5000, ""), executeAt)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "654321", scheduled.ToAccountNum)
+	mockScheduledRepo.AssertExpectations(t)
+}
+
+func TestScheduleTransfer_ExecuteAtInThePast(t *testing.T) {
+	service, _, mockScheduledRepo, _ := newScheduledTransferTestService()
+
+	_, err := service.ScheduleTransfer("123456", "654321", models.BRL(5000), time.Now().Add(-time.Minute))
+
+	assert.ErrorIs(t, err, services.ErrExecuteAtNotInFuture)
+	mockScheduledRepo.AssertNotCalled(t, "CreateScheduledTransfer", mock.Anything)
+}
+
+func TestScheduleTransfer_DestinationNotFound(t *testing.T) {
+	service, mockClientRepo, mockScheduledRepo, _ := newScheduledTransferTestService()
+
+	mockClientRepo.On("GetClientByAccountNum", "123456").Return(&models.Client{AccountNum: "123456", Balance: models.BRL(0), Status: models.AccountStatusActive}, nil)
+	mockClientRepo.On("GetClientByAccountNum", "999999").Return((*models.Client)(nil), repositories.ErrClientNotFound)
+
+	_, err := service.ScheduleTransfer("123456", "999999", models.BRL(5000), time.Now().Add(time.Hour))
+
+	assert.Equal(t, models.FailureDestinationNotFound, services.FailureReason(err))
+	mockScheduledRepo.AssertNotCalled(t, "CreateScheduledTransfer", mock.Anything)
+}
+
+func TestScheduleTransfer_InvalidAmount(t *testing.T) {
+	service, _, _, _ := newScheduledTransferTestService()
+
+	_, err := service.ScheduleTransfer("123456", "654321", models.BRL(0), time.Now().Add(time.Hour))
+
+	assert.Equal(t, models.FailureInvalidAmount, services.FailureReason(err))
+}
+
+func TestExecuteDue_RecordsOutcomes(t *testing.T) {
+	service, _, mockScheduledRepo, mockTransferService := newScheduledTransferTestService()
+
+	now := time.Now()
+	staleBefore := mock.AnythingOfType("time.Time")
+	due := []models.ScheduledTransfer{
+		{ID: 1, FromAccountNum: "123456", ToAccountNum: "654321", Amount: models.BRL(1000)},
+		{ID: 2, FromAccountNum: "123456", ToAccountNum: "654321", Amount: models.BRL(9000)},
+		{ID: 3, FromAccountNum: "123456", ToAccountNum: "654321", Amount: models.BRL(500)},
+	}
+	mockScheduledRepo.On("GetDueScheduledTransfers", now, staleBefore, mock.AnythingOfType("int")).Return(due, nil)
+	mockScheduledRepo.On("ClaimScheduledTransfer", 1, now, staleBefore).Return(nil)
+	mockScheduledRepo.On("ClaimScheduledTransfer", 2, now, staleBefore).Return(nil)
+	// O agendamento 3 foi cancelado depois de listado
+	mockScheduledRepo.On("ClaimScheduledTransfer", 3, now, staleBefore).Return(repositories.ErrScheduledTransferNotPending)
+
+	mockTransferService.On("TransferFunds", "123456", "654321", models.BRL(1000)).Return(&models.Transfer{}, nil)
+	mockTransferService.On("TransferFunds", "123456", "654321", models.BRL(9000)).
+		Return(nil, &services.TransferError{Reason: models.FailureInsufficientBalance, Err: repositories.ErrInsufficientBalance})
+
+	mockScheduledRepo.On("CompleteScheduledTransfer", 1, now, models.ScheduledStatusExecuted, "", mock.AnythingOfType("time.Time")).Return(nil)
+	mockScheduledRepo.On("CompleteScheduledTransfer", 2, now, models.ScheduledStatusFailed, models.FailureInsufficientBalance, mock.AnythingOfType("time.Time")).Return(nil)
+
+	err := service.ExecuteDue(now)
+
+	assert.NoError(t, err)
+	mockScheduledRepo.AssertExpectations(t)
+	mockTransferService.AssertNumberOfCalls(t, "TransferFunds", 2)
+}
+
+func TestExecuteDue_ReclaimsStaleClaims(t *testing.T) {
+	service, _, mockScheduledRepo, _ := newScheduledTransferTestService()
+
+	now := time.Now()
+	mockScheduledRepo.On("GetDueScheduledTransfers", now, mock.MatchedBy(func(staleBefore time.Time) bool {
+		return staleBefore.Before(now)
+	}), mock.AnythingOfType("int")).Return([]models.ScheduledTransfer{}, nil)
+
+	assert.NoError(t, service.ExecuteDue(now))
+	mockScheduledRepo.AssertExpectations(t)
+}
+
+func TestExecuteDue_UnexpectedErrorLeavesPending(t *testing.T) {
+	service, _, mockScheduledRepo, mockTransferService := newScheduledTransferTestService()
+
+	now := time.Now()
+	mockScheduledRepo.On("GetDueScheduledTransfers", now, mock.AnythingOfType("time.Time"), mock.AnythingOfType("int")).
+		Return([]models.ScheduledTransfer{{ID: 1, FromAccountNum: "123456", ToAccountNum: "654321", Amount: models.BRL(1000)}}, nil)
+	mockScheduledRepo.On("ClaimScheduledTransfer", 1, now, mock.AnythingOfType("time.Time")).Return(nil)
+	mockTransferService.On("TransferFunds", "123456", "654321", models.BRL(1000)).Return(nil, errors.New("database is locked"))
+	mockScheduledRepo.On("ReleaseScheduledTransfer", 1, now).Return(nil)
+
+	err := service.ExecuteDue(now)
+
+	assert.NoError(t, err)
+	mockScheduledRepo.AssertExpectations(t)
+	mockScheduledRepo.AssertNotCalled(t, "CompleteScheduledTransfer", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestExecuteDue_CompletionErrorUndoesTransfer(t *testing.T) {
+	service, _, mockScheduledRepo, mockTransferService := newScheduledTransferTestService()
+
+	now := time.Now()
+	mockScheduledRepo.On("GetDueScheduledTransfers", now, mock.AnythingOfType("time.Time"), mock.AnythingOfType("int")).
+		Return([]models.ScheduledTransfer{{ID: 1, FromAccountNum: "123456", ToAccountNum: "654321", Amount: models.BRL(1000)}}, nil)
+	mockScheduledRepo.On("ClaimScheduledTransfer", 1, now, mock.AnythingOfType("time.Time")).Return(nil)
+	mockTransferService.On("TransferFunds", "123456", "654321", models.BRL(1000)).Return(&models.Transfer{}, nil)
+	// O resultado não pôde ser gravado: a transferência é desfeita junto e o
+	// agendamento volta para "pending"
+	mockScheduledRepo.On("CompleteScheduledTransfer", 1, now, models.ScheduledStatusExecuted, "", mock.AnythingOfType("time.Time")).
+		Return(errors.New("disk I/O error"))
+	mockScheduledRepo.On("ReleaseScheduledTransfer", 1, now).Return(nil)
+
+	err := service.ExecuteDue(now)
+
+	assert.NoError(t, err)
+	mockScheduledRepo.AssertExpectations(t)
+}
+
+func TestExecuteDue_StopsOnRepositoryError(t *testing.T) {
+	service, _, mockScheduledRepo, mockTransferService := newScheduledTransferTestService()
+
+	now := time.Now()
+	mockScheduledRepo.On("GetDueScheduledTransfers", now, mock.AnythingOfType("time.Time"), mock.AnythingOfType("int")).
+		Return([]models.ScheduledTransfer{{ID: 1}}, nil)
+	mockScheduledRepo.On("ClaimScheduledTransfer", 1, now, mock.AnythingOfType("time.Time")).Return(errors.New("database is locked"))
+
+	err := service.ExecuteDue(now)
+
+	assert.Error(t, err)
+	mockTransferService.AssertNotCalled(t, "TransferFunds", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCancelScheduledTransfer_NotPending(t *testing.T) {
+	service, _, mockScheduledRepo, _ := newScheduledTransferTestService()
+
+	mockScheduledRepo.On("CancelScheduledTransfer", 1).Return(repositories.ErrScheduledTransferNotPending)
+
+	scheduled, err := service.CancelScheduledTransfer(1)
+
+	assert.ErrorIs(t, err, repositories.ErrScheduledTransferNotPending)
+	assert.Nil(t, scheduled)
+}
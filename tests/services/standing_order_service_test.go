@@ -0,0 +1,280 @@
+// test/standing_order_service_test.go
+package test
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"banking/src/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newStandingOrderTestService() (*services.StandingOrderService, *MockClientRepository, *MockStandingOrderRepository, *MockTransferService) {
+	mockClientRepo := new(MockClientRepository)
+	mockOrderRepo := new(MockStandingOrderRepository)
+	// A execução e o andamento da ordem são gravados pelo Then da transferência
+	mockTransferService := &MockTransferService{Repos: repositories.Repositories{StandingOrders: mockOrderRepo}}
+	mockUow := NewMockUnitOfWork(mockClientRepo, new(MockTransferRepository), new(MockLedgerRepository))
+	mockUow.StandingOrders = mockOrderRepo
+	service := services.NewStandingOrderService(mockClientRepo, mockOrderRepo, mockUow, mockTransferService)
+	return service, mockClientRepo, mockOrderRepo, mockTransferService
+}
+
+func mockExistingAccounts(mockClientRepo *MockClientRepository, accountNums ...string) {
+	for _, accountNum := range accountNums {
+		mockClientRepo.On("GetClientByAccountNum", accountNum).Return(&models.Client{AccountNum: accountNum, Balance: models.BRL(0), Status: models.AccountStatusActive}, nil)
+	}
+}
+
+// monthlyOrder é uma ordem mensal ativa cuja ocorrência pendente é due
+func monthlyOrder(due time.Time) models.StandingOrder {
+	return models.StandingOrder{
+		ID:               1,
+		FromAccountNum:   "123456",
+		ToAccountNum:     "654321",
+		Amount:           models.BRL(150000),
+		Frequency:        models.FrequencyMonthly,
+		Interval:         1,
+		StartAt:          due,
+		NextOccurrenceAt: due,
+		NextRunAt:        &due,
+		Status:           models.StandingOrderActive,
+	}
+}
+
+func TestCreateStandingOrder_SchedulesFirstOccurrence(t *testing.T) {
+	service, mockClientRepo, mockOrderRepo, _ := newStandingOrderTestService()
+
+	start := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Second)
+	mockExistingAccounts(mockClientRepo, "123456", "654321")
+	mockOrderRepo.On("CreateStandingOrder", mock.MatchedBy(func(order *models.StandingOrder) bool {
+		return order.Status == models.StandingOrderActive && order.Interval == 1 &&
+			order.NextOccurrenceAt.Equal(start) && order.NextRunAt.Equal(start)
+	})).Return(nil)
+
+	order, err := service.CreateStandingOrder(models.StandingOrder{
+		FromAccountNum: "123456",
+		ToAccountNum:   "654321",
+		Amount:         models.NewMoney(150000, ""),
+		Frequency:      models.FrequencyMonthly,
+		StartAt:        start,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "BRL", order.Amount.Currency)
+	mockOrderRepo.AssertExpectations(t)
+}
+
+func TestCreateStandingOrder_StartInThePastBeginsAtNextOccurrence(t *testing.T) {
+	service, mockClientRepo, mockOrderRepo, _ := newStandingOrderTestService()
+
+	start := time.Now().Add(-36 * time.Hour)
+	mockExistingAccounts(mockClientRepo, "123456", "654321")
+	mockOrderRepo.On("CreateStandingOrder", mock.MatchedBy(func(order *models.StandingOrder) bool {
+		return order.NextOccurrenceAt.Equal(start.Add(48 * time.Hour))
+	})).Return(nil)
+
+	_, err := service.CreateStandingOrder(models.StandingOrder{
+		FromAccountNum: "123456",
+		ToAccountNum:   "654321",
+		Amount:         models.BRL(1000),
+		Frequency:      models.FrequencyDaily,
+		StartAt:        start,
+	})
+
+	assert.NoError(t, err)
+	mockOrderRepo.AssertExpectations(t)
+}
+
+func TestCreateStandingOrder_InvalidRule(t *testing.T) {
+	service, _, mockOrderRepo, _ := newStandingOrderTestService()
+
+	_, err := service.CreateStandingOrder(models.StandingOrder{
+		FromAccountNum: "123456",
+		ToAccountNum:   "654321",
+		Amount:         models.BRL(1000),
+		Frequency:      models.FrequencyCron,
+		Cron:           "every monday",
+	})
+
+	assert.ErrorIs(t, err, services.ErrInvalidStandingOrder)
+	assert.ErrorIs(t, err, models.ErrInvalidCron)
+	mockOrderRepo.AssertNotCalled(t, "CreateStandingOrder", mock.Anything)
+}
+
+func TestCreateStandingOrder_NoOccurrenceBeforeEnd(t *testing.T) {
+	service, mockClientRepo, _, _ := newStandingOrderTestService()
+
+	start := time.Now().Add(time.Hour)
+	end := start.Add(time.Hour)
+	mockExistingAccounts(mockClientRepo, "123456", "654321")
+
+	// Nenhum dia 29 de fevereiro entre start e end
+	_, err := service.CreateStandingOrder(models.StandingOrder{
+		FromAccountNum: "123456",
+		ToAccountNum:   "654321",
+		Amount:         models.BRL(1000),
+		Frequency:      models.FrequencyCron,
+		Cron:           "0 0 29 2 *",
+		StartAt:        start,
+		EndAt:          &end,
+	})
+
+	assert.ErrorIs(t, err, services.ErrInvalidStandingOrder)
+}
+
+func TestExecuteStandingOrders_SuccessAdvancesToNextOccurrence(t *testing.T) {
+	service, _, mockOrderRepo, mockTransferService := newStandingOrderTestService()
+
+	due := time.Date(2030, 1, 5, 12, 0, 0, 0, time.UTC)
+	now := due.Add(5 * time.Second)
+	mockOrderRepo.On("GetDueStandingOrders", now, now.Add(-5*time.Minute), mock.AnythingOfType("int")).Return([]models.StandingOrder{monthlyOrder(due)}, nil)
+	mockOrderRepo.On("ClaimStandingOrder", 1, now, now.Add(-5*time.Minute)).Return(nil)
+	mockTransferService.On("TransferFunds", "123456", "654321", models.BRL(150000)).Return(&models.Transfer{}, nil)
+	mockOrderRepo.On("CreateStandingOrderRun", mock.MatchedBy(func(run *models.StandingOrderRun) bool {
+		return run.Status == models.StandingOrderRunSuccess && run.Attempt == 1 && run.OccurrenceAt.Equal(due) && run.RetryAt == nil
+	})).Return(nil)
+	next := time.Date(2030, 2, 5, 12, 0, 0, 0, time.UTC)
+	mockOrderRepo.On("UpdateStandingOrderSchedule", mock.MatchedBy(func(order *models.StandingOrder) bool {
+		return order.Occurrences == 1 && order.Retries == 0 && order.NextOccurrenceAt.Equal(next) &&
+			order.NextRunAt.Equal(next) && order.Status == models.StandingOrderActive
+	}), now).Return(nil)
+
+	err := service.ExecuteDue(now)
+
+	assert.NoError(t, err)
+	mockOrderRepo.AssertExpectations(t)
+}
+
+func TestExecuteStandingOrders_RetriesInsufficientBalance(t *testing.T) {
+	service, _, mockOrderRepo, mockTransferService := newStandingOrderTestService()
+
+	due := time.Date(2030, 1, 5, 12, 0, 0, 0, time.UTC)
+	now := due.Add(time.Minute)
+	mockOrderRepo.On("GetDueStandingOrders", now, now.Add(-5*time.Minute), mock.AnythingOfType("int")).Return([]models.StandingOrder{monthlyOrder(due)}, nil)
+	mockOrderRepo.On("ClaimStandingOrder", 1, now, now.Add(-5*time.Minute)).Return(nil)
+	mockTransferService.On("TransferFunds", "123456", "654321", models.BRL(150000)).
+		Return(nil, &services.TransferError{Reason: models.FailureInsufficientBalance, Err: repositories.ErrInsufficientBalance})
+	mockOrderRepo.On("CreateStandingOrderRun", mock.MatchedBy(func(run *models.StandingOrderRun) bool {
+		return run.Status == models.StandingOrderRunFailed && run.FailureReason == models.FailureInsufficientBalance &&
+			run.RetryAt != nil && run.RetryAt.Equal(now.Add(time.Hour))
+	})).Return(nil)
+	mockOrderRepo.On("UpdateStandingOrderSchedule", mock.MatchedBy(func(order *models.StandingOrder) bool {
+		// A ocorrência continua pendente, só a próxima tentativa muda
+		return order.Occurrences == 0 && order.Retries == 1 && order.NextOccurrenceAt.Equal(due) && order.NextRunAt.Equal(now.Add(time.Hour))
+	}), now).Return(nil)
+
+	err := service.ExecuteDue(now)
+
+	assert.NoError(t, err)
+	mockOrderRepo.AssertExpectations(t)
+}
+
+func TestExecuteStandingOrders_GivesUpAfterLastRetry(t *testing.T) {
+	service, _, mockOrderRepo, mockTransferService := newStandingOrderTestService()
+
+	due := time.Date(2030, 1, 5, 12, 0, 0, 0, time.UTC)
+	now := due.Add(3 * time.Hour)
+	order := monthlyOrder(due)
+	order.Retries = 3
+	order.MaxOccurrences = 1
+	mockOrderRepo.On("GetDueStandingOrders", now, now.Add(-5*time.Minute), mock.AnythingOfType("int")).Return([]models.StandingOrder{order}, nil)
+	mockOrderRepo.On("ClaimStandingOrder", 1, now, now.Add(-5*time.Minute)).Return(nil)
+	mockTransferService.On("TransferFunds", "123456", "654321", models.BRL(150000)).
+		Return(nil, &services.TransferError{Reason: models.FailureInsufficientBalance, Err: repositories.ErrInsufficientBalance})
+	mockOrderRepo.On("CreateStandingOrderRun", mock.MatchedBy(func(run *models.StandingOrderRun) bool {
+		return run.Attempt == 4 && run.Status == models.StandingOrderRunFailed && run.RetryAt == nil
+	})).Return(nil)
+	// A única ocorrência permitida foi consumida, então a ordem é concluída
+	mockOrderRepo.On("UpdateStandingOrderSchedule", mock.MatchedBy(func(order *models.StandingOrder) bool {
+		return order.Occurrences == 1 && order.Retries == 0 && order.Status == models.StandingOrderCompleted && order.NextRunAt == nil
+	}), now).Return(nil)
+
+	err := service.ExecuteDue(now)
+
+	assert.NoError(t, err)
+	mockOrderRepo.AssertExpectations(t)
+}
+
+func TestExecuteStandingOrders_LostClaimUndoesTheTransfer(t *testing.T) {
+	service, _, mockOrderRepo, mockTransferService := newStandingOrderTestService()
+
+	due := time.Date(2030, 1, 5, 12, 0, 0, 0, time.UTC)
+	now := due.Add(5 * time.Second)
+	mockOrderRepo.On("GetDueStandingOrders", now, now.Add(-5*time.Minute), mock.AnythingOfType("int")).Return([]models.StandingOrder{monthlyOrder(due)}, nil)
+	mockOrderRepo.On("ClaimStandingOrder", 1, now, now.Add(-5*time.Minute)).Return(nil)
+	mockTransferService.On("TransferFunds", "123456", "654321", models.BRL(150000)).Return(&models.Transfer{}, nil)
+	// A reivindicação expirou e outro executor assumiu a ordem: o Then falha e a
+	// transferência é desfeita, sem registrar uma execução
+	mockOrderRepo.On("UpdateStandingOrderSchedule", mock.Anything, now).Return(repositories.ErrStandingOrderNotDue)
+
+	err := service.ExecuteDue(now)
+
+	assert.NoError(t, err)
+	mockOrderRepo.AssertNumberOfCalls(t, "UpdateStandingOrderSchedule", 1)
+	mockOrderRepo.AssertNotCalled(t, "CreateStandingOrderRun", mock.Anything)
+}
+
+func TestExecuteStandingOrders_SkipsOrdersClaimedElsewhere(t *testing.T) {
+	service, _, mockOrderRepo, mockTransferService := newStandingOrderTestService()
+
+	now := time.Now()
+	mockOrderRepo.On("GetDueStandingOrders", now, now.Add(-5*time.Minute), mock.AnythingOfType("int")).Return([]models.StandingOrder{monthlyOrder(now)}, nil)
+	mockOrderRepo.On("ClaimStandingOrder", 1, now, now.Add(-5*time.Minute)).Return(repositories.ErrStandingOrderNotDue)
+
+	err := service.ExecuteDue(now)
+
+	assert.NoError(t, err)
+	mockTransferService.AssertNotCalled(t, "TransferFunds", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestResumeStandingOrder_SkipsOccurrencesMissedWhilePaused(t *testing.T) {
+	service, _, mockOrderRepo, _ := newStandingOrderTestService()
+
+	start := time.Now().Add(-50 * time.Hour)
+	order := models.StandingOrder{
+		ID:               1,
+		Frequency:        models.FrequencyDaily,
+		Interval:         1,
+		StartAt:          start,
+		NextOccurrenceAt: start,
+		Retries:          2,
+		Status:           models.StandingOrderPaused,
+	}
+	mockOrderRepo.On("GetStandingOrderByID", 1).Return(&order, nil)
+	mockOrderRepo.On("ResumeStandingOrder", mock.MatchedBy(func(resumed *models.StandingOrder) bool {
+		return resumed.NextOccurrenceAt.Equal(start.Add(72*time.Hour)) && resumed.Retries == 0
+	})).Return(nil)
+
+	_, err := service.ResumeStandingOrder(1)
+
+	assert.NoError(t, err)
+	mockOrderRepo.AssertExpectations(t)
+}
+
+func TestResumeStandingOrder_OnlyPausedOrders(t *testing.T) {
+	service, _, mockOrderRepo, _ := newStandingOrderTestService()
+
+	mockOrderRepo.On("GetStandingOrderByID", 1).Return(&models.StandingOrder{ID: 1, Status: models.StandingOrderCancelled}, nil)
+
+	_, err := service.ResumeStandingOrder(1)
+
+	assert.ErrorIs(t, err, repositories.ErrStandingOrderInvalidState)
+	mockOrderRepo.AssertNotCalled(t, "ResumeStandingOrder", mock.Anything)
+}
+
+func TestCancelStandingOrder_FromActiveOrPaused(t *testing.T) {
+	service, _, mockOrderRepo, _ := newStandingOrderTestService()
+
+	mockOrderRepo.On("SetStandingOrderStatus", 1, models.StandingOrderCancelled,
+		[]string{models.StandingOrderActive, models.StandingOrderPaused}).Return(nil)
+	mockOrderRepo.On("GetStandingOrderByID", 1).Return(&models.StandingOrder{ID: 1, Status: models.StandingOrderCancelled}, nil)
+
+	order, err := service.CancelStandingOrder(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.StandingOrderCancelled, order.Status)
+}
@@ -0,0 +1,77 @@
+// src/services/statement_service_test.go
+package test
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"banking/src/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetStatement_Success(t *testing.T) {
+	mockClientRepo := new(MockClientRepository)
+	mockLedgerRepo := new(MockLedgerRepository)
+	statementService := services.NewStatementService(mockClientRepo, mockLedgerRepo)
+
+	from := time.Date(2030, 3, 1, 0, 0, 0, 0, models.BusinessLocation)
+	to := from.AddDate(0, 1, 0)
+	mockClientRepo.On("GetClientByAccountNum", "123456").Return(&models.Client{AccountNum: "123456", Balance: models.BRL(0)}, nil)
+	mockLedgerRepo.On("GetBalanceAt", "123456", "BRL", from).Return(models.BRL(10000), nil)
+	mockLedgerRepo.On("GetStatementLines", "123456", "BRL", from, to).Return([]models.StatementLine{
+		{EntryID: 7, Counterparty: "654321", Amount: models.BRL(-2500)},
+	}, nil)
+
+	statement, err := statementService.GetStatement("123456", from, to)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(7500), statement.Movements[0].Balance)
+	assert.Equal(t, models.BRL(7500), statement.ClosingBalance)
+	assert.Equal(t, models.BRL(2500), statement.TotalDebits)
+	mockLedgerRepo.AssertExpectations(t)
+}
+
+func TestGetStatement_DefaultsToCurrentMonth(t *testing.T) {
+	mockClientRepo := new(MockClientRepository)
+	mockLedgerRepo := new(MockLedgerRepository)
+	statementService := services.NewStatementService(mockClientRepo, mockLedgerRepo)
+
+	mockClientRepo.On("GetClientByAccountNum", "123456").Return(&models.Client{AccountNum: "123456", Balance: models.BRL(0)}, nil)
+	mockLedgerRepo.On("GetBalanceAt", "123456", "BRL", mock.Anything).Return(models.BRL(0), nil)
+	mockLedgerRepo.On("GetStatementLines", "123456", "BRL", mock.Anything, mock.Anything).Return([]models.StatementLine{}, nil)
+
+	statement, err := statementService.GetStatement("123456", time.Time{}, time.Time{})
+
+	assert.NoError(t, err)
+	assert.True(t, statement.From.Equal(models.StartOfBusinessMonth(time.Now())))
+	assert.WithinDuration(t, time.Now(), statement.To, time.Minute)
+}
+
+func TestGetStatement_InvalidPeriod(t *testing.T) {
+	mockClientRepo := new(MockClientRepository)
+	mockLedgerRepo := new(MockLedgerRepository)
+	statementService := services.NewStatementService(mockClientRepo, mockLedgerRepo)
+
+	day := time.Date(2030, 3, 1, 0, 0, 0, 0, models.BusinessLocation)
+	mockClientRepo.On("GetClientByAccountNum", "123456").Return(&models.Client{AccountNum: "123456", Balance: models.BRL(0)}, nil)
+
+	_, err := statementService.GetStatement("123456", day, day)
+
+	assert.ErrorIs(t, err, models.ErrInvalidPeriod)
+	mockLedgerRepo.AssertNotCalled(t, "GetStatementLines", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetStatement_UnknownAccount(t *testing.T) {
+	mockClientRepo := new(MockClientRepository)
+	mockLedgerRepo := new(MockLedgerRepository)
+	statementService := services.NewStatementService(mockClientRepo, mockLedgerRepo)
+
+	mockClientRepo.On("GetClientByAccountNum", "999999").Return((*models.Client)(nil), repositories.ErrClientNotFound)
+
+	_, err := statementService.GetStatement("999999", time.Time{}, time.Time{})
+
+	assert.ErrorIs(t, err, repositories.ErrClientNotFound)
+}
@@ -0,0 +1,242 @@
+// test/transfer_service_benchmark_test.go
+package test
+
+import (
+	"banking/src/models"
+	"banking/src/repositories"
+	"banking/src/services"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// storageLatency simula o tempo de ida e volta ao banco de dados de uma
+// transferência, durante o qual as contas envolvidas ficam bloqueadas
+const storageLatency = 200 * time.Microsecond
+
+// memoryStore guarda clientes e saldos do livro-razão em memória para o benchmark
+type memoryStore struct {
+	mu       sync.Mutex
+	clients  map[string]models.Client
+	ledger   map[string]models.Money
+	transfer int64
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{clients: make(map[string]models.Client), ledger: make(map[string]models.Money)}
+}
+
+func (s *memoryStore) GetClientByAccountNum(accountNum string) (*models.Client, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	client, ok := s.clients[accountNum]
+	if !ok {
+		return nil, repositories.ErrClientNotFound
+	}
+	return &client, nil
+}
+
+func (s *memoryStore) UpdateClient(client *models.Client) error {
+	return s.UpdateClientBalance(client)
+}
+
+func (s *memoryStore) UpdateClientBalance(client *models.Client) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clients[client.AccountNum] = *client
+	return nil
+}
+
+func (s *memoryStore) DebitClientBalance(accountNum string, amount models.Money) (models.Money, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	client := s.clients[accountNum]
+	if client.Balance.LessThan(amount) {
+		return models.Money{}, repositories.ErrInsufficientBalance
+	}
+	client.Balance = client.Balance.Sub(amount)
+	s.clients[accountNum] = client
+	return client.Balance, nil
+}
+
+func (s *memoryStore) CreditClientBalance(accountNum string, amount models.Money) (models.Money, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	client := s.clients[accountNum]
+	client.Balance = client.Balance.Add(amount)
+	s.clients[accountNum] = client
+	return client.Balance, nil
+}
+
+func (s *memoryStore) ChargeClientBalance(accountNum string, amount models.Money) (models.Money, error) {
+	return s.CreditClientBalance(accountNum, amount.Neg())
+}
+
+func (s *memoryStore) UpdateOverdraft(accountNum string, limit models.Money, rateBps int) (*models.Client, error) {
+	return nil, repositories.ErrClientNotFound
+}
+
+func (s *memoryStore) UpdateClientStatus(accountNum, status string) (*models.Client, error) {
+	return nil, repositories.ErrClientNotFound
+}
+
+func (s *memoryStore) HoldClientBalance(accountNum string, amount models.Money) (models.Money, error) {
+	return models.Money{}, repositories.ErrInsufficientBalance
+}
+
+func (s *memoryStore) ReleaseClientHold(accountNum string, amount models.Money) (models.Money, error) {
+	return models.Money{}, repositories.ErrClientNotFound
+}
+
+func (s *memoryStore) CreateClient(client *models.Client) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clients[client.AccountNum] = *client
+	s.ledger[client.AccountNum] = client.Balance
+	return nil
+}
+
+func (s *memoryStore) GetClients() ([]models.Client, error) {
+	return nil, nil
+}
+
+func (s *memoryStore) GetClientsByCustomer(customerID int) ([]models.Client, error) {
+	return nil, nil
+}
+
+func (s *memoryStore) GetTotalBalance(currency string) (models.Money, error) {
+	return models.NewMoney(0, currency), nil
+}
+
+func (s *memoryStore) CreateTransfer(transfer *models.Transfer) error {
+	transfer.ID = int(atomic.AddInt64(&s.transfer, 1))
+	return nil
+}
+
+func (s *memoryStore) GetTransferByID(id int) (*models.Transfer, error) {
+	return nil, repositories.ErrTransferNotFound
+}
+
+func (s *memoryStore) GetReversedAmount(transferID int, currency string) (models.Money, error) {
+	return models.NewMoney(0, currency), nil
+}
+
+func (s *memoryStore) GetTransfersByAccountNum(accountNum string) ([]models.Transfer, error) {
+	return nil, nil
+}
+
+func (s *memoryStore) HasFunding(accountNum string) (bool, error) {
+	return false, nil
+}
+
+func (s *memoryStore) CreateEntry(entry *models.JournalEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, posting := range entry.Postings {
+		s.ledger[posting.AccountNum] = s.ledger[posting.AccountNum].Add(posting.Amount)
+	}
+	return nil
+}
+
+func (s *memoryStore) GetAccountBalance(accountNum, currency string) (models.Money, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ledger[accountNum], nil
+}
+
+func (s *memoryStore) GetAccountBalances(currency string) (map[string]models.Money, error) {
+	return nil, nil
+}
+
+func (s *memoryStore) GetBalanceAt(accountNum, currency string, at time.Time) (models.Money, error) {
+	return models.NewMoney(0, currency), nil
+}
+
+func (s *memoryStore) GetStatementLines(accountNum, currency string, from, to time.Time) ([]models.StatementLine, error) {
+	return nil, nil
+}
+
+// O benchmark não configura limites de transferência
+func (s *memoryStore) GetLimits(accountNum string) (*models.TransferLimits, error) {
+	return nil, repositories.ErrLimitsNotFound
+}
+
+func (s *memoryStore) SaveLimits(limits *models.TransferLimits) error {
+	return nil
+}
+
+func (s *memoryStore) DeleteLimits(accountNum string) error {
+	return repositories.ErrLimitsNotFound
+}
+
+func (s *memoryStore) GetUsage(accountNum, currency string, now time.Time) (models.LimitUsage, error) {
+	return models.LimitUsage{}, nil
+}
+
+// Do executa fn após a latência simulada, sem transação real
+func (s *memoryStore) Do(fn func(repos repositories.Repositories) error) error {
+	time.Sleep(storageLatency)
+	return fn(repositories.Repositories{Clients: s, Transfers: s, Ledger: s, Limits: s, Fees: noFees{}})
+}
+
+// benchmarkTransfers executa b.N transferências com workers goroutines. Com
+// disjoint, cada worker usa o seu próprio par de contas; caso contrário todos
+// disputam o mesmo par.
+func benchmarkTransfers(b *testing.B, workers int, disjoint bool) {
+	store := newMemoryStore()
+	service := services.NewTransferService(store, store, store, store, services.NewAccountLocks())
+
+	pairs := make([][2]string, workers)
+	for i := range pairs {
+		pair := 0
+		if disjoint {
+			pair = i
+		}
+		pairs[i] = [2]string{fmt.Sprintf("A%03d", pair), fmt.Sprintf("B%03d", pair)}
+		for _, accountNum := range pairs[i] {
+			store.CreateClient(&models.Client{AccountNum: accountNum, Balance: models.BRL(1000000), Status: models.AccountStatusActive})
+		}
+	}
+
+	var remaining int64 = int64(b.N)
+	var wg sync.WaitGroup
+	b.ResetTimer()
+	start := time.Now()
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(pair [2]string) {
+			defer wg.Done()
+			for n := 0; atomic.AddInt64(&remaining, -1) >= 0; n++ {
+				from, to := pair[n%2], pair[(n+1)%2]
+				if _, err := service.TransferFunds(from, to, models.BRL(1)); err != nil {
+					b.Error(err)
+					return
+				}
+			}
+		}(pairs[i])
+	}
+	wg.Wait()
+	b.ReportMetric(float64(b.N)/time.Since(start).Seconds(), "transfers/s")
+}
+
+// BenchmarkTransferFunds_DisjointAccounts mostra que a vazão de transferências
+// entre pares de contas distintos cresce com a concorrência
+func BenchmarkTransferFunds_DisjointAccounts(b *testing.B) {
+	for _, workers := range []int{1, 4, 16} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			benchmarkTransfers(b, workers, true)
+		})
+	}
+}
+
+// BenchmarkTransferFunds_SamePair serve de comparação: transferências que
+// disputam as mesmas contas continuam serializadas
+func BenchmarkTransferFunds_SamePair(b *testing.B) {
+	for _, workers := range []int{1, 4, 16} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			benchmarkTransfers(b, workers, false)
+		})
+	}
+}
@@ -3,91 +3,492 @@ package test
 
 import (
 	"banking/src/models"
+	"banking/src/repositories"
 	"banking/src/services"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
+// failedTransfer casa com o registro de uma tentativa recusada pelo motivo informado
+func failedTransfer(reason string) interface{} {
+	return mock.MatchedBy(func(transfer *models.Transfer) bool {
+		return transfer.Status == models.TransferStatusFailed && transfer.FailureReason == reason
+	})
+}
+
 // Testes
 
 func TestTransferFunds_Success(t *testing.T) {
 	mockClientRepo := new(MockClientRepository)
 	mockTransferRepo := new(MockTransferRepository)
-	transferService := services.NewTransferService(mockClientRepo, mockTransferRepo)
+	mockLedgerRepo := new(MockLedgerRepository)
+	mockUow := NewMockUnitOfWork(mockClientRepo, mockTransferRepo, mockLedgerRepo)
+	transferService := services.NewTransferService(mockClientRepo, mockTransferRepo, mockLedgerRepo, mockUow, services.NewAccountLocks())
 
-	fromClient := &models.Client{AccountNum: "123456", Balance: 5000}
-	toClient := &models.Client{AccountNum: "654321", Balance: 1000}
-	amount := 1000.0
+	fromClient := &models.Client{AccountNum: "123456", Balance: models.BRL(500000), Status: models.AccountStatusActive}
+	toClient := &models.Client{AccountNum: "654321", Balance: models.BRL(100000), Status: models.AccountStatusActive}
+	amount := models.BRL(100000)
 
 	mockClientRepo.On("GetClientByAccountNum", "123456").Return(fromClient, nil)
 	mockClientRepo.On("GetClientByAccountNum", "654321").Return(toClient, nil)
-	mockClientRepo.On("UpdateClientBalance", fromClient).Return(nil)
-	mockClientRepo.On("UpdateClientBalance", toClient).Return(nil)
+	mockClientRepo.On("DebitClientBalance", "123456", amount).Return(models.BRL(400000), nil)
+	mockClientRepo.On("CreditClientBalance", "654321", amount).Return(models.BRL(200000), nil)
 	mockTransferRepo.On("CreateTransfer", mock.AnythingOfType("*models.Transfer")).Return(nil)
+	mockLedgerRepo.On("CreateEntry", mock.MatchedBy(func(entry *models.JournalEntry) bool {
+		return entry.Validate() == nil &&
+			entry.Postings[0] == models.Posting{AccountNum: "123456", Amount: models.BRL(-100000)} &&
+			entry.Postings[1] == models.Posting{AccountNum: "654321", Amount: models.BRL(100000)}
+	})).Return(nil)
+	mockLedgerRepo.On("GetAccountBalance", "123456", "BRL").Return(models.BRL(400000), nil)
+	mockLedgerRepo.On("GetAccountBalance", "654321", "BRL").Return(models.BRL(200000), nil)
 
-	err := transferService.TransferFunds("123456", "654321", amount)
+	_, err := transferService.TransferFunds("123456", "654321", amount)
 
 	assert.NoError(t, err)
-	assert.Equal(t, 4000.0, fromClient.Balance)
-	assert.Equal(t, 2000.0, toClient.Balance)
+	assert.Equal(t, models.BRL(400000), fromClient.Balance)
+	assert.Equal(t, models.BRL(200000), toClient.Balance)
+	assert.True(t, mockUow.Committed)
 	mockClientRepo.AssertExpectations(t)
 	mockTransferRepo.AssertExpectations(t)
+	mockLedgerRepo.AssertExpectations(t)
+}
+
+func TestTransferFundsWith_ThenErrorsAreNotRecordedAsDeclines(t *testing.T) {
+	mockClientRepo := new(MockClientRepository)
+	mockTransferRepo := new(MockTransferRepository)
+	mockLedgerRepo := new(MockLedgerRepository)
+	mockUow := NewMockUnitOfWork(mockClientRepo, mockTransferRepo, mockLedgerRepo)
+	transferService := services.NewTransferService(mockClientRepo, mockTransferRepo, mockLedgerRepo, mockUow, services.NewAccountLocks())
+
+	amount := models.BRL(100000)
+	mockClientRepo.On("GetClientByAccountNum", "123456").Return(&models.Client{AccountNum: "123456", Balance: models.BRL(500000), Status: models.AccountStatusActive}, nil)
+	mockClientRepo.On("GetClientByAccountNum", "654321").Return(&models.Client{AccountNum: "654321", Balance: models.BRL(100000), Status: models.AccountStatusActive}, nil)
+	mockClientRepo.On("DebitClientBalance", "123456", amount).Return(models.BRL(400000), nil)
+	mockClientRepo.On("CreditClientBalance", "654321", amount).Return(models.BRL(200000), nil)
+	mockTransferRepo.On("CreateTransfer", mock.AnythingOfType("*models.Transfer")).Return(nil)
+	mockLedgerRepo.On("CreateEntry", mock.Anything).Return(nil)
+	mockLedgerRepo.On("GetAccountBalance", "123456", "BRL").Return(models.BRL(400000), nil)
+	mockLedgerRepo.On("GetAccountBalance", "654321", "BRL").Return(models.BRL(200000), nil)
+
+	// Outro executor assumiu o agendamento: a transferência é desfeita, mas
+	// ninguém a recusou, então nada entra no histórico
+	_, err := transferService.TransferFundsWith("123456", "654321", amount, services.TransferOptions{
+		Then: func(repos repositories.Repositories, transfer *models.Transfer) error {
+			return repositories.ErrScheduledTransferNotPending
+		},
+	})
+	assert.ErrorIs(t, err, repositories.ErrScheduledTransferNotPending)
+	assert.True(t, mockUow.RolledBack)
+	mockTransferRepo.AssertNotCalled(t, "CreateTransfer", failedTransfer(models.FailureInternalError))
+
+	// Uma recusa feita pelo Then continua registrada
+	_, err = transferService.TransferFundsWith("123456", "654321", amount, services.TransferOptions{
+		Then: func(repos repositories.Repositories, transfer *models.Transfer) error {
+			return &services.TransferError{Reason: models.FailureInvalidAmount, Err: assert.AnError}
+		},
+	})
+	assert.Equal(t, models.FailureInvalidAmount, services.FailureReason(err))
+	mockTransferRepo.AssertCalled(t, "CreateTransfer", failedTransfer(models.FailureInvalidAmount))
 }
 
 func TestTransferFunds_InsufficientBalance(t *testing.T) {
 	mockClientRepo := new(MockClientRepository)
 	mockTransferRepo := new(MockTransferRepository)
-	transferService := services.NewTransferService(mockClientRepo, mockTransferRepo)
+	mockLedgerRepo := new(MockLedgerRepository)
+	mockUow := NewMockUnitOfWork(mockClientRepo, mockTransferRepo, mockLedgerRepo)
+	transferService := services.NewTransferService(mockClientRepo, mockTransferRepo, mockLedgerRepo, mockUow, services.NewAccountLocks())
 
-	fromClient := &models.Client{AccountNum: "123456", Balance: 500}
-	toClient := &models.Client{AccountNum: "654321", Balance: 1000}
-	amount := 1000.0
+	fromClient := &models.Client{AccountNum: "123456", Balance: models.BRL(50000), Status: models.AccountStatusActive}
+	toClient := &models.Client{AccountNum: "654321", Balance: models.BRL(100000), Status: models.AccountStatusActive}
+	amount := models.BRL(100000)
 
 	mockClientRepo.On("GetClientByAccountNum", "123456").Return(fromClient, nil)
 	mockClientRepo.On("GetClientByAccountNum", "654321").Return(toClient, nil)
+	mockClientRepo.On("DebitClientBalance", "123456", amount).Return(models.Money{}, repositories.ErrInsufficientBalance)
+	mockTransferRepo.On("CreateTransfer", failedTransfer(models.FailureInsufficientBalance)).Return(nil)
 
-	err := transferService.TransferFunds("123456", "654321", amount)
+	_, err := transferService.TransferFunds("123456", "654321", amount)
 
 	assert.Error(t, err)
 	assert.EqualError(t, err, "insufficient balance")
-	mockClientRepo.AssertNotCalled(t, "UpdateClientBalance", fromClient)
-	mockClientRepo.AssertNotCalled(t, "UpdateClientBalance", toClient)
-	mockTransferRepo.AssertNotCalled(t, "CreateTransfer", mock.Anything)
+	assert.Equal(t, models.FailureInsufficientBalance, services.FailureReason(err))
+	assert.True(t, mockUow.RolledBack)
+	mockClientRepo.AssertNotCalled(t, "CreditClientBalance", mock.Anything, mock.Anything)
+	mockTransferRepo.AssertExpectations(t)
 }
 
 func TestTransferFunds_AmountExceedsLimit(t *testing.T) {
 	mockClientRepo := new(MockClientRepository)
 	mockTransferRepo := new(MockTransferRepository)
-	transferService := services.NewTransferService(mockClientRepo, mockTransferRepo)
+	mockLedgerRepo := new(MockLedgerRepository)
+	mockUow := NewMockUnitOfWork(mockClientRepo, mockTransferRepo, mockLedgerRepo)
+	transferService := services.NewTransferService(mockClientRepo, mockTransferRepo, mockLedgerRepo, mockUow, services.NewAccountLocks())
+
+	mockLimitRepo := new(MockLimitRepository)
+	mockUow.Limits = mockLimitRepo
 
-	amount := 15000.0 // Excede o limite
+	amount := models.BRL(1500000) // Excede o limite padrão de R$ 10.000,00
+	mockClientRepo.On("GetClientByAccountNum", "123456").Return(&models.Client{AccountNum: "123456", Balance: models.BRL(2000000), Status: models.AccountStatusActive}, nil)
+	mockClientRepo.On("GetClientByAccountNum", "654321").Return(&models.Client{AccountNum: "654321", Balance: models.BRL(0), Status: models.AccountStatusActive}, nil)
+	mockLimitRepo.On("GetLimits", "123456").Return(nil, repositories.ErrLimitsNotFound)
+	mockLimitRepo.On("GetLimits", models.DefaultLimitsKey).Return(&models.TransferLimits{AccountNum: models.DefaultLimitsKey, PerTransfer: models.BRL(1000000)}, nil)
+	mockLimitRepo.On("GetUsage", "123456", "BRL", mock.Anything).Return(models.LimitUsage{}, nil)
+	mockTransferRepo.On("CreateTransfer", failedTransfer(models.FailureLimitExceeded)).Return(nil)
 
-	err := transferService.TransferFunds("123456", "654321", amount)
+	_, err := transferService.TransferFunds("123456", "654321", amount)
 
 	assert.Error(t, err)
-	assert.EqualError(t, err, "amount must be between 0 and 10,000")
-	mockClientRepo.AssertNotCalled(t, "GetClientByAccountNum", "123456")
-	mockClientRepo.AssertNotCalled(t, "UpdateClientBalance", mock.Anything)
-	mockTransferRepo.AssertNotCalled(t, "CreateTransfer", mock.Anything)
+	assert.EqualError(t, err, "per_transfer limit exceeded: remaining allowance is 10000.00 BRL")
+	assert.True(t, mockUow.RolledBack)
+	mockClientRepo.AssertNotCalled(t, "DebitClientBalance", mock.Anything, mock.Anything)
+	mockTransferRepo.AssertExpectations(t)
+	mockLimitRepo.AssertExpectations(t)
+}
+
+func TestTransferFunds_AccountDailyLimitReportsRemainingAllowance(t *testing.T) {
+	mockClientRepo := new(MockClientRepository)
+	mockTransferRepo := new(MockTransferRepository)
+	mockLedgerRepo := new(MockLedgerRepository)
+	mockUow := NewMockUnitOfWork(mockClientRepo, mockTransferRepo, mockLedgerRepo)
+	transferService := services.NewTransferService(mockClientRepo, mockTransferRepo, mockLedgerRepo, mockUow, services.NewAccountLocks())
+
+	mockLimitRepo := new(MockLimitRepository)
+	mockUow.Limits = mockLimitRepo
+
+	amount := models.BRL(30000)
+	mockClientRepo.On("GetClientByAccountNum", "123456").Return(&models.Client{AccountNum: "123456", Balance: models.BRL(100000), Status: models.AccountStatusActive}, nil)
+	mockClientRepo.On("GetClientByAccountNum", "654321").Return(&models.Client{AccountNum: "654321", Balance: models.BRL(0), Status: models.AccountStatusActive}, nil)
+	// Os limites próprios da conta substituem os padrão
+	mockLimitRepo.On("GetLimits", "123456").Return(&models.TransferLimits{AccountNum: "123456", PerTransfer: models.BRL(0), Daily: models.BRL(50000)}, nil)
+	mockLimitRepo.On("GetUsage", "123456", "BRL", mock.Anything).Return(models.LimitUsage{Daily: models.BRL(40000), Monthly: models.BRL(40000), NightTotal: models.BRL(0)}, nil)
+	mockTransferRepo.On("CreateTransfer", failedTransfer(models.FailureLimitExceeded)).Return(nil)
+
+	_, err := transferService.TransferFunds("123456", "654321", amount)
+
+	assert.EqualError(t, err, "daily limit exceeded: remaining allowance is 100.00 BRL")
+	var limitErr *models.LimitExceededError
+	assert.ErrorAs(t, err, &limitErr)
+	assert.Equal(t, models.BRL(10000), limitErr.Remaining)
+	mockLimitRepo.AssertNotCalled(t, "GetLimits", models.DefaultLimitsKey)
+	mockClientRepo.AssertNotCalled(t, "DebitClientBalance", mock.Anything, mock.Anything)
 }
 
 func TestGetTransferHistory_Success(t *testing.T) {
 	mockClientRepo := new(MockClientRepository)
 	mockTransferRepo := new(MockTransferRepository)
-	transferService := services.NewTransferService(mockClientRepo, mockTransferRepo)
+	mockLedgerRepo := new(MockLedgerRepository)
+	mockUow := NewMockUnitOfWork(mockClientRepo, mockTransferRepo, mockLedgerRepo)
+	transferService := services.NewTransferService(mockClientRepo, mockTransferRepo, mockLedgerRepo, mockUow, services.NewAccountLocks())
 
 	transfers := []models.Transfer{
-		{FromAccountNum: "123456", ToAccountNum: "654321", Amount: 500, Status: "success"},
-		{FromAccountNum: "654321", ToAccountNum: "123456", Amount: 300, Status: "success"},
+		{FromAccountNum: "123456", ToAccountNum: "654321", Amount: models.BRL(50000), Status: "success"},
+		{FromAccountNum: "654321", ToAccountNum: "123456", Amount: models.BRL(30000), Status: "success"},
 	}
 
-	mockTransferRepo.On("GetTransfersByAccountNum", "123456").Return(transfers, nil)
+	mockLedgerRepo.On("GetTransfersByAccountNum", "123456").Return(transfers, nil)
 
 	result, err := transferService.GetTransferHistory("123456")
 
 	assert.NoError(t, err)
 	assert.Equal(t, transfers, result)
+	mockLedgerRepo.AssertExpectations(t)
+}
+
+func TestTransferFunds_CurrencyMismatch(t *testing.T) {
+	mockClientRepo := new(MockClientRepository)
+	mockTransferRepo := new(MockTransferRepository)
+	mockLedgerRepo := new(MockLedgerRepository)
+	mockUow := NewMockUnitOfWork(mockClientRepo, mockTransferRepo, mockLedgerRepo)
+	transferService := services.NewTransferService(mockClientRepo, mockTransferRepo, mockLedgerRepo, mockUow, services.NewAccountLocks())
+
+	fromClient := &models.Client{AccountNum: "123456", Balance: models.BRL(500000), Status: models.AccountStatusActive}
+
+	mockClientRepo.On("GetClientByAccountNum", "123456").Return(fromClient, nil)
+	mockTransferRepo.On("CreateTransfer", failedTransfer(models.FailureCurrencyMismatch)).Return(nil)
+
+	_, err := transferService.TransferFunds("123456", "654321", models.NewMoney(1000, "USD"))
+
+	assert.ErrorIs(t, err, models.ErrCurrencyMismatch)
+	mockClientRepo.AssertNotCalled(t, "DebitClientBalance", mock.Anything, mock.Anything)
 	mockTransferRepo.AssertExpectations(t)
 }
+
+func TestTransferFunds_RollsBackWhenCreditFails(t *testing.T) {
+	mockClientRepo := new(MockClientRepository)
+	mockTransferRepo := new(MockTransferRepository)
+	mockLedgerRepo := new(MockLedgerRepository)
+	mockUow := NewMockUnitOfWork(mockClientRepo, mockTransferRepo, mockLedgerRepo)
+	transferService := services.NewTransferService(mockClientRepo, mockTransferRepo, mockLedgerRepo, mockUow, services.NewAccountLocks())
+
+	fromClient := &models.Client{AccountNum: "123456", Balance: models.BRL(500000), Status: models.AccountStatusActive}
+	toClient := &models.Client{AccountNum: "654321", Balance: models.BRL(100000), Status: models.AccountStatusActive}
+
+	mockClientRepo.On("GetClientByAccountNum", "123456").Return(fromClient, nil)
+	mockClientRepo.On("GetClientByAccountNum", "654321").Return(toClient, nil)
+	mockClientRepo.On("DebitClientBalance", "123456", models.BRL(100000)).Return(models.BRL(400000), nil)
+	mockClientRepo.On("CreditClientBalance", "654321", models.BRL(100000)).Return(models.Money{}, errors.New("disk I/O error"))
+	mockTransferRepo.On("CreateTransfer", failedTransfer(models.FailureInternalError)).Return(nil)
+
+	_, err := transferService.TransferFunds("123456", "654321", models.BRL(100000))
+
+	assert.EqualError(t, err, "disk I/O error")
+	assert.True(t, mockUow.RolledBack)
+	assert.False(t, mockUow.Committed)
+	mockTransferRepo.AssertNotCalled(t, "CreateTransfer", mock.MatchedBy(func(transfer *models.Transfer) bool {
+		return transfer.Status == models.TransferStatusSuccess
+	}))
+}
+
+func TestTransferFunds_RollsBackWhenTransferRecordFails(t *testing.T) {
+	mockClientRepo := new(MockClientRepository)
+	mockTransferRepo := new(MockTransferRepository)
+	mockLedgerRepo := new(MockLedgerRepository)
+	mockUow := NewMockUnitOfWork(mockClientRepo, mockTransferRepo, mockLedgerRepo)
+	transferService := services.NewTransferService(mockClientRepo, mockTransferRepo, mockLedgerRepo, mockUow, services.NewAccountLocks())
+
+	fromClient := &models.Client{AccountNum: "123456", Balance: models.BRL(500000), Status: models.AccountStatusActive}
+	toClient := &models.Client{AccountNum: "654321", Balance: models.BRL(100000), Status: models.AccountStatusActive}
+
+	mockClientRepo.On("GetClientByAccountNum", "123456").Return(fromClient, nil)
+	mockClientRepo.On("GetClientByAccountNum", "654321").Return(toClient, nil)
+	mockClientRepo.On("DebitClientBalance", "123456", models.BRL(100000)).Return(models.BRL(400000), nil)
+	mockClientRepo.On("CreditClientBalance", "654321", models.BRL(100000)).Return(models.BRL(200000), nil)
+	mockTransferRepo.On("CreateTransfer", mock.AnythingOfType("*models.Transfer")).Return(errors.New("constraint failed"))
+
+	_, err := transferService.TransferFunds("123456", "654321", models.BRL(100000))
+
+	assert.EqualError(t, err, "constraint failed")
+	assert.True(t, mockUow.RolledBack)
+	assert.False(t, mockUow.Committed)
+}
+
+func TestTransferFunds_RollsBackOnLedgerMismatch(t *testing.T) {
+	mockClientRepo := new(MockClientRepository)
+	mockTransferRepo := new(MockTransferRepository)
+	mockLedgerRepo := new(MockLedgerRepository)
+	mockUow := NewMockUnitOfWork(mockClientRepo, mockTransferRepo, mockLedgerRepo)
+	transferService := services.NewTransferService(mockClientRepo, mockTransferRepo, mockLedgerRepo, mockUow, services.NewAccountLocks())
+
+	fromClient := &models.Client{AccountNum: "123456", Balance: models.BRL(500000), Status: models.AccountStatusActive}
+	toClient := &models.Client{AccountNum: "654321", Balance: models.BRL(100000), Status: models.AccountStatusActive}
+
+	mockClientRepo.On("GetClientByAccountNum", "123456").Return(fromClient, nil)
+	mockClientRepo.On("GetClientByAccountNum", "654321").Return(toClient, nil)
+	mockClientRepo.On("DebitClientBalance", "123456", models.BRL(100000)).Return(models.BRL(400000), nil)
+	mockClientRepo.On("CreditClientBalance", "654321", models.BRL(100000)).Return(models.BRL(200000), nil)
+	mockTransferRepo.On("CreateTransfer", mock.AnythingOfType("*models.Transfer")).Return(nil)
+	mockLedgerRepo.On("CreateEntry", mock.AnythingOfType("*models.JournalEntry")).Return(nil)
+	// O livro-razão só conhece 3.000,00 na conta de origem
+	mockLedgerRepo.On("GetAccountBalance", "123456", "BRL").Return(models.BRL(200000), nil)
+
+	_, err := transferService.TransferFunds("123456", "654321", models.BRL(100000))
+
+	assert.ErrorIs(t, err, services.ErrLedgerMismatch)
+	assert.True(t, mockUow.RolledBack)
+	assert.False(t, mockUow.Committed)
+}
+
+func TestTransferFunds_RecordsUnknownDestination(t *testing.T) {
+	mockClientRepo := new(MockClientRepository)
+	mockTransferRepo := new(MockTransferRepository)
+	mockLedgerRepo := new(MockLedgerRepository)
+	mockUow := NewMockUnitOfWork(mockClientRepo, mockTransferRepo, mockLedgerRepo)
+	transferService := services.NewTransferService(mockClientRepo, mockTransferRepo, mockLedgerRepo, mockUow, services.NewAccountLocks())
+
+	fromClient := &models.Client{AccountNum: "123456", Balance: models.BRL(500000), Status: models.AccountStatusActive}
+
+	mockClientRepo.On("GetClientByAccountNum", "123456").Return(fromClient, nil)
+	mockClientRepo.On("GetClientByAccountNum", "999999").Return((*models.Client)(nil), repositories.ErrClientNotFound)
+	mockTransferRepo.On("CreateTransfer", mock.MatchedBy(func(transfer *models.Transfer) bool {
+		return transfer.Status == models.TransferStatusFailed &&
+			transfer.FailureReason == models.FailureDestinationNotFound &&
+			transfer.FromAccountNum == "123456" &&
+			transfer.ToAccountNum == "999999" &&
+			transfer.Amount == models.BRL(1000)
+	})).Return(nil)
+
+	_, err := transferService.TransferFunds("123456", "999999", models.BRL(1000))
+
+	assert.EqualError(t, err, "client not found")
+	assert.Equal(t, models.FailureDestinationNotFound, services.FailureReason(err))
+	assert.True(t, mockUow.RolledBack)
+	mockTransferRepo.AssertExpectations(t)
+}
+
+func TestTransferFunds_RecordingFailureKeepsOriginalError(t *testing.T) {
+	mockClientRepo := new(MockClientRepository)
+	mockTransferRepo := new(MockTransferRepository)
+	mockLedgerRepo := new(MockLedgerRepository)
+	mockUow := NewMockUnitOfWork(mockClientRepo, mockTransferRepo, mockLedgerRepo)
+	transferService := services.NewTransferService(mockClientRepo, mockTransferRepo, mockLedgerRepo, mockUow, services.NewAccountLocks())
+
+	mockTransferRepo.On("CreateTransfer", failedTransfer(models.FailureInvalidAmount)).Return(errors.New("database is locked"))
+
+	_, err := transferService.TransferFunds("123456", "654321", models.BRL(0))
+
+	assert.EqualError(t, err, "amount must be greater than zero")
+	assert.Equal(t, models.FailureInvalidAmount, services.FailureReason(err))
+	mockTransferRepo.AssertExpectations(t)
+}
+
+func newReversalTestService() (*services.TransferService, *MockClientRepository, *MockTransferRepository, *MockLedgerRepository, *MockUnitOfWork) {
+	mockClientRepo := new(MockClientRepository)
+	mockTransferRepo := new(MockTransferRepository)
+	mockLedgerRepo := new(MockLedgerRepository)
+	mockUow := NewMockUnitOfWork(mockClientRepo, mockTransferRepo, mockLedgerRepo)
+	transferService := services.NewTransferService(mockClientRepo, mockTransferRepo, mockLedgerRepo, mockUow, services.NewAccountLocks())
+	for _, accountNum := range []string{"123456", "654321"} {
+		mockClientRepo.On("GetClientByAccountNum", accountNum).Return(&models.Client{AccountNum: accountNum, Balance: models.BRL(0), Status: models.AccountStatusActive}, nil).Maybe()
+	}
+	return transferService, mockClientRepo, mockTransferRepo, mockLedgerRepo, mockUow
+}
+
+func originalTransfer() *models.Transfer {
+	return &models.Transfer{
+		ID:             10,
+		FromAccountNum: "123456",
+		ToAccountNum:   "654321",
+		Amount:         models.BRL(10000),
+		Type:           models.TransferTypeTransfer,
+		Status:         models.TransferStatusSuccess,
+	}
+}
+
+func TestReverseTransfer_FullReversal(t *testing.T) {
+	transferService, mockClientRepo, mockTransferRepo, mockLedgerRepo, mockUow := newReversalTestService()
+
+	mockTransferRepo.On("GetTransferByID", 10).Return(originalTransfer(), nil)
+	// 25,00 já foram estornados: o estorno total devolve os 75,00 restantes
+	mockTransferRepo.On("GetReversedAmount", 10, "BRL").Return(models.BRL(2500), nil)
+	mockClientRepo.On("DebitClientBalance", "654321", models.BRL(7500)).Return(models.BRL(0), nil)
+	mockClientRepo.On("CreditClientBalance", "123456", models.BRL(7500)).Return(models.BRL(7500), nil)
+	mockTransferRepo.On("CreateTransfer", mock.MatchedBy(func(transfer *models.Transfer) bool {
+		return transfer.Type == models.TransferTypeReversal &&
+			transfer.Status == models.TransferStatusSuccess &&
+			transfer.ReversalOf != nil && *transfer.ReversalOf == 10
+	})).Return(nil)
+	mockLedgerRepo.On("CreateEntry", mock.MatchedBy(func(entry *models.JournalEntry) bool {
+		return entry.Description == models.EntryReversal &&
+			entry.Postings[0] == models.Posting{AccountNum: "654321", Amount: models.BRL(-7500)} &&
+			entry.Postings[1] == models.Posting{AccountNum: "123456", Amount: models.BRL(7500)}
+	})).Return(nil)
+	mockLedgerRepo.On("GetAccountBalance", "654321", "BRL").Return(models.BRL(0), nil)
+	mockLedgerRepo.On("GetAccountBalance", "123456", "BRL").Return(models.BRL(7500), nil)
+
+	reversal, err := transferService.ReverseTransfer(10, models.Money{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.BRL(7500), reversal.Amount)
+	assert.Equal(t, "654321", reversal.FromAccountNum)
+	assert.Equal(t, "123456", reversal.ToAccountNum)
+	assert.True(t, mockUow.Committed)
+	mockClientRepo.AssertExpectations(t)
+	mockLedgerRepo.AssertExpectations(t)
+}
+
+func TestReverseTransfer_ExceedsOriginal(t *testing.T) {
+	transferService, mockClientRepo, mockTransferRepo, _, mockUow := newReversalTestService()
+
+	mockTransferRepo.On("GetTransferByID", 10).Return(originalTransfer(), nil)
+	mockTransferRepo.On("GetReversedAmount", 10, "BRL").Return(models.BRL(6000), nil)
+	mockTransferRepo.On("CreateTransfer", failedTransfer(models.FailureReversalExceeded)).Return(nil)
+
+	reversal, err := transferService.ReverseTransfer(10, models.BRL(5000))
+
+	assert.Nil(t, reversal)
+	assert.Equal(t, models.FailureReversalExceeded, services.FailureReason(err))
+	assert.True(t, mockUow.RolledBack)
+	mockClientRepo.AssertNotCalled(t, "DebitClientBalance", mock.Anything, mock.Anything)
+	mockTransferRepo.AssertExpectations(t)
+}
+
+func TestReverseTransfer_AlreadyFullyReversed(t *testing.T) {
+	transferService, _, mockTransferRepo, _, _ := newReversalTestService()
+
+	mockTransferRepo.On("GetTransferByID", 10).Return(originalTransfer(), nil)
+	mockTransferRepo.On("GetReversedAmount", 10, "BRL").Return(models.BRL(10000), nil)
+	mockTransferRepo.On("CreateTransfer", failedTransfer(models.FailureReversalExceeded)).Return(nil)
+
+	_, err := transferService.ReverseTransfer(10, models.Money{})
+
+	assert.Equal(t, models.FailureReversalExceeded, services.FailureReason(err))
+}
+
+func TestReverseTransfer_RecipientHasInsufficientBalance(t *testing.T) {
+	transferService, mockClientRepo, mockTransferRepo, _, mockUow := newReversalTestService()
+
+	mockTransferRepo.On("GetTransferByID", 10).Return(originalTransfer(), nil)
+	mockTransferRepo.On("GetReversedAmount", 10, "BRL").Return(models.BRL(0), nil)
+	mockClientRepo.On("DebitClientBalance", "654321", models.BRL(4000)).Return(models.Money{}, repositories.ErrInsufficientBalance)
+	mockTransferRepo.On("CreateTransfer", mock.MatchedBy(func(transfer *models.Transfer) bool {
+		return transfer.Status == models.TransferStatusFailed &&
+			transfer.FailureReason == models.FailureInsufficientBalance &&
+			transfer.Type == models.TransferTypeReversal &&
+			*transfer.ReversalOf == 10
+	})).Return(nil)
+
+	_, err := transferService.ReverseTransfer(10, models.BRL(4000))
+
+	assert.ErrorIs(t, err, repositories.ErrInsufficientBalance)
+	assert.True(t, mockUow.RolledBack)
+	mockClientRepo.AssertNotCalled(t, "CreditClientBalance", mock.Anything, mock.Anything)
+	mockTransferRepo.AssertExpectations(t)
+}
+
+func TestReverseTransfer_OnlyTransfersCanBeReversed(t *testing.T) {
+	transferService, _, mockTransferRepo, _, _ := newReversalTestService()
+
+	failed := originalTransfer()
+	failed.Status = models.TransferStatusFailed
+	mockTransferRepo.On("GetTransferByID", 10).Return(failed, nil)
+	mockTransferRepo.On("CreateTransfer", failedTransfer(models.FailureNotReversible)).Return(nil)
+
+	_, err := transferService.ReverseTransfer(10, models.Money{})
+
+	assert.Equal(t, models.FailureNotReversible, services.FailureReason(err))
+	mockTransferRepo.AssertNotCalled(t, "GetReversedAmount", mock.Anything, mock.Anything)
+}
+
+func TestReverseTransfer_NotFound(t *testing.T) {
+	transferService, _, mockTransferRepo, _, _ := newReversalTestService()
+
+	mockTransferRepo.On("GetTransferByID", 99).Return(nil, repositories.ErrTransferNotFound)
+
+	_, err := transferService.ReverseTransfer(99, models.Money{})
+
+	assert.ErrorIs(t, err, repositories.ErrTransferNotFound)
+	mockTransferRepo.AssertNotCalled(t, "CreateTransfer", mock.Anything)
+}
+
+func TestTransferFunds_AccountStatusRestrictsDebitsAndCredits(t *testing.T) {
+	cases := []struct {
+		fromStatus, toStatus string
+		reason               string
+	}{
+		{models.AccountStatusFrozen, models.AccountStatusActive, models.FailureDebitsNotAllowed},
+		{models.AccountStatusActive, models.AccountStatusBlocked, models.FailureCreditsNotAllowed},
+		{models.AccountStatusActive, models.AccountStatusClosed, models.FailureCreditsNotAllowed},
+	}
+	for _, tc := range cases {
+		mockClientRepo := new(MockClientRepository)
+		mockTransferRepo := new(MockTransferRepository)
+		mockLedgerRepo := new(MockLedgerRepository)
+		mockUow := NewMockUnitOfWork(mockClientRepo, mockTransferRepo, mockLedgerRepo)
+		transferService := services.NewTransferService(mockClientRepo, mockTransferRepo, mockLedgerRepo, mockUow, services.NewAccountLocks())
+
+		mockClientRepo.On("GetClientByAccountNum", "123456").Return(&models.Client{AccountNum: "123456", Balance: models.BRL(500000), Status: tc.fromStatus}, nil)
+		mockClientRepo.On("GetClientByAccountNum", "654321").Return(&models.Client{AccountNum: "654321", Balance: models.BRL(0), Status: tc.toStatus}, nil)
+		mockTransferRepo.On("CreateTransfer", failedTransfer(tc.reason)).Return(nil)
+
+		transfer, err := transferService.TransferFunds("123456", "654321", models.BRL(1000))
+
+		assert.Nil(t, transfer)
+		assert.Equal(t, tc.reason, services.FailureReason(err), "%s -> %s", tc.fromStatus, tc.toStatus)
+		assert.True(t, mockUow.RolledBack)
+		mockClientRepo.AssertNotCalled(t, "DebitClientBalance", mock.Anything, mock.Anything)
+		mockTransferRepo.AssertExpectations(t)
+	}
+}
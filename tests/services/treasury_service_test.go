@@ -0,0 +1,54 @@
+// src/services/treasury_service_test.go
+package test
+
+import (
+	"banking/src/models"
+	"banking/src/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPosition_Balanced(t *testing.T) {
+	mockClientRepo := new(MockClientRepository)
+	mockLedgerRepo := new(MockLedgerRepository)
+	mockUow := NewMockUnitOfWork(mockClientRepo, nil, mockLedgerRepo)
+	treasuryService := services.NewTreasuryService(mockUow)
+
+	mockLedgerRepo.On("GetAccountBalances", "BRL").Return(map[string]models.Money{
+		models.TreasuryAccountNum: models.BRL(-300000),
+		models.CashAccountNum:     models.BRL(-5000),
+		"123456":                  models.BRL(205000),
+		"654321":                  models.BRL(100000),
+	}, nil)
+	mockClientRepo.On("GetTotalBalance", "BRL").Return(models.BRL(305000), nil)
+
+	position, err := treasuryService.GetPosition("brl")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "BRL", position.Currency)
+	assert.Equal(t, models.BRL(-300000), position.TreasuryBalance)
+	assert.Equal(t, map[string]models.Money{models.CashAccountNum: models.BRL(-5000)}, position.InternalBalances)
+	assert.Equal(t, models.BRL(305000), position.ClientBalances)
+	assert.True(t, position.LedgerTotal.IsZero())
+	assert.True(t, position.Balanced)
+}
+
+func TestGetPosition_DetectsMoneyOutsideTheLedger(t *testing.T) {
+	mockClientRepo := new(MockClientRepository)
+	mockLedgerRepo := new(MockLedgerRepository)
+	mockUow := NewMockUnitOfWork(mockClientRepo, nil, mockLedgerRepo)
+	treasuryService := services.NewTreasuryService(mockUow)
+
+	mockLedgerRepo.On("GetAccountBalances", "BRL").Return(map[string]models.Money{
+		models.TreasuryAccountNum: models.BRL(-100000),
+		"123456":                  models.BRL(100000),
+	}, nil)
+	// Um saldo de cliente alterado sem lançamento no livro-razão
+	mockClientRepo.On("GetTotalBalance", "BRL").Return(models.BRL(150000), nil)
+
+	position, err := treasuryService.GetPosition("")
+
+	assert.NoError(t, err)
+	assert.False(t, position.Balanced)
+}